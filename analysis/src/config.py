@@ -72,7 +72,10 @@ PPR_SF_8 = Segment(
 
 # Master registry: every runnable segment, keyed by its segment key. Add new
 # league combos here (e.g. a 1QB or half-PPR Segment) and they become valid
-# --segment values everywhere.
+# --segment values everywhere. Keys must follow the backend's grammar
+# (valuation.Segment.Key): <scoring>-<sf|1qb>-<size>[-<league_type>], e.g.
+# "half_ppr-1qb-10-dynasty" — the backend maps leagues to segments by key and
+# values their trades once a segment has published snapshots.
 SEGMENTS: dict[str, Segment] = {s.key: s for s in [PPR_SF_12, PPR_SF_10, PPR_SF_8]}
 
 DEFAULT_SEGMENT_KEY = PPR_SF_12.key
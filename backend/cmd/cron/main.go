@@ -2,10 +2,11 @@
 // max-duration, runs the matching job under a deadline context, and exits.
 // It's the replacement entrypoint for pipelines migrated off Temporal — see
 // docs/superpowers/specs/2026-07-15-discovery-cron-migration-design.md.
-// Registers "discovery", "lifetime-counts", and "transactions"; adding
-// another (draft-sync, etc., when its turn comes) is a matter of registering
-// another function in the registry built in main(), not restructuring this
-// file.
+// Registers "discovery", "lifetime-counts", "transactions", "market-pulse",
+// "valuation-movers", "trade-suggestions", and the one-off "register-sleeper-league" (which takes
+// -sleeper-league-id; see docs/sleeper-league-import.md); adding another
+// (draft-sync, etc., when its turn comes) is a matter of registering another
+// function in the registry built in main(), not restructuring this file.
 package main
 
 import (
@@ -21,7 +22,10 @@ import (
 	"backend/internal/discoverycron"
 	"backend/internal/sleeper"
 	"backend/internal/statscron"
+	"backend/internal/suggestioncron"
 	"backend/internal/transactioncron"
+	"backend/internal/trendingcron"
+	"backend/internal/valuationcron"
 )
 
 // buildID identifies the commit this binary was built from. Set via
@@ -32,6 +36,23 @@ var buildID = "dev"
 
 var errUnknownJob = errors.New("unknown job")
 
+var errMissingSleeperLeagueID = errors.New("register-sleeper-league needs -sleeper-league-id")
+
+// registerSleeperLeague imports a Sleeper league's full history the way
+// RegisterSleeperLeagueWorkflow does, without going through Temporal.
+func registerSleeperLeague(ctx context.Context, sla *activities.SleeperLeagueActivities, sleeperLeagueID string) error {
+	if sleeperLeagueID == "" {
+		return errMissingSleeperLeagueID
+	}
+	res, err := sla.RegisterSleeperLeague(ctx, activities.RegisterSleeperLeagueParams{SleeperLeagueID: sleeperLeagueID})
+	if err != nil {
+		return err
+	}
+	log.Printf("register-sleeper-league: %s -> league %d, %d seasons, %d matchups, %d box scores (%d unresolved players)",
+		sleeperLeagueID, res.LeagueID, res.Seasons, res.Matchups, res.BoxScores, res.UnresolvedPlayers)
+	return nil
+}
+
 // resolveJob looks up name in registry, returning errUnknownJob (wrapped
 // with the attempted name) if it isn't registered.
 func resolveJob(registry map[string]func(context.Context) error, name string) (func(context.Context) error, error) {
@@ -84,6 +105,7 @@ func txnJobFailed(report transactioncron.Report) error {
 func main() {
 	jobName := flag.String("job", "", "job to run (see registry in main.go)")
 	maxDuration := flag.Duration("max-duration", 0, "hard deadline for the job, e.g. 50m")
+	sleeperLeagueID := flag.String("sleeper-league-id", "", "Sleeper league ID to import (register-sleeper-league only)")
 	flag.Parse()
 
 	if *jobName == "" {
@@ -134,6 +156,38 @@ func main() {
 			}
 			return txnJobFailed(report)
 		},
+		"market-pulse": func(ctx context.Context) error {
+			report, err := trendingcron.RunMarketPulse(ctx, database.DB)
+			if err != nil {
+				return err
+			}
+			log.Printf("market-pulse: %d transactions (%d skipped) -> %d player_trends rows", report.Transactions, report.Skipped, report.Rows)
+			faab, err := trendingcron.RunFAABRollup(ctx, database.DB)
+			if err != nil {
+				return err
+			}
+			log.Printf("market-pulse: %d weeks, %d FAAB bids (%d skipped) -> %d faab_bid_stats rows", faab.Weeks, faab.Bids, faab.Skipped, faab.Rows)
+			return nil
+		},
+		"valuation-movers": func(ctx context.Context) error {
+			report, err := valuationcron.RunMovers(ctx, database.DB)
+			if err != nil {
+				return err
+			}
+			log.Printf("valuation-movers: %d segments -> %d valuation_movers rows", report.Segments, report.Movers)
+			return nil
+		},
+		"trade-suggestions": func(ctx context.Context) error {
+			report, err := suggestioncron.RunSuggestions(ctx, database.DB)
+			if err != nil {
+				return err
+			}
+			log.Printf("trade-suggestions: %d requests claimed, %d completed, %d failed -> %d trade_suggestions rows", report.Claimed, report.Completed, report.Failed, report.Suggestions)
+			return nil
+		},
+		"register-sleeper-league": func(ctx context.Context) error {
+			return registerSleeperLeague(ctx, &activities.SleeperLeagueActivities{DB: database.DB, Sleeper: sc}, *sleeperLeagueID)
+		},
 	}
 
 	fn, err := resolveJob(registry, *jobName)
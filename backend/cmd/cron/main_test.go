@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"backend/internal/activities"
 	"backend/internal/discoverycron"
 	"backend/internal/transactioncron"
 )
@@ -101,3 +102,10 @@ func TestTxnJobFailed_OnlyFailedCountsStillCountAsProgress(t *testing.T) {
 		t.Errorf("expected a run with real (even if failed) processing activity to not be treated as total failure, got %v", err)
 	}
 }
+
+func TestRegisterSleeperLeague_RequiresALeagueID(t *testing.T) {
+	err := registerSleeperLeague(context.Background(), &activities.SleeperLeagueActivities{}, "")
+	if !errors.Is(err, errMissingSleeperLeagueID) {
+		t.Errorf("expected errMissingSleeperLeagueID, got %v", err)
+	}
+}
@@ -83,6 +83,16 @@ func main() {
 		log.Printf("Warning: Could not initialize database: %v", err)
 		log.Printf("Server will continue without database connection")
 	}
+	// The archive DB serves trade and transaction history older than the
+	// scavenger's hot window; without it those endpoints read cloud only.
+	if cfg.ArchiveDB.Enabled() {
+		if err := database.InitializeArchive(cfg); err != nil {
+			log.Printf("Warning: Could not initialize archive database: %v", err)
+			database.Archive = nil
+		}
+	} else {
+		log.Println("ARCHIVE_DATABASE_URL not set — archive database disabled")
+	}
 
 	r := gin.Default()
 	config := cors.Config{
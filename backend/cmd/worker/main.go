@@ -90,6 +90,7 @@ func main() {
 	dfa := &activities.DataFetchActivities{DB: database.DB, Archive: database.Archive, Sleeper: sc}
 	psa := &activities.PlayerSyncActivities{DB: database.DB, Sleeper: sc}
 	wsa := &activities.WeekStatsActivities{DB: database.DB, Sleeper: sc}
+	sla := &activities.SleeperLeagueActivities{DB: database.DB, Sleeper: sc}
 
 	// The drafts sync queue is I/O-bound, and Temporal task distribution is
 	// pull-based: the fleet with more free activity slots and pollers takes a
@@ -123,16 +124,28 @@ func main() {
 	psw.RegisterWorkflow(workflows.PlayerDatabaseSyncWorkflow)
 	psw.RegisterActivity(psa)
 
-	// Week stats worker: WeekStatsSyncDispatcher + SyncWeekStats
+	// Week stats worker: WeekStatsSyncDispatcher + SyncWeekStats, and the
+	// projections counterparts WeekProjectionsSyncDispatcher + SyncWeekProjections
 	wsw := worker.New(c, workflows.TaskQueueWeekStats, worker.Options{
 		DeploymentOptions: deploymentOpts,
 		SysInfoProvider:   sysinfo.SysInfoProvider(),
 	})
 	wsw.RegisterWorkflow(workflows.WeekStatsSyncDispatcher)
 	wsw.RegisterWorkflow(workflows.SyncWeekStats)
+	wsw.RegisterWorkflow(workflows.WeekProjectionsSyncDispatcher)
+	wsw.RegisterWorkflow(workflows.SyncWeekProjections)
 	wsw.RegisterActivity(wsa)
 
-	workers := []worker.Worker{draftsw, psw, wsw}
+	// Sleeper league worker: RegisterSleeperLeagueWorkflow + SleeperLeagueSyncDispatcher
+	lw := worker.New(c, workflows.TaskQueueLeagues, worker.Options{
+		DeploymentOptions: deploymentOpts,
+		SysInfoProvider:   sysinfo.SysInfoProvider(),
+	})
+	lw.RegisterWorkflow(workflows.RegisterSleeperLeagueWorkflow)
+	lw.RegisterWorkflow(workflows.SleeperLeagueSyncDispatcher)
+	lw.RegisterActivity(sla)
+
+	workers := []worker.Worker{draftsw, psw, wsw, lw}
 	if cfg.ArchiveDB.Enabled() {
 		sa := &activities.ScavengerActivities{Cloud: database.DB, Archive: database.Archive}
 		aw := worker.New(c, workflows.TaskQueueArchive, worker.Options{
@@ -143,8 +156,9 @@ func main() {
 		aw.RegisterActivity(sa)
 		workers = append(workers, aw)
 
-		// ADP worker: ADPRollupDispatcher + SegmentSeasonADPRollupWorkflow.
-		// Requires the archive DB (Read) — see ADPRollupActivities.
+		// ADP worker: ADPRollupDispatcher + SegmentSeasonADPRollupWorkflow +
+		// PickValuationWorkflow. Requires the archive DB (Read) — see
+		// ADPRollupActivities.
 		aa := &activities.ADPRollupActivities{Read: database.Archive, Write: database.DB}
 		adpw := worker.New(c, workflows.TaskQueueADP, worker.Options{
 			MaxConcurrentActivityExecutionSize: 50,
@@ -154,6 +168,7 @@ func main() {
 		})
 		adpw.RegisterWorkflow(workflows.ADPRollupDispatcher)
 		adpw.RegisterWorkflow(workflows.SegmentSeasonADPRollupWorkflow)
+		adpw.RegisterWorkflow(workflows.PickValuationWorkflow)
 		adpw.RegisterActivity(aa)
 		workers = append(workers, adpw)
 	}
@@ -0,0 +1,113 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+)
+
+// minRefinedADPDrafts is the fewest qualifying drafts a refined ADP segment
+// (one with TE premium, passing TD or lineup set) needs before
+// ListSegmentSeasonADPRefinements lists it for materializing. Below it the API falls
+// back to a coarser segment rather than serve ADP from a handful of drafts.
+const minRefinedADPDrafts = 50
+
+// adpStarterSlotsSQL counts a league's starting slots: every roster_positions
+// entry but bench, IR and taxi. As in auctionPriceSQL, JSON functions differ
+// by dialect — "postgres" in production, SQLite only ever in tests.
+func adpStarterSlotsSQL(dialect string) string {
+	if dialect == "postgres" {
+		return "(SELECT COUNT(*) FROM jsonb_array_elements_text(l.roster_positions) AS rp(pos) WHERE rp.pos NOT IN ('BN', 'IR', 'TAXI'))"
+	}
+	return "(SELECT COUNT(*) FROM json_each(CAST(l.roster_positions AS TEXT)) WHERE value NOT IN ('BN', 'IR', 'TAXI'))"
+}
+
+// adpDimensionSQL returns expressions bucketing a league (aliased "l") into
+// each optional segment dimension's values — models.ADPTEPremiums,
+// models.ADPPassTDs, models.ADPLineups — or an empty string where it falls
+// in none.
+func adpDimensionSQL(dialect string) (tep, passTD, lineup string) {
+	tep = "CASE WHEN COALESCE(l.te_premium, 0) > 0 THEN 'tep' ELSE 'no_tep' END"
+	passTDValue := "json_extract(CAST(l.scoring_settings AS TEXT), '$.pass_td')"
+	if dialect == "postgres" {
+		passTDValue = "CAST(l.scoring_settings->>'pass_td' AS NUMERIC)"
+	}
+	passTD = fmt.Sprintf("CASE %s WHEN 4 THEN '4pt' WHEN 6 THEN '6pt' ELSE '' END", passTDValue)
+	starters := adpStarterSlotsSQL(dialect)
+	lineup = fmt.Sprintf("CASE WHEN l.roster_positions IS NULL THEN '' WHEN %[1]s <= 8 THEN 'small' WHEN %[1]s <= 10 THEN 'standard' ELSE 'deep' END", starters)
+	return tep, passTD, lineup
+}
+
+// applyRefinementPredicate appends WHERE conditions for a segment's optional
+// dimensions; a no-op for a base segment.
+func applyRefinementPredicate(db *gorm.DB, seg models.ADPSegment) *gorm.DB {
+	if !seg.Refined() {
+		return db
+	}
+	tep, passTD, lineup := adpDimensionSQL(db.Dialector.Name())
+	if seg.TEPremium != "" {
+		db = db.Where(tep+" = ?", seg.TEPremium)
+	}
+	if seg.PassTD != "" {
+		db = db.Where(passTD+" = ?", seg.PassTD)
+	}
+	if seg.Lineup != "" {
+		db = db.Where(lineup+" = ?", seg.Lineup)
+	}
+	return db
+}
+
+type adpDimensionCountRow struct {
+	TEPremium string `gorm:"column:te_premium"`
+	PassTD    string `gorm:"column:pass_td"`
+	Lineup    string `gorm:"column:lineup"`
+	Drafts    int    `gorm:"column:drafts"`
+}
+
+// ListSegmentSeasonADPRefinements returns, sorted by key, the refined
+// segments under params.Segment (a base segment) that have at least
+// minRefinedADPDrafts qualifying drafts: one grouped count of the season's
+// drafts by dimension bucket, summed up into every combination of set
+// dimensions. SegmentSeasonADPRollupWorkflow then materializes each with its
+// own ComputeSegmentSeasonADP activity, so no one activity runs dozens of
+// rollups under a single timeout. Refinements below the bar are skipped, not
+// cleared — drafts only accumulate, so a materialized refinement stays above
+// it.
+func (a *ADPRollupActivities) ListSegmentSeasonADPRefinements(ctx context.Context, params ComputeSegmentSeasonADPParams) ([]models.ADPSegment, error) {
+	tep, passTD, lineup := adpDimensionSQL(a.Read.Dialector.Name())
+	db := a.Read.WithContext(ctx).
+		Table("sleeper_drafts d").
+		Select(fmt.Sprintf("%s AS te_premium, %s AS pass_td, %s AS lineup, COUNT(*) AS drafts", tep, passTD, lineup)).
+		Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = d.sleeper_league_id").
+		Where("d.status = ? AND d.type IN ? AND d.season = ?", "complete", qualifyingDraftTypes, params.Season)
+	db = applySegmentPredicate(db, params.Segment.Base())
+
+	var rows []adpDimensionCountRow
+	if err := db.Group("1, 2, 3").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	drafts := map[models.ADPSegment]int{}
+	for _, r := range rows {
+		full := params.Segment.Base()
+		full.TEPremium, full.PassTD, full.Lineup = r.TEPremium, r.PassTD, r.Lineup
+		for _, seg := range full.Fallbacks() {
+			if seg.Refined() {
+				drafts[seg] += r.Drafts
+			}
+		}
+	}
+	var segments []models.ADPSegment
+	for seg, n := range drafts {
+		if n >= minRefinedADPDrafts {
+			segments = append(segments, seg)
+		}
+	}
+	slices.SortFunc(segments, func(x, y models.ADPSegment) int { return strings.Compare(x.Key(), y.Key()) })
+	return segments, nil
+}
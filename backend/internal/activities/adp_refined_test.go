@@ -0,0 +1,83 @@
+package activities_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"backend/internal/activities"
+	"backend/internal/models"
+)
+
+func seedRefinedADPLeague(t *testing.T, db *gorm.DB, id string, tePremium float64, passTD int, starters []string) {
+	t.Helper()
+	scoring, _ := json.Marshal(map[string]float64{"rec": 1, "pass_td": float64(passTD)})
+	roster, _ := json.Marshal(append(starters, "BN", "BN", "BN"))
+	if err := db.Create(&models.SleeperLeague{
+		SleeperLeagueID: id,
+		TotalRosters:    12,
+		PPR:             floatPtr(1),
+		TEPremium:       &tePremium,
+		IsSuperflex:     boolPtr(true),
+		LeagueType:      "redraft",
+		ScoringSettings: scoring,
+		RosterPositions: roster,
+	}).Error; err != nil {
+		t.Fatalf("seed league %s: %v", id, err)
+	}
+}
+
+func TestListSegmentSeasonADPRefinements_ListsOnlyWellSampledCombinations(t *testing.T) {
+	db := newTestDB(t)
+	standard := []string{"QB", "RB", "RB", "WR", "WR", "TE", "FLEX", "SUPER_FLEX", "K", "DEF"}
+	deep := append(standard, "FLEX", "FLEX")
+	seedRefinedADPLeague(t, db, "lg-tep", 0.5, 6, standard)
+	seedRefinedADPLeague(t, db, "lg-plain", 0, 4, deep)
+	for i := range 50 {
+		id := fmt.Sprintf("d-tep-%d", i)
+		seedADPDraft(t, db, id, "lg-tep", "snake", "complete", "2025")
+		seedADPPick(t, db, id, 1, 2, "p1")
+	}
+	for i := range 10 {
+		id := fmt.Sprintf("d-plain-%d", i)
+		seedADPDraft(t, db, id, "lg-plain", "snake", "complete", "2025")
+		seedADPPick(t, db, id, 1, 8, "p1")
+	}
+
+	a := &activities.ADPRollupActivities{Read: db, Write: db}
+	segments, err := a.ListSegmentSeasonADPRefinements(context.Background(), activities.ComputeSegmentSeasonADPParams{
+		Segment: adpTestSegment,
+		Season:  "2025",
+	})
+	if err != nil {
+		t.Fatalf("ListSegmentSeasonADPRefinements: %v", err)
+	}
+	// Every combination of tep/6pt/standard has the 50 TE-premium drafts;
+	// nothing covering only the 10 plain drafts clears the bar.
+	var keys []string
+	for _, seg := range segments {
+		keys = append(keys, seg.Key())
+	}
+	want := []string{"12-ppr-sf-6pt", "12-ppr-sf-6pt-standard", "12-ppr-sf-standard", "12-ppr-sf-tep",
+		"12-ppr-sf-tep-6pt", "12-ppr-sf-tep-6pt-standard", "12-ppr-sf-tep-standard"}
+	if !slices.Equal(keys, want) {
+		t.Fatalf("expected %v, got %v", want, keys)
+	}
+
+	// A listed refinement materializes from only its own drafts.
+	full := segments[5]
+	if _, err := a.ComputeSegmentSeasonADP(context.Background(), activities.ComputeSegmentSeasonADPParams{Segment: full, Season: "2025"}); err != nil {
+		t.Fatalf("ComputeSegmentSeasonADP: %v", err)
+	}
+	var row models.DraftADP
+	if err := db.Where("segment = ? AND season = ?", full.Key(), "2025").First(&row).Error; err != nil {
+		t.Fatalf("fetch fully refined row: %v", err)
+	}
+	if row.AvgPickNo != 2 || row.PickCount != 50 {
+		t.Errorf("expected ADP 2 from the 50 TE-premium drafts, got %+v", row)
+	}
+}
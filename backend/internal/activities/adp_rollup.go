@@ -2,6 +2,7 @@ package activities
 
 import (
 	"context"
+	"slices"
 	"strconv"
 
 	"gorm.io/gorm"
@@ -12,7 +13,8 @@ import (
 
 // qualifyingDraftTypes are the Sleeper draft types comparable to a snake
 // pick order. Auction pick_no reflects nomination order, not draft slot
-// value, so auction drafts are excluded from ADP.
+// value, so auction drafts are excluded from ADP — their prices are rolled
+// up separately by ComputeSegmentSeasonAuctionValues.
 var qualifyingDraftTypes = []string{"snake", "linear"}
 
 // ADPRollupActivities holds dependencies for the daily ADP rollup worker.
@@ -23,15 +25,21 @@ type ADPRollupActivities struct {
 	Write *gorm.DB
 }
 
-// ListADPSeasons returns the distinct seasons with at least one qualifying
-// (complete, snake/linear, redraft) draft, so the dispatcher doesn't need a
+// adpLeagueTypes are the sleeper_leagues.league_type values ADP is computed
+// for — every type, each its own set of segments (see models.ADPFormats).
+var adpLeagueTypes = []string{"redraft", "keeper", "dynasty"}
+
+// ListADPSeasons returns the distinct seasons with at least one complete
+// draft that ADP or auction values are computed from (snake, linear or
+// auction, in any ADP league type), so the dispatcher doesn't need a
 // hardcoded season list.
 func (a *ADPRollupActivities) ListADPSeasons(ctx context.Context) ([]string, error) {
+	draftTypes := append(slices.Clone(qualifyingDraftTypes), auctionDraftType)
 	var seasons []string
 	err := a.Read.WithContext(ctx).
 		Table("sleeper_drafts d").
 		Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = d.sleeper_league_id").
-		Where("d.status = ? AND d.type IN ? AND l.league_type = ?", "complete", qualifyingDraftTypes, "redraft").
+		Where("d.status = ? AND d.type IN ? AND l.league_type IN ?", "complete", draftTypes, adpLeagueTypes).
 		Distinct("d.season").
 		Pluck("d.season", &seasons).Error
 	return seasons, err
@@ -84,8 +92,8 @@ func (a *ADPRollupActivities) ComputeSegmentSeasonADP(ctx context.Context, param
 		Select(adpSelectClause(a.Read.Dialector.Name())).
 		Joins("JOIN sleeper_drafts d ON d.sleeper_draft_id = p.sleeper_draft_id").
 		Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = d.sleeper_league_id").
-		Where("d.status = ? AND d.type IN ? AND l.league_type = ? AND d.season = ?",
-			"complete", qualifyingDraftTypes, "redraft", params.Season).
+		Where("d.status = ? AND d.type IN ? AND d.season = ?",
+			"complete", qualifyingDraftTypes, params.Season).
 		Where("p.sleeper_player_id != ''")
 	db = applySegmentPredicate(db, params.Segment)
 
@@ -133,9 +141,20 @@ func (a *ADPRollupActivities) ComputeSegmentSeasonADP(ctx context.Context, param
 }
 
 // applySegmentPredicate appends WHERE conditions for one ADP segment's
-// league_size/scoring_format/superflex bucket onto a query already joined to
-// sleeper_leagues as "l".
+// format/league_size/scoring_format/superflex bucket, plus any optional
+// dimensions (applyRefinementPredicate), onto a query already joined to
+// sleeper_leagues as "l" and sleeper_drafts as "d".
 func applySegmentPredicate(db *gorm.DB, seg models.ADPSegment) *gorm.DB {
+	switch seg.Format {
+	case models.ADPFormatStartup:
+		db = db.Where("l.league_type = ? AND d.rounds > ?", "dynasty", models.DynastyRookieMaxRounds)
+	case models.ADPFormatRookie:
+		db = db.Where("l.league_type = ? AND d.rounds <= ?", "dynasty", models.DynastyRookieMaxRounds)
+	case models.ADPFormatKeeper:
+		db = db.Where("l.league_type = ?", "keeper")
+	default:
+		db = db.Where("l.league_type = ?", "redraft")
+	}
 	if seg.LeagueSize == "14+" {
 		db = db.Where("l.total_rosters >= ?", 14)
 	} else if n, err := strconv.Atoi(seg.LeagueSize); err == nil {
@@ -149,5 +168,6 @@ func applySegmentPredicate(db *gorm.DB, seg models.ADPSegment) *gorm.DB {
 	case "ppr":
 		db = db.Where("l.ppr = ?", 1)
 	}
-	return db.Where("l.is_superflex = ?", seg.Superflex)
+	db = db.Where("l.is_superflex = ?", seg.Superflex)
+	return applyRefinementPredicate(db, seg)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"testing"
 
 	"gorm.io/gorm"
@@ -60,17 +61,21 @@ func TestListADPSeasons_ReturnsOnlyQualifyingSeasons(t *testing.T) {
 	db := newTestDB(t)
 	seedADPLeague(t, db, "lg1", 12, 1.0, true, "redraft")
 	seedADPDraft(t, db, "d1", "lg1", "snake", "complete", "2024")   // qualifying
-	seedADPDraft(t, db, "d2", "lg1", "auction", "complete", "2025") // wrong draft type
+	seedADPDraft(t, db, "d2", "lg1", "auction", "complete", "2025") // qualifying, for auction values
+	seedADPDraft(t, db, "d4", "lg1", "snake", "pre_draft", "2027")  // not complete
 	seedADPLeague(t, db, "lg2", 12, 1.0, true, "dynasty")
-	seedADPDraft(t, db, "d3", "lg2", "snake", "complete", "2026") // wrong league type
+	seedADPDraft(t, db, "d3", "lg2", "snake", "complete", "2026") // qualifying, for dynasty ADP
+	seedADPLeague(t, db, "lg3", 12, 1.0, true, "")
+	seedADPDraft(t, db, "d5", "lg3", "snake", "complete", "2028") // league details not fetched
 
 	a := &activities.ADPRollupActivities{Read: db, Write: db}
 	seasons, err := a.ListADPSeasons(context.Background())
 	if err != nil {
 		t.Fatalf("ListADPSeasons error: %v", err)
 	}
-	if len(seasons) != 1 || seasons[0] != "2024" {
-		t.Errorf("expected [2024], got %v", seasons)
+	slices.Sort(seasons)
+	if !slices.Equal(seasons, []string{"2024", "2025", "2026"}) {
+		t.Errorf("expected [2024 2025 2026], got %v", seasons)
 	}
 }
 
@@ -184,6 +189,58 @@ func TestComputeSegmentSeasonADP_ExcludesAuctionAndNonRedraft(t *testing.T) {
 	}
 }
 
+func TestComputeSegmentSeasonADP_SplitsDynastyKeeperFormats(t *testing.T) {
+	db := newTestDB(t)
+	seedADPLeague(t, db, "lg-dyn", 12, 1.0, true, "dynasty")
+	seedADPLeague(t, db, "lg-keep", 12, 1.0, true, "keeper")
+	seedADPLeague(t, db, "lg-red", 12, 1.0, true, "redraft")
+	for _, d := range []struct {
+		id, league string
+		rounds     int
+	}{
+		{"d-startup", "lg-dyn", 25},
+		{"d-rookie", "lg-dyn", 4},
+		{"d-keeper", "lg-keep", 15},
+		{"d-redraft", "lg-red", 15},
+	} {
+		rounds := d.rounds
+		if err := db.Create(&models.SleeperDraft{
+			SleeperDraftID: d.id, SleeperLeagueID: d.league, Type: "snake", Status: "complete", Season: "2025", Rounds: &rounds,
+		}).Error; err != nil {
+			t.Fatalf("seed draft %s: %v", d.id, err)
+		}
+	}
+	// A dynasty draft without a round count is neither startup nor rookie.
+	seedADPDraft(t, db, "d-unknown", "lg-dyn", "snake", "complete", "2025")
+	seedADPPick(t, db, "d-startup", 1, 1, "p-startup")
+	seedADPPick(t, db, "d-rookie", 1, 1, "p-rookie")
+	seedADPPick(t, db, "d-keeper", 1, 1, "p-keeper")
+	seedADPPick(t, db, "d-redraft", 1, 1, "p-redraft")
+	seedADPPick(t, db, "d-unknown", 1, 1, "p-unknown")
+
+	a := &activities.ADPRollupActivities{Read: db, Write: db}
+	for format, want := range map[string]string{
+		models.ADPFormatRedraft: "p-redraft",
+		models.ADPFormatStartup: "p-startup",
+		models.ADPFormatRookie:  "p-rookie",
+		models.ADPFormatKeeper:  "p-keeper",
+	} {
+		seg := adpTestSegment
+		seg.Format = format
+		if _, err := a.ComputeSegmentSeasonADP(context.Background(), activities.ComputeSegmentSeasonADPParams{
+			Segment: seg,
+			Season:  "2025",
+		}); err != nil {
+			t.Fatalf("ComputeSegmentSeasonADP(%s): %v", format, err)
+		}
+		var players []string
+		db.Model(&models.DraftADP{}).Where("segment = ?", seg.Key()).Pluck("sleeper_player_id", &players)
+		if !slices.Equal(players, []string{want}) {
+			t.Errorf("%s (%s): expected [%s], got %v", format, seg.Key(), want, players)
+		}
+	}
+}
+
 func TestComputeSegmentSeasonADP_NoMinDraftsThresholdAtWriteTime(t *testing.T) {
 	db := newTestDB(t)
 	seedADPLeague(t, db, "lg1", 12, 1.0, true, "redraft")
@@ -0,0 +1,84 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"backend/internal/models"
+)
+
+const (
+	// adpWeekMillis is one ADP trend bucket.
+	adpWeekMillis = int64(7 * 24 * time.Hour / time.Millisecond)
+	// adpWeekEpochMillis is the first Monday after the Unix epoch (1970-01-05
+	// UTC), which bucket boundaries are measured from so every week_start is
+	// a Monday.
+	adpWeekEpochMillis = int64(4 * 24 * time.Hour / time.Millisecond)
+)
+
+type adpWeekRow struct {
+	SleeperPlayerID string  `gorm:"column:sleeper_player_id"`
+	WeekIndex       int64   `gorm:"column:week_index"`
+	AvgPickNo       float64 `gorm:"column:avg_pick_no"`
+	PickCount       int     `gorm:"column:pick_count"`
+}
+
+// adpWeekStart returns the Monday (UTC) that begins trend bucket weekIndex.
+func adpWeekStart(weekIndex int64) time.Time {
+	return time.UnixMilli(adpWeekEpochMillis + weekIndex*adpWeekMillis).UTC()
+}
+
+// ComputeSegmentSeasonADPTrend computes ADP per week of draft start for
+// every player picked in the qualifying drafts ComputeSegmentSeasonADP
+// reads, then upserts one draft_adp_weekly row per (player, week). Drafts
+// without a recorded start time are left out. The week index is integer
+// division on the start time, so the bucketing is the same under every SQL
+// dialect.
+func (a *ADPRollupActivities) ComputeSegmentSeasonADPTrend(ctx context.Context, params ComputeSegmentSeasonADPParams) (ADPRollupResult, error) {
+	weekIndex := fmt.Sprintf("(d.start_time - %d) / %d", adpWeekEpochMillis, adpWeekMillis)
+	db := a.Read.WithContext(ctx).
+		Table("sleeper_draft_picks p").
+		Select("p.sleeper_player_id, "+weekIndex+" AS week_index, AVG(p.pick_no) AS avg_pick_no, COUNT(*) AS pick_count").
+		Joins("JOIN sleeper_drafts d ON d.sleeper_draft_id = p.sleeper_draft_id").
+		Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = d.sleeper_league_id").
+		Where("d.status = ? AND d.type IN ? AND d.season = ?",
+			"complete", qualifyingDraftTypes, params.Season).
+		Where("d.start_time IS NOT NULL AND d.start_time >= ?", adpWeekEpochMillis).
+		Where("p.sleeper_player_id != ''")
+	db = applySegmentPredicate(db, params.Segment)
+
+	var rows []adpWeekRow
+	if err := db.Group("p.sleeper_player_id, " + weekIndex).Scan(&rows).Error; err != nil {
+		return ADPRollupResult{}, err
+	}
+	if len(rows) == 0 {
+		return ADPRollupResult{}, nil
+	}
+
+	segmentKey := params.Segment.Key()
+	records := make([]models.DraftADPWeek, len(rows))
+	for i, r := range rows {
+		records[i] = models.DraftADPWeek{
+			Segment:         segmentKey,
+			Season:          params.Season,
+			WeekStart:       adpWeekStart(r.WeekIndex),
+			SleeperPlayerID: r.SleeperPlayerID,
+			AvgPickNo:       r.AvgPickNo,
+			PickCount:       r.PickCount,
+		}
+	}
+
+	if err := a.Write.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "segment"}, {Name: "season"}, {Name: "week_start"}, {Name: "sleeper_player_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"avg_pick_no", "pick_count", "updated_at"}),
+	}).CreateInBatches(&records, 500).Error; err != nil {
+		return ADPRollupResult{}, err
+	}
+
+	return ADPRollupResult{PlayersUpserted: len(records)}, nil
+}
@@ -0,0 +1,64 @@
+package activities_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/activities"
+	"backend/internal/models"
+)
+
+func TestComputeSegmentSeasonADPTrend_BucketsByDraftStartWeek(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.AutoMigrate(&models.DraftADPWeek{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	seedADPLeague(t, db, "lg1", 12, 1.0, true, "redraft")
+	// Two drafts in the week of Monday 2025-08-04 (a Tuesday and the
+	// Sunday), one the Monday after.
+	starts := map[string]time.Time{
+		"d1": time.Date(2025, 8, 5, 20, 0, 0, 0, time.UTC),
+		"d2": time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC),
+		"d3": time.Date(2025, 8, 11, 1, 0, 0, 0, time.UTC),
+	}
+	for id, start := range starts {
+		ms := start.UnixMilli()
+		if err := db.Create(&models.SleeperDraft{
+			SleeperDraftID: id, SleeperLeagueID: "lg1", Type: "snake", Status: "complete", Season: "2025", StartTime: &ms,
+		}).Error; err != nil {
+			t.Fatalf("seed draft %s: %v", id, err)
+		}
+	}
+	// No start time: counted in season ADP, not in any week.
+	seedADPDraft(t, db, "d4", "lg1", "snake", "complete", "2025")
+	seedADPPick(t, db, "d1", 1, 4, "p1")
+	seedADPPick(t, db, "d2", 1, 6, "p1")
+	seedADPPick(t, db, "d3", 1, 2, "p1")
+	seedADPPick(t, db, "d4", 1, 9, "p1")
+
+	a := &activities.ADPRollupActivities{Read: db, Write: db}
+	res, err := a.ComputeSegmentSeasonADPTrend(context.Background(), activities.ComputeSegmentSeasonADPParams{
+		Segment: adpTestSegment,
+		Season:  "2025",
+	})
+	if err != nil {
+		t.Fatalf("ComputeSegmentSeasonADPTrend: %v", err)
+	}
+	if res.PlayersUpserted != 2 {
+		t.Errorf("expected 2 player-weeks, got %d", res.PlayersUpserted)
+	}
+
+	var weeks []models.DraftADPWeek
+	db.Where("segment = ? AND season = ? AND sleeper_player_id = ?", "12-ppr-sf", "2025", "p1").
+		Order("week_start").Find(&weeks)
+	if len(weeks) != 2 {
+		t.Fatalf("expected 2 weeks, got %+v", weeks)
+	}
+	if !weeks[0].WeekStart.Equal(time.Date(2025, 8, 4, 0, 0, 0, 0, time.UTC)) || weeks[0].AvgPickNo != 5 || weeks[0].PickCount != 2 {
+		t.Errorf("expected week of 08-04 at ADP 5 from 2 drafts, got %+v", weeks[0])
+	}
+	if !weeks[1].WeekStart.Equal(time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)) || weeks[1].AvgPickNo != 2 {
+		t.Errorf("expected week of 08-11 at ADP 2, got %+v", weeks[1])
+	}
+}
@@ -0,0 +1,116 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+
+	"backend/internal/models"
+)
+
+// auctionDraftType is the Sleeper draft type excluded from ADP by
+// qualifyingDraftTypes and rolled up into auction_values instead.
+const auctionDraftType = "auction"
+
+type auctionValueRow struct {
+	SleeperPlayerID string  `gorm:"column:sleeper_player_id"`
+	AvgValue        float64 `gorm:"column:avg_value"`
+	PickCount       int     `gorm:"column:pick_count"`
+	MinValue        float64 `gorm:"column:min_value"`
+	MaxValue        float64 `gorm:"column:max_value"`
+	P10Value        float64 `gorm:"column:p10_value"`
+	P50Value        float64 `gorm:"column:p50_value"`
+	P90Value        float64 `gorm:"column:p90_value"`
+}
+
+// auctionPriceSQL returns the expression for one pick's price normalized to
+// models.AuctionReferenceBudget, and the predicate keeping only picks with a
+// numeric price. Sleeper records the price as a string in the pick's
+// metadata.amount. The JSON operators differ by dialect, as in
+// adpSelectClause: "postgres" in production, SQLite only ever in tests.
+func auctionPriceSQL(dialect string) (price, hasPrice string) {
+	amount := "CAST(json_extract(CAST(p.metadata AS TEXT), '$.amount') AS REAL)"
+	hasPrice = "json_extract(CAST(p.metadata AS TEXT), '$.amount') IS NOT NULL"
+	if dialect == "postgres" {
+		amount = "CAST(p.metadata->>'amount' AS DOUBLE PRECISION)"
+		// No "?" in the pattern: gorm would read it as a bind placeholder.
+		hasPrice = `p.metadata->>'amount' ~ '^[0-9]+([.][0-9]+){0,1}$'`
+	}
+	budget := fmt.Sprintf("COALESCE(NULLIF(d.budget, 0), %d)", models.AuctionReferenceBudget)
+	return fmt.Sprintf("%s * %d / %s", amount, models.AuctionReferenceBudget, budget), hasPrice
+}
+
+// auctionSelectClause mirrors adpSelectClause for auction prices: ordinary
+// aggregates everywhere, plus the 10th/50th/90th price percentiles via
+// PERCENTILE_CONT on Postgres only (left at zero under SQLite).
+func auctionSelectClause(dialect, price string) string {
+	r := strings.NewReplacer("{price}", price)
+	sel := r.Replace(
+		"p.sleeper_player_id, AVG({price}) AS avg_value, COUNT(*) AS pick_count, MIN({price}) AS min_value, MAX({price}) AS max_value")
+	if dialect == "postgres" {
+		sel += r.Replace(
+			", PERCENTILE_CONT(0.1) WITHIN GROUP (ORDER BY {price}) AS p10_value" +
+				", PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY {price}) AS p50_value" +
+				", PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY {price}) AS p90_value")
+	}
+	return sel
+}
+
+// ComputeSegmentSeasonAuctionValues is ComputeSegmentSeasonADP for auction
+// drafts: it computes every bought player's normalized price stats in
+// complete auction drafts matching params.Segment and params.Season,
+// then upserts one auction_values row per player. As with ADP, the minimum
+// sample size is enforced at API read time.
+func (a *ADPRollupActivities) ComputeSegmentSeasonAuctionValues(ctx context.Context, params ComputeSegmentSeasonADPParams) (ADPRollupResult, error) {
+	dialect := a.Read.Dialector.Name()
+	price, hasPrice := auctionPriceSQL(dialect)
+	db := a.Read.WithContext(ctx).
+		Table("sleeper_draft_picks p").
+		Select(auctionSelectClause(dialect, price)).
+		Joins("JOIN sleeper_drafts d ON d.sleeper_draft_id = p.sleeper_draft_id").
+		Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = d.sleeper_league_id").
+		Where("d.status = ? AND d.type = ? AND d.season = ?",
+			"complete", auctionDraftType, params.Season).
+		Where("p.sleeper_player_id != ''").
+		Where(hasPrice)
+	db = applySegmentPredicate(db, params.Segment)
+
+	var rows []auctionValueRow
+	if err := db.Group("p.sleeper_player_id").Scan(&rows).Error; err != nil {
+		return ADPRollupResult{}, err
+	}
+	if len(rows) == 0 {
+		return ADPRollupResult{}, nil
+	}
+
+	segmentKey := params.Segment.Key()
+	records := make([]models.AuctionValue, len(rows))
+	for i, r := range rows {
+		records[i] = models.AuctionValue{
+			Segment:         segmentKey,
+			Season:          params.Season,
+			SleeperPlayerID: r.SleeperPlayerID,
+			AvgValue:        r.AvgValue,
+			PickCount:       r.PickCount,
+			MinValue:        r.MinValue,
+			MaxValue:        r.MaxValue,
+			P10Value:        r.P10Value,
+			P50Value:        r.P50Value,
+			P90Value:        r.P90Value,
+		}
+	}
+
+	// One batched upsert, for the same reason as ComputeSegmentSeasonADP's.
+	if err := a.Write.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "segment"}, {Name: "season"}, {Name: "sleeper_player_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"avg_value", "pick_count", "min_value", "max_value", "p10_value", "p50_value", "p90_value", "updated_at",
+		}),
+	}).CreateInBatches(&records, 500).Error; err != nil {
+		return ADPRollupResult{}, err
+	}
+
+	return ADPRollupResult{PlayersUpserted: len(records)}, nil
+}
@@ -0,0 +1,92 @@
+package activities_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"backend/internal/activities"
+	"backend/internal/models"
+)
+
+func seedAuctionDraft(t *testing.T, db *gorm.DB, id, leagueID, season string, budget *int) {
+	t.Helper()
+	if err := db.Create(&models.SleeperDraft{
+		SleeperDraftID: id, SleeperLeagueID: leagueID, Type: "auction", Status: "complete", Season: season, Budget: budget,
+	}).Error; err != nil {
+		t.Fatalf("seed draft %s: %v", id, err)
+	}
+}
+
+func seedAuctionPick(t *testing.T, db *gorm.DB, draftID string, pickNo int, playerID string, metadata map[string]string) {
+	t.Helper()
+	raw, _ := json.Marshal(metadata)
+	if err := db.Create(&models.SleeperDraftPick{
+		SleeperDraftID: draftID, Round: 1, PickNo: pickNo, SleeperPlayerID: playerID, Metadata: raw,
+	}).Error; err != nil {
+		t.Fatalf("seed pick %s/%d: %v", draftID, pickNo, err)
+	}
+}
+
+func TestComputeSegmentSeasonAuctionValues_NormalizesByBudget(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.AutoMigrate(&models.AuctionValue{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	seedADPLeague(t, db, "lg1", 12, 1.0, true, "redraft")
+	seedADPLeague(t, db, "lg-dyn", 12, 1.0, true, "dynasty")
+	big := 1000
+	seedAuctionDraft(t, db, "a1", "lg1", "2025", nil)  // no recorded budget: assumed $200
+	seedAuctionDraft(t, db, "a2", "lg1", "2025", &big) // $1000 budget: prices scaled by 1/5
+	seedAuctionDraft(t, db, "a3", "lg-dyn", "2025", nil)
+	seedAuctionPick(t, db, "a1", 1, "p1", map[string]string{"amount": "60"})
+	seedAuctionPick(t, db, "a2", 1, "p1", map[string]string{"amount": "200"})
+	seedAuctionPick(t, db, "a1", 2, "p2", map[string]string{"amount": "1"})
+	// A pick without a price, and one in a dynasty league, aren't counted.
+	seedAuctionPick(t, db, "a2", 2, "p2", map[string]string{"years_exp": "3"})
+	seedAuctionPick(t, db, "a3", 1, "p1", map[string]string{"amount": "150"})
+	// A snake draft's picks never feed auction values.
+	seedADPDraft(t, db, "s1", "lg1", "snake", "complete", "2025")
+	seedADPPick(t, db, "s1", 1, 1, "p1")
+
+	a := &activities.ADPRollupActivities{Read: db, Write: db}
+	res, err := a.ComputeSegmentSeasonAuctionValues(context.Background(), activities.ComputeSegmentSeasonADPParams{
+		Segment: adpTestSegment,
+		Season:  "2025",
+	})
+	if err != nil {
+		t.Fatalf("ComputeSegmentSeasonAuctionValues: %v", err)
+	}
+	if res.PlayersUpserted != 2 {
+		t.Errorf("expected 2 players upserted, got %d", res.PlayersUpserted)
+	}
+
+	var p1 models.AuctionValue
+	if err := db.Where("segment = ? AND season = ? AND sleeper_player_id = ?", "12-ppr-sf", "2025", "p1").First(&p1).Error; err != nil {
+		t.Fatalf("load p1: %v", err)
+	}
+	// $60 of $200 and $200 of $1000 ($40 of $200).
+	if p1.PickCount != 2 || p1.AvgValue != 50 || p1.MinValue != 40 || p1.MaxValue != 60 {
+		t.Errorf("expected p1 bought twice for $40-$60 normalized, got %+v", p1)
+	}
+	var p2 models.AuctionValue
+	db.Where("sleeper_player_id = ?", "p2").First(&p2)
+	if p2.PickCount != 1 || p2.AvgValue != 1 {
+		t.Errorf("expected p2's one priced purchase, got %+v", p2)
+	}
+
+	// Re-running upserts in place.
+	if _, err := a.ComputeSegmentSeasonAuctionValues(context.Background(), activities.ComputeSegmentSeasonADPParams{
+		Segment: adpTestSegment,
+		Season:  "2025",
+	}); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	var count int64
+	db.Model(&models.AuctionValue{}).Count(&count)
+	if count != 2 {
+		t.Errorf("expected 2 rows after a re-run, got %d", count)
+	}
+}
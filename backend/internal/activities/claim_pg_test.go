@@ -86,7 +86,7 @@ func TestClaimLeaguesForDrafts_Eligibility(t *testing.T) {
 	}
 }
 
-func TestClaimLeaguesForDrafts_ExcludesNonRedraftLeagues(t *testing.T) {
+func TestClaimLeaguesForDrafts_ClaimsEveryADPLeagueType(t *testing.T) {
 	db := newPGTestDB(t)
 	now := time.Now().UTC()
 	seedLeague(t, db, models.SleeperLeague{SleeperLeagueID: "redraft-lg", Status: "pre_draft", LastFetchedAt: &now, LeagueType: "redraft"})
@@ -102,11 +102,9 @@ func TestClaimLeaguesForDrafts_ExcludesNonRedraftLeagues(t *testing.T) {
 	for _, id := range got {
 		claimed[id] = true
 	}
-	if !claimed["redraft-lg"] {
-		t.Error("expected redraft-lg to be claimed")
-	}
-	if claimed["keeper-lg"] || claimed["dynasty-lg"] {
-		t.Errorf("expected keeper/dynasty leagues NOT to be claimed, got %v", got)
+	// Keeper and dynasty drafts feed their own ADP segments.
+	if !claimed["redraft-lg"] || !claimed["keeper-lg"] || !claimed["dynasty-lg"] {
+		t.Errorf("expected redraft, keeper and dynasty leagues to be claimed, got %v", got)
 	}
 }
 
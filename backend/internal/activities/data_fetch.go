@@ -35,13 +35,15 @@ func currentSleeperSeason() string {
 	return strconv.Itoa(time.Now().Year())
 }
 
-// IsPlayerOnlyTransaction mirrors the valuation model's query-time filter
-// (analysis/src/parsing.py parse_trade) at write time, so this data never
-// reaches cloud or the archive DB at all. Shared by ScavengerActivities'
-// transaction replication and internal/transactioncron's ingest-time
-// filtering.
-func IsPlayerOnlyTransaction(draftPicks, waiverBudget json.RawMessage) bool {
-	return isEmptyJSONArray(draftPicks) && isEmptyJSONArray(waiverBudget)
+// IsIngestedTransaction reports whether a transaction is kept at write time
+// — everything except FAAB-bearing trades, so that data never reaches cloud
+// or the archive DB at all. Trades with draft picks are kept: they're valued
+// via pick_valuations (see valuation.ComputeTradeValues), and the valuation
+// model still skips them at query time (analysis/src/parsing.py
+// parse_trade). Shared by ScavengerActivities' transaction replication and
+// internal/transactioncron's ingest-time filtering.
+func IsIngestedTransaction(waiverBudget json.RawMessage) bool {
+	return isEmptyJSONArray(waiverBudget)
 }
 
 func isEmptyJSONArray(raw json.RawMessage) bool {
@@ -71,16 +73,16 @@ func (a *DataFetchActivities) GetDraftSyncConfig(ctx context.Context) (DraftSync
 // the two sync paths never contend). Leagues whose drafting is finished
 // (in_season/complete) and already fetched are excluded — completed drafts are
 // immutable, so refetching them buys nothing; pre_draft and drafting leagues
-// keep rechecking until their drafts complete. league_type = 'redraft'
-// excludes keeper/dynasty leagues, which the valuation model never reads
-// (analysis/src/db.py get_adp); last_fetched_at IS NOT NULL above guarantees
-// league_type is already populated (set together in FetchLeagueDetails).
+// keep rechecking until their drafts complete. Every league type is synced:
+// keeper and dynasty drafts feed their own ADP segments (models.ADPFormats).
+// last_fetched_at IS NOT NULL above guarantees league_type is already
+// populated (set together in FetchLeagueDetails).
 const claimLeaguesForDraftsSQL = `
 UPDATE sleeper_leagues SET drafts_claimed_at = now()
 WHERE sleeper_league_id IN (
     SELECT sleeper_league_id FROM sleeper_leagues
     WHERE skipped_at IS NULL AND last_fetched_at IS NOT NULL AND season >= '2025'
-      AND league_type = 'redraft'
+      AND league_type IN ('redraft', 'keeper', 'dynasty')
       AND NOT (status IN ('in_season', 'complete') AND last_drafts_fetched_at IS NOT NULL)
       AND (drafts_claimed_at IS NULL OR drafts_claimed_at < now() - interval '20 minutes')
     ORDER BY last_drafts_fetched_at ASC NULLS FIRST
@@ -195,10 +197,13 @@ func (a *DataFetchActivities) syncOneLeagueDrafts(ctx context.Context, leagueID
 			Type:            d.Type,
 			Status:          d.Status,
 			Season:          d.Season,
+			Budget:          auctionBudget(d),
+			StartTime:       draftStartTime(d),
+			Rounds:          draftRounds(d),
 		}
 		if err := a.DB.WithContext(ctx).Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "sleeper_draft_id"}},
-			DoUpdates: clause.AssignmentColumns([]string{"status", "type", "season"}),
+			DoUpdates: clause.AssignmentColumns([]string{"status", "type", "season", "budget", "start_time", "rounds"}),
 		}).Create(&row).Error; err != nil {
 			return err
 		}
@@ -295,15 +300,47 @@ func (a *DataFetchActivities) upsertArchiveDraftHeader(ctx context.Context, d sl
 		Type:            d.Type,
 		Status:          d.Status,
 		Season:          d.Season,
+		Budget:          auctionBudget(d),
+		StartTime:       draftStartTime(d),
+		Rounds:          draftRounds(d),
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}
 	return a.Archive.WithContext(ctx).Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "sleeper_draft_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"status", "type", "season", "updated_at"}),
+		DoUpdates: clause.AssignmentColumns([]string{"status", "type", "season", "budget", "start_time", "rounds", "updated_at"}),
 	}).Create(&row).Error
 }
 
+// auctionBudget returns an auction draft's per-team budget, nil for any other
+// draft type or when Sleeper didn't report one.
+func auctionBudget(d sleeper.Draft) *int {
+	if d.Type != "auction" || d.Settings.Budget <= 0 {
+		return nil
+	}
+	budget := d.Settings.Budget
+	return &budget
+}
+
+// draftStartTime returns a draft's start time in Unix milliseconds, nil when
+// Sleeper reports none.
+func draftStartTime(d sleeper.Draft) *int64 {
+	if d.StartTime <= 0 {
+		return nil
+	}
+	start := d.StartTime
+	return &start
+}
+
+// draftRounds returns a draft's round count, nil when Sleeper reports none.
+func draftRounds(d sleeper.Draft) *int {
+	if d.Settings.Rounds <= 0 {
+		return nil
+	}
+	rounds := d.Settings.Rounds
+	return &rounds
+}
+
 // fetchArchiveDraftPicks mirrors fetchDraftPicks but writes directly to the
 // archive DB for an old (archive-routed) draft — see syncOneLeagueDrafts.
 func (a *DataFetchActivities) fetchArchiveDraftPicks(ctx context.Context, draftID string) error {
@@ -45,6 +45,9 @@ func newTestDB(t *testing.T) *gorm.DB {
 		&models.SleeperPlayer{},
 		&models.SleeperPlayerWeekStat{},
 		&models.SleeperWeekStatFetch{},
+		&models.SleeperPlayerWeekStatLine{},
+		&models.SleeperPlayerWeekProjection{},
+		&models.SleeperWeekProjectionFetch{},
 		&models.DraftADP{},
 		&models.Player{},
 	); err != nil {
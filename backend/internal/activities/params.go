@@ -36,6 +36,19 @@ type GetFinalizedWeeksParams struct {
 	Season string
 }
 
+type FetchWeekProjectionsParams struct {
+	Season string
+	Week   int
+}
+
+type RegisterSleeperLeagueParams struct {
+	SleeperLeagueID string
+}
+
+type SyncSleeperLeagueParams struct {
+	LeagueID uint
+}
+
 type ComputeSegmentSeasonADPParams struct {
 	Segment models.ADPSegment
 	Season  string
@@ -125,8 +138,37 @@ type WeekStatsResult struct {
 	Finalized       bool
 }
 
-// ADPRollupResult reports how many player rows ComputeSegmentSeasonADP
-// upserted for one (segment, season) pair.
+// WeekProjectionsResult reports how many player rows FetchWeekProjections
+// upserted for one week, and whether that week is finalized.
+type WeekProjectionsResult struct {
+	PlayersUpserted int
+	Finalized       bool
+}
+
+// ADPRollupResult reports how many rows ComputeSegmentSeasonADP (or
+// ComputeSegmentSeasonADPTrend, or ComputeSegmentSeasonAuctionValues)
+// upserted for one (segment, season) pair — one per player, or per player
+// per week for the trend.
 type ADPRollupResult struct {
 	PlayersUpserted int
 }
+
+// PickValuationResult reports what ComputePickValuations wrote: how many
+// valuation segments got pick values and how many pick_valuations rows were
+// upserted across them.
+type PickValuationResult struct {
+	SegmentsValued int
+	PicksUpserted  int
+}
+
+// SleeperLeagueImportResult reports what RegisterSleeperLeague or
+// SyncSleeperLeague wrote for one league. UnresolvedPlayers counts lineup
+// entries skipped for want of a players row (see sleeperimport.Result).
+type SleeperLeagueImportResult struct {
+	LeagueID          uint
+	Seasons           int
+	Teams             int
+	Matchups          int
+	BoxScores         int
+	UnresolvedPlayers int
+}
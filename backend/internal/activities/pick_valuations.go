@@ -0,0 +1,102 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+// pickValuationMinDrafts is the minimum number of drafts a player must have
+// been picked in for his ADP to place him against a pick — the same floor
+// GET /sleeper/adp applies by default, so one-off reaches don't move a slot.
+const pickValuationMinDrafts = 20
+
+// ComputePickValuations writes pick_valuations for every segment with
+// player_valuations (valuation.ValuedSegments) from the segment's latest ADP
+// season and latest player_valuations date (see
+// valuation.ComputePickSlotValues). It reads and writes only cloud (Write),
+// where both draft_adp and player_valuations live, and runs on the ADP
+// worker because draft_adp only exists where that worker does. A segment
+// missing either input is skipped, not an error.
+func (a *ADPRollupActivities) ComputePickValuations(ctx context.Context) (PickValuationResult, error) {
+	db := a.Write.WithContext(ctx)
+	var res PickValuationResult
+	segments, err := valuation.ValuedSegments(db)
+	if err != nil {
+		return res, fmt.Errorf("valued segments: %w", err)
+	}
+	for _, seg := range segments {
+		adpSegment := valuation.ADPSegmentFor(seg)
+		if adpSegment == "" {
+			continue
+		}
+		var latest []valuation.Snapshot
+		if err := db.Table("player_valuations").
+			Select("valuation_date").
+			Where("segment = ?", seg).
+			Order("valuation_date DESC").
+			Limit(1).
+			Scan(&latest).Error; err != nil {
+			return res, fmt.Errorf("latest valuation date for %s: %w", seg, err)
+		}
+		if len(latest) == 0 {
+			continue
+		}
+		valuationDate := latest[0].ValuationDate
+
+		var snaps []valuation.Snapshot
+		if err := db.Table("player_valuations").
+			Select("sleeper_player_id, value").
+			Where("segment = ? AND valuation_date = ?", seg, valuationDate).
+			Scan(&snaps).Error; err != nil {
+			return res, fmt.Errorf("player valuations for %s: %w", seg, err)
+		}
+		playerValues := make(map[string]float64, len(snaps))
+		for _, s := range snaps {
+			playerValues[s.SleeperPlayerID] = s.Value
+		}
+
+		var adpSeasons []string
+		if err := db.Model(&models.DraftADP{}).
+			Where("segment = ?", adpSegment).
+			Distinct("season").
+			Order("season DESC").
+			Limit(1).
+			Pluck("season", &adpSeasons).Error; err != nil {
+			return res, fmt.Errorf("latest ADP season for %s: %w", adpSegment, err)
+		}
+		if len(adpSeasons) == 0 {
+			continue
+		}
+		var adpRows []models.DraftADP
+		if err := db.Where("segment = ? AND season = ? AND pick_count >= ?", adpSegment, adpSeasons[0], pickValuationMinDrafts).
+			Find(&adpRows).Error; err != nil {
+			return res, fmt.Errorf("ADP for %s %s: %w", adpSegment, adpSeasons[0], err)
+		}
+		adp := make([]valuation.ADPEntry, len(adpRows))
+		for i, r := range adpRows {
+			adp[i] = valuation.ADPEntry{SleeperPlayerID: r.SleeperPlayerID, AvgPickNo: r.AvgPickNo}
+		}
+
+		slots := valuation.ComputePickSlotValues(adp, playerValues, valuation.TeamsInSegment(seg))
+		records := valuation.PickSeasonValues(seg, adpSeasons[0], valuationDate, slots)
+		if len(records) == 0 {
+			continue
+		}
+		if err := db.Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "segment"}, {Name: "season"}, {Name: "round"}, {Name: "slot"}, {Name: "valuation_date"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{"value"}),
+		}).CreateInBatches(&records, 500).Error; err != nil {
+			return res, fmt.Errorf("upsert pick valuations for %s: %w", seg, err)
+		}
+		res.SegmentsValued++
+		res.PicksUpserted += len(records)
+	}
+	return res, nil
+}
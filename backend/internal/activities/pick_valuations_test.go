@@ -0,0 +1,69 @@
+package activities_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"backend/internal/activities"
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+func TestComputePickValuations_WritesSegmentsWithADPAndValues(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.AutoMigrate(&models.DraftADP{}, &valuation.Snapshot{}, &valuation.PickSnapshot{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	older := time.Date(2025, 8, 30, 0, 0, 0, 0, time.UTC)
+	latest := time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)
+	// 40 players for an 8-team draft, ADP equal to their pick number: five
+	// full rounds. The 2024 season's ADP is ignored in favour of 2025's.
+	for i := 1; i <= 40; i++ {
+		id := "p" + strconv.Itoa(i)
+		db.Create(&models.DraftADP{Segment: "8-ppr-sf", Season: "2025", SleeperPlayerID: id, AvgPickNo: float64(i), PickCount: 30})
+		db.Create(&models.DraftADP{Segment: "8-ppr-sf", Season: "2024", SleeperPlayerID: id, AvgPickNo: float64(41 - i), PickCount: 30})
+		db.Create(&valuation.Snapshot{Segment: "ppr-sf-8", SleeperPlayerID: id, ValuationDate: latest, Value: float64(5000 - 100*i)})
+		db.Create(&valuation.Snapshot{Segment: "ppr-sf-8", SleeperPlayerID: id, ValuationDate: older, Value: 1})
+	}
+	// Too few drafts to count, so it doesn't pull pick 1 down.
+	db.Create(&models.DraftADP{Segment: "8-ppr-sf", Season: "2025", SleeperPlayerID: "reach", AvgPickNo: 1, PickCount: 2})
+	db.Create(&valuation.Snapshot{Segment: "ppr-sf-8", SleeperPlayerID: "reach", ValuationDate: latest, Value: 0})
+	// ppr-sf-10 has valuations but no ADP: skipped.
+	db.Create(&valuation.Snapshot{Segment: "ppr-sf-10", SleeperPlayerID: "p1", ValuationDate: latest, Value: 4900})
+
+	a := &activities.ADPRollupActivities{Write: db}
+	res, err := a.ComputePickValuations(context.Background())
+	if err != nil {
+		t.Fatalf("ComputePickValuations: %v", err)
+	}
+	// 5 rounds x (8 slots + round average) x 4 seasons.
+	if res.SegmentsValued != 1 || res.PicksUpserted != 180 {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	var first valuation.PickSnapshot
+	if err := db.Where("segment = ? AND season = ? AND round = 1 AND slot = 1", "ppr-sf-8", "2025").First(&first).Error; err != nil {
+		t.Fatalf("load 2025 1.01: %v", err)
+	}
+	// Mean of players 1-5 at the latest valuation date.
+	if first.Value != 4700 || !first.ValuationDate.Equal(latest) {
+		t.Errorf("expected 2025 1.01 = 4700 dated %s, got %+v", latest, first)
+	}
+	var future valuation.PickSnapshot
+	db.Where("segment = ? AND season = ? AND round = 1 AND slot = 0", "ppr-sf-8", "2028").First(&future)
+	if future.Value <= 0 || future.Value >= first.Value {
+		t.Errorf("expected the 2028 1st discounted below the 2025 1.01, got %+v", future)
+	}
+
+	// Re-running the same day updates in place.
+	if _, err := a.ComputePickValuations(context.Background()); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	var count int64
+	db.Model(&valuation.PickSnapshot{}).Count(&count)
+	if count != 180 {
+		t.Errorf("expected 180 rows after a re-run, got %d", count)
+	}
+}
@@ -164,10 +164,10 @@ func (a *ScavengerActivities) ReplicateLeaguesBatch(ctx context.Context, params
 }
 
 const selectDraftHeadersBatchSQL = `
-SELECT d.sleeper_draft_id, d.sleeper_league_id, d.type, d.status, d.season, d.last_fetched_at, d.created_at, d.updated_at
+SELECT d.sleeper_draft_id, d.sleeper_league_id, d.type, d.status, d.season, d.budget, d.start_time, d.rounds, d.last_fetched_at, d.created_at, d.updated_at
 FROM sleeper_drafts d
 JOIN sleeper_leagues l ON l.sleeper_league_id = d.sleeper_league_id
-WHERE l.league_type = 'redraft'
+WHERE l.league_type IN ('redraft', 'keeper', 'dynasty')
   AND (d.created_at, d.sleeper_draft_id) > (?, ?)
   AND d.created_at <= ?
 ORDER BY d.created_at, d.sleeper_draft_id
@@ -179,7 +179,7 @@ LIMIT ?`
 // an existing draft (sleeper_drafts.updated_at is dead — never assigned by
 // the upsert in data_fetch.go); those are caught separately, once picks
 // land, by ReplicateDraftPicksBatch's last_fetched_at watermark. The join to
-// sleeper_leagues (excluding keeper/dynasty, as in claimLeaguesForDraftsSQL)
+// sleeper_leagues (league types with ADP, as in claimLeaguesForDraftsSQL)
 // is defense-in-depth: this path is otherwise dead since T15 routes all
 // drafts straight to archive, never through cloud.
 func (a *ScavengerActivities) ReplicateDraftHeadersBatch(ctx context.Context, params ReplicateBatchParams) (ReplicateBatchResult, error) {
@@ -202,8 +202,8 @@ func (a *ScavengerActivities) ReplicateDraftHeadersBatch(ctx context.Context, pa
 	for i, r := range rows {
 		archiveRows[i] = models.ArchiveSleeperDraft{
 			SleeperDraftID: r.SleeperDraftID, SleeperLeagueID: r.SleeperLeagueID, Type: r.Type,
-			Status: r.Status, Season: r.Season, LastFetchedAt: r.LastFetchedAt,
-			CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt,
+			Status: r.Status, Season: r.Season, Budget: r.Budget, StartTime: r.StartTime, Rounds: r.Rounds,
+			LastFetchedAt: r.LastFetchedAt, CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt,
 		}
 	}
 	last := rows[len(rows)-1]
@@ -213,7 +213,7 @@ func (a *ScavengerActivities) ReplicateDraftHeadersBatch(ctx context.Context, pa
 		if err := tx.Clauses(clause.OnConflict{
 			Columns: []clause.Column{{Name: "sleeper_draft_id"}},
 			DoUpdates: clause.AssignmentColumns([]string{
-				"sleeper_league_id", "type", "status", "season", "last_fetched_at", "updated_at",
+				"sleeper_league_id", "type", "status", "season", "budget", "start_time", "rounds", "last_fetched_at", "updated_at",
 			}),
 		}).CreateInBatches(archiveRows, 500).Error; err != nil {
 			return err
@@ -227,10 +227,10 @@ func (a *ScavengerActivities) ReplicateDraftHeadersBatch(ctx context.Context, pa
 }
 
 const selectDraftsByPicksWatermarkSQL = `
-SELECT d.sleeper_draft_id, d.sleeper_league_id, d.type, d.status, d.season, d.last_fetched_at, d.created_at, d.updated_at
+SELECT d.sleeper_draft_id, d.sleeper_league_id, d.type, d.status, d.season, d.budget, d.start_time, d.rounds, d.last_fetched_at, d.created_at, d.updated_at
 FROM sleeper_drafts d
 JOIN sleeper_leagues l ON l.sleeper_league_id = d.sleeper_league_id
-WHERE l.league_type = 'redraft'
+WHERE l.league_type IN ('redraft', 'keeper', 'dynasty')
   AND d.last_fetched_at IS NOT NULL
   AND (d.last_fetched_at, d.sleeper_draft_id) > (?, ?)
   AND d.last_fetched_at <= ?
@@ -243,7 +243,7 @@ LIMIT ?`
 // fetchDraftPicks). This also re-copies the draft row itself, so by the time
 // a draft's picks are replicated its status is current too (picks are only
 // fetched once a draft reaches "complete"). Joined to sleeper_leagues to
-// exclude keeper/dynasty leagues — see selectDraftHeadersBatchSQL.
+// keep to the league types with ADP — see selectDraftHeadersBatchSQL.
 func (a *ScavengerActivities) ReplicateDraftPicksBatch(ctx context.Context, params ReplicateBatchParams) (ReplicateBatchResult, error) {
 	cur, err := readCursor(ctx, a.Archive, streamDraftPicks)
 	if err != nil {
@@ -266,8 +266,8 @@ func (a *ScavengerActivities) ReplicateDraftPicksBatch(ctx context.Context, para
 		draftIDs[i] = d.SleeperDraftID
 		archiveDrafts[i] = models.ArchiveSleeperDraft{
 			SleeperDraftID: d.SleeperDraftID, SleeperLeagueID: d.SleeperLeagueID, Type: d.Type,
-			Status: d.Status, Season: d.Season, LastFetchedAt: d.LastFetchedAt,
-			CreatedAt: d.CreatedAt, UpdatedAt: d.UpdatedAt,
+			Status: d.Status, Season: d.Season, Budget: d.Budget, StartTime: d.StartTime, Rounds: d.Rounds,
+			LastFetchedAt: d.LastFetchedAt, CreatedAt: d.CreatedAt, UpdatedAt: d.UpdatedAt,
 		}
 	}
 
@@ -290,7 +290,7 @@ func (a *ScavengerActivities) ReplicateDraftPicksBatch(ctx context.Context, para
 		if err := tx.Clauses(clause.OnConflict{
 			Columns: []clause.Column{{Name: "sleeper_draft_id"}},
 			DoUpdates: clause.AssignmentColumns([]string{
-				"sleeper_league_id", "type", "status", "season", "last_fetched_at", "updated_at",
+				"sleeper_league_id", "type", "status", "season", "budget", "start_time", "rounds", "last_fetched_at", "updated_at",
 			}),
 		}).CreateInBatches(archiveDrafts, 500).Error; err != nil {
 			return err
@@ -311,7 +311,7 @@ func (a *ScavengerActivities) ReplicateDraftPicksBatch(ctx context.Context, para
 
 const selectTransactionsBatchSQL = `
 SELECT sleeper_transaction_id, sleeper_league_id, type, status, created_at_sleeper, leg,
-       adds, drops, draft_picks, waiver_budget, created_at
+       adds, drops, draft_picks, waiver_budget, waiver_bid, waiver_bid_pct, created_at
 FROM sleeper_transactions
 WHERE (created_at, sleeper_transaction_id) > (?, ?)
   AND created_at <= ?
@@ -338,18 +338,19 @@ func (a *ScavengerActivities) ReplicateTransactionsBatch(ctx context.Context, pa
 		return ReplicateBatchResult{Drained: true}, nil
 	}
 
-	// Rows filtered out by IsPlayerOnlyTransaction still advance the cursor
+	// Rows filtered out by IsIngestedTransaction still advance the cursor
 	// below: cursor position tracks how far into cloud we've scanned, not
 	// what got written to archive.
 	var archiveRows []models.ArchiveSleeperTransaction
 	for _, r := range rows {
-		if !IsPlayerOnlyTransaction(r.DraftPicks, r.WaiverBudget) {
+		if !IsIngestedTransaction(r.WaiverBudget) {
 			continue
 		}
 		archiveRows = append(archiveRows, models.ArchiveSleeperTransaction{
 			SleeperTransactionID: r.SleeperTransactionID, SleeperLeagueID: r.SleeperLeagueID,
 			Type: r.Type, Status: r.Status, CreatedAtSleeper: r.CreatedAtSleeper, Leg: r.Leg,
 			Adds: r.Adds, Drops: r.Drops, DraftPicks: r.DraftPicks, WaiverBudget: r.WaiverBudget,
+			WaiverBid: r.WaiverBid, WaiverBidPct: r.WaiverBidPct,
 			CreatedAt: r.CreatedAt,
 		})
 	}
@@ -225,7 +225,7 @@ func TestReplicateTransactionsBatch_SecondRunIsNoOp(t *testing.T) {
 	}
 }
 
-func TestReplicateTransactionsBatch_ExcludesRowsWithDraftPicksOrFAAB(t *testing.T) {
+func TestReplicateTransactionsBatch_ExcludesRowsWithFAAB(t *testing.T) {
 	cloud, archive := newScavengerTestDBs(t)
 	now := time.Now().UTC().Add(-10 * time.Minute)
 	rows := []models.SleeperTransaction{
@@ -246,14 +246,14 @@ func TestReplicateTransactionsBatch_ExcludesRowsWithDraftPicksOrFAAB(t *testing.
 	if err != nil {
 		t.Fatalf("ReplicateTransactionsBatch: %v", err)
 	}
-	// All 3 rows are scanned/cursor-advanced even though only 1 is archived.
+	// All 3 rows are scanned/cursor-advanced even though only 2 are archived.
 	if res.Replicated != 3 || !res.Drained {
 		t.Errorf("res = %+v, want {Replicated: 3, Drained: true}", res)
 	}
 	var archiveIDs []string
-	archive.Model(&models.ArchiveSleeperTransaction{}).Pluck("sleeper_transaction_id", &archiveIDs)
-	if len(archiveIDs) != 1 || archiveIDs[0] != "t-clean" {
-		t.Errorf("expected only t-clean in archive, got %v", archiveIDs)
+	archive.Model(&models.ArchiveSleeperTransaction{}).Order("sleeper_transaction_id").Pluck("sleeper_transaction_id", &archiveIDs)
+	if len(archiveIDs) != 2 || archiveIDs[0] != "t-clean" || archiveIDs[1] != "t-picks" {
+		t.Errorf("expected t-clean and t-picks in archive, got %v", archiveIDs)
 	}
 
 	// Second run must not re-scan the filtered-out rows (cursor advanced past them).
@@ -284,7 +284,7 @@ func TestReplicateTransactionsBatch_RespectsSafetyLag(t *testing.T) {
 }
 
 // seedRedraftLeague satisfies the draft replicate queries' INNER JOIN to
-// sleeper_leagues (added to exclude keeper/dynasty leagues).
+// sleeper_leagues (which keeps to the league types with ADP).
 func seedRedraftLeague(t *testing.T, cloud *gorm.DB, id string) {
 	t.Helper()
 	if err := cloud.Create(&models.SleeperLeague{SleeperLeagueID: id, Season: "2026", LeagueType: "redraft"}).Error; err != nil {
@@ -0,0 +1,83 @@
+package activities
+
+import (
+	"context"
+	"log"
+
+	"gorm.io/gorm"
+
+	"backend/internal/etl"
+	"backend/internal/models"
+	"backend/internal/sleeper"
+	"backend/internal/sleeperimport"
+)
+
+// SleeperLeagueActivities holds dependencies for importing Sleeper leagues
+// into League/Team/Matchup/BoxScore (see internal/sleeperimport).
+type SleeperLeagueActivities struct {
+	DB      *gorm.DB
+	Sleeper *sleeper.Client
+}
+
+func (a *SleeperLeagueActivities) importer() *sleeperimport.Importer {
+	return &sleeperimport.Importer{DB: a.DB, Sleeper: a.Sleeper}
+}
+
+// RegisterSleeperLeague imports a Sleeper league's full history, then
+// computes expected wins for every imported season.
+func (a *SleeperLeagueActivities) RegisterSleeperLeague(ctx context.Context, params RegisterSleeperLeagueParams) (SleeperLeagueImportResult, error) {
+	league, res, err := a.importer().Register(ctx, params.SleeperLeagueID)
+	if err != nil {
+		return SleeperLeagueImportResult{}, err
+	}
+	processExpectedWins(league.ID, 0)
+	return newSleeperLeagueImportResult(league.ID, res), nil
+}
+
+// ListSleeperLeagues returns the IDs of every registered Sleeper league.
+func (a *SleeperLeagueActivities) ListSleeperLeagues(ctx context.Context) ([]uint, error) {
+	var ids []uint
+	err := a.DB.WithContext(ctx).Model(&models.League{}).
+		Where("platform = ?", sleeperimport.Platform).
+		Order("id ASC").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// SyncSleeperLeague re-imports a registered league's current season — the
+// renewed one, if Sync found a renewal — then recomputes that season's
+// expected wins.
+func (a *SleeperLeagueActivities) SyncSleeperLeague(ctx context.Context, params SyncSleeperLeagueParams) (SleeperLeagueImportResult, error) {
+	res, err := a.importer().Sync(ctx, params.LeagueID)
+	if err != nil {
+		return SleeperLeagueImportResult{}, err
+	}
+	var league models.League
+	if err := a.DB.WithContext(ctx).Select("season").First(&league, params.LeagueID).Error; err != nil {
+		return SleeperLeagueImportResult{}, err
+	}
+	processExpectedWins(params.LeagueID, uint(league.Season))
+	return newSleeperLeagueImportResult(params.LeagueID, res), nil
+}
+
+// processExpectedWins runs the ETL's expected-wins pass (year 0 = every
+// season). It reads database.DB, which the worker points at the same DB as
+// SleeperLeagueActivities.DB. A failure is logged rather than failing the
+// import, matching the ETL — the imported matchups are still good, and the
+// next sync retries.
+func processExpectedWins(leagueID, year uint) {
+	if err := etl.ProcessExpectedWinsWithYear(leagueID, year); err != nil {
+		log.Printf("sleeper league import: expected wins for league %d failed: %v", leagueID, err)
+	}
+}
+
+func newSleeperLeagueImportResult(leagueID uint, res sleeperimport.Result) SleeperLeagueImportResult {
+	return SleeperLeagueImportResult{
+		LeagueID:          leagueID,
+		Seasons:           res.Seasons,
+		Teams:             res.Teams,
+		Matchups:          res.Matchups,
+		BoxScores:         res.BoxScores,
+		UnresolvedPlayers: res.UnresolvedPlayers,
+	}
+}
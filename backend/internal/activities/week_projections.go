@@ -0,0 +1,102 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"backend/internal/models"
+	"backend/internal/sleeper"
+)
+
+// FetchWeekProjections fetches one week of Sleeper projections, filters to
+// fantasy-relevant positions and upserts sleeper_player_week_projections
+// (overwriting on refetch, so the row tracks Sleeper's latest projection),
+// then stamps sleeper_week_projection_fetches the same way FetchWeekStats
+// stamps sleeper_week_stat_fetches.
+func (a *WeekStatsActivities) FetchWeekProjections(ctx context.Context, params FetchWeekProjectionsParams) (WeekProjectionsResult, error) {
+	raw, err := a.Sleeper.GetWeekProjections(ctx, params.Season, params.Week)
+	if err != nil {
+		var nfe *sleeper.NotFoundError
+		if !errors.As(err, &nfe) {
+			return WeekProjectionsResult{}, err
+		}
+		raw = nil // no projections published for this week yet
+	}
+
+	upserted := 0
+	if len(raw) > 0 {
+		fantasyIDs, err := a.fantasyPlayerIDs(ctx)
+		if err != nil {
+			return WeekProjectionsResult{}, err
+		}
+
+		for id, projBytes := range raw {
+			if _, ok := fantasyIDs[id]; !ok {
+				continue
+			}
+			var pts weekStatPoints
+			if err := json.Unmarshal(projBytes, &pts); err != nil {
+				return WeekProjectionsResult{PlayersUpserted: upserted}, err
+			}
+			row := models.SleeperPlayerWeekProjection{
+				Season:          params.Season,
+				Week:            params.Week,
+				SleeperPlayerID: id,
+				PtsPPR:          pts.PtsPPR,
+				PtsHalfPPR:      pts.PtsHalfPPR,
+				PtsStd:          pts.PtsStd,
+				Stats:           json.RawMessage(projBytes),
+			}
+			if err := a.DB.WithContext(ctx).Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "season"}, {Name: "week"}, {Name: "sleeper_player_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"pts_ppr", "pts_half_ppr", "pts_std", "stats", "updated_at",
+				}),
+			}).Create(&row).Error; err != nil {
+				return WeekProjectionsResult{PlayersUpserted: upserted}, err
+			}
+			upserted++
+		}
+	}
+
+	finalized, err := a.weekFinalized(ctx, params.Season, params.Week)
+	if err != nil {
+		return WeekProjectionsResult{PlayersUpserted: upserted}, err
+	}
+
+	now := time.Now().UTC()
+	fetchRow := models.SleeperWeekProjectionFetch{
+		Season:        params.Season,
+		Week:          params.Week,
+		LastFetchedAt: &now,
+		Finalized:     finalized,
+	}
+	if err := a.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "season"}, {Name: "week"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_fetched_at", "finalized"}),
+	}).Create(&fetchRow).Error; err != nil {
+		return WeekProjectionsResult{PlayersUpserted: upserted}, err
+	}
+
+	return WeekProjectionsResult{PlayersUpserted: upserted, Finalized: finalized}, nil
+}
+
+// GetFinalizedProjectionWeeks returns the weeks whose projections are already
+// finalized for season, so SyncWeekProjections can skip re-fetching them.
+func (a *WeekStatsActivities) GetFinalizedProjectionWeeks(ctx context.Context, params GetFinalizedWeeksParams) ([]int, error) {
+	var rows []models.SleeperWeekProjectionFetch
+	if err := a.DB.WithContext(ctx).
+		Where("season = ? AND finalized = ?", params.Season, true).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	weeks := make([]int, len(rows))
+	for i, r := range rows {
+		weeks[i] = r.Week
+	}
+	return weeks, nil
+}
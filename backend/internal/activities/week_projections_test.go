@@ -0,0 +1,59 @@
+package activities_test
+
+import (
+	"context"
+	"testing"
+
+	"backend/internal/activities"
+	"backend/internal/models"
+	"backend/internal/sleeper"
+)
+
+func TestFetchWeekProjections_UpsertsAndTracksFetch(t *testing.T) {
+	db := newTestDB(t)
+	db.Create(&models.SleeperPlayer{SleeperPlayerID: "421", Position: "RB"})
+	db.Create(&models.SleeperPlayer{SleeperPlayerID: "999", Position: "DL"})
+
+	srv1 := weekStatsServer(t, `{"421":{"pts_ppr":14.2,"pts_half_ppr":12.7,"pts_std":11.2,"rush_att":16},"999":{"pts_ppr":4}}`, 10, "2025")
+	wsa := &activities.WeekStatsActivities{DB: db, Sleeper: sleeper.NewWithBaseURL(srv1.URL)}
+	result, err := wsa.FetchWeekProjections(context.Background(), activities.FetchWeekProjectionsParams{Season: "2025", Week: 10})
+	srv1.Close()
+	if err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	if result.PlayersUpserted != 1 || result.Finalized {
+		t.Errorf("expected 1 upserted, current week not finalized; got %+v", result)
+	}
+
+	// A refetch overwrites with Sleeper's revised projection.
+	srv2 := weekStatsServer(t, `{"421":{"pts_ppr":9.5}}`, 11, "2025")
+	defer srv2.Close()
+	wsa.Sleeper = sleeper.NewWithBaseURL(srv2.URL)
+	result, err = wsa.FetchWeekProjections(context.Background(), activities.FetchWeekProjectionsParams{Season: "2025", Week: 10})
+	if err != nil {
+		t.Fatalf("refetch: %v", err)
+	}
+	if !result.Finalized {
+		t.Errorf("expected week 10 finalized once the NFL is in week 11")
+	}
+
+	var rows []models.SleeperPlayerWeekProjection
+	db.Find(&rows)
+	if len(rows) != 1 || rows[0].SleeperPlayerID != "421" || rows[0].PtsPPR == nil || *rows[0].PtsPPR != 9.5 {
+		t.Fatalf("expected one overwritten projection row, got %+v", rows)
+	}
+
+	weeks, err := wsa.GetFinalizedProjectionWeeks(context.Background(), activities.GetFinalizedWeeksParams{Season: "2025"})
+	if err != nil {
+		t.Fatalf("GetFinalizedProjectionWeeks: %v", err)
+	}
+	if len(weeks) != 1 || weeks[0] != 10 {
+		t.Errorf("expected week 10 finalized, got %v", weeks)
+	}
+	// Projection fetches are tracked apart from stat fetches.
+	var statFetches int64
+	db.Model(&models.SleeperWeekStatFetch{}).Count(&statFetches)
+	if statFetches != 0 {
+		t.Errorf("expected no stat fetch rows, got %d", statFetches)
+	}
+}
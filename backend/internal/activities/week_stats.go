@@ -30,9 +30,11 @@ type weekStatPoints struct {
 }
 
 // FetchWeekStats fetches one week of Sleeper stats, filters to fantasy-relevant
-// positions, upserts sleeper_player_week_stats (overwriting on refetch so in-season
-// corrections land), and stamps sleeper_week_stat_fetches — including whether the
-// week is finalized per Sleeper's current NFL state.
+// positions, upserts sleeper_player_week_stats and its typed
+// sleeper_player_week_stat_lines row in one transaction (overwriting on
+// refetch so in-season corrections land), and stamps
+// sleeper_week_stat_fetches — including whether the week is finalized per
+// Sleeper's current NFL state.
 func (a *WeekStatsActivities) FetchWeekStats(ctx context.Context, params FetchWeekStatsParams) (WeekStatsResult, error) {
 	raw, err := a.Sleeper.GetWeekStats(ctx, params.Season, params.Week)
 	if err != nil {
@@ -45,16 +47,10 @@ func (a *WeekStatsActivities) FetchWeekStats(ctx context.Context, params FetchWe
 
 	upserted := 0
 	if len(raw) > 0 {
-		var players []models.SleeperPlayer
-		if err := a.DB.WithContext(ctx).
-			Where("position IN ?", fantasyPositions).
-			Find(&players).Error; err != nil {
+		fantasyIDs, err := a.fantasyPlayerIDs(ctx)
+		if err != nil {
 			return WeekStatsResult{}, err
 		}
-		fantasyIDs := make(map[string]struct{}, len(players))
-		for _, p := range players {
-			fantasyIDs[p.SleeperPlayerID] = struct{}{}
-		}
 
 		for id, statBytes := range raw {
 			if _, ok := fantasyIDs[id]; !ok {
@@ -73,23 +69,36 @@ func (a *WeekStatsActivities) FetchWeekStats(ctx context.Context, params FetchWe
 				PtsStd:          pts.PtsStd,
 				Stats:           json.RawMessage(statBytes),
 			}
-			if err := a.DB.WithContext(ctx).Clauses(clause.OnConflict{
-				Columns: []clause.Column{{Name: "season"}, {Name: "week"}, {Name: "sleeper_player_id"}},
-				DoUpdates: clause.AssignmentColumns([]string{
-					"pts_ppr", "pts_half_ppr", "pts_std", "stats", "updated_at",
-				}),
-			}).Create(&row).Error; err != nil {
+			line, err := models.ParseSleeperPlayerWeekStatLine(params.Season, params.Week, id, statBytes)
+			if err != nil {
+				return WeekStatsResult{PlayersUpserted: upserted}, err
+			}
+			// The raw row and its stat line go in together so a failed
+			// write can't leave a refetched row beside a stale line.
+			if err := a.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := tx.Clauses(clause.OnConflict{
+					Columns: []clause.Column{{Name: "season"}, {Name: "week"}, {Name: "sleeper_player_id"}},
+					DoUpdates: clause.AssignmentColumns([]string{
+						"pts_ppr", "pts_half_ppr", "pts_std", "stats", "updated_at",
+					}),
+				}).Create(&row).Error; err != nil {
+					return err
+				}
+				return tx.Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: "season"}, {Name: "week"}, {Name: "sleeper_player_id"}},
+					UpdateAll: true,
+				}).Create(&line).Error
+			}); err != nil {
 				return WeekStatsResult{PlayersUpserted: upserted}, err
 			}
 			upserted++
 		}
 	}
 
-	state, err := a.Sleeper.GetNFLState(ctx)
+	finalized, err := a.weekFinalized(ctx, params.Season, params.Week)
 	if err != nil {
 		return WeekStatsResult{PlayersUpserted: upserted}, err
 	}
-	finalized := params.Season < state.Season || (params.Season == state.Season && params.Week < state.Week)
 
 	now := time.Now().UTC()
 	fetchRow := models.SleeperWeekStatFetch{
@@ -108,6 +117,31 @@ func (a *WeekStatsActivities) FetchWeekStats(ctx context.Context, params FetchWe
 	return WeekStatsResult{PlayersUpserted: upserted, Finalized: finalized}, nil
 }
 
+// fantasyPlayerIDs returns the Sleeper IDs of players at fantasyPositions.
+func (a *WeekStatsActivities) fantasyPlayerIDs(ctx context.Context) (map[string]struct{}, error) {
+	var players []models.SleeperPlayer
+	if err := a.DB.WithContext(ctx).
+		Where("position IN ?", fantasyPositions).
+		Find(&players).Error; err != nil {
+		return nil, err
+	}
+	ids := make(map[string]struct{}, len(players))
+	for _, p := range players {
+		ids[p.SleeperPlayerID] = struct{}{}
+	}
+	return ids, nil
+}
+
+// weekFinalized reports whether season/week is behind Sleeper's current NFL
+// week, i.e. its games are over and Sleeper won't revise it further.
+func (a *WeekStatsActivities) weekFinalized(ctx context.Context, season string, week int) (bool, error) {
+	state, err := a.Sleeper.GetNFLState(ctx)
+	if err != nil {
+		return false, err
+	}
+	return season < state.Season || (season == state.Season && week < state.Week), nil
+}
+
 // GetFinalizedWeeks returns the weeks already marked finalized for season, so
 // SyncWeekStats can skip re-fetching them.
 func (a *WeekStatsActivities) GetFinalizedWeeks(ctx context.Context, params GetFinalizedWeeksParams) ([]int, error) {
@@ -34,7 +34,7 @@ func TestFetchWeekStats_FiltersToFantasyPositionsAndUpserts(t *testing.T) {
 	db.Create(&models.SleeperPlayer{SleeperPlayerID: "999", Position: "DL"}) // not fantasy-relevant
 	// "555" is absent from sleeper_players entirely — must be skipped too.
 
-	body := `{"421":{"pts_ppr":24.06,"pts_half_ppr":20.56,"pts_std":17.06},"999":{"pts_ppr":5},"555":{"pts_ppr":3}}`
+	body := `{"421":{"pts_ppr":24.06,"pts_half_ppr":20.56,"pts_std":17.06,"rush_att":18,"rec_tgt":7,"off_snp":52,"tm_off_snp":65},"999":{"pts_ppr":5},"555":{"pts_ppr":3}}`
 	srv := weekStatsServer(t, body, 10, "2025")
 	defer srv.Close()
 
@@ -58,6 +58,39 @@ func TestFetchWeekStats_FiltersToFantasyPositionsAndUpserts(t *testing.T) {
 	if rows[0].SleeperPlayerID != "421" || rows[0].PtsPPR == nil || *rows[0].PtsPPR != 24.06 {
 		t.Errorf("unexpected row: %+v", rows[0])
 	}
+
+	var lines []models.SleeperPlayerWeekStatLine
+	db.Find(&lines)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 typed stat line, got %d", len(lines))
+	}
+	if l := lines[0]; l.SleeperPlayerID != "421" || l.Week != 3 || l.RushAtt != 18 || l.RecTgt != 7 || l.OffSnp != 52 || l.TmOffSnp != 65 {
+		t.Errorf("unexpected stat line: %+v", l)
+	}
+}
+
+func TestFetchWeekStats_SkipsNonNumericStatValues(t *testing.T) {
+	db := newTestDB(t)
+	db.Create(&models.SleeperPlayer{SleeperPlayerID: "421", Position: "RB"})
+
+	srv := weekStatsServer(t, `{"421":{"pts_ppr":12,"rush_att":14,"off_snp":"DNP","gms_active":null}}`, 10, "2025")
+	defer srv.Close()
+	wsa := &activities.WeekStatsActivities{DB: db, Sleeper: sleeper.NewWithBaseURL(srv.URL)}
+	result, err := wsa.FetchWeekStats(context.Background(), activities.FetchWeekStatsParams{Season: "2025", Week: 3})
+	if err != nil {
+		t.Fatalf("FetchWeekStats error: %v", err)
+	}
+	if result.PlayersUpserted != 1 {
+		t.Errorf("expected PlayersUpserted 1, got %d", result.PlayersUpserted)
+	}
+
+	var line models.SleeperPlayerWeekStatLine
+	if err := db.First(&line).Error; err != nil {
+		t.Fatalf("expected a stat line: %v", err)
+	}
+	if line.RushAtt != 14 || line.OffSnp != 0 {
+		t.Errorf("expected rush_att kept and off_snp dropped, got %+v", line)
+	}
 }
 
 func TestFetchWeekStats_RefetchOverwrites(t *testing.T) {
@@ -71,7 +104,7 @@ func TestFetchWeekStats_RefetchOverwrites(t *testing.T) {
 	}
 	srv1.Close()
 
-	srv2 := weekStatsServer(t, `{"421":{"pts_ppr":15.5}}`, 10, "2025")
+	srv2 := weekStatsServer(t, `{"421":{"pts_ppr":15.5,"rec_tgt":9}}`, 10, "2025")
 	defer srv2.Close()
 	wsa2 := &activities.WeekStatsActivities{DB: db, Sleeper: sleeper.NewWithBaseURL(srv2.URL)}
 	result, err := wsa2.FetchWeekStats(context.Background(), activities.FetchWeekStatsParams{Season: "2025", Week: 3})
@@ -92,6 +125,11 @@ func TestFetchWeekStats_RefetchOverwrites(t *testing.T) {
 	if count != 1 {
 		t.Errorf("expected exactly 1 row after refetch, got %d", count)
 	}
+	var line models.SleeperPlayerWeekStatLine
+	db.First(&line)
+	if line.RecTgt != 9 {
+		t.Errorf("expected overwritten stat line RecTgt 9, got %+v", line)
+	}
 }
 
 func TestFetchWeekStats_MarksFinalized_PastWeek(t *testing.T) {
@@ -24,7 +24,7 @@ func newAdminTestDB(t *testing.T) *gorm.DB {
 	if err := db.AutoMigrate(
 		&models.SleeperLeague{}, &models.SleeperTransaction{}, &models.SleeperPlayer{}, &models.Player{},
 		&models.SleeperUser{}, &models.SleeperLifetimeCount{}, &models.SleeperDraft{},
-		&models.SleeperTransactionFetchAgeSnapshot{},
+		&models.SleeperTransactionFetchAgeSnapshot{}, &models.TradeRetrospective{},
 	); err != nil {
 		t.Fatalf("automigrate: %v", err)
 	}
@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"math"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 	"backend/internal/database"
 	"backend/internal/models"
@@ -28,24 +32,54 @@ type SleeperADPItem struct {
 	MaxPickNo       int     `json:"max_pick_no"`
 	CILowPickNo     float64 `json:"ci_low_pick_no"`
 	CIHighPickNo    float64 `json:"ci_high_pick_no"`
-	PlayerID        *string `json:"player_id,omitempty"`
+	// WeekAvgPickNo is the player's ADP in the latest trend week, and
+	// ADPChange how far it moved from the week before (previous minus
+	// latest, so positive means drafted earlier: rising). Nil when either
+	// week has too few drafts of the player — see defaultADPTrendMinDrafts.
+	WeekAvgPickNo *float64 `json:"week_avg_pick_no"`
+	ADPChange     *float64 `json:"adp_change"`
+	PlayerID      *string  `json:"player_id,omitempty"`
 }
 
-// SleeperADPResponse is the paginated response for GET /api/v1/sleeper/adp.
+// SleeperADPResponse is the paginated response for GET /api/v1/sleeper/adp
+// with draft_type=snake (the default); see SleeperAuctionValuesResponse for
+// draft_type=auction.
 type SleeperADPResponse struct {
-	Players          []SleeperADPItem `json:"players"`
-	Season           string           `json:"season"`
-	AvailableSeasons []string         `json:"available_seasons"`
-	Total            int64            `json:"total"`
-	Page             int              `json:"page"`
-	Limit            int              `json:"limit"`
-	TotalPages       int              `json:"total_pages"`
+	Players   []SleeperADPItem `json:"players"`
+	DraftType string           `json:"draft_type"`
+	// Segment is the segment key served; it differs from RequestedSegment
+	// when a refined segment had no ADP and a coarser one stood in.
+	Segment          string   `json:"segment"`
+	RequestedSegment string   `json:"requested_segment"`
+	Season           string   `json:"season"`
+	AvailableSeasons []string `json:"available_seasons"`
+	// TrendWeekStart is the latest week (its Monday) adp_change compares
+	// against the week before; nil while the season has under two weeks of
+	// drafts.
+	TrendWeekStart *string `json:"trend_week_start"`
+	Total          int64   `json:"total"`
+	Page           int     `json:"page"`
+	Limit          int     `json:"limit"`
+	TotalPages     int     `json:"total_pages"`
 }
 
 // defaultADPMinDrafts is the minimum number of qualifying drafts a player
 // must appear in for a segment/season before showing up in the ADP list.
 const defaultADPMinDrafts = 20
 
+// defaultADPTrendMinDrafts is the minimum number of drafts in a week a
+// player's weekly ADP needs to count toward adp_change. Lower than
+// defaultADPMinDrafts since a week holds a fraction of the season's drafts.
+const defaultADPTrendMinDrafts = 5
+
+// adpSorts maps GET /sleeper/adp's sort parameter to its ORDER BY. risers and
+// fallers only list players with an adp_change.
+var adpSorts = map[string]string{
+	"adp":     "a.avg_pick_no ASC",
+	"risers":  "adp_change DESC, a.avg_pick_no ASC",
+	"fallers": "adp_change ASC, a.avg_pick_no ASC",
+}
+
 // firstADPSeason is the earliest season Sleeper draft data is tracked for.
 // The season list is hardcoded rather than queried from draft_adp: which
 // seasons have rows varies by segment (a thin segment may be missing a
@@ -65,34 +99,138 @@ func adpSeasons() []string {
 }
 
 type adpItemRow struct {
-	SleeperPlayerID string  `gorm:"column:sleeper_player_id"`
-	Name            string  `gorm:"column:full_name"`
-	Position        string  `gorm:"column:position"`
-	NflTeam         string  `gorm:"column:nfl_team"`
-	AvgPickNo       float64 `gorm:"column:avg_pick_no"`
-	PickCount       int     `gorm:"column:pick_count"`
-	MinPickNo       int     `gorm:"column:min_pick_no"`
-	MaxPickNo       int     `gorm:"column:max_pick_no"`
-	CILowPickNo     float64 `gorm:"column:ci_low_pick_no"`
-	CIHighPickNo    float64 `gorm:"column:ci_high_pick_no"`
+	SleeperPlayerID string   `gorm:"column:sleeper_player_id"`
+	Name            string   `gorm:"column:full_name"`
+	Position        string   `gorm:"column:position"`
+	NflTeam         string   `gorm:"column:nfl_team"`
+	AvgPickNo       float64  `gorm:"column:avg_pick_no"`
+	PickCount       int      `gorm:"column:pick_count"`
+	MinPickNo       int      `gorm:"column:min_pick_no"`
+	MaxPickNo       int      `gorm:"column:max_pick_no"`
+	CILowPickNo     float64  `gorm:"column:ci_low_pick_no"`
+	CIHighPickNo    float64  `gorm:"column:ci_high_pick_no"`
+	WeekAvgPickNo   *float64 `gorm:"column:week_avg_pick_no"`
+	ADPChange       *float64 `gorm:"column:adp_change"`
+}
+
+// latestADPTrendWeeks returns the newest two week_starts with weekly ADP for
+// segment and season, newest first — fewer when the season has fewer.
+func latestADPTrendWeeks(segment, season string) ([]time.Time, error) {
+	var weeks []time.Time
+	err := database.DB.Model(&models.DraftADPWeek{}).
+		Where("segment = ? AND season = ?", segment, season).
+		Distinct("week_start").
+		Order("week_start DESC").
+		Limit(2).
+		Pluck("week_start", &weeks).Error
+	return weeks, err
+}
+
+// joinADPTrend joins the latest two trend weeks onto a draft_adp query
+// aliased "a", for adp_change. Players short of defaultADPTrendMinDrafts in
+// either week get no change.
+func joinADPTrend(db *gorm.DB, weeks []time.Time) *gorm.DB {
+	const join = "LEFT JOIN draft_adp_weekly %s ON %[1]s.segment = a.segment AND %[1]s.season = a.season " +
+		"AND %[1]s.sleeper_player_id = a.sleeper_player_id AND %[1]s.week_start = ? AND %[1]s.pick_count >= ?"
+	return db.
+		Joins(fmt.Sprintf(join, "cur"), weeks[0], defaultADPTrendMinDrafts).
+		Joins(fmt.Sprintf(join, "prev"), weeks[1], defaultADPTrendMinDrafts)
+}
+
+// adpPassTDs maps GET /sleeper/adp's pass_td parameter to its segment
+// dimension value.
+var adpPassTDs = map[string]string{"4": "4pt", "6": "6pt"}
+
+// adpSegmentQuery reads the ADP segment filters shared by the /sleeper/adp
+// endpoints — league_size (default 12), scoring_format (default ppr),
+// superflex (default true), format (default redraft), and the optional
+// te_premium (tep|no_tep), pass_td (4|6) and lineup (small|standard|deep) —
+// into a segment. The error is a message for a 400.
+func adpSegmentQuery(c *gin.Context) (models.ADPSegment, error) {
+	seg := models.ADPSegment{
+		LeagueSize:    c.DefaultQuery("league_size", "12"),
+		ScoringFormat: c.DefaultQuery("scoring_format", "ppr"),
+		Superflex:     c.DefaultQuery("superflex", "true") == "true",
+		Format:        c.DefaultQuery("format", models.ADPFormatRedraft),
+		TEPremium:     c.Query("te_premium"),
+		Lineup:        c.Query("lineup"),
+	}
+	if !slices.Contains(models.ADPFormats, seg.Format) {
+		return seg, errors.New("format must be one of redraft, startup, rookie, keeper")
+	}
+	if seg.TEPremium != "" && !slices.Contains(models.ADPTEPremiums, seg.TEPremium) {
+		return seg, errors.New("te_premium must be tep or no_tep")
+	}
+	if v := c.Query("pass_td"); v != "" {
+		passTD, ok := adpPassTDs[v]
+		if !ok {
+			return seg, errors.New("pass_td must be 4 or 6")
+		}
+		seg.PassTD = passTD
+	}
+	if seg.Lineup != "" && !slices.Contains(models.ADPLineups, seg.Lineup) {
+		return seg, errors.New("lineup must be one of small, standard, deep")
+	}
+	return seg, nil
+}
+
+// resolveADPSegment returns the key of the nearest segment to seg (see
+// models.ADPSegment.Fallbacks) with draft_adp rows for season. Refined
+// segments are only materialized once they have enough drafts, so a thin
+// combination falls back to a coarser one; the base segment is the last
+// resort, whether or not it has rows. draft_adp_weekly and auction_values
+// are only ever rolled up for base segments, so their readers use
+// seg.Base() directly.
+func resolveADPSegment(seg models.ADPSegment, season string) string {
+	for _, f := range seg.Fallbacks() {
+		if !f.Refined() {
+			return f.Key()
+		}
+		var found []string
+		if err := database.DB.Table("draft_adp").
+			Where("segment = ? AND season = ?", f.Key(), season).
+			Limit(1).
+			Pluck("segment", &found).Error; err != nil {
+			slog.Error("Failed to check ADP segment", "segment", f.Key(), "error", err)
+			continue
+		}
+		if len(found) > 0 {
+			return f.Key()
+		}
+	}
+	return seg.Base().Key()
 }
 
 // GetSleeperADP returns a paginated, ADP-ranked player list for one
-// (league_size, scoring_format, superflex, season) combination, populated by
-// the daily ADP rollup worker.
+// (league_size, scoring_format, superflex, format, season) combination,
+// populated by the daily ADP rollup worker.
 // Supports query filters: league_size (8|10|12|14+, default 12),
 // scoring_format (standard|half_ppr|ppr, default ppr), superflex
-// (true|false, default true), season (defaults to the current year;
-// available seasons are hardcoded from firstADPSeason onward, not derived
-// from data), min_drafts (default 20).
+// (true|false, default true), format (redraft|startup|rookie|keeper,
+// default redraft — startup and rookie are dynasty drafts), the optional
+// te_premium, pass_td and lineup refinements (falling back to the nearest
+// populated segment — see resolveADPSegment), season (defaults to the
+// current year; available seasons are hardcoded from firstADPSeason onward,
+// not derived from data), min_drafts (default 20), sort (adp|risers|fallers, default
+// adp — risers/fallers rank by adp_change over the latest two weeks of
+// drafts), and draft_type (snake|auction, default snake) — auction switches
+// to average auction values, served by getSleeperAuctionValues with the
+// same filters.
 func GetSleeperADP(c *gin.Context) {
 	page, limit := parsePagination(c)
 	offset := (page - 1) * limit
 
-	leagueSize := c.DefaultQuery("league_size", "12")
-	scoringFormat := c.DefaultQuery("scoring_format", "ppr")
-	superflex := c.DefaultQuery("superflex", "true") == "true"
-	segment := models.ADPSegmentKey(leagueSize, scoringFormat, superflex)
+	draftType := c.DefaultQuery("draft_type", "snake")
+	if draftType != "snake" && draftType != "auction" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "draft_type must be snake or auction"})
+		return
+	}
+
+	requested, err := adpSegmentQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	minDrafts := defaultADPMinDrafts
 	if v, err := strconv.Atoi(c.Query("min_drafts")); err == nil && v >= 0 {
@@ -105,6 +243,26 @@ func GetSleeperADP(c *gin.Context) {
 	if season == "" && len(availableSeasons) > 0 {
 		season = availableSeasons[0]
 	}
+	if draftType == "auction" {
+		segment := requested.Base().Key()
+		getSleeperAuctionValues(c, segment, season, availableSeasons, minDrafts, page, limit)
+		return
+	}
+	segment := resolveADPSegment(requested, season)
+
+	sort := c.DefaultQuery("sort", "adp")
+	order, ok := adpSorts[sort]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be one of adp, risers, fallers"})
+		return
+	}
+	weeks, err := latestADPTrendWeeks(segment, season)
+	if err != nil {
+		slog.Error("Failed to resolve ADP trend weeks", "error", err)
+		weeks = nil
+	}
+	hasTrend := len(weeks) == 2
+	movers := sort != "adp"
 
 	var total int64
 	countQuery := database.DB.Table("draft_adp a").
@@ -112,18 +270,36 @@ func GetSleeperADP(c *gin.Context) {
 	if playerID := c.Query("sleeper_player_id"); playerID != "" {
 		countQuery = countQuery.Where("a.sleeper_player_id = ?", playerID)
 	}
+	if movers && hasTrend {
+		countQuery = joinADPTrend(countQuery, weeks).Where("cur.avg_pick_no IS NOT NULL AND prev.avg_pick_no IS NOT NULL")
+	}
+	if movers && !hasTrend {
+		// No two weeks to compare: nobody has moved.
+		countQuery = countQuery.Where("1 = 0")
+	}
 	countQuery.Count(&total)
 
 	var rows []adpItemRow
+	selectCols := "a.sleeper_player_id, p.full_name, p.position, p.nfl_team, a.avg_pick_no, a.pick_count, a.min_pick_no, a.max_pick_no, a.ci_low_pick_no, a.ci_high_pick_no"
 	itemsQuery := database.DB.Table("draft_adp a").
-		Select("a.sleeper_player_id, p.full_name, p.position, p.nfl_team, a.avg_pick_no, a.pick_count, a.min_pick_no, a.max_pick_no, a.ci_low_pick_no, a.ci_high_pick_no").
 		Joins("JOIN sleeper_players p ON p.sleeper_player_id = a.sleeper_player_id").
 		Where("a.segment = ? AND a.season = ? AND a.pick_count >= ?", segment, season, minDrafts)
 	if playerID := c.Query("sleeper_player_id"); playerID != "" {
 		itemsQuery = itemsQuery.Where("a.sleeper_player_id = ?", playerID)
 	}
+	if hasTrend {
+		selectCols += ", cur.avg_pick_no AS week_avg_pick_no, prev.avg_pick_no - cur.avg_pick_no AS adp_change"
+		itemsQuery = joinADPTrend(itemsQuery, weeks)
+		if movers {
+			itemsQuery = itemsQuery.Where("cur.avg_pick_no IS NOT NULL AND prev.avg_pick_no IS NOT NULL")
+		}
+	}
+	if movers && !hasTrend {
+		itemsQuery = itemsQuery.Where("1 = 0")
+	}
 	itemsQuery.
-		Order("a.avg_pick_no ASC").
+		Select(selectCols).
+		Order(order).
 		Limit(limit).Offset(offset).
 		Scan(&rows)
 
@@ -153,6 +329,8 @@ func GetSleeperADP(c *gin.Context) {
 			MaxPickNo:       r.MaxPickNo,
 			CILowPickNo:     r.CILowPickNo,
 			CIHighPickNo:    r.CIHighPickNo,
+			WeekAvgPickNo:   r.WeekAvgPickNo,
+			ADPChange:       r.ADPChange,
 		}
 		if player, ok := sleeperMatches[r.SleeperPlayerID]; ok {
 			playerID := strconv.FormatUint(uint64(player.ID), 10)
@@ -160,11 +338,21 @@ func GetSleeperADP(c *gin.Context) {
 		}
 	}
 
+	var trendWeekStart *string
+	if hasTrend {
+		week := weeks[0].Format("2006-01-02")
+		trendWeekStart = &week
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(limit)))
 	c.JSON(http.StatusOK, SleeperADPResponse{
 		Players:          items,
+		DraftType:        draftType,
+		Segment:          segment,
+		RequestedSegment: requested.Key(),
 		Season:           season,
 		AvailableSeasons: availableSeasons,
+		TrendWeekStart:   trendWeekStart,
 		Total:            total,
 		Page:             page,
 		Limit:            limit,
@@ -20,7 +20,7 @@ func newDraftADPTestDB(t *testing.T) *gorm.DB {
 	if err != nil {
 		t.Fatalf("open sqlite: %v", err)
 	}
-	if err := db.AutoMigrate(&models.DraftADP{}, &models.SleeperPlayer{}, &models.Player{}); err != nil {
+	if err := db.AutoMigrate(&models.DraftADP{}, &models.DraftADPWeek{}, &models.SleeperPlayer{}, &models.Player{}); err != nil {
 		t.Fatalf("automigrate: %v", err)
 	}
 	return db
@@ -133,6 +133,28 @@ func TestGetSleeperADP_ExplicitFiltersBuildSegmentKey(t *testing.T) {
 	}
 }
 
+func TestGetSleeperADP_FormatSelectsDynastyAndKeeperSegments(t *testing.T) {
+	db := newDraftADPTestDB(t)
+	withDraftADPTestDB(t, db)
+
+	seedADPPlayer(t, db, "p1", "Redraft Pick", "RB", "KC")
+	seedADPPlayer(t, db, "p2", "Rookie Pick", "WR", "SF")
+	seedADPRow(t, db, "12-ppr-sf", "2025", "p1", 1.0, 25)
+	seedADPRow(t, db, "12-ppr-sf-rookie", "2025", "p2", 1.0, 25)
+
+	_, redraft := performGetSleeperADP(t, "?season=2025")
+	if len(redraft.Players) != 1 || redraft.Players[0].SleeperPlayerID != "p1" {
+		t.Errorf("expected redraft ADP by default, got %+v", redraft.Players)
+	}
+	_, rookie := performGetSleeperADP(t, "?season=2025&format=rookie")
+	if len(rookie.Players) != 1 || rookie.Players[0].SleeperPlayerID != "p2" {
+		t.Errorf("expected rookie-draft ADP, got %+v", rookie.Players)
+	}
+	if w, _ := performGetSleeperADP(t, "?format=bestball"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown format, got %d", w.Code)
+	}
+}
+
 // TestGetSleeperADP_SeasonListIsHardcoded verifies the available season list
 // and default season come from the hardcoded adpSeasons() list rather than
 // from whichever seasons happen to have draft_adp rows for the resolved
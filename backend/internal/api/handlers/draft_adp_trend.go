@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/database"
+	"backend/internal/models"
+)
+
+// SleeperADPTrendPoint is one week of a player's ADP series. WeekStart is
+// the Monday (UTC) the week of draft start times begins on; PickCount is the
+// number of drafts that week the player was picked in.
+type SleeperADPTrendPoint struct {
+	WeekStart string  `json:"week_start"`
+	AvgPickNo float64 `json:"avg_pick_no"`
+	PickCount int     `json:"pick_count"`
+}
+
+// SleeperADPTrendResponse is the response for GET /api/v1/sleeper/adp/trend.
+type SleeperADPTrendResponse struct {
+	SleeperPlayerID string                 `json:"sleeper_player_id"`
+	Segment         string                 `json:"segment"`
+	Season          string                 `json:"season"`
+	Points          []SleeperADPTrendPoint `json:"points"`
+}
+
+// GetSleeperADPTrend returns one player's weekly ADP series for a segment and
+// season, oldest week first, from the draft_adp_weekly rows the ADP rollup
+// writes. sleeper_player_id is required; league_size, scoring_format,
+// superflex, format and season default as in GetSleeperADP. The weekly
+// series is only rolled up for base segments, so te_premium, pass_td and
+// lineup fall back to the base segment. Every week is returned,
+// however thin — pick_count lets the chart weigh them.
+func GetSleeperADPTrend(c *gin.Context) {
+	playerID := c.Query("sleeper_player_id")
+	if playerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sleeper_player_id is required"})
+		return
+	}
+
+	requested, err := adpSegmentQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	season := c.Query("season")
+	if seasons := adpSeasons(); season == "" && len(seasons) > 0 {
+		season = seasons[0]
+	}
+	segment := requested.Base().Key()
+
+	var weeks []models.DraftADPWeek
+	if err := database.DB.
+		Where("segment = ? AND season = ? AND sleeper_player_id = ?", segment, season, playerID).
+		Order("week_start ASC").
+		Find(&weeks).Error; err != nil {
+		slog.Error("Failed to fetch ADP trend", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch ADP trend"})
+		return
+	}
+
+	points := make([]SleeperADPTrendPoint, len(weeks))
+	for i, w := range weeks {
+		points[i] = SleeperADPTrendPoint{
+			WeekStart: w.WeekStart.Format("2006-01-02"),
+			AvgPickNo: w.AvgPickNo,
+			PickCount: w.PickCount,
+		}
+	}
+
+	c.JSON(http.StatusOK, SleeperADPTrendResponse{
+		SleeperPlayerID: playerID,
+		Segment:         segment,
+		Season:          season,
+		Points:          points,
+	})
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+)
+
+func seedADPWeek(t *testing.T, db *gorm.DB, playerID string, weekStart time.Time, avgPick float64, pickCount int) {
+	t.Helper()
+	if err := db.Create(&models.DraftADPWeek{
+		Segment:         "12-ppr-sf",
+		Season:          "2025",
+		WeekStart:       weekStart,
+		SleeperPlayerID: playerID,
+		AvgPickNo:       avgPick,
+		PickCount:       pickCount,
+	}).Error; err != nil {
+		t.Fatalf("seed adp week %s: %v", playerID, err)
+	}
+}
+
+func seedADPMovers(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	prev := time.Date(2025, 8, 4, 0, 0, 0, 0, time.UTC)
+	cur := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+	for _, id := range []string{"p1", "p2", "p3", "p4"} {
+		seedADPPlayer(t, db, id, "Player "+id, "RB", "KC")
+	}
+	seedADPRow(t, db, "12-ppr-sf", "2025", "p1", 10, 40)
+	seedADPRow(t, db, "12-ppr-sf", "2025", "p2", 20, 40)
+	seedADPRow(t, db, "12-ppr-sf", "2025", "p3", 30, 40)
+	seedADPRow(t, db, "12-ppr-sf", "2025", "p4", 40, 40)
+	// p1 rises 4 spots, p2 falls 3, p3 holds; p4 is too thin this week.
+	seedADPWeek(t, db, "p1", prev, 12, 10)
+	seedADPWeek(t, db, "p1", cur, 8, 10)
+	seedADPWeek(t, db, "p2", prev, 19, 10)
+	seedADPWeek(t, db, "p2", cur, 22, 10)
+	seedADPWeek(t, db, "p3", prev, 30, 10)
+	seedADPWeek(t, db, "p3", cur, 30, 10)
+	seedADPWeek(t, db, "p4", prev, 45, 10)
+	seedADPWeek(t, db, "p4", cur, 35, 2)
+}
+
+func TestGetSleeperADP_IncludesWeekOverWeekChange(t *testing.T) {
+	db := newDraftADPTestDB(t)
+	withDraftADPTestDB(t, db)
+	seedADPMovers(t, db)
+
+	w, resp := performGetSleeperADP(t, "?season=2025")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.TrendWeekStart == nil || *resp.TrendWeekStart != "2025-08-11" {
+		t.Errorf("expected trend week 2025-08-11, got %v", resp.TrendWeekStart)
+	}
+	if len(resp.Players) != 4 {
+		t.Fatalf("expected every player in ADP order, got %+v", resp.Players)
+	}
+	p1 := resp.Players[0]
+	if p1.ADPChange == nil || *p1.ADPChange != 4 || p1.WeekAvgPickNo == nil || *p1.WeekAvgPickNo != 8 {
+		t.Errorf("expected p1 up 4 at weekly ADP 8, got %+v", p1)
+	}
+	if resp.Players[3].ADPChange != nil {
+		t.Errorf("expected no change for a thin week, got %v", *resp.Players[3].ADPChange)
+	}
+}
+
+func TestGetSleeperADP_RisersAndFallers(t *testing.T) {
+	db := newDraftADPTestDB(t)
+	withDraftADPTestDB(t, db)
+	seedADPMovers(t, db)
+
+	_, risers := performGetSleeperADP(t, "?season=2025&sort=risers")
+	if risers.Total != 3 || len(risers.Players) != 3 ||
+		risers.Players[0].SleeperPlayerID != "p1" || risers.Players[2].SleeperPlayerID != "p2" {
+		t.Errorf("expected p1, p3, p2 by change, got %+v", risers.Players)
+	}
+	_, fallers := performGetSleeperADP(t, "?season=2025&sort=fallers")
+	if len(fallers.Players) != 3 || fallers.Players[0].SleeperPlayerID != "p2" {
+		t.Errorf("expected p2 to fall most, got %+v", fallers.Players)
+	}
+
+	if w, _ := performGetSleeperADP(t, "?sort=value"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown sort, got %d", w.Code)
+	}
+}
+
+func TestGetSleeperADP_RisersEmptyWithoutTwoWeeks(t *testing.T) {
+	db := newDraftADPTestDB(t)
+	withDraftADPTestDB(t, db)
+	seedADPPlayer(t, db, "p1", "Player One", "RB", "KC")
+	seedADPRow(t, db, "12-ppr-sf", "2025", "p1", 10, 40)
+	seedADPWeek(t, db, "p1", time.Date(2025, 8, 4, 0, 0, 0, 0, time.UTC), 10, 10)
+
+	_, resp := performGetSleeperADP(t, "?season=2025&sort=risers")
+	if resp.Total != 0 || len(resp.Players) != 0 || resp.TrendWeekStart != nil {
+		t.Errorf("expected no movers from a single week, got %+v", resp)
+	}
+}
+
+func TestGetSleeperADPTrend(t *testing.T) {
+	db := newDraftADPTestDB(t)
+	withDraftADPTestDB(t, db)
+	seedADPMovers(t, db)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sleeper/adp/trend", GetSleeperADPTrend)
+
+	req := httptest.NewRequest(http.MethodGet, "/sleeper/adp/trend?season=2025&sleeper_player_id=p4", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SleeperADPTrendResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Segment != "12-ppr-sf" || len(resp.Points) != 2 {
+		t.Fatalf("expected both weeks for p4, got %+v", resp)
+	}
+	if resp.Points[0].WeekStart != "2025-08-04" || resp.Points[0].AvgPickNo != 45 || resp.Points[1].PickCount != 2 {
+		t.Errorf("unexpected points %+v", resp.Points)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/sleeper/adp/trend", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without sleeper_player_id, got %d", w.Code)
+	}
+}
@@ -0,0 +1,381 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/database"
+	"backend/internal/mockdraft"
+	"backend/internal/models"
+	"backend/internal/sleeper"
+	"backend/internal/valuation"
+)
+
+// sleeperAPI is the Sleeper client the live draft assistant polls. Tests
+// point it at a fake server.
+var sleeperAPI = sleeper.New()
+
+// Draft assistant polling and scoring defaults. Sleeper has no push API for
+// draft picks, so the stream polls GetDraftPicks every poll_seconds; every
+// open stream polls on its own, so at most maxDraftAssistantStreams are open
+// per draft.
+const (
+	defaultDraftAssistantPollSeconds = 5
+	minDraftAssistantPollSeconds     = 2
+	maxDraftAssistantPollSeconds     = 60
+	defaultDraftAssistantLimit       = 10
+	maxDraftAssistantLimit           = 50
+	draftAssistantSims               = 300
+	maxDraftAssistantStreams         = 4
+)
+
+// draftAssistantStreams counts the open streams by draft ID.
+var draftAssistantStreams = struct {
+	sync.Mutex
+	open map[string]int
+}{open: map[string]int{}}
+
+// openDraftAssistantStream claims one of a draft's stream slots, reporting
+// false when they're all taken. Release it with closeDraftAssistantStream.
+func openDraftAssistantStream(draftID string) bool {
+	draftAssistantStreams.Lock()
+	defer draftAssistantStreams.Unlock()
+	if draftAssistantStreams.open[draftID] >= maxDraftAssistantStreams {
+		return false
+	}
+	draftAssistantStreams.open[draftID]++
+	return true
+}
+
+func closeDraftAssistantStream(draftID string) {
+	draftAssistantStreams.Lock()
+	defer draftAssistantStreams.Unlock()
+	if draftAssistantStreams.open[draftID]--; draftAssistantStreams.open[draftID] <= 0 {
+		delete(draftAssistantStreams.open, draftID)
+	}
+}
+
+// DraftAssistantRecommendation is one candidate for the user's next pick.
+// The factors are mockdraft.Recommendation's; MarketValue is the player's
+// latest player_valuations value, when they have one.
+type DraftAssistantRecommendation struct {
+	SleeperPlayerID string   `json:"sleeper_player_id"`
+	Name            string   `json:"name"`
+	Position        string   `json:"position"`
+	NflTeam         string   `json:"nfl_team"`
+	AvgPickNo       float64  `json:"avg_pick_no"`
+	MarketValue     *float64 `json:"market_value"`
+	Value           float64  `json:"value"`
+	Need            float64  `json:"need"`
+	Scarcity        float64  `json:"scarcity"`
+	Available       float64  `json:"available"`
+	Survival        float64  `json:"survival"`
+	Score           float64  `json:"score"`
+}
+
+// DraftAssistantUpdate is one event on the GET
+// /api/v1/sleeper/drafts/:id/assistant stream, sent after every pick.
+// NextUserPick is 0 once the user has no picks left; OnClockPick is 0 once
+// the draft is complete.
+type DraftAssistantUpdate struct {
+	DraftID          string                         `json:"draft_id"`
+	Segment          string                         `json:"segment"`
+	ValuationSegment string                         `json:"valuation_segment"`
+	UserSlot         int                            `json:"user_slot"`
+	PicksMade        int                            `json:"picks_made"`
+	OnClockPick      int                            `json:"on_clock_pick"`
+	UserOnClock      bool                           `json:"user_on_clock"`
+	NextUserPick     int                            `json:"next_user_pick"`
+	Recommendations  []DraftAssistantRecommendation `json:"recommendations"`
+}
+
+// draftAssistant is everything the stream needs that doesn't change between
+// picks. slotByRoster maps each roster ID to its draft slot, from the
+// draft's slot_to_roster_id.
+type draftAssistant struct {
+	draft            *sleeper.Draft
+	cfg              mockdraft.Config
+	userSlot         int
+	slotByRoster     map[int]int
+	segment          string
+	valuationSegment string
+	pool             map[string]mockDraftPoolRow
+	players          []mockdraft.Player
+	values           map[string]*PlayerCompareValuation
+	limit            int
+}
+
+// newDraftAssistant resolves a Sleeper draft's user slot, ADP segment,
+// roster positions and valuation segment. When the draft's league has been
+// scraped into sleeper_leagues, all of them come from it; otherwise the ADP
+// segment comes from the GET /sleeper/adp filters, the valuation segment
+// from valuation_segment, and the roster from defaultMockRosterPositions.
+// The error is a message for a 400.
+func newDraftAssistant(c *gin.Context, draft *sleeper.Draft) (*draftAssistant, error) {
+	a := &draftAssistant{
+		draft: draft,
+		cfg: mockdraft.Config{
+			Teams:         draft.Settings.Teams,
+			Rounds:        draft.Settings.Rounds,
+			Linear:        draft.Type == "linear",
+			ReversalRound: draft.Settings.ReversalRound,
+		},
+		slotByRoster: make(map[int]int, len(draft.SlotToRosterID)),
+		limit:        queryIntInRange(c, "limit", defaultDraftAssistantLimit, 1, maxDraftAssistantLimit),
+	}
+	for slot, rosterID := range draft.SlotToRosterID {
+		if n, err := strconv.Atoi(slot); err == nil && rosterID != 0 {
+			a.slotByRoster[rosterID] = n
+		}
+	}
+	if draft.Type == "auction" {
+		return nil, errors.New("auction drafts are not supported")
+	}
+	if a.cfg.Teams < 2 || a.cfg.Rounds < 1 {
+		return nil, errors.New("draft has no teams or rounds set")
+	}
+	if userID := c.Query("user_id"); userID != "" {
+		a.userSlot = draft.DraftOrder[userID]
+	} else {
+		a.userSlot, _ = strconv.Atoi(c.Query("slot"))
+	}
+	if a.userSlot < 1 || a.userSlot > a.cfg.Teams {
+		return nil, errors.New("slot (1-teams), or the user_id of a drafter, is required")
+	}
+
+	requested, err := adpSegmentQuery(c)
+	if err != nil {
+		return nil, err
+	}
+	a.valuationSegment = c.DefaultQuery("valuation_segment", defaultPlayerValuationSegment)
+	var league models.SleeperLeague
+	if err := database.DB.Where("sleeper_league_id = ?", draft.LeagueID).Limit(1).Find(&league).Error; err != nil {
+		slog.Error("Failed to fetch league for draft assistant", "league", draft.LeagueID, "error", err)
+	}
+	if league.SleeperLeagueID != "" {
+		if seg, ok := models.ADPSegmentForLeague(league.TotalRosters, league.PPR, league.IsSuperflex); ok {
+			seg.Format = models.ADPFormatForLeague(league.LeagueType, a.cfg.Rounds)
+			requested = seg
+		}
+		if key := valuation.SegmentKeyForLeague(league.PPR, league.IsSuperflex, league.TotalRosters, league.LeagueType); key != "" && hasPlayerValuations(key) {
+			a.valuationSegment = key
+		}
+		if err := json.Unmarshal(league.RosterPositions, &a.cfg.RosterPositions); err != nil {
+			a.cfg.RosterPositions = nil
+		}
+	}
+	if len(a.cfg.RosterPositions) == 0 {
+		a.cfg.RosterPositions = slices.Clone(defaultMockRosterPositions)
+	}
+
+	a.segment = resolveADPSegment(requested, draft.Season)
+	return a, nil
+}
+
+// hasPlayerValuations reports whether the model has produced any
+// player_valuations for segment (valuation.HasValuations). A failed lookup
+// counts as none.
+func hasPlayerValuations(segment string) bool {
+	ok, err := valuation.HasValuations(database.DB, segment)
+	if err != nil {
+		slog.Error("Failed to check player valuations", "segment", segment, "error", err)
+		return false
+	}
+	return ok
+}
+
+// load reads the ADP pool and the pool's latest valuations.
+func (a *draftAssistant) load() error {
+	pool, err := loadMockDraftPool(a.segment, a.draft.Season)
+	if err != nil {
+		return err
+	}
+	a.pool = pool
+	a.players = mockDraftPlayers(pool)
+	ids := make([]string, 0, len(pool))
+	for id := range pool {
+		ids = append(ids, id)
+	}
+	a.values = loadCompareValuations(database.DB, a.valuationSegment, ids)
+	return nil
+}
+
+// owners returns who makes each pick that isn't the turn order's: a made
+// pick goes to its draft_slot, or to the slot of the roster that made it
+// when that's another (a traded pick); a pick still to come goes to its
+// current owner in the draft's traded picks.
+func (a *draftAssistant) owners(picks []sleeper.DraftPick, traded []sleeper.TradedPick) map[int]int {
+	owners := map[int]int{}
+	for _, t := range traded {
+		from, fromOK := a.slotByRoster[t.RosterID]
+		to, toOK := a.slotByRoster[t.OwnerID]
+		if t.Season != a.draft.Season || t.Round < 1 || t.Round > a.cfg.Rounds || !fromOK || !toOK {
+			continue
+		}
+		owners[a.cfg.PickNo(t.Round, from)] = to
+	}
+	for _, p := range picks {
+		slot := p.DraftSlot
+		if s, ok := a.slotByRoster[p.RosterID]; ok {
+			slot = s
+		}
+		if slot >= 1 && slot <= a.cfg.Teams {
+			owners[p.PickNo] = slot
+		}
+	}
+	return owners
+}
+
+// update replays picks onto the pool, with each pick attributed to the slot
+// that made or owns it, and recommends the user's next pick.
+func (a *draftAssistant) update(picks []sleeper.DraftPick, traded []sleeper.TradedPick) (DraftAssistantUpdate, error) {
+	sort.Slice(picks, func(i, j int) bool { return picks[i].PickNo < picks[j].PickNo })
+	ids := make([]string, len(picks))
+	for i, p := range picks {
+		ids[i] = p.PlayerID
+	}
+	cfg := a.cfg
+	cfg.Owners = a.owners(picks, traded)
+	d, err := mockdraft.New(cfg, a.players, ids)
+	if err != nil {
+		return DraftAssistantUpdate{}, err
+	}
+
+	values := make(map[string]float64, len(a.values))
+	for id, v := range a.values {
+		values[id] = v.Value
+	}
+	upd := DraftAssistantUpdate{
+		DraftID:          a.draft.DraftID,
+		Segment:          a.segment,
+		ValuationSegment: a.valuationSegment,
+		UserSlot:         a.userSlot,
+		PicksMade:        len(picks),
+		OnClockPick:      d.NextPick(),
+		UserOnClock:      d.NextPick() != 0 && d.SlotForPick(d.NextPick()) == a.userSlot,
+		Recommendations:  []DraftAssistantRecommendation{},
+	}
+	if next := d.PicksForSlot(a.userSlot, len(picks)); len(next) > 0 {
+		upd.NextUserPick = next[0]
+	}
+	rng := rand.New(rand.NewSource(int64(len(picks))))
+	for _, r := range d.Recommend(a.userSlot, values, a.limit, draftAssistantSims, rng) {
+		row := a.pool[r.PlayerID]
+		rec := DraftAssistantRecommendation{
+			SleeperPlayerID: r.PlayerID,
+			Name:            row.Name,
+			Position:        r.Position,
+			NflTeam:         row.NflTeam,
+			AvgPickNo:       r.ADP,
+			Value:           r.Value,
+			Need:            r.Need,
+			Scarcity:        r.Scarcity,
+			Available:       r.Available,
+			Survival:        r.Survival,
+			Score:           r.Score,
+		}
+		if v, ok := a.values[r.PlayerID]; ok {
+			rec.MarketValue = &v.Value
+		}
+		upd.Recommendations = append(upd.Recommendations, rec)
+	}
+	return upd, nil
+}
+
+// GetSleeperDraftAssistant streams pick recommendations for an in-progress
+// Sleeper draft as server-sent events. It polls the draft's picks every
+// poll_seconds (default 5, 2-60) and sends an "update" event
+// (DraftAssistantUpdate) whenever the pick count changes — and once on
+// connect — ending with a "complete" event when every pick is made. The
+// user is identified by slot or user_id; limit (default 10) caps the
+// recommendations. Each candidate is scored on ADP (draft_adp) and market
+// value (player_valuations), roster need and positional scarcity under the
+// league's roster_positions, and the odds it's there at the user's next pick
+// and lasts to the one after — see mockdraft.Draft.Recommend. The user's
+// picks follow the draft's order, reversal round and traded picks. A failed
+// poll sends an "error" event and the stream keeps polling. At most
+// maxDraftAssistantStreams streams are open per draft; past that it's a 429.
+func GetSleeperDraftAssistant(c *gin.Context) {
+	ctx := c.Request.Context()
+	draft, err := sleeperAPI.GetDraft(ctx, c.Param("id"))
+	if err != nil {
+		var nfe *sleeper.NotFoundError
+		if errors.As(err, &nfe) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "draft not found"})
+			return
+		}
+		slog.Error("Failed to fetch Sleeper draft", "draft", c.Param("id"), "error", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch draft from Sleeper"})
+		return
+	}
+	assistant, err := newDraftAssistant(c, draft)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := assistant.load(); err != nil {
+		slog.Error("Failed to load draft assistant pool", "draft", draft.DraftID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load ADP"})
+		return
+	}
+	poll := time.Duration(queryIntInRange(c, "poll_seconds", defaultDraftAssistantPollSeconds,
+		minDraftAssistantPollSeconds, maxDraftAssistantPollSeconds)) * time.Second
+	if !openDraftAssistantStream(draft.DraftID) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many assistant streams open for this draft"})
+		return
+	}
+	defer closeDraftAssistantStream(draft.DraftID)
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	lastCount := -1
+	c.Stream(func(w io.Writer) bool {
+		picks, err := sleeperAPI.GetDraftPicks(ctx, draft.DraftID)
+		switch {
+		case ctx.Err() != nil:
+			return false
+		case err != nil:
+			slog.Error("Failed to poll Sleeper draft picks", "draft", draft.DraftID, "error", err)
+			c.SSEvent("error", gin.H{"error": "Failed to fetch draft picks from Sleeper"})
+		case len(picks) != lastCount:
+			traded, err := sleeperAPI.GetDraftTradedPicks(ctx, draft.DraftID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return false
+				}
+				slog.Error("Failed to fetch Sleeper draft traded picks", "draft", draft.DraftID, "error", err)
+				c.SSEvent("error", gin.H{"error": "Failed to fetch traded picks from Sleeper"})
+				break
+			}
+			lastCount = len(picks)
+			upd, err := assistant.update(picks, traded)
+			if err != nil {
+				slog.Error("Failed to replay Sleeper draft picks", "draft", draft.DraftID, "error", err)
+				c.SSEvent("error", gin.H{"error": "Failed to replay draft picks"})
+				return false
+			}
+			c.SSEvent("update", upd)
+			if upd.OnClockPick == 0 {
+				c.SSEvent("complete", gin.H{"draft_id": draft.DraftID})
+				return false
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(poll):
+			return true
+		}
+	})
+}
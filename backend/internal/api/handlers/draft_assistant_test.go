@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/mockdraft"
+	"backend/internal/models"
+	"backend/internal/sleeper"
+)
+
+// withFakeSleeperDraft serves draft d-1 — 2 teams, 2 rounds, user u2 in
+// slot 2 — from a fake Sleeper API, returning picks[n] on the n-th poll
+// (the last entry thereafter). No picks have been traded.
+func withFakeSleeperDraft(t *testing.T, picks ...[]sleeper.DraftPick) {
+	t.Helper()
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/draft/d-1":
+			json.NewEncoder(w).Encode(sleeper.Draft{
+				DraftID:    "d-1",
+				LeagueID:   "lg-1",
+				Type:       "snake",
+				Status:     "drafting",
+				Season:     "2025",
+				Settings:   sleeper.DraftSettings{Teams: 2, Rounds: 2},
+				DraftOrder: map[string]int{"u1": 1, "u2": 2},
+			})
+		case "/v1/draft/d-1/picks":
+			n := min(int(polls.Add(1))-1, len(picks)-1)
+			json.NewEncoder(w).Encode(picks[n])
+		case "/v1/draft/d-1/traded_picks":
+			json.NewEncoder(w).Encode([]sleeper.TradedPick{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	original := sleeperAPI
+	sleeperAPI = sleeper.NewWithBaseURL(srv.URL)
+	t.Cleanup(func() { sleeperAPI = original })
+}
+
+type sseEvent struct {
+	name string
+	data string
+}
+
+// performGetDraftAssistant runs the stream over a real server — the
+// recorder can't stand in for a streaming response — and returns the status
+// and body with its events parsed.
+func performGetDraftAssistant(t *testing.T, path string) (int, string, []sseEvent) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sleeper/drafts/:id/assistant", GetSleeperDraftAssistant)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+
+	var events []sseEvent
+	scanner := bufio.NewScanner(strings.NewReader(string(body)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			events = append(events, sseEvent{name: strings.TrimPrefix(line, "event:")})
+		case strings.HasPrefix(line, "data:") && len(events) > 0:
+			events[len(events)-1].data = strings.TrimPrefix(line, "data:")
+		}
+	}
+	return resp.StatusCode, string(body), events
+}
+
+func TestGetSleeperDraftAssistant_StreamsUpdatesUntilComplete(t *testing.T) {
+	db := newDraftADPTestDB(t)
+	if err := db.AutoMigrate(&models.SleeperLeague{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	if err := db.Exec(`CREATE TABLE player_valuations (
+		segment TEXT NOT NULL,
+		sleeper_player_id TEXT NOT NULL,
+		valuation_date DATE NOT NULL,
+		value FLOAT NOT NULL
+	)`).Error; err != nil {
+		t.Fatalf("create player_valuations: %v", err)
+	}
+	withDraftADPTestDB(t, db)
+	for i, id := range []string{"p1", "p2", "p3", "p4", "p5"} {
+		seedADPPlayer(t, db, id, "Player "+id, "RB", "KC")
+		seedADPRow(t, db, "12-ppr-sf", "2025", id, float64(i+1), 25)
+	}
+	if err := db.Exec("INSERT INTO player_valuations (segment, sleeper_player_id, valuation_date, value) VALUES (?, ?, ?, ?)",
+		defaultPlayerValuationSegment, "p3", "2025-08-01", 5000).Error; err != nil {
+		t.Fatalf("seed valuation: %v", err)
+	}
+
+	withFakeSleeperDraft(t,
+		[]sleeper.DraftPick{{PickNo: 1, PlayerID: "p1"}},
+		[]sleeper.DraftPick{{PickNo: 1, PlayerID: "p1"}, {PickNo: 2, PlayerID: "p2"}, {PickNo: 3, PlayerID: "p3"}, {PickNo: 4, PlayerID: "p4"}},
+	)
+
+	code, body, events := performGetDraftAssistant(t, "/sleeper/drafts/d-1/assistant?user_id=u2&poll_seconds=2")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", code, body)
+	}
+	if len(events) != 3 || events[0].name != "update" || events[1].name != "update" || events[2].name != "complete" {
+		t.Fatalf("expected two updates then complete, got %+v", events)
+	}
+
+	var first DraftAssistantUpdate
+	if err := json.Unmarshal([]byte(events[0].data), &first); err != nil {
+		t.Fatalf("unmarshal update: %v", err)
+	}
+	if first.UserSlot != 2 || !first.UserOnClock || first.OnClockPick != 2 || first.NextUserPick != 2 {
+		t.Errorf("expected slot 2 on the clock at pick 2, got %+v", first)
+	}
+	if len(first.Recommendations) != 4 {
+		t.Fatalf("expected the 4 undrafted players recommended, got %+v", first.Recommendations)
+	}
+	for _, rec := range first.Recommendations {
+		if rec.SleeperPlayerID == "p1" {
+			t.Errorf("expected drafted p1 not to be recommended")
+		}
+		if rec.Available != 1 {
+			t.Errorf("%s: expected certain availability on the clock, got %v", rec.SleeperPlayerID, rec.Available)
+		}
+		if (rec.MarketValue != nil) != (rec.SleeperPlayerID == "p3") {
+			t.Errorf("%s: expected a market value only for p3, got %v", rec.SleeperPlayerID, rec.MarketValue)
+		}
+	}
+
+	var last DraftAssistantUpdate
+	if err := json.Unmarshal([]byte(events[1].data), &last); err != nil {
+		t.Fatalf("unmarshal update: %v", err)
+	}
+	if last.PicksMade != 4 || last.OnClockPick != 0 || len(last.Recommendations) != 0 {
+		t.Errorf("expected a finished draft with nothing to recommend, got %+v", last)
+	}
+}
+
+func TestGetSleeperDraftAssistant_RejectsUnknownDraftAndUser(t *testing.T) {
+	withDraftADPTestDB(t, newDraftADPTestDB(t))
+	withFakeSleeperDraft(t, []sleeper.DraftPick{})
+
+	if code, _, _ := performGetDraftAssistant(t, "/sleeper/drafts/nope/assistant?slot=1"); code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown draft, got %d", code)
+	}
+	if code, _, _ := performGetDraftAssistant(t, "/sleeper/drafts/d-1/assistant?user_id=stranger"); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a user not in the draft, got %d", code)
+	}
+}
+
+func TestDraftAssistantUpdate_FollowsTradedPicks(t *testing.T) {
+	pool := map[string]mockDraftPoolRow{
+		"p1": {SleeperPlayerID: "p1", Position: "QB", AvgPickNo: 1},
+		"p2": {SleeperPlayerID: "p2", Position: "QB", AvgPickNo: 2},
+		"p3": {SleeperPlayerID: "p3", Position: "RB", AvgPickNo: 3},
+		"p4": {SleeperPlayerID: "p4", Position: "RB", AvgPickNo: 4},
+	}
+	a := &draftAssistant{
+		draft:        &sleeper.Draft{DraftID: "d-1", Season: "2025"},
+		cfg:          mockdraft.Config{Teams: 2, Rounds: 3, RosterPositions: []string{"QB", "RB", "BN"}},
+		userSlot:     2,
+		slotByRoster: map[int]int{1: 1, 2: 2},
+		pool:         pool,
+		players:      mockDraftPlayers(pool),
+		limit:        10,
+	}
+
+	// Roster 2 (the user) made pick 1 with slot 1's pick, and traded its
+	// own round-2 pick — pick 3 — to roster 1: the order runs 2, 2, 1, 1, 1, 2.
+	upd, err := a.update(
+		[]sleeper.DraftPick{{PickNo: 1, DraftSlot: 1, RosterID: 2, PlayerID: "p1"}},
+		[]sleeper.TradedPick{{Season: "2025", Round: 2, RosterID: 2, OwnerID: 1}, {Season: "2026", Round: 1, RosterID: 1, OwnerID: 2}},
+	)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !upd.UserOnClock || upd.OnClockPick != 2 || upd.NextUserPick != 2 {
+		t.Errorf("expected the user on the clock at pick 2, got %+v", upd)
+	}
+	for _, rec := range upd.Recommendations {
+		if rec.SleeperPlayerID == "p2" && rec.Need != 0.5 {
+			t.Errorf("expected p2 as a bench QB behind the user's p1, got need %v", rec.Need)
+		}
+	}
+
+	upd, err = a.update([]sleeper.DraftPick{
+		{PickNo: 1, DraftSlot: 1, RosterID: 2, PlayerID: "p1"},
+		{PickNo: 2, DraftSlot: 2, RosterID: 2, PlayerID: "p3"},
+	}, []sleeper.TradedPick{{Season: "2025", Round: 2, RosterID: 2, OwnerID: 1}})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if upd.UserOnClock || upd.OnClockPick != 3 || upd.NextUserPick != 6 {
+		t.Errorf("expected the user's traded pick 3 skipped for pick 6, got %+v", upd)
+	}
+}
+
+func TestGetSleeperDraftAssistant_CapsStreamsPerDraft(t *testing.T) {
+	withDraftADPTestDB(t, newDraftADPTestDB(t))
+	withFakeSleeperDraft(t, []sleeper.DraftPick{})
+	for range maxDraftAssistantStreams {
+		if !openDraftAssistantStream("d-1") {
+			t.Fatalf("expected a free stream slot")
+		}
+		t.Cleanup(func() { closeDraftAssistantStream("d-1") })
+	}
+
+	if code, body, _ := performGetDraftAssistant(t, "/sleeper/drafts/d-1/assistant?user_id=u2"); code != http.StatusTooManyRequests {
+		t.Errorf("expected 429 with every stream slot taken, got %d: %s", code, body)
+	}
+}
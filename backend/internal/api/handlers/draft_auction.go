@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/database"
+	"backend/internal/models"
+)
+
+// SleeperAuctionValueItem is a single player's auction value row. Values are
+// prices normalized to a models.AuctionReferenceBudget ($200) budget;
+// PickCount is the number of auction drafts the player was bought in.
+type SleeperAuctionValueItem struct {
+	SleeperPlayerID string  `json:"sleeper_player_id"`
+	Name            string  `json:"name"`
+	Position        string  `json:"position"`
+	NflTeam         string  `json:"nfl_team"`
+	AvgValue        float64 `json:"avg_value"`
+	PickCount       int     `json:"pick_count"`
+	MinValue        float64 `json:"min_value"`
+	MaxValue        float64 `json:"max_value"`
+	P10Value        float64 `json:"p10_value"`
+	P50Value        float64 `json:"p50_value"`
+	P90Value        float64 `json:"p90_value"`
+	PlayerID        *string `json:"player_id,omitempty"`
+}
+
+// SleeperAuctionValuesResponse is the paginated response for GET
+// /api/v1/sleeper/adp?draft_type=auction.
+type SleeperAuctionValuesResponse struct {
+	Players          []SleeperAuctionValueItem `json:"players"`
+	DraftType        string                    `json:"draft_type"`
+	Budget           int                       `json:"budget"`
+	Season           string                    `json:"season"`
+	AvailableSeasons []string                  `json:"available_seasons"`
+	Total            int64                     `json:"total"`
+	Page             int                       `json:"page"`
+	Limit            int                       `json:"limit"`
+	TotalPages       int                       `json:"total_pages"`
+}
+
+type auctionValueItemRow struct {
+	SleeperPlayerID string  `gorm:"column:sleeper_player_id"`
+	Name            string  `gorm:"column:full_name"`
+	Position        string  `gorm:"column:position"`
+	NflTeam         string  `gorm:"column:nfl_team"`
+	AvgValue        float64 `gorm:"column:avg_value"`
+	PickCount       int     `gorm:"column:pick_count"`
+	MinValue        float64 `gorm:"column:min_value"`
+	MaxValue        float64 `gorm:"column:max_value"`
+	P10Value        float64 `gorm:"column:p10_value"`
+	P50Value        float64 `gorm:"column:p50_value"`
+	P90Value        float64 `gorm:"column:p90_value"`
+}
+
+// getSleeperAuctionValues serves GetSleeperADP's draft_type=auction: the
+// auction_values rollup for the already-resolved segment and season, most
+// expensive first, with min_drafts applied to the number of auctions each
+// player was bought in.
+func getSleeperAuctionValues(c *gin.Context, segment, season string, availableSeasons []string, minDrafts, page, limit int) {
+	offset := (page - 1) * limit
+
+	db := database.DB.Table("auction_values a").
+		Joins("JOIN sleeper_players p ON p.sleeper_player_id = a.sleeper_player_id").
+		Where("a.segment = ? AND a.season = ? AND a.pick_count >= ?", segment, season, minDrafts)
+	if playerID := c.Query("sleeper_player_id"); playerID != "" {
+		db = db.Where("a.sleeper_player_id = ?", playerID)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		slog.Error("Failed to count auction values", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch auction values"})
+		return
+	}
+	var rows []auctionValueItemRow
+	if err := db.Select("a.sleeper_player_id, p.full_name, p.position, p.nfl_team, a.avg_value, a.pick_count, a.min_value, a.max_value, a.p10_value, a.p50_value, a.p90_value").
+		Order("a.avg_value DESC, a.sleeper_player_id ASC").
+		Limit(limit).Offset(offset).
+		Scan(&rows).Error; err != nil {
+		slog.Error("Failed to fetch auction values", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch auction values"})
+		return
+	}
+
+	sleeperIDs := make([]string, 0, len(rows))
+	for _, r := range rows {
+		sleeperIDs = append(sleeperIDs, r.SleeperPlayerID)
+	}
+	sleeperMatches, err := models.GetPlayersBySleeperIDs(database.DB, sleeperIDs)
+	if err != nil {
+		slog.Error("Failed to resolve players for auction values", "error", err)
+		sleeperMatches = map[string]models.Player{}
+	}
+
+	items := make([]SleeperAuctionValueItem, len(rows))
+	for i, r := range rows {
+		items[i] = SleeperAuctionValueItem{
+			SleeperPlayerID: r.SleeperPlayerID,
+			Name:            r.Name,
+			Position:        r.Position,
+			NflTeam:         r.NflTeam,
+			AvgValue:        r.AvgValue,
+			PickCount:       r.PickCount,
+			MinValue:        r.MinValue,
+			MaxValue:        r.MaxValue,
+			P10Value:        r.P10Value,
+			P50Value:        r.P50Value,
+			P90Value:        r.P90Value,
+		}
+		if player, ok := sleeperMatches[r.SleeperPlayerID]; ok {
+			playerID := strconv.FormatUint(uint64(player.ID), 10)
+			items[i].PlayerID = &playerID
+		}
+	}
+
+	c.JSON(http.StatusOK, SleeperAuctionValuesResponse{
+		Players:          items,
+		DraftType:        "auction",
+		Budget:           models.AuctionReferenceBudget,
+		Season:           season,
+		AvailableSeasons: availableSeasons,
+		Total:            total,
+		Page:             page,
+		Limit:            limit,
+		TotalPages:       int(math.Ceil(float64(total) / float64(limit))),
+	})
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"backend/internal/models"
+)
+
+func TestGetSleeperADP_AuctionDraftType(t *testing.T) {
+	db := newDraftADPTestDB(t)
+	if err := db.AutoMigrate(&models.AuctionValue{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	withDraftADPTestDB(t, db)
+
+	seedADPPlayer(t, db, "p1", "Player One", "RB", "KC")
+	seedADPPlayer(t, db, "p2", "Player Two", "WR", "SF")
+	seedADPPlayer(t, db, "p3", "Player Three", "TE", "DET")
+	for _, row := range []models.AuctionValue{
+		{Segment: "12-ppr-sf", Season: "2025", SleeperPlayerID: "p1", AvgValue: 12, PickCount: 25, P50Value: 11},
+		{Segment: "12-ppr-sf", Season: "2025", SleeperPlayerID: "p2", AvgValue: 48.5, PickCount: 30, P90Value: 60},
+		// Too few auctions to show at the default min_drafts.
+		{Segment: "12-ppr-sf", Season: "2025", SleeperPlayerID: "p3", AvgValue: 70, PickCount: 3},
+	} {
+		if err := db.Create(&row).Error; err != nil {
+			t.Fatalf("seed auction value %s: %v", row.SleeperPlayerID, err)
+		}
+	}
+	// Snake ADP for the same segment isn't mixed in.
+	seedADPRow(t, db, "12-ppr-sf", "2025", "p3", 1.0, 40)
+
+	w, _ := performGetSleeperADP(t, "?season=2025&draft_type=auction")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SleeperAuctionValuesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.DraftType != "auction" || resp.Budget != 200 || resp.Season != "2025" {
+		t.Errorf("unexpected response metadata %+v", resp)
+	}
+	if resp.Total != 2 || len(resp.Players) != 2 || resp.Players[0].SleeperPlayerID != "p2" || resp.Players[0].P90Value != 60 {
+		t.Errorf("expected p2 then p1 by average value, got %+v", resp.Players)
+	}
+
+	_, snake := performGetSleeperADP(t, "?season=2025")
+	if snake.DraftType != "snake" || len(snake.Players) != 1 || snake.Players[0].SleeperPlayerID != "p3" {
+		t.Errorf("expected snake ADP by default, got %+v", snake)
+	}
+}
+
+func TestGetSleeperADP_RejectsUnknownDraftType(t *testing.T) {
+	db := newDraftADPTestDB(t)
+	withDraftADPTestDB(t, db)
+
+	if w, _ := performGetSleeperADP(t, "?draft_type=linear"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown draft_type, got %d", w.Code)
+	}
+}
@@ -0,0 +1,478 @@
+package handlers
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/database"
+	"backend/internal/mockdraft"
+	"backend/internal/models"
+)
+
+// defaultMockRosterPositions is Sleeper's default redraft lineup plus bench,
+// used when a mock draft is created without roster_positions or a league.
+// A superflex segment adds a SUPER_FLEX slot.
+var defaultMockRosterPositions = []string{"QB", "RB", "RB", "WR", "WR", "TE", "FLEX", "K", "DEF", "BN", "BN", "BN", "BN", "BN", "BN"}
+
+// Mock draft availability simulation defaults and caps: how many Monte Carlo
+// drafts, how many upcoming user picks, and how many top available players
+// each GET reports on. Every simulated draft replays up to teams*rounds
+// picks, so the setup caps bound each GET's work along with the sims cap.
+const (
+	defaultMockDraftSims    = 200
+	maxMockDraftSims        = 500
+	defaultMockDraftHorizon = 3
+	defaultMockDraftLimit   = 60
+	maxMockDraftLimit       = 200
+	maxMockDraftTeams       = 20
+	maxMockDraftRounds      = 30
+)
+
+// mockDraftTTL is how long a mock draft lives after its last pick. Expired
+// drafts read as not found and are deleted by the next CreateMockDraft.
+const mockDraftTTL = 24 * time.Hour
+
+// mockDraftReserveSlots are the roster positions no draft pick fills, left
+// out of the default rounds.
+var mockDraftReserveSlots = []string{"IR", "TAXI"}
+
+// CreateMockDraftRequest is the body of POST /api/v1/mock-drafts. Every
+// field is optional: with a sleeper_league_id, teams and roster_positions
+// come from that league; otherwise teams defaults to the segment's league
+// size and roster_positions to defaultMockRosterPositions. Rounds defaults
+// to the league's draft rounds when its draft has been scraped, otherwise to
+// the number of roster positions other than IR and TAXI; user_slot defaults
+// to 1.
+type CreateMockDraftRequest struct {
+	SleeperLeagueID string   `json:"sleeper_league_id"`
+	Teams           int      `json:"teams"`
+	Rounds          int      `json:"rounds"`
+	UserSlot        int      `json:"user_slot"`
+	RosterPositions []string `json:"roster_positions"`
+	Seed            *int64   `json:"seed"`
+}
+
+// MockDraftPickRequest is the body of POST /api/v1/mock-drafts/:id/picks.
+type MockDraftPickRequest struct {
+	SleeperPlayerID string `json:"sleeper_player_id"`
+}
+
+// MockDraftPick is one pick made in a mock draft. User marks the picks made
+// through the API rather than simulated.
+type MockDraftPick struct {
+	PickNo          int    `json:"pick_no"`
+	Round           int    `json:"round"`
+	Slot            int    `json:"slot"`
+	SleeperPlayerID string `json:"sleeper_player_id"`
+	Name            string `json:"name"`
+	Position        string `json:"position"`
+	NflTeam         string `json:"nfl_team"`
+	User            bool   `json:"user"`
+}
+
+// MockDraftAvailability is how likely one undrafted player is to still be on
+// the board at each of the user's upcoming picks: Probabilities[i] is for
+// UpcomingPicks[i] of the response.
+type MockDraftAvailability struct {
+	SleeperPlayerID string    `json:"sleeper_player_id"`
+	Name            string    `json:"name"`
+	Position        string    `json:"position"`
+	NflTeam         string    `json:"nfl_team"`
+	AvgPickNo       float64   `json:"avg_pick_no"`
+	Probabilities   []float64 `json:"probabilities"`
+}
+
+// MockDraftResponse is a mock draft's state, returned by every
+// /api/v1/mock-drafts endpoint. ID is random and is the only handle on the
+// draft, so only its creator can read or pick in it. OnClockPick is 0 once
+// the draft is complete.
+type MockDraftResponse struct {
+	ID              string                  `json:"id"`
+	Segment         string                  `json:"segment"`
+	Season          string                  `json:"season"`
+	Teams           int                     `json:"teams"`
+	Rounds          int                     `json:"rounds"`
+	UserSlot        int                     `json:"user_slot"`
+	RosterPositions []string                `json:"roster_positions"`
+	OnClockPick     int                     `json:"on_clock_pick"`
+	Picks           []MockDraftPick         `json:"picks"`
+	UpcomingPicks   []int                   `json:"upcoming_picks"`
+	Availability    []MockDraftAvailability `json:"availability"`
+}
+
+type mockDraftPoolRow struct {
+	SleeperPlayerID string  `gorm:"column:sleeper_player_id"`
+	Name            string  `gorm:"column:full_name"`
+	Position        string  `gorm:"column:position"`
+	NflTeam         string  `gorm:"column:nfl_team"`
+	AvgPickNo       float64 `gorm:"column:avg_pick_no"`
+	MinPickNo       int     `gorm:"column:min_pick_no"`
+	MaxPickNo       int     `gorm:"column:max_pick_no"`
+	CILowPickNo     float64 `gorm:"column:ci_low_pick_no"`
+	CIHighPickNo    float64 `gorm:"column:ci_high_pick_no"`
+}
+
+// loadMockDraftPool returns the draftable players for a segment and season —
+// every draft_adp row with at least defaultADPMinDrafts drafts behind it —
+// keyed by Sleeper player ID.
+func loadMockDraftPool(segment, season string) (map[string]mockDraftPoolRow, error) {
+	var rows []mockDraftPoolRow
+	if err := database.DB.Table("draft_adp a").
+		Select("a.sleeper_player_id, p.full_name, p.position, p.nfl_team, a.avg_pick_no, a.min_pick_no, a.max_pick_no, a.ci_low_pick_no, a.ci_high_pick_no").
+		Joins("JOIN sleeper_players p ON p.sleeper_player_id = a.sleeper_player_id").
+		Where("a.segment = ? AND a.season = ? AND a.pick_count >= ?", segment, season, defaultADPMinDrafts).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	pool := make(map[string]mockDraftPoolRow, len(rows))
+	for _, r := range rows {
+		pool[r.SleeperPlayerID] = r
+	}
+	return pool, nil
+}
+
+// buildMockDraft replays a stored mock draft onto its segment's current ADP
+// pool.
+func buildMockDraft(md models.MockDraft) (*mockdraft.Draft, map[string]mockDraftPoolRow, error) {
+	var rosterPositions, picks []string
+	if err := json.Unmarshal(md.RosterPositions, &rosterPositions); err != nil {
+		return nil, nil, err
+	}
+	if err := json.Unmarshal(md.Picks, &picks); err != nil {
+		return nil, nil, err
+	}
+	pool, err := loadMockDraftPool(md.Segment, md.Season)
+	if err != nil {
+		return nil, nil, err
+	}
+	d, err := mockdraft.New(mockdraft.Config{Teams: md.Teams, Rounds: md.Rounds, RosterPositions: rosterPositions}, mockDraftPlayers(pool), picks)
+	return d, pool, err
+}
+
+// mockDraftPlayers converts a loaded pool to the simulator's players.
+func mockDraftPlayers(pool map[string]mockDraftPoolRow) []mockdraft.Player {
+	players := make([]mockdraft.Player, 0, len(pool))
+	for _, r := range pool {
+		players = append(players, mockdraft.PlayerFromADP(r.SleeperPlayerID, r.Position, r.AvgPickNo, r.CILowPickNo, r.CIHighPickNo, r.MinPickNo, r.MaxPickNo))
+	}
+	return players
+}
+
+// mockDraftRand seeds the simulation for the draft's current pick, so
+// replaying a request replays the same simulated picks.
+func mockDraftRand(md models.MockDraft, d *mockdraft.Draft) *rand.Rand {
+	return rand.New(rand.NewSource(md.Seed + int64(d.NextPick())))
+}
+
+// advanceMockDraft simulates picks up to the user's next turn and stores the
+// draft.
+func advanceMockDraft(md *models.MockDraft, d *mockdraft.Draft) error {
+	d.AutoPick(md.UserSlot, mockDraftRand(*md, d))
+	picks, err := json.Marshal(d.Picks)
+	if err != nil {
+		return err
+	}
+	md.Picks = picks
+	return database.DB.Save(md).Error
+}
+
+// mockDraftResponse renders a mock draft with the availability of the top
+// undrafted players at the user's upcoming picks, read from the sims,
+// horizon and limit query parameters.
+func mockDraftResponse(c *gin.Context, md models.MockDraft, d *mockdraft.Draft, pool map[string]mockDraftPoolRow) MockDraftResponse {
+	sims := queryIntInRange(c, "sims", defaultMockDraftSims, 1, maxMockDraftSims)
+	horizon := queryIntInRange(c, "horizon", defaultMockDraftHorizon, 1, md.Rounds)
+	limit := queryIntInRange(c, "limit", defaultMockDraftLimit, 1, maxMockDraftLimit)
+
+	resp := MockDraftResponse{
+		ID:              md.ID,
+		Segment:         md.Segment,
+		Season:          md.Season,
+		Teams:           md.Teams,
+		Rounds:          md.Rounds,
+		UserSlot:        md.UserSlot,
+		RosterPositions: d.RosterPositions,
+		OnClockPick:     d.NextPick(),
+		Picks:           make([]MockDraftPick, len(d.Picks)),
+		UpcomingPicks:   []int{},
+		Availability:    []MockDraftAvailability{},
+	}
+	for i, id := range d.Picks {
+		pickNo := i + 1
+		slot := d.SlotForPick(pickNo)
+		r := pool[id]
+		resp.Picks[i] = MockDraftPick{
+			PickNo:          pickNo,
+			Round:           (pickNo-1)/md.Teams + 1,
+			Slot:            slot,
+			SleeperPlayerID: id,
+			Name:            r.Name,
+			Position:        r.Position,
+			NflTeam:         r.NflTeam,
+			User:            slot == md.UserSlot,
+		}
+	}
+
+	upcoming := d.PicksForSlot(md.UserSlot, len(d.Picks))
+	if len(upcoming) > horizon {
+		upcoming = upcoming[:horizon]
+	}
+	if len(upcoming) == 0 {
+		return resp
+	}
+	resp.UpcomingPicks = upcoming
+	for _, a := range d.SimulateAvailability(upcoming, limit, sims, mockDraftRand(md, d)) {
+		r := pool[a.PlayerID]
+		resp.Availability = append(resp.Availability, MockDraftAvailability{
+			SleeperPlayerID: a.PlayerID,
+			Name:            r.Name,
+			Position:        r.Position,
+			NflTeam:         r.NflTeam,
+			AvgPickNo:       r.AvgPickNo,
+			Probabilities:   a.Probabilities,
+		})
+	}
+	return resp
+}
+
+// newMockDraftID returns a random 128-bit mock draft ID, hex-encoded.
+func newMockDraftID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// mockDraftRounds returns the rounds of a Sleeper league's latest scraped
+// non-auction draft, 0 when there's none. Draft headers live in the archive
+// whenever it's configured (see DataFetchActivities.syncOneLeagueDrafts). A
+// failed lookup counts as none.
+func mockDraftRounds(sleeperLeagueID string) int {
+	db := database.DB
+	if database.Archive != nil {
+		db = database.Archive
+	}
+	var rounds []int
+	if err := db.Table("sleeper_drafts").
+		Where("sleeper_league_id = ? AND type <> ? AND rounds > 0", sleeperLeagueID, "auction").
+		Order("season DESC, start_time DESC").Limit(1).
+		Pluck("rounds", &rounds).Error; err != nil {
+		slog.Error("Failed to fetch league draft rounds for mock draft", "league", sleeperLeagueID, "error", err)
+		return 0
+	}
+	if len(rounds) == 0 {
+		return 0
+	}
+	return rounds[0]
+}
+
+// draftedRosterSlots counts the roster positions a draft fills.
+func draftedRosterSlots(positions []string) int {
+	n := 0
+	for _, p := range positions {
+		if !slices.Contains(mockDraftReserveSlots, p) {
+			n++
+		}
+	}
+	return n
+}
+
+// queryIntInRange reads an integer query parameter, falling back to def when
+// it's missing or malformed and clamping it to [lo, hi].
+func queryIntInRange(c *gin.Context, name string, def, lo, hi int) int {
+	v, err := strconv.Atoi(c.DefaultQuery(name, strconv.Itoa(def)))
+	if err != nil {
+		v = def
+	}
+	return min(max(v, lo), hi)
+}
+
+// CreateMockDraft starts a mock draft over one ADP segment's pool and
+// simulates the picks before the user's first turn. The segment comes from
+// the same query filters as GET /sleeper/adp (league_size, scoring_format,
+// superflex, format, te_premium, pass_td, lineup, season — falling back to
+// the nearest populated segment), the draft setup from a
+// CreateMockDraftRequest body. Responds 201 with the draft's state; sims,
+// horizon and limit shape its availability (see GetMockDraft).
+func CreateMockDraft(c *gin.Context) {
+	requested, err := adpSegmentQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req CreateMockDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if req.SleeperLeagueID != "" {
+		var league models.SleeperLeague
+		if err := database.DB.Where("sleeper_league_id = ?", req.SleeperLeagueID).First(&league).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+				return
+			}
+			slog.Error("Failed to fetch league for mock draft", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create mock draft"})
+			return
+		}
+		req.Teams = league.TotalRosters
+		if req.Rounds == 0 {
+			req.Rounds = mockDraftRounds(league.SleeperLeagueID)
+		}
+		if err := json.Unmarshal(league.RosterPositions, &req.RosterPositions); err != nil {
+			req.RosterPositions = nil
+		}
+	}
+	if len(req.RosterPositions) == 0 {
+		req.RosterPositions = slices.Clone(defaultMockRosterPositions)
+		if requested.Superflex {
+			req.RosterPositions = slices.Insert(req.RosterPositions, 7, "SUPER_FLEX")
+		}
+	}
+	if req.Teams == 0 {
+		req.Teams, _ = strconv.Atoi(requested.LeagueSize)
+		if requested.LeagueSize == "14+" {
+			req.Teams = 14
+		}
+	}
+	if req.Rounds == 0 {
+		req.Rounds = draftedRosterSlots(req.RosterPositions)
+	}
+	if req.UserSlot == 0 {
+		req.UserSlot = 1
+	}
+	if req.Teams < 2 || req.Teams > maxMockDraftTeams || req.Rounds < 1 || req.Rounds > maxMockDraftRounds {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("teams must be 2-%d and rounds 1-%d", maxMockDraftTeams, maxMockDraftRounds)})
+		return
+	}
+	if req.UserSlot < 1 || req.UserSlot > req.Teams {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_slot must be between 1 and teams"})
+		return
+	}
+
+	season := c.Query("season")
+	if seasons := adpSeasons(); season == "" && len(seasons) > 0 {
+		season = seasons[0]
+	}
+	seed := time.Now().UnixNano()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+	if err := database.DB.Where("updated_at < ?", time.Now().Add(-mockDraftTTL)).Delete(&models.MockDraft{}).Error; err != nil {
+		slog.Error("Failed to delete expired mock drafts", "error", err)
+	}
+	id, err := newMockDraftID()
+	if err != nil {
+		slog.Error("Failed to generate mock draft ID", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create mock draft"})
+		return
+	}
+	rosterPositions, _ := json.Marshal(req.RosterPositions)
+	md := models.MockDraft{
+		ID:              id,
+		Segment:         resolveADPSegment(requested, season),
+		Season:          season,
+		Teams:           req.Teams,
+		Rounds:          req.Rounds,
+		UserSlot:        req.UserSlot,
+		RosterPositions: rosterPositions,
+		Picks:           json.RawMessage("[]"),
+		Seed:            seed,
+	}
+	d, pool, err := buildMockDraft(md)
+	if err != nil {
+		slog.Error("Failed to build mock draft", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create mock draft"})
+		return
+	}
+	if len(pool) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no ADP for this segment and season"})
+		return
+	}
+	if err := advanceMockDraft(&md, d); err != nil {
+		slog.Error("Failed to save mock draft", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create mock draft"})
+		return
+	}
+	c.JSON(http.StatusCreated, mockDraftResponse(c, md, d, pool))
+}
+
+// loadMockDraft fetches and replays the mock draft named by :id, writing the
+// error response and returning ok=false on failure. A draft past
+// mockDraftTTL is not found.
+func loadMockDraft(c *gin.Context) (models.MockDraft, *mockdraft.Draft, map[string]mockDraftPoolRow, bool) {
+	var md models.MockDraft
+	if err := database.DB.First(&md, "id = ? AND updated_at >= ?", c.Param("id"), time.Now().Add(-mockDraftTTL)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "mock draft not found"})
+			return md, nil, nil, false
+		}
+		slog.Error("Failed to fetch mock draft", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch mock draft"})
+		return md, nil, nil, false
+	}
+	d, pool, err := buildMockDraft(md)
+	if err != nil {
+		slog.Error("Failed to replay mock draft", "id", md.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch mock draft"})
+		return md, nil, nil, false
+	}
+	return md, d, pool, true
+}
+
+// GetMockDraft returns a mock draft's picks so far and, for the limit
+// (default 60) highest-ADP players still available, the probability each is
+// still there at the user's next horizon (default 3) picks, estimated over
+// sims (default 200, at most 500) simulated drafts.
+func GetMockDraft(c *gin.Context) {
+	md, d, pool, ok := loadMockDraft(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, mockDraftResponse(c, md, d, pool))
+}
+
+// MakeMockDraftPick makes the user's pick — the body's sleeper_player_id,
+// who must still be available — then simulates every pick up to the user's
+// next turn. 409 when the user isn't on the clock.
+func MakeMockDraftPick(c *gin.Context) {
+	var req MockDraftPickRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.SleeperPlayerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sleeper_player_id is required"})
+		return
+	}
+	md, d, pool, ok := loadMockDraft(c)
+	if !ok {
+		return
+	}
+	if pickNo := d.NextPick(); pickNo == 0 || d.SlotForPick(pickNo) != md.UserSlot {
+		c.JSON(http.StatusConflict, gin.H{"error": "user is not on the clock"})
+		return
+	}
+	if err := d.Pick(req.SleeperPlayerID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := advanceMockDraft(&md, d); err != nil {
+		slog.Error("Failed to save mock draft", "id", md.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save mock draft"})
+		return
+	}
+	c.JSON(http.StatusOK, mockDraftResponse(c, md, d, pool))
+}
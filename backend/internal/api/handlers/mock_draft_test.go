@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+)
+
+func performMockDraftRequest(t *testing.T, method, path, body string) (*httptest.ResponseRecorder, MockDraftResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/mock-drafts", CreateMockDraft)
+	r.GET("/mock-drafts/:id", GetMockDraft)
+	r.POST("/mock-drafts/:id/picks", MakeMockDraftPick)
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp MockDraftResponse
+	if w.Code == http.StatusOK || w.Code == http.StatusCreated {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+// seedMockDraftPool seeds 20 players, p1..p20, at ADP 1..20 in 12-ppr-sf.
+func seedMockDraftPool(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := newDraftADPTestDB(t)
+	if err := db.AutoMigrate(&models.MockDraft{}, &models.SleeperLeague{}, &models.SleeperDraft{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	withDraftADPTestDB(t, db)
+	positions := []string{"QB", "RB", "WR", "TE"}
+	for i := 1; i <= 20; i++ {
+		id := fmt.Sprintf("p%d", i)
+		seedADPPlayer(t, db, id, "Player "+id, positions[i%len(positions)], "KC")
+		seedADPRow(t, db, "12-ppr-sf", "2025", id, float64(i), 25)
+	}
+	return db
+}
+
+func TestCreateMockDraft_SimulatesUpToUserPick(t *testing.T) {
+	seedMockDraftPool(t)
+
+	w, resp := performMockDraftRequest(t, http.MethodPost, "/mock-drafts?season=2025",
+		`{"teams": 4, "rounds": 3, "user_slot": 3, "seed": 42}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(resp.ID) != 32 {
+		t.Errorf("expected a random 32-character hex ID, got %q", resp.ID)
+	}
+	if resp.Segment != "12-ppr-sf" || resp.OnClockPick != 3 || len(resp.Picks) != 2 {
+		t.Fatalf("expected two simulated picks with pick 3 on the clock, got %+v", resp)
+	}
+	if resp.Picks[0].User || resp.Picks[0].Name == "" {
+		t.Errorf("expected a named simulated first pick, got %+v", resp.Picks[0])
+	}
+	if want := []int{3, 6, 11}; fmt.Sprint(resp.UpcomingPicks) != fmt.Sprint(want) {
+		t.Errorf("expected upcoming picks %v, got %v", want, resp.UpcomingPicks)
+	}
+	if len(resp.Availability) != 18 {
+		t.Fatalf("expected availability for the 18 undrafted players, got %d", len(resp.Availability))
+	}
+	for _, a := range resp.Availability {
+		if a.Probabilities[0] != 1 {
+			t.Errorf("%s: expected certain availability at the pick on the clock, got %v", a.SleeperPlayerID, a.Probabilities[0])
+		}
+	}
+}
+
+func TestMakeMockDraftPick_RecordsPickAndAdvances(t *testing.T) {
+	seedMockDraftPool(t)
+
+	_, created := performMockDraftRequest(t, http.MethodPost, "/mock-drafts?season=2025",
+		`{"teams": 4, "rounds": 3, "user_slot": 3, "seed": 42}`)
+	path := "/mock-drafts/" + created.ID + "/picks"
+
+	taken := created.Picks[0].SleeperPlayerID
+	if w, _ := performMockDraftRequest(t, http.MethodPost, path, `{"sleeper_player_id": "`+taken+`"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a drafted player, got %d", w.Code)
+	}
+
+	w, resp := performMockDraftRequest(t, http.MethodPost, path, `{"sleeper_player_id": "p20"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.OnClockPick != 6 || len(resp.Picks) != 5 {
+		t.Fatalf("expected picks 4 and 5 simulated and pick 6 on the clock, got %d picks, on clock %d", len(resp.Picks), resp.OnClockPick)
+	}
+	if p := resp.Picks[2]; !p.User || p.SleeperPlayerID != "p20" {
+		t.Errorf("expected the user's pick of p20 at pick 3, got %+v", p)
+	}
+
+	w, fetched := performMockDraftRequest(t, http.MethodGet, "/mock-drafts/"+created.ID, "")
+	if w.Code != http.StatusOK || len(fetched.Picks) != 5 {
+		t.Errorf("expected the stored draft to have 5 picks, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateMockDraft_RejectsBadSetup(t *testing.T) {
+	seedMockDraftPool(t)
+
+	if w, _ := performMockDraftRequest(t, http.MethodPost, "/mock-drafts?season=2025", `{"teams": 4, "user_slot": 5}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a user_slot past teams, got %d", w.Code)
+	}
+	if w, _ := performMockDraftRequest(t, http.MethodPost, "/mock-drafts?season=2025&league_size=10", `{}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a segment without ADP, got %d", w.Code)
+	}
+	if w, _ := performMockDraftRequest(t, http.MethodPost, "/mock-drafts?season=2025", `{"teams": 32, "rounds": 3}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for teams past the cap, got %d", w.Code)
+	}
+	if w, _ := performMockDraftRequest(t, http.MethodGet, "/mock-drafts/999", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown mock draft, got %d", w.Code)
+	}
+}
+
+func TestMockDraft_ExpiresAfterTTL(t *testing.T) {
+	db := seedMockDraftPool(t)
+
+	_, stale := performMockDraftRequest(t, http.MethodPost, "/mock-drafts?season=2025", `{"teams": 4, "rounds": 3, "seed": 42}`)
+	if err := db.Model(&models.MockDraft{}).Where("id = ?", stale.ID).
+		UpdateColumn("updated_at", time.Now().Add(-mockDraftTTL-time.Hour)).Error; err != nil {
+		t.Fatalf("age mock draft: %v", err)
+	}
+	if w, _ := performMockDraftRequest(t, http.MethodGet, "/mock-drafts/"+stale.ID, ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an expired mock draft, got %d", w.Code)
+	}
+
+	_, fresh := performMockDraftRequest(t, http.MethodPost, "/mock-drafts?season=2025", `{"teams": 4, "rounds": 3, "seed": 42}`)
+	var ids []string
+	db.Model(&models.MockDraft{}).Pluck("id", &ids)
+	if len(ids) != 1 || ids[0] != fresh.ID {
+		t.Errorf("expected creating a draft to delete the expired one, got %v", ids)
+	}
+}
+
+func TestCreateMockDraft_DefaultRoundsSkipReserveSlots(t *testing.T) {
+	db := seedMockDraftPool(t)
+
+	w, resp := performMockDraftRequest(t, http.MethodPost, "/mock-drafts?season=2025",
+		`{"teams": 4, "roster_positions": ["QB", "RB", "WR", "BN", "IR", "TAXI"]}`)
+	if w.Code != http.StatusCreated || resp.Rounds != 4 {
+		t.Fatalf("expected 4 rounds without IR and TAXI, got %d rounds (%d): %s", resp.Rounds, w.Code, w.Body.String())
+	}
+
+	if err := db.Create(&models.SleeperLeague{SleeperLeagueID: "L1", TotalRosters: 4,
+		RosterPositions: json.RawMessage(`["QB", "RB", "WR", "BN", "IR"]`)}).Error; err != nil {
+		t.Fatalf("create league: %v", err)
+	}
+	rounds := 3
+	if err := db.Create(&models.SleeperDraft{SleeperDraftID: "D1", SleeperLeagueID: "L1", Type: "snake", Season: "2025", Rounds: &rounds}).Error; err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+	w, resp = performMockDraftRequest(t, http.MethodPost, "/mock-drafts?season=2025", `{"sleeper_league_id": "L1"}`)
+	if w.Code != http.StatusCreated || resp.Rounds != 3 {
+		t.Fatalf("expected the league draft's 3 rounds, got %d rounds (%d): %s", resp.Rounds, w.Code, w.Body.String())
+	}
+}
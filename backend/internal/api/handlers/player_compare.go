@@ -0,0 +1,446 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/database"
+	"backend/internal/finishes"
+	"backend/internal/models"
+)
+
+// Bounds on how many players GET /players/compare accepts in one request.
+const (
+	minComparePlayers = 2
+	maxComparePlayers = 6
+)
+
+// PlayerCompareWeek is one player's line for one week of the compared
+// season. Every field is nil when the player has no data that week (bye,
+// injury, not yet played), so all players' Weeks stay index-aligned with
+// PlayerCompareResponse.Weeks.
+type PlayerCompareWeek struct {
+	Week            int      `json:"week"`
+	Points          *float64 `json:"points"`
+	ProjectedPoints *float64 `json:"projectedPoints"`
+	Finish          *int     `json:"finish"`
+}
+
+// PlayerCompareADP is a player's draft_adp row in the compared season and
+// requested ADP segment.
+type PlayerCompareADP struct {
+	AvgPickNo float64 `json:"avgPickNo"`
+	PickCount int     `json:"pickCount"`
+	MinPickNo int     `json:"minPickNo"`
+	MaxPickNo int     `json:"maxPickNo"`
+}
+
+// PlayerCompareValuation is a player's most recent player_valuations
+// snapshot in the requested valuation segment.
+type PlayerCompareValuation struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+// PlayerCompareTransaction is one of our leagues' transactions involving
+// the player during the compared season.
+type PlayerCompareTransaction struct {
+	ID       string          `json:"id"`
+	Date     string          `json:"date"`
+	Week     uint            `json:"week"`
+	Type     TransactionType `json:"type"`
+	LeagueID string          `json:"leagueId"`
+	Team     string          `json:"team"`
+}
+
+// PlayerCompareEntry is one compared player. ADP and Valuation are nil when
+// the player has no Sleeper ID or no row in the requested segment.
+type PlayerCompareEntry struct {
+	ID                 string                     `json:"id"`
+	SleeperID          string                     `json:"sleeperId"`
+	Name               string                     `json:"name"`
+	Position           string                     `json:"position"`
+	Team               string                     `json:"team"`
+	GamesPlayed        int                        `json:"gamesPlayed"`
+	TotalFantasyPoints float64                    `json:"totalFantasyPoints"`
+	AvgFantasyPoints   float64                    `json:"avgFantasyPoints"`
+	Weeks              []PlayerCompareWeek        `json:"weeks"`
+	ADP                *PlayerCompareADP          `json:"adp"`
+	Valuation          *PlayerCompareValuation    `json:"valuation"`
+	Transactions       []PlayerCompareTransaction `json:"transactions"`
+}
+
+// PlayerCompareResponse is the response for GET /api/v1/players/compare.
+// Players are returned in the order their IDs were requested.
+type PlayerCompareResponse struct {
+	Season           string               `json:"season"`
+	Source           string               `json:"source"`
+	Scoring          string               `json:"scoring,omitempty"`
+	ADPSegment       string               `json:"adpSegment"`
+	ValuationSegment string               `json:"valuationSegment"`
+	Weeks            []int                `json:"weeks"`
+	Players          []PlayerCompareEntry `json:"players"`
+}
+
+// parseCompareIDs parses the comma-separated ids query param into distinct
+// players.id values, preserving request order.
+func parseCompareIDs(raw string) ([]uint, bool) {
+	var ids []uint
+	seen := make(map[uint]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		if !seen[uint(id)] {
+			seen[uint(id)] = true
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids, true
+}
+
+// GetPlayerComparison returns two to six players side by side for one
+// season: week-aligned points, projections and positional finishes, plus
+// each player's ADP, latest valuation and transactions.
+// Supports query params: ids (required, comma-separated players.id),
+// season (defaults to the latest season the source has data for), source
+// (sleeper|league, default sleeper), scoring (ppr|half_ppr|std, default
+// ppr; sleeper source only), league_size/scoring_format/superflex (ADP
+// segment, same defaults as /sleeper/adp) and valuation_segment (default
+// ppr-sf-10). Projections always come from box_scores, the only place we
+// store them.
+func GetPlayerComparison(c *gin.Context) {
+	ids, ok := parseCompareIDs(c.Query("ids"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids must be comma-separated player IDs"})
+		return
+	}
+	if len(ids) < minComparePlayers || len(ids) > maxComparePlayers {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Compare between 2 and 6 distinct players"})
+		return
+	}
+
+	source := c.DefaultQuery("source", finishSourceSleeper)
+	if source != finishSourceSleeper && source != finishSourceLeague {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "source must be sleeper or league"})
+		return
+	}
+	scoring := c.DefaultQuery("scoring", "ppr")
+	if _, ok := finishScoringColumns[scoring]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scoring must be ppr, half_ppr, or std"})
+		return
+	}
+	adpSegment := models.ADPSegmentKey(
+		c.DefaultQuery("league_size", "12"),
+		c.DefaultQuery("scoring_format", "ppr"),
+		c.DefaultQuery("superflex", "true") == "true",
+	)
+	valuationSegment := c.DefaultQuery("valuation_segment", defaultPlayerValuationSegment)
+
+	var players []models.Player
+	if err := database.DB.Where("id IN ?", ids).Find(&players).Error; err != nil {
+		slog.Error("Failed to fetch players for comparison", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compare players"})
+		return
+	}
+	if len(players) != len(ids) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+		return
+	}
+	byID := make(map[uint]models.Player, len(players))
+	for _, p := range players {
+		byID[p.ID] = p
+	}
+
+	season := c.Query("season")
+	if season == "" {
+		latest, err := latestWeekScoreSeason(database.DB, source)
+		if err != nil {
+			slog.Error("Failed to resolve latest comparison season", "error", err, "source", source)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compare players"})
+			return
+		}
+		season = latest
+	}
+
+	response := PlayerCompareResponse{
+		Season:           season,
+		Source:           source,
+		ADPSegment:       adpSegment,
+		ValuationSegment: valuationSegment,
+		Weeks:            []int{},
+		Players:          make([]PlayerCompareEntry, 0, len(ids)),
+	}
+	if source == finishSourceSleeper {
+		response.Scoring = scoring
+	}
+
+	weeks, err := loadCompareWeeks(database.DB, players, source, scoring, season)
+	if err != nil {
+		slog.Error("Failed to load comparison week scores", "error", err, "season", season)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compare players"})
+		return
+	}
+	projections, err := loadCompareProjections(database.DB, ids, season)
+	if err != nil {
+		slog.Error("Failed to load comparison projections", "error", err, "season", season)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compare players"})
+		return
+	}
+
+	// The compared weeks are the union of every week any player scored or
+	// was projected in, so a bye for one player still lines up with the
+	// others.
+	weekSet := make(map[int]bool)
+	for _, byWeek := range weeks {
+		for w := range byWeek {
+			weekSet[w] = true
+		}
+	}
+	for _, byWeek := range projections {
+		for w := range byWeek {
+			weekSet[w] = true
+		}
+	}
+	for w := range weekSet {
+		response.Weeks = append(response.Weeks, w)
+	}
+	sort.Ints(response.Weeks)
+
+	sleeperIDs := make([]string, 0, len(players))
+	for _, p := range players {
+		if p.SleeperID != "" {
+			sleeperIDs = append(sleeperIDs, p.SleeperID)
+		}
+	}
+	adp := loadCompareADP(database.DB, adpSegment, season, sleeperIDs)
+	valuations := loadCompareValuations(database.DB, valuationSegment, sleeperIDs)
+	transactions := loadCompareTransactions(database.DB, ids, season)
+
+	for _, id := range ids {
+		player := byID[id]
+		key := compareWeekKey(player, source)
+		entry := PlayerCompareEntry{
+			ID:           strconv.FormatUint(uint64(player.ID), 10),
+			SleeperID:    player.SleeperID,
+			Name:         player.Name,
+			Position:     player.Position,
+			Team:         player.Team,
+			Weeks:        make([]PlayerCompareWeek, 0, len(response.Weeks)),
+			Transactions: transactions[player.ID],
+		}
+		if entry.Transactions == nil {
+			entry.Transactions = []PlayerCompareTransaction{}
+		}
+		for _, w := range response.Weeks {
+			week := PlayerCompareWeek{Week: w}
+			if ranked, ok := weeks[key][w]; ok {
+				points, finish := ranked.Points, ranked.Finish
+				week.Points = &points
+				week.Finish = &finish
+				entry.GamesPlayed++
+				entry.TotalFantasyPoints += points
+			}
+			if projected, ok := projections[player.ID][w]; ok {
+				week.ProjectedPoints = &projected
+			}
+			entry.Weeks = append(entry.Weeks, week)
+		}
+		if entry.GamesPlayed > 0 {
+			entry.AvgFantasyPoints = entry.TotalFantasyPoints / float64(entry.GamesPlayed)
+		}
+		if player.SleeperID != "" {
+			entry.ADP = adp[player.SleeperID]
+			entry.Valuation = valuations[player.SleeperID]
+		}
+		response.Players = append(response.Players, entry)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// compareWeekKey is the PlayerKey a player's week scores are stored under
+// for source.
+func compareWeekKey(player models.Player, source string) string {
+	if source == finishSourceSleeper {
+		return player.SleeperID
+	}
+	return strconv.FormatUint(uint64(player.ID), 10)
+}
+
+// loadCompareWeeks ranks every compared position's week scores for season
+// and returns the compared players' ranked weeks keyed by PlayerKey, then
+// week. Each position is ranked against its full pool so finishes match
+// GET /players?view=finishes.
+func loadCompareWeeks(db *gorm.DB, players []models.Player, source, scoring, season string) (map[string]map[int]finishes.RankedWeek, error) {
+	result := make(map[string]map[int]finishes.RankedWeek)
+	if season == "" {
+		return result, nil
+	}
+	wanted := make(map[string]bool)
+	positions := make(map[string]bool)
+	for _, p := range players {
+		key := compareWeekKey(p, source)
+		if key == "" {
+			continue
+		}
+		wanted[key] = true
+		if source == finishSourceSleeper {
+			positions[sleeperPosition(p.Position)] = true
+		} else {
+			positions[p.Position] = true
+		}
+	}
+
+	for position := range positions {
+		var scores []finishes.WeekScore
+		var err error
+		if source == finishSourceSleeper {
+			scores, err = loadSleeperWeekScores(db, scoring, []string{season}, position)
+		} else {
+			scores, err = loadLeagueWeekScores(db, []string{season}, position)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range finishes.Rank(scores) {
+			if !wanted[r.PlayerKey] {
+				continue
+			}
+			if result[r.PlayerKey] == nil {
+				result[r.PlayerKey] = make(map[int]finishes.RankedWeek)
+			}
+			result[r.PlayerKey][r.Week] = r
+		}
+	}
+	return result, nil
+}
+
+// loadCompareProjections returns each player's box-score projection per
+// week of season.
+func loadCompareProjections(db *gorm.DB, playerIDs []uint, season string) (map[uint]map[int]float64, error) {
+	result := make(map[uint]map[int]float64)
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return result, nil
+	}
+	var rows []struct {
+		PlayerID  uint    `gorm:"column:player_id"`
+		Week      int     `gorm:"column:week"`
+		Projected float64 `gorm:"column:projected"`
+	}
+	if err := db.Table("box_scores bs").
+		Select("bs.player_id, m.week, MAX(bs.projected_points) AS projected").
+		Joins("JOIN matchups m ON m.id = bs.matchup_id").
+		Where("bs.deleted_at IS NULL AND bs.player_id IN ? AND m.year = ?", playerIDs, year).
+		Group("bs.player_id, m.week").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		if result[r.PlayerID] == nil {
+			result[r.PlayerID] = make(map[int]float64)
+		}
+		result[r.PlayerID][r.Week] = r.Projected
+	}
+	return result, nil
+}
+
+// loadCompareADP returns each player's ADP in segment for season. Lookup
+// failures are logged and leave ADP empty rather than failing the
+// comparison.
+func loadCompareADP(db *gorm.DB, segment, season string, sleeperIDs []string) map[string]*PlayerCompareADP {
+	result := make(map[string]*PlayerCompareADP)
+	if len(sleeperIDs) == 0 {
+		return result
+	}
+	var rows []models.DraftADP
+	if err := db.Where("segment = ? AND season = ? AND sleeper_player_id IN ?", segment, season, sleeperIDs).
+		Find(&rows).Error; err != nil {
+		slog.Error("Failed to fetch ADP for comparison", "error", err, "segment", segment, "season", season)
+		return result
+	}
+	for _, r := range rows {
+		result[r.SleeperPlayerID] = &PlayerCompareADP{
+			AvgPickNo: r.AvgPickNo,
+			PickCount: r.PickCount,
+			MinPickNo: r.MinPickNo,
+			MaxPickNo: r.MaxPickNo,
+		}
+	}
+	return result
+}
+
+// loadCompareValuations returns each player's most recent valuation in
+// segment. Like loadCompareADP, failures leave the field empty.
+func loadCompareValuations(db *gorm.DB, segment string, sleeperIDs []string) map[string]*PlayerCompareValuation {
+	result := make(map[string]*PlayerCompareValuation)
+	if len(sleeperIDs) == 0 {
+		return result
+	}
+	var rows []struct {
+		SleeperPlayerID string    `gorm:"column:sleeper_player_id"`
+		ValuationDate   time.Time `gorm:"column:valuation_date"`
+		Value           float64   `gorm:"column:value"`
+	}
+	if err := db.Table("player_valuations").
+		Select("sleeper_player_id, valuation_date, value").
+		Where("segment = ? AND sleeper_player_id IN ?", segment, sleeperIDs).
+		Where("valuation_date = (SELECT MAX(pv.valuation_date) FROM player_valuations pv WHERE pv.segment = player_valuations.segment AND pv.sleeper_player_id = player_valuations.sleeper_player_id)").
+		Scan(&rows).Error; err != nil {
+		slog.Error("Failed to fetch valuations for comparison", "error", err, "segment", segment)
+		return result
+	}
+	for _, r := range rows {
+		result[r.SleeperPlayerID] = &PlayerCompareValuation{
+			Date:  r.ValuationDate.Format("2006-01-02"),
+			Value: r.Value,
+		}
+	}
+	return result
+}
+
+// loadCompareTransactions returns our leagues' transactions involving each
+// player in season, most recent first.
+func loadCompareTransactions(db *gorm.DB, playerIDs []uint, season string) map[uint][]PlayerCompareTransaction {
+	result := make(map[uint][]PlayerCompareTransaction)
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return result
+	}
+	var txs []models.Transaction
+	if err := db.Preload("Team").
+		Where("player_id IN ? AND year = ?", playerIDs, year).
+		Order("date desc").
+		Find(&txs).Error; err != nil {
+		slog.Error("Failed to fetch transactions for comparison", "error", err, "season", season)
+		return result
+	}
+	for _, tx := range txs {
+		team := ""
+		if tx.Team != nil {
+			team = tx.Team.Owner
+		}
+		result[tx.PlayerID] = append(result[tx.PlayerID], PlayerCompareTransaction{
+			ID:       strconv.FormatUint(uint64(tx.ID), 10),
+			Date:     tx.Date.Format("Jan 02, 2006"),
+			Week:     tx.Week,
+			Type:     txTypeFromModel(tx.TransactionType),
+			LeagueID: strconv.FormatUint(uint64(tx.LeagueID), 10),
+			Team:     team,
+		})
+	}
+	return result
+}
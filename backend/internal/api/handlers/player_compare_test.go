@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetPlayerComparison_AlignsWeeksAndAttachesContext(t *testing.T) {
+	// Reuses the finishes fixture (wr1 and wr2 score weeks 1-2); wr2 also
+	// gets a week-3 box-score projection so week 3 appears for everyone.
+	db := setupFinishesDB(t)
+	if err := db.AutoMigrate(&models.DraftADP{}, &models.Transaction{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	if err := db.Exec(`CREATE TABLE player_valuations (
+		segment TEXT NOT NULL,
+		sleeper_player_id TEXT NOT NULL,
+		valuation_date DATE NOT NULL,
+		value FLOAT NOT NULL
+	)`).Error; err != nil {
+		t.Fatalf("create player_valuations: %v", err)
+	}
+
+	wr1 := models.Player{SleeperID: "wr1", Name: "WR One", Position: "WR"}
+	wr2 := models.Player{SleeperID: "wr2", Name: "WR Two", Position: "WR"}
+	db.Create(&wr1)
+	db.Create(&wr2)
+
+	league := models.League{Name: "League"}
+	db.Create(&league)
+	m := models.Matchup{LeagueID: league.ID, Week: 3, Year: 2025}
+	db.Create(&m)
+	db.Create(&models.BoxScore{MatchupID: m.ID, PlayerID: wr2.ID, ProjectedPoints: 12.5})
+
+	db.Create(&models.DraftADP{Segment: "12-ppr-sf", Season: "2025", SleeperPlayerID: "wr1", AvgPickNo: 4.2, PickCount: 50})
+	db.Create(&models.DraftADP{Segment: "10-ppr-sf", Season: "2025", SleeperPlayerID: "wr2", AvgPickNo: 30})
+	for _, row := range []struct {
+		date  string
+		value float64
+	}{{"2025-09-01", 5000}, {"2025-09-02", 5100}} {
+		db.Exec("INSERT INTO player_valuations (segment, sleeper_player_id, valuation_date, value) VALUES (?, ?, ?, ?)",
+			defaultPlayerValuationSegment, "wr1", row.date, row.value)
+	}
+	db.Create(&models.Transaction{PlayerID: wr2.ID, TransactionType: "ADDED", Year: 2025, Week: 2, LeagueID: league.ID, Date: time.Date(2025, 9, 10, 0, 0, 0, 0, time.UTC)})
+	db.Create(&models.Transaction{PlayerID: wr2.ID, TransactionType: "DROPPED", Year: 2024, Week: 2, LeagueID: league.ID})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/players/compare", GetPlayerComparison)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/players/compare?ids=%d,%d", wr2.ID, wr1.ID), nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response PlayerCompareResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if response.Season != "2025" || len(response.Weeks) != 3 {
+		t.Fatalf("expected season 2025 with weeks 1-3, got %s %v", response.Season, response.Weeks)
+	}
+	if len(response.Players) != 2 || response.Players[0].SleeperID != "wr2" {
+		t.Fatalf("expected players in request order, got %+v", response.Players)
+	}
+
+	two, one := response.Players[0], response.Players[1]
+	if len(two.Weeks) != 3 || len(one.Weeks) != 3 {
+		t.Fatalf("expected every player aligned to 3 weeks, got %d and %d", len(two.Weeks), len(one.Weeks))
+	}
+	if *two.Weeks[0].Points != 20 || *two.Weeks[0].Finish != 2 || *two.Weeks[1].Finish != 3 {
+		t.Errorf("unexpected wr2 weeks 1-2: %+v %+v", two.Weeks[0], two.Weeks[1])
+	}
+	if two.Weeks[2].Points != nil || two.Weeks[2].ProjectedPoints == nil || *two.Weeks[2].ProjectedPoints != 12.5 {
+		t.Errorf("expected wr2 week 3 to carry only a projection, got %+v", two.Weeks[2])
+	}
+	if one.Weeks[2].Points != nil || one.Weeks[2].ProjectedPoints != nil {
+		t.Errorf("expected wr1 week 3 empty, got %+v", one.Weeks[2])
+	}
+	if one.GamesPlayed != 2 || one.TotalFantasyPoints != 55 {
+		t.Errorf("unexpected wr1 totals: games=%d total=%v", one.GamesPlayed, one.TotalFantasyPoints)
+	}
+
+	if one.ADP == nil || one.ADP.AvgPickNo != 4.2 {
+		t.Errorf("expected wr1 12-ppr-sf ADP, got %+v", one.ADP)
+	}
+	if two.ADP != nil {
+		t.Errorf("expected no wr2 ADP in the default segment, got %+v", two.ADP)
+	}
+	if one.Valuation == nil || one.Valuation.Date != "2025-09-02" || one.Valuation.Value != 5100 {
+		t.Errorf("expected wr1's latest valuation, got %+v", one.Valuation)
+	}
+	if len(two.Transactions) != 1 || two.Transactions[0].Type != TransactionTypeWaiver {
+		t.Errorf("expected wr2's one 2025 transaction, got %+v", two.Transactions)
+	}
+	if len(one.Transactions) != 0 {
+		t.Errorf("expected no wr1 transactions, got %+v", one.Transactions)
+	}
+}
+
+func TestGetPlayerComparison_RejectsBadPlayerCounts(t *testing.T) {
+	setupFinishesDB(t)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/players/compare", GetPlayerComparison)
+	for _, ids := range []string{"", "1", "1,1", "1,2,3,4,5,6,7", "1,x"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/players/compare?ids="+ids, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("ids=%q: expected 400, got %d", ids, w.Code)
+		}
+	}
+}
+
+func TestGetPlayerComparison_NormalizesPositionsForSleeperStats(t *testing.T) {
+	db := setupFinishesDB(t)
+	if err := db.AutoMigrate(&models.DraftADP{}, &models.Transaction{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	if err := db.Exec(`CREATE TABLE player_valuations (
+		segment TEXT NOT NULL,
+		sleeper_player_id TEXT NOT NULL,
+		valuation_date DATE NOT NULL,
+		value FLOAT NOT NULL
+	)`).Error; err != nil {
+		t.Fatalf("create player_valuations: %v", err)
+	}
+
+	// ESPN names defenses D/ST; Sleeper names them DEF.
+	pts := 11.0
+	db.Create(&models.SleeperPlayer{SleeperPlayerID: "KC", FullName: "Kansas City", Position: "DEF"})
+	db.Create(&models.SleeperPlayerWeekStat{Season: "2025", Week: 1, SleeperPlayerID: "KC", PtsPPR: &pts})
+	dst := models.Player{SleeperID: "KC", Name: "Chiefs D/ST", Position: "D/ST"}
+	wr1 := models.Player{SleeperID: "wr1", Name: "WR One", Position: "WR"}
+	db.Create(&dst)
+	db.Create(&wr1)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/players/compare", GetPlayerComparison)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/players/compare?ids=%d,%d", dst.ID, wr1.ID), nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response PlayerCompareResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	defense := response.Players[0]
+	if len(defense.Weeks) == 0 || defense.Weeks[0].Points == nil || *defense.Weeks[0].Points != 11 || *defense.Weeks[0].Finish != 1 {
+		t.Errorf("expected the D/ST's week-1 Sleeper finish, got %+v", defense.Weeks)
+	}
+}
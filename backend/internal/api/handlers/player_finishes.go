@@ -0,0 +1,468 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/database"
+	"backend/internal/finishes"
+	"backend/internal/models"
+)
+
+// Finish profiles can be computed from two sources: Sleeper's league-agnostic
+// weekly points (sleeper_player_week_stats, every NFL player, in one of three
+// scoring formats) or our own leagues' box_scores (only rostered players,
+// scored by each league's own settings).
+const (
+	finishSourceSleeper = "sleeper"
+	finishSourceLeague  = "league"
+)
+
+// finishScoringColumns maps the scoring query param to its
+// sleeper_player_week_stats column. Ignored for the league source, whose
+// box scores are already scored.
+var finishScoringColumns = map[string]string{
+	"ppr":      "pts_ppr",
+	"half_ppr": "pts_half_ppr",
+	"std":      "pts_std",
+}
+
+// sleeperPositionNames maps players.position values (ESPN naming) to the
+// sleeper_players naming where the two differ.
+var sleeperPositionNames = map[string]string{
+	"D/ST": "DEF",
+	"DST":  "DEF",
+	"PK":   "K",
+}
+
+// sleeperPosition returns position as sleeper_players names it.
+func sleeperPosition(position string) string {
+	if name, ok := sleeperPositionNames[position]; ok {
+		return name
+	}
+	return position
+}
+
+// defaultFinishMinGames keeps one- and two-game samples, whose rates are
+// all 0 or 1, off the leaderboard.
+const defaultFinishMinGames = 4
+
+// PlayerFinishProfileResponse is one player-season's weekly positional
+// finish distribution. Rates are fractions of GamesPlayed in [0, 1].
+type PlayerFinishProfileResponse struct {
+	Source                 string  `json:"source"`
+	Scoring                string  `json:"scoring,omitempty"`
+	Season                 string  `json:"season"`
+	Position               string  `json:"position"`
+	GamesPlayed            int     `json:"gamesPlayed"`
+	TotalFantasyPoints     float64 `json:"totalFantasyPoints"`
+	AvgFantasyPoints       float64 `json:"avgFantasyPoints"`
+	AvgFinish              float64 `json:"avgFinish"`
+	MedianFinish           float64 `json:"medianFinish"`
+	BestFinish             int     `json:"bestFinish"`
+	Top3Rate               float64 `json:"top3Rate"`
+	Top12Rate              float64 `json:"top12Rate"`
+	Top24Rate              float64 `json:"top24Rate"`
+	Top36Rate              float64 `json:"top36Rate"`
+	BoomFinish             int     `json:"boomFinish"`
+	BoomRate               float64 `json:"boomRate"`
+	BustFinish             int     `json:"bustFinish"`
+	BustRate               float64 `json:"bustRate"`
+	Volatility             float64 `json:"volatility"`
+	CoefficientOfVariation float64 `json:"coefficientOfVariation"`
+}
+
+// PlayerFinishLeaderboardEntry is one leaderboard row. ID is the internal
+// players.id when the player is known to us (always for the league source,
+// via sleeper_id for the Sleeper source); empty otherwise.
+type PlayerFinishLeaderboardEntry struct {
+	ID        string `json:"id,omitempty"`
+	SleeperID string `json:"sleeperId,omitempty"`
+	Name      string `json:"name"`
+	PlayerFinishProfileResponse
+}
+
+// PlayerFinishLeaderboardResponse is the paginated response for
+// GET /api/v1/players?view=finishes.
+type PlayerFinishLeaderboardResponse struct {
+	Players []PlayerFinishLeaderboardEntry `json:"players"`
+	Source  string                         `json:"source"`
+	Scoring string                         `json:"scoring,omitempty"`
+	Season  string                         `json:"season"`
+	Sort    string                         `json:"sort"`
+	Total   int                            `json:"total"`
+	Page    int                            `json:"page"`
+	Limit   int                            `json:"limit"`
+}
+
+// finishSortKeys maps the leaderboard's sort param to the profile field it
+// orders by.
+var finishSortKeys = map[string]func(p PlayerFinishProfileResponse) float64{
+	"top3_rate":    func(p PlayerFinishProfileResponse) float64 { return p.Top3Rate },
+	"top12_rate":   func(p PlayerFinishProfileResponse) float64 { return p.Top12Rate },
+	"top24_rate":   func(p PlayerFinishProfileResponse) float64 { return p.Top24Rate },
+	"top36_rate":   func(p PlayerFinishProfileResponse) float64 { return p.Top36Rate },
+	"boom_rate":    func(p PlayerFinishProfileResponse) float64 { return p.BoomRate },
+	"bust_rate":    func(p PlayerFinishProfileResponse) float64 { return p.BustRate },
+	"volatility":   func(p PlayerFinishProfileResponse) float64 { return p.Volatility },
+	"avg_points":   func(p PlayerFinishProfileResponse) float64 { return p.AvgFantasyPoints },
+	"avg_finish":   func(p PlayerFinishProfileResponse) float64 { return p.AvgFinish },
+	"games_played": func(p PlayerFinishProfileResponse) float64 { return float64(p.GamesPlayed) },
+}
+
+func newFinishProfileResponse(source, scoring string, p finishes.Profile) PlayerFinishProfileResponse {
+	if source != finishSourceSleeper {
+		scoring = ""
+	}
+	return PlayerFinishProfileResponse{
+		Source:                 source,
+		Scoring:                scoring,
+		Season:                 p.Season,
+		Position:               p.Position,
+		GamesPlayed:            p.Games,
+		TotalFantasyPoints:     p.TotalPoints,
+		AvgFantasyPoints:       p.AvgPoints,
+		AvgFinish:              p.AvgFinish,
+		MedianFinish:           p.MedianFinish,
+		BestFinish:             p.BestFinish,
+		Top3Rate:               p.TopNRates[3],
+		Top12Rate:              p.TopNRates[12],
+		Top24Rate:              p.TopNRates[24],
+		Top36Rate:              p.TopNRates[36],
+		BoomFinish:             finishes.BoomFinish(p.Position),
+		BoomRate:               p.BoomRate,
+		BustFinish:             finishes.BustFinish(p.Position),
+		BustRate:               p.BustRate,
+		Volatility:             p.Volatility,
+		CoefficientOfVariation: p.CoefficientOfVariation,
+	}
+}
+
+type finishWeekRow struct {
+	PlayerKey string  `gorm:"column:player_key"`
+	Position  string  `gorm:"column:position"`
+	Season    string  `gorm:"column:season"`
+	Week      int     `gorm:"column:week"`
+	Points    float64 `gorm:"column:points"`
+}
+
+// loadSleeperWeekScores reads every ranked-eligible Sleeper week score for
+// the given seasons, optionally narrowed to one position. Rows with no
+// points in the requested format (players who didn't play) are skipped so
+// they neither count as games nor pad the positional ranks.
+func loadSleeperWeekScores(db *gorm.DB, scoring string, seasons []string, position string) ([]finishes.WeekScore, error) {
+	column, ok := finishScoringColumns[scoring]
+	if !ok {
+		return nil, fmt.Errorf("unknown scoring format %q", scoring)
+	}
+	query := db.Table("sleeper_player_week_stats ws").
+		Select("ws.sleeper_player_id AS player_key, sp.position, ws.season, ws.week, ws."+column+" AS points").
+		Joins("JOIN sleeper_players sp ON sp.sleeper_player_id = ws.sleeper_player_id").
+		Where("ws."+column+" IS NOT NULL AND ws.season IN ?", seasons)
+	if position != "" {
+		query = query.Where("sp.position = ?", position)
+	}
+	var rows []finishWeekRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return finishWeekScores(rows), nil
+}
+
+// loadLeagueWeekScores reads per-player week scores from box_scores for the
+// given seasons. The ETL keeps one box score per player-week, but a player
+// on rosters in several leagues can still appear more than once, so scores
+// are collapsed to the week's best.
+func loadLeagueWeekScores(db *gorm.DB, seasons []string, position string) ([]finishes.WeekScore, error) {
+	years := make([]int, 0, len(seasons))
+	for _, s := range seasons {
+		if y, err := strconv.Atoi(s); err == nil {
+			years = append(years, y)
+		}
+	}
+	query := db.Table("box_scores bs").
+		Select("bs.player_id AS player_key, p.position, m.year AS season, m.week, MAX(bs.actual_points) AS points").
+		Joins("JOIN matchups m ON m.id = bs.matchup_id").
+		Joins("JOIN players p ON p.id = bs.player_id").
+		Where("bs.deleted_at IS NULL AND m.year IN ?", years).
+		Group("bs.player_id, p.position, m.year, m.week")
+	if position != "" {
+		query = query.Where("p.position = ?", position)
+	}
+	var rows []finishWeekRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return finishWeekScores(rows), nil
+}
+
+// latestWeekScoreSeason returns the most recent season source has week
+// scores for, or "" when it has none.
+func latestWeekScoreSeason(db *gorm.DB, source string) (string, error) {
+	var latest *string
+	var err error
+	if source == finishSourceSleeper {
+		err = db.Table("sleeper_player_week_stats").Select("MAX(season)").Scan(&latest).Error
+	} else {
+		err = db.Table("matchups").Select("CAST(MAX(year) AS TEXT)").Scan(&latest).Error
+	}
+	if err != nil || latest == nil {
+		return "", err
+	}
+	return *latest, nil
+}
+
+func finishWeekScores(rows []finishWeekRow) []finishes.WeekScore {
+	scores := make([]finishes.WeekScore, len(rows))
+	for i, r := range rows {
+		scores[i] = finishes.WeekScore{
+			PlayerKey: r.PlayerKey,
+			Position:  r.Position,
+			Season:    r.Season,
+			Week:      r.Week,
+			Points:    r.Points,
+		}
+	}
+	return scores
+}
+
+// playerFinishProfiles computes a single player's finish profiles from both
+// sources, most recent season first. season narrows to one season; "all"
+// covers every season the player has scores in. Each season is ranked
+// against the full position pool for that season, not just this player.
+func playerFinishProfiles(db *gorm.DB, player models.Player, season, scoring string) ([]PlayerFinishProfileResponse, error) {
+	profiles := []PlayerFinishProfileResponse{}
+
+	if player.SleeperID != "" {
+		var sp models.SleeperPlayer
+		position := sleeperPosition(player.Position)
+		if err := db.Select("position").Where("sleeper_player_id = ?", player.SleeperID).Limit(1).Find(&sp).Error; err != nil {
+			return nil, err
+		}
+		if sp.Position != "" {
+			position = sp.Position
+		}
+		var seasons []string
+		seasonQuery := db.Table("sleeper_player_week_stats").Distinct("season").Where("sleeper_player_id = ?", player.SleeperID)
+		if season != "all" {
+			seasonQuery = seasonQuery.Where("season = ?", season)
+		}
+		if err := seasonQuery.Pluck("season", &seasons).Error; err != nil {
+			return nil, err
+		}
+		if len(seasons) > 0 && position != "" {
+			scores, err := loadSleeperWeekScores(db, scoring, seasons, position)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range finishes.BuildProfiles(finishes.Rank(scores)) {
+				if p.PlayerKey == player.SleeperID {
+					profiles = append(profiles, newFinishProfileResponse(finishSourceSleeper, scoring, p))
+				}
+			}
+		}
+	}
+
+	if player.Position != "" {
+		var years []int
+		yearQuery := db.Table("box_scores bs").
+			Joins("JOIN matchups m ON m.id = bs.matchup_id").
+			Where("bs.player_id = ? AND bs.deleted_at IS NULL", player.ID).
+			Distinct("m.year")
+		if season != "all" {
+			yearQuery = yearQuery.Where("m.year = ?", season)
+		}
+		if err := yearQuery.Pluck("m.year", &years).Error; err != nil {
+			return nil, err
+		}
+		if len(years) > 0 {
+			seasons := make([]string, len(years))
+			for i, y := range years {
+				seasons[i] = strconv.Itoa(y)
+			}
+			scores, err := loadLeagueWeekScores(db, seasons, player.Position)
+			if err != nil {
+				return nil, err
+			}
+			key := strconv.FormatUint(uint64(player.ID), 10)
+			for _, p := range finishes.BuildProfiles(finishes.Rank(scores)) {
+				if p.PlayerKey == key {
+					profiles = append(profiles, newFinishProfileResponse(finishSourceLeague, scoring, p))
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(profiles, func(i, j int) bool { return profiles[i].Season > profiles[j].Season })
+	return profiles, nil
+}
+
+// GetPlayerFinishLeaderboard returns a paginated, sortable leaderboard of
+// player-season weekly-finish profiles, served by GetPlayers for
+// view=finishes.
+// Supports query filters: source (sleeper|league, default sleeper), scoring
+// (ppr|half_ppr|std, default ppr; sleeper source only), season (defaults to
+// the latest season the source has data for), position, min_games
+// (default 4), sort (top3_rate|top12_rate|top24_rate|top36_rate|boom_rate|
+// bust_rate|volatility|avg_points|avg_finish|games_played, default
+// top12_rate) and order (asc|desc, default desc).
+func GetPlayerFinishLeaderboard(c *gin.Context) {
+	page, limit := parsePagination(c)
+
+	source := c.DefaultQuery("source", finishSourceSleeper)
+	if source != finishSourceSleeper && source != finishSourceLeague {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "source must be sleeper or league"})
+		return
+	}
+	scoring := c.DefaultQuery("scoring", "ppr")
+	if _, ok := finishScoringColumns[scoring]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scoring must be ppr, half_ppr, or std"})
+		return
+	}
+	sortKey := c.DefaultQuery("sort", "top12_rate")
+	sortValue, ok := finishSortKeys[sortKey]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort"})
+		return
+	}
+	ascending := c.DefaultQuery("order", "desc") == "asc"
+	minGames := defaultFinishMinGames
+	if v, err := strconv.Atoi(c.Query("min_games")); err == nil && v >= 0 {
+		minGames = v
+	}
+	position := c.Query("position")
+
+	season := c.Query("season")
+	if season == "" {
+		latest, err := latestWeekScoreSeason(database.DB, source)
+		if err != nil {
+			slog.Error("Failed to resolve latest finish season", "error", err, "source", source)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch player finishes"})
+			return
+		}
+		season = latest
+	}
+
+	response := PlayerFinishLeaderboardResponse{
+		Players: []PlayerFinishLeaderboardEntry{},
+		Source:  source,
+		Season:  season,
+		Sort:    sortKey,
+		Page:    page,
+		Limit:   limit,
+	}
+	if source == finishSourceSleeper {
+		response.Scoring = scoring
+	}
+	if season == "" {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	var scores []finishes.WeekScore
+	var err error
+	if source == finishSourceSleeper {
+		scores, err = loadSleeperWeekScores(database.DB, scoring, []string{season}, position)
+	} else {
+		scores, err = loadLeagueWeekScores(database.DB, []string{season}, position)
+	}
+	if err != nil {
+		slog.Error("Failed to load week scores for finishes", "error", err, "source", source, "season", season)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch player finishes"})
+		return
+	}
+
+	type ranked struct {
+		key     string
+		profile PlayerFinishProfileResponse
+	}
+	var rows []ranked
+	for _, p := range finishes.BuildProfiles(finishes.Rank(scores)) {
+		if p.Games < minGames {
+			continue
+		}
+		rows = append(rows, ranked{p.PlayerKey, newFinishProfileResponse(source, scoring, p)})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := sortValue(rows[i].profile), sortValue(rows[j].profile)
+		if a == b {
+			return rows[i].profile.TotalFantasyPoints > rows[j].profile.TotalFantasyPoints
+		}
+		if ascending {
+			return a < b
+		}
+		return a > b
+	})
+
+	response.Total = len(rows)
+	start := min((page-1)*limit, len(rows))
+	rows = rows[start:min(start+limit, len(rows))]
+
+	pageKeys := make([]string, len(rows))
+	for i, r := range rows {
+		pageKeys[i] = r.key
+	}
+	names, ids, err := resolveFinishPlayers(database.DB, source, pageKeys)
+	if err != nil {
+		slog.Error("Failed to resolve players for finish leaderboard", "error", err)
+	}
+
+	for _, r := range rows {
+		entry := PlayerFinishLeaderboardEntry{
+			Name:                        names[r.key],
+			ID:                          ids[r.key],
+			PlayerFinishProfileResponse: r.profile,
+		}
+		if source == finishSourceSleeper {
+			entry.SleeperID = r.key
+		}
+		response.Players = append(response.Players, entry)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// resolveFinishPlayers maps leaderboard player keys to display names and
+// internal players.id values. Lookup failures leave the maps partially
+// filled rather than failing the whole leaderboard.
+func resolveFinishPlayers(db *gorm.DB, source string, keys []string) (names, ids map[string]string, err error) {
+	names = make(map[string]string, len(keys))
+	ids = make(map[string]string, len(keys))
+	if len(keys) == 0 {
+		return names, ids, nil
+	}
+
+	if source == finishSourceLeague {
+		var players []models.Player
+		if err := db.Select("id, name").Where("id IN ?", keys).Find(&players).Error; err != nil {
+			return names, ids, err
+		}
+		for _, p := range players {
+			key := strconv.FormatUint(uint64(p.ID), 10)
+			names[key] = p.Name
+			ids[key] = key
+		}
+		return names, ids, nil
+	}
+
+	var sleeperPlayers []models.SleeperPlayer
+	if err := db.Select("sleeper_player_id, full_name").Where("sleeper_player_id IN ?", keys).Find(&sleeperPlayers).Error; err != nil {
+		return names, ids, err
+	}
+	for _, sp := range sleeperPlayers {
+		names[sp.SleeperPlayerID] = sp.FullName
+	}
+	matches, err := models.GetPlayersBySleeperIDs(db, keys)
+	if err != nil {
+		return names, ids, err
+	}
+	for sleeperID, p := range matches {
+		ids[sleeperID] = strconv.FormatUint(uint64(p.ID), 10)
+	}
+	return names, ids, nil
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/database"
+	"backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupFinishesDB seeds three WRs and a TE across two weeks of 2025 Sleeper
+// stats. WR finishes: wr1 1st/1st, wr2 2nd/3rd, wr3 3rd/2nd.
+func setupFinishesDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Player{}, &models.SleeperPlayer{}, &models.SleeperPlayerWeekStat{},
+		&models.League{}, &models.Team{}, &models.Matchup{}, &models.BoxScore{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+
+	points := map[string][]float64{
+		"wr1": {30, 25},
+		"wr2": {20, 2},
+		"wr3": {10, 15},
+		"te1": {8, 9},
+	}
+	for id, weeks := range points {
+		position := "WR"
+		if id == "te1" {
+			position = "TE"
+		}
+		db.Create(&models.SleeperPlayer{SleeperPlayerID: id, FullName: "Player " + id, Position: position})
+		for i, pts := range weeks {
+			pts := pts
+			db.Create(&models.SleeperPlayerWeekStat{Season: "2025", Week: i + 1, SleeperPlayerID: id, PtsPPR: &pts})
+		}
+	}
+	// A 2024 row for wr1 with no points must not count as a game.
+	db.Create(&models.SleeperPlayerWeekStat{Season: "2024", Week: 1, SleeperPlayerID: "wr1"})
+
+	original := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = original })
+	return db
+}
+
+func TestGetPlayerFinishLeaderboard_SortsByRequestedRate(t *testing.T) {
+	db := setupFinishesDB(t)
+	db.Create(&models.Player{SleeperID: "wr3", Name: "Internal WR3", Position: "WR"})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/players", GetPlayers)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/players?view=finishes&position=WR&min_games=2&sort=volatility&order=asc", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response PlayerFinishLeaderboardResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if response.Season != "2025" {
+		t.Errorf("expected default season 2025 (latest), got %q", response.Season)
+	}
+	if response.Total != 3 || len(response.Players) != 3 {
+		t.Fatalf("expected 3 WRs, got total=%d players=%+v", response.Total, response.Players)
+	}
+	// Volatility (population stddev): wr3 2.5, wr1 2.5, wr2 9 — ties break
+	// on total points, so wr1 (55) precedes wr3 (25).
+	got := []string{response.Players[0].SleeperID, response.Players[1].SleeperID, response.Players[2].SleeperID}
+	want := []string{"wr1", "wr3", "wr2"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, got)
+		}
+	}
+	wr3 := response.Players[1]
+	if wr3.ID == "" || wr3.Name != "Player wr3" {
+		t.Errorf("expected wr3 resolved to an internal player and named, got %+v", wr3)
+	}
+	if wr3.Top3Rate != 1 || wr3.BestFinish != 2 || wr3.AvgFinish != 2.5 {
+		t.Errorf("unexpected wr3 profile: %+v", wr3.PlayerFinishProfileResponse)
+	}
+}
+
+func TestGetPlayerFinishLeaderboard_RejectsUnknownSort(t *testing.T) {
+	setupFinishesDB(t)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/players", GetPlayers)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/players?view=finishes&sort=nonsense", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetPlayerByID_IncludesFinishProfilesFromBothSources(t *testing.T) {
+	db := setupFinishesDB(t)
+
+	player := models.Player{SleeperID: "wr2", Name: "WR Two", Position: "WR"}
+	other := models.Player{Name: "WR Other", Position: "WR"}
+	db.Create(&player)
+	db.Create(&other)
+	league := models.League{Name: "League"}
+	db.Create(&league)
+	for week := uint(1); week <= 2; week++ {
+		m := models.Matchup{LeagueID: league.ID, Week: week, Year: 2025}
+		db.Create(&m)
+		// player finishes 2nd in week 1 and 1st in week 2 among box scores.
+		db.Create(&models.BoxScore{MatchupID: m.ID, PlayerID: player.ID, ActualPoints: float64(5 * week * week)})
+		db.Create(&models.BoxScore{MatchupID: m.ID, PlayerID: other.ID, ActualPoints: 10})
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/players/:id", GetPlayerByID)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/players/%d?include=finishes", player.ID), nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response PlayerDetailResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if len(response.FinishProfiles) != 2 {
+		t.Fatalf("expected a sleeper and a league profile, got %+v", response.FinishProfiles)
+	}
+	bySource := map[string]PlayerFinishProfileResponse{}
+	for _, p := range response.FinishProfiles {
+		bySource[p.Source] = p
+	}
+	sleeperProfile := bySource[finishSourceSleeper]
+	if sleeperProfile.Scoring != "ppr" || sleeperProfile.GamesPlayed != 2 || sleeperProfile.BestFinish != 2 || sleeperProfile.MedianFinish != 2.5 {
+		t.Errorf("unexpected sleeper profile: %+v", sleeperProfile)
+	}
+	leagueProfile := bySource[finishSourceLeague]
+	if leagueProfile.Scoring != "" || leagueProfile.GamesPlayed != 2 || leagueProfile.Top3Rate != 1 || leagueProfile.AvgFinish != 1.5 {
+		t.Errorf("unexpected league profile: %+v", leagueProfile)
+	}
+
+	// Profiles rank the full position pool, so they're opt-in.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/players/%d", player.ID), nil))
+	response = PlayerDetailResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.FinishProfiles != nil {
+		t.Errorf("expected no finish profiles without include=finishes, got %+v (%v)", response.FinishProfiles, err)
+	}
+}
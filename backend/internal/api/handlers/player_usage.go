@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"backend/internal/database"
+	"backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PlayerUsageWeek is one week of a player's opportunity stats from
+// sleeper_player_week_stat_lines. SnapShare is nil when Sleeper reported no
+// team offensive snaps for the week.
+type PlayerUsageWeek struct {
+	Week          int      `json:"week"`
+	PassAttempts  float64  `json:"passAttempts"`
+	RushAttempts  float64  `json:"rushAttempts"`
+	Targets       float64  `json:"targets"`
+	Receptions    float64  `json:"receptions"`
+	Snaps         float64  `json:"snaps"`
+	TeamSnaps     float64  `json:"teamSnaps"`
+	SnapShare     *float64 `json:"snapShare"`
+	FieldGoalAtts float64  `json:"fieldGoalAttempts"`
+}
+
+// PlayerUsageSummary averages a season's weeks. AvgSnapShare is snaps over
+// team snaps across the weeks that reported both, not a mean of weekly
+// shares, so a week on the field for 10 snaps doesn't weigh like a full game.
+type PlayerUsageSummary struct {
+	Weeks           int      `json:"weeks"`
+	AvgPassAttempts float64  `json:"avgPassAttempts"`
+	AvgRushAttempts float64  `json:"avgRushAttempts"`
+	AvgTargets      float64  `json:"avgTargets"`
+	AvgReceptions   float64  `json:"avgReceptions"`
+	AvgSnaps        float64  `json:"avgSnaps"`
+	AvgSnapShare    *float64 `json:"avgSnapShare"`
+}
+
+type PlayerUsageResponse struct {
+	SleeperID string             `json:"sleeperId"`
+	Season    string             `json:"season"`
+	Summary   PlayerUsageSummary `json:"summary"`
+	Weeks     []PlayerUsageWeek  `json:"weeks"`
+}
+
+// GetPlayerUsage returns a player's weekly usage — attempts, targets, snaps
+// and snap share — for one season (default: the player's latest season with
+// stats).
+func GetPlayerUsage(c *gin.Context) {
+	id := c.Param("id")
+	playerID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+		return
+	}
+
+	var player models.Player
+	if err := database.DB.Select("sleeper_id").Where("id = ?", playerID).First(&player).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+			return
+		}
+		slog.Error("Failed to fetch player for usage", "error", err, "id", id)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch player usage"})
+		return
+	}
+
+	response := PlayerUsageResponse{
+		SleeperID: player.SleeperID,
+		Season:    c.Query("season"),
+		Weeks:     []PlayerUsageWeek{},
+	}
+	if player.SleeperID == "" {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	if response.Season == "" {
+		var seasons []string
+		if err := database.DB.Model(&models.SleeperPlayerWeekStatLine{}).
+			Where("sleeper_player_id = ?", player.SleeperID).
+			Order("season DESC").Limit(1).
+			Pluck("season", &seasons).Error; err != nil {
+			slog.Error("Failed to resolve usage season", "error", err, "id", id)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch player usage"})
+			return
+		}
+		if len(seasons) == 0 {
+			c.JSON(http.StatusOK, response)
+			return
+		}
+		response.Season = seasons[0]
+	}
+
+	var lines []models.SleeperPlayerWeekStatLine
+	if err := database.DB.
+		Where("sleeper_player_id = ? AND season = ?", player.SleeperID, response.Season).
+		Order("week ASC").
+		Find(&lines).Error; err != nil {
+		slog.Error("Failed to fetch player usage", "error", err, "id", id)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch player usage"})
+		return
+	}
+
+	response.Weeks, response.Summary = playerUsageWeeks(lines)
+	c.JSON(http.StatusOK, response)
+}
+
+// playerUsageWeeks converts stat lines into usage weeks and their summary.
+func playerUsageWeeks(lines []models.SleeperPlayerWeekStatLine) ([]PlayerUsageWeek, PlayerUsageSummary) {
+	weeks := make([]PlayerUsageWeek, 0, len(lines))
+	summary := PlayerUsageSummary{Weeks: len(lines)}
+	var sharedSnaps, sharedTeamSnaps float64
+	for _, l := range lines {
+		w := PlayerUsageWeek{
+			Week:          l.Week,
+			PassAttempts:  l.PassAtt,
+			RushAttempts:  l.RushAtt,
+			Targets:       l.RecTgt,
+			Receptions:    l.Rec,
+			Snaps:         l.OffSnp,
+			TeamSnaps:     l.TmOffSnp,
+			FieldGoalAtts: l.FGA,
+		}
+		if l.TmOffSnp > 0 {
+			share := l.OffSnp / l.TmOffSnp
+			w.SnapShare = &share
+			sharedSnaps += l.OffSnp
+			sharedTeamSnaps += l.TmOffSnp
+		}
+		weeks = append(weeks, w)
+
+		summary.AvgPassAttempts += l.PassAtt
+		summary.AvgRushAttempts += l.RushAtt
+		summary.AvgTargets += l.RecTgt
+		summary.AvgReceptions += l.Rec
+		summary.AvgSnaps += l.OffSnp
+	}
+	if n := float64(len(lines)); n > 0 {
+		summary.AvgPassAttempts /= n
+		summary.AvgRushAttempts /= n
+		summary.AvgTargets /= n
+		summary.AvgReceptions /= n
+		summary.AvgSnaps /= n
+	}
+	if sharedTeamSnaps > 0 {
+		share := sharedSnaps / sharedTeamSnaps
+		summary.AvgSnapShare = &share
+	}
+	return weeks, summary
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/database"
+	"backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGetPlayerUsage_DefaultsToLatestSeasonAndPoolsSnapShare(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Player{}, &models.SleeperPlayerWeekStatLine{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	player := models.Player{SleeperID: "wr1", Name: "Receiver"}
+	if err := db.Create(&player).Error; err != nil {
+		t.Fatalf("create player: %v", err)
+	}
+	for _, line := range []models.SleeperPlayerWeekStatLine{
+		{Season: "2024", Week: 1, SleeperPlayerID: "wr1", RecTgt: 99},
+		{Season: "2025", Week: 2, SleeperPlayerID: "wr1", RecTgt: 6, Rec: 4, OffSnp: 10, TmOffSnp: 40},
+		{Season: "2025", Week: 1, SleeperPlayerID: "wr1", RecTgt: 10, Rec: 8, OffSnp: 50, TmOffSnp: 60},
+		{Season: "2025", Week: 3, SleeperPlayerID: "wr1", RecTgt: 2},
+	} {
+		if err := db.Create(&line).Error; err != nil {
+			t.Fatalf("seed stat line: %v", err)
+		}
+	}
+
+	original := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = original })
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/players/:id/usage", GetPlayerUsage)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/players/1/usage", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp PlayerUsageResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Season != "2025" || len(resp.Weeks) != 3 {
+		t.Fatalf("expected 3 weeks of 2025, got season %q weeks %+v", resp.Season, resp.Weeks)
+	}
+	if resp.Weeks[0].Week != 1 || resp.Weeks[0].SnapShare == nil || math.Abs(*resp.Weeks[0].SnapShare-50.0/60) > 1e-9 {
+		t.Errorf("unexpected week 1: %+v", resp.Weeks[0])
+	}
+	if resp.Weeks[2].SnapShare != nil {
+		t.Errorf("expected nil snap share without team snaps, got %v", *resp.Weeks[2].SnapShare)
+	}
+	if resp.Summary.AvgTargets != 6 {
+		t.Errorf("expected 6 avg targets, got %v", resp.Summary.AvgTargets)
+	}
+	// 60 of 100 team snaps, not the ~0.54 mean of the weekly shares.
+	if resp.Summary.AvgSnapShare == nil || math.Abs(*resp.Summary.AvgSnapShare-0.6) > 1e-9 {
+		t.Errorf("expected pooled 0.6 snap share, got %v", resp.Summary.AvgSnapShare)
+	}
+}
@@ -98,6 +98,10 @@ type PlayerDetailResponse struct {
 	TotalStats           PlayerStatsResponse `json:"totalStats"`
 	AnnualStats          []AnnualStatsEntry  `json:"annualStats"`
 	GameLog              []GameLogEntry      `json:"gameLog"`
+	// FinishProfiles is the player's weekly positional-finish distribution
+	// per season, from both Sleeper week stats and our own box scores. Only
+	// set with include=finishes, since it ranks the full position pool.
+	FinishProfiles []PlayerFinishProfileResponse `json:"finishProfiles,omitempty"`
 }
 
 const defaultPlayerValuationSegment = "ppr-sf-10"
@@ -137,8 +141,15 @@ type PlayerSummaryResponse struct {
 	TotalStats           PlayerStatsResponse `json:"totalStats"`
 }
 
-// GetPlayers returns all players with optional filtering and pagination
+// GetPlayers returns all players with optional filtering and pagination.
+// view=finishes serves the weekly-finish leaderboard instead (see
+// GetPlayerFinishLeaderboard).
 func GetPlayers(c *gin.Context) {
+	if c.Query("view") == "finishes" {
+		GetPlayerFinishLeaderboard(c)
+		return
+	}
+
 	position := c.Query("position")                  // Filter by position if provided
 	year := c.DefaultQuery("year", "all")            // Default to all years for career stats
 	rank := c.DefaultQuery("rank", "fantasy_points") // Ranking method: fantasy_points, avg_points, projected_points, games_played, vs_projection
@@ -600,6 +611,21 @@ func GetPlayerByID(c *gin.Context) {
 		gameLog = append(gameLog, gameLogEntry)
 	}
 
+	var finishProfiles []PlayerFinishProfileResponse
+	if c.Query("include") == "finishes" {
+		scoring := c.DefaultQuery("scoring", "ppr")
+		if _, ok := finishScoringColumns[scoring]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "scoring must be ppr, half_ppr, or std"})
+			return
+		}
+		finishProfiles, err = playerFinishProfiles(database.DB, player, year, scoring)
+		if err != nil {
+			slog.Error("Failed to compute finish profiles", "error", err, "player_id", player.ID)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch player statistics"})
+			return
+		}
+	}
+
 	// Create response
 	response := PlayerDetailResponse{
 		ID:                   strconv.FormatUint(uint64(player.ID), 10),
@@ -621,6 +647,7 @@ func GetPlayerByID(c *gin.Context) {
 		TotalStats:           totalStats,
 		AnnualStats:          annualStats,
 		GameLog:              gameLog,
+		FinishProfiles:       finishProfiles,
 	}
 
 	c.JSON(http.StatusOK, response)
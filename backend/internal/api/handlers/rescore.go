@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/database"
+	"backend/internal/models"
+	"backend/internal/scoring"
+)
+
+// RescoredStanding is a scoring.Standing with the team's display names and
+// its original-scoring rank alongside.
+type RescoredStanding struct {
+	scoring.Standing
+	Owner      string `json:"owner"`
+	TeamName   string `json:"team_name"`
+	ActualRank int    `json:"actual_rank"`
+	RankChange int    `json:"rank_change"`
+}
+
+// GetRescoredSeasonResponse is the response for
+// GET /api/v1/leagues/:leagueId/rescore/:year.
+type GetRescoredSeasonResponse struct {
+	Year               uint                    `json:"year"`
+	ScoringSettings    models.ScoringSettings  `json:"scoring_settings"`
+	Standings          []RescoredStanding      `json:"standings"`
+	Matchups           []scoring.MatchupResult `json:"matchups"`
+	ActualChampionID   *uint                   `json:"actual_champion_id"`
+	RescoredChampionID *uint                   `json:"rescored_champion_id"`
+	ChampionChanged    bool                    `json:"champion_changed"`
+	Coverage           scoring.Coverage        `json:"coverage"`
+}
+
+// scoringOverrides maps each accepted query param to the ScoringSettings
+// field it overrides. Param names match ScoringSettings' JSON tags.
+func scoringOverrides(s *models.ScoringSettings) map[string]*float64 {
+	return map[string]*float64{
+		"passing_yards":     &s.PassingYards,
+		"passing_td":        &s.PassingTD,
+		"interception":      &s.Interception,
+		"rushing_yards":     &s.RushingYards,
+		"rushing_td":        &s.RushingTD,
+		"reception":         &s.Reception,
+		"receiving_yards":   &s.ReceivingYards,
+		"receiving_td":      &s.ReceivingTD,
+		"fumble":            &s.Fumble,
+		"field_goal_0to39":  &s.FieldGoal0to39,
+		"field_goal_40to49": &s.FieldGoal40to49,
+		"field_goal_50plus": &s.FieldGoal50plus,
+		"extra_point":       &s.ExtraPoint,
+	}
+}
+
+// GetRescoredSeason re-scores a league's season under alternate scoring
+// settings and returns the resulting standings, matchups and champion next
+// to the originals. The league's stored ScoringSettings are the starting
+// point; preset (standard|half_ppr|ppr) replaces the reception value, and
+// any ScoringSettings field can be overridden by its JSON name (e.g.
+// passing_td=6). Only the league's settings and presets are cached: an
+// override set is scored in memory, since every one would otherwise claim
+// its own scoring_week_points rows.
+func GetRescoredSeason(c *gin.Context) {
+	leagueID, ok := parseLeagueID(c)
+	if !ok {
+		return
+	}
+	year, err := strconv.ParseUint(c.Param("year"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year"})
+		return
+	}
+
+	var league models.League
+	if err := database.DB.First(&league, leagueID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "League not found"})
+			return
+		}
+		slog.Error("Failed to fetch league for rescore", "error", err, "league_id", leagueID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch league"})
+		return
+	}
+
+	settings := league.ScoringSettings
+	if preset := c.Query("preset"); preset != "" {
+		if settings, ok = scoring.ApplyPreset(settings, preset); !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "preset must be standard, half_ppr, or ppr"})
+			return
+		}
+	}
+	overridden := false
+	for param, field := range scoringOverrides(&settings) {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+			return
+		}
+		*field = v
+		overridden = true
+	}
+
+	result, err := scoring.RescoreSeason(c.Request.Context(), database.DB, leagueID, uint(year), settings, !overridden)
+	if err != nil {
+		slog.Error("Failed to rescore season", "error", err, "league_id", leagueID, "year", year)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rescore season"})
+		return
+	}
+
+	var teams []models.Team
+	if err := database.DB.Where("league_id = ?", leagueID).Find(&teams).Error; err != nil {
+		slog.Error("Failed to fetch teams for rescore", "error", err, "league_id", leagueID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch teams"})
+		return
+	}
+	teamsByID := make(map[uint]models.Team, len(teams))
+	for _, t := range teams {
+		teamsByID[t.ID] = t
+	}
+	actualRanks := make(map[uint]int, len(result.ActualStandings))
+	for _, s := range result.ActualStandings {
+		actualRanks[s.TeamID] = s.Rank
+	}
+
+	standings := make([]RescoredStanding, 0, len(result.RescoredStandings))
+	for _, s := range result.RescoredStandings {
+		team := teamsByID[s.TeamID]
+		standings = append(standings, RescoredStanding{
+			Standing:   s,
+			Owner:      team.Owner,
+			TeamName:   team.Name,
+			ActualRank: actualRanks[s.TeamID],
+			RankChange: actualRanks[s.TeamID] - s.Rank,
+		})
+	}
+
+	matchups := result.Matchups
+	if matchups == nil {
+		matchups = []scoring.MatchupResult{}
+	}
+	championChanged := (result.ActualChampionID == nil) != (result.RescoredChampionID == nil) ||
+		(result.ActualChampionID != nil && result.RescoredChampionID != nil && *result.ActualChampionID != *result.RescoredChampionID)
+
+	c.JSON(http.StatusOK, GetRescoredSeasonResponse{
+		Year:               uint(year),
+		ScoringSettings:    settings,
+		Standings:          standings,
+		Matchups:           matchups,
+		ActualChampionID:   result.ActualChampionID,
+		RescoredChampionID: result.RescoredChampionID,
+		ChampionChanged:    championChanged,
+		Coverage:           result.Coverage,
+	})
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"backend/internal/database"
+	"backend/internal/models"
+)
+
+// seedRescoreSeason seeds a four-team league with a two-week regular season
+// and a week-3 final between the top two seeds. Each team starts one WR
+// scored from GameStats. Under standard scoring A and B make the final and
+// B wins it; under PPR, C's catches lift it to the top seed, pushing B out,
+// and C's week-3 consolation score beats A in the replayed final.
+func seedRescoreSeason(t *testing.T) (league models.League, teams map[string]models.Team) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.League{}, &models.Team{}, &models.TeamNameHistory{}, &models.Player{},
+		&models.Matchup{}, &models.BoxScore{}, &models.SleeperPlayer{}, &models.SleeperPlayerWeekStat{},
+		&models.SleeperWeekStatFetch{}, &models.ScoringWeekPoints{}, &models.ScoringWeekPointsBuild{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	original := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = original })
+
+	league = models.League{Name: "Rescore League"}
+	if err := db.Create(&league).Error; err != nil {
+		t.Fatalf("create league: %v", err)
+	}
+	teams = make(map[string]models.Team)
+	players := make(map[string]models.Player)
+	for i, name := range []string{"A", "B", "C", "D"} {
+		team := models.Team{Name: name, ESPNID: uint(i + 1), LeagueID: league.ID}
+		if err := db.Create(&team).Error; err != nil {
+			t.Fatalf("create team: %v", err)
+		}
+		teams[name] = team
+		player := models.Player{Name: "WR " + name, Position: "WR"}
+		db.Create(&player)
+		players[name] = player
+	}
+
+	// Each side is {team, receiving yards, receptions}.
+	type side struct {
+		team    string
+		yards   float64
+		catches float64
+	}
+	for _, g := range []struct {
+		week       uint
+		gameType   string
+		home, away side
+	}{
+		{1, "NONE", side{"A", 100, 0}, side{"B", 80, 0}},
+		{1, "NONE", side{"C", 60, 10}, side{"D", 50, 0}},
+		{2, "NONE", side{"A", 100, 0}, side{"C", 70, 10}},
+		{2, "NONE", side{"B", 90, 0}, side{"D", 40, 0}},
+		{3, "WINNERS_BRACKET", side{"A", 100, 0}, side{"B", 120, 0}},
+		{3, "LOSERS_CONSOLATION_LADDER", side{"C", 90, 10}, side{"D", 30, 0}},
+	} {
+		m := models.Matchup{LeagueID: league.ID, Week: g.week, Year: 2025, GameType: g.gameType, Completed: true,
+			HomeTeamID: teams[g.home.team].ID, AwayTeamID: teams[g.away.team].ID,
+			HomeTeamFinalScore: g.home.yards / 10, AwayTeamFinalScore: g.away.yards / 10}
+		if err := db.Create(&m).Error; err != nil {
+			t.Fatalf("create matchup: %v", err)
+		}
+		for _, s := range []side{g.home, g.away} {
+			db.Create(&models.BoxScore{MatchupID: m.ID, PlayerID: players[s.team].ID, TeamID: teams[s.team].ID,
+				SlotPosition: "WR", ActualPoints: s.yards / 10,
+				GameStats: models.PlayerStats{Receptions: s.catches, ReceivingYards: s.yards}})
+		}
+	}
+	return league, teams
+}
+
+func TestGetRescoredSeason_ReseedsAndReplaysTheBracket(t *testing.T) {
+	league, teams := seedRescoreSeason(t)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/leagues/:leagueId/rescore/:year", GetRescoredSeason)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/leagues/%d/rescore/2025?preset=ppr", league.ID), nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp GetRescoredSeasonResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if len(resp.Standings) != 4 || resp.Standings[0].TeamID != teams["C"].ID || resp.Standings[0].ActualRank != 3 {
+		t.Fatalf("expected C re-seeded first from third, got %+v", resp.Standings)
+	}
+	if resp.ActualChampionID == nil || *resp.ActualChampionID != teams["B"].ID {
+		t.Errorf("expected B as the actual champion, got %v", resp.ActualChampionID)
+	}
+	if resp.RescoredChampionID == nil || *resp.RescoredChampionID != teams["C"].ID || !resp.ChampionChanged {
+		t.Errorf("expected C to win the replayed final, got %v", resp.RescoredChampionID)
+	}
+}
+
+func TestGetRescoredSeason_ScoresOverridesWithoutCaching(t *testing.T) {
+	league, _ := seedRescoreSeason(t)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/leagues/:leagueId/rescore/:year", GetRescoredSeason)
+	countBuilds := func() int64 {
+		var n int64
+		database.DB.Model(&models.ScoringWeekPointsBuild{}).Count(&n)
+		return n
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/leagues/%d/rescore/2025?preset=ppr", league.ID), nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	presetBuilds := countBuilds()
+	if presetBuilds != 3 {
+		t.Fatalf("expected the preset's three weeks cached, got %d", presetBuilds)
+	}
+
+	for _, query := range []string{"preset=ppr&reception=0.75", "receiving_yards=0.2"} {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/leagues/%d/rescore/2025?%s", league.ID, query), nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", query, w.Code, w.Body.String())
+		}
+		var resp GetRescoredSeasonResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+		if len(resp.Matchups) != 6 {
+			t.Errorf("%s: expected all six matchups rescored, got %d", query, len(resp.Matchups))
+		}
+	}
+	if n := countBuilds(); n != presetBuilds {
+		t.Errorf("expected overrides to leave the cache alone, got %d builds", n)
+	}
+}
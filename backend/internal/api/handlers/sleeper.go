@@ -68,30 +68,38 @@ type TradeSidePlayer struct {
 }
 
 // TradeSide groups the assets received by one roster in a trade. TotalValue
-// is set only when every player on the side has a persisted valuation
-// (sleeper_transactions.trade_values, written by transactioncron at sync
-// time — see internal/valuation.ComputeTradeValues); nil otherwise, whether
-// because the league's format isn't covered by the model or a player on the
-// side hasn't gotten a fresh-enough valuation yet.
+// is set only when every player and pick on the side has a persisted
+// valuation (sleeper_transactions.trade_values, written by transactioncron
+// at sync time — see internal/valuation.ComputeTradeValues); nil otherwise,
+// whether because the league's format isn't covered by the model or an asset
+// on the side hasn't gotten a fresh-enough valuation yet. ValueNow,
+// ValueLater and RealizedPoints come from the trade's trade_retrospectives
+// row and are nil until it has them.
 type TradeSide struct {
-	RosterID   int               `json:"roster_id"`
-	Players    []TradeSidePlayer `json:"players"`
-	Picks      []string          `json:"picks"`
-	TotalValue *float64          `json:"total_value"`
+	RosterID       int               `json:"roster_id"`
+	Players        []TradeSidePlayer `json:"players"`
+	Picks          []string          `json:"picks"`
+	TotalValue     *float64          `json:"total_value"`
+	ValueNow       *float64          `json:"value_now"`
+	ValueLater     *float64          `json:"value_later"`
+	RealizedPoints *float64          `json:"realized_points"`
 }
 
-// SleeperTradeItem is a single row in the trades list.
+// SleeperTradeItem is a single row in the trades list. HindsightGap is the
+// spread between its sides' values today (trade_retrospectives), nil until
+// every side has one.
 type SleeperTradeItem struct {
-	ID         string      `json:"id"`
-	LeagueID   string      `json:"league_id"`
-	LeagueName string      `json:"league_name"`
-	Season     string      `json:"season"`
-	Scoring    string      `json:"scoring"`
-	Superflex  bool        `json:"superflex"`
-	LeagueSize string      `json:"league_size"`
-	Status     string      `json:"status"`
-	Sides      []TradeSide `json:"sides"`
-	CreatedAt  int64       `json:"created_at"`
+	ID           string      `json:"id"`
+	LeagueID     string      `json:"league_id"`
+	LeagueName   string      `json:"league_name"`
+	Season       string      `json:"season"`
+	Scoring      string      `json:"scoring"`
+	Superflex    bool        `json:"superflex"`
+	LeagueSize   string      `json:"league_size"`
+	Status       string      `json:"status"`
+	Sides        []TradeSide `json:"sides"`
+	HindsightGap *float64    `json:"hindsight_gap"`
+	CreatedAt    int64       `json:"created_at"`
 }
 
 // SleeperTradesResponse is the paginated response for GET /api/v1/sleeper/trades.
@@ -290,34 +298,46 @@ func hasLeagueFilters(c *gin.Context) bool {
 		c.Query("superflex") != ""
 }
 
-// applyTradeFilters adds the player and age-window constraints used by the
-// player market view. Sleeper stores transaction adds as JSONB in Postgres;
-// SQLite uses the equivalent JSON1 expression so handler tests exercise the
-// same behavior.
-func applyTradeFilters(db *gorm.DB, c *gin.Context) (*gorm.DB, bool) {
-	filtered := false
-	if playerID := c.Query("sleeper_player_id"); playerID != "" {
-		if database.DB.Dialector.Name() == "postgres" {
-			db = db.Where("jsonb_exists(t.adds, ?)", playerID)
-		} else {
-			db = db.Where("json_type(t.adds, '$.' || ?) IS NOT NULL", playerID)
-		}
-		filtered = true
+// applyTradeFilters adds the player constraint used by the player market
+// view. Sleeper stores transaction adds as JSONB in Postgres; SQLite uses the
+// equivalent JSON1 expression so handler tests exercise the same behavior.
+func applyTradeFilters(db *gorm.DB, c *gin.Context) *gorm.DB {
+	playerID := c.Query("sleeper_player_id")
+	if playerID == "" {
+		return db
 	}
-	if days, err := strconv.Atoi(c.Query("days")); err == nil && days > 0 {
-		db = db.Where("t.created_at_sleeper >= ?", time.Now().Add(-time.Duration(days)*24*time.Hour).UnixMilli())
-		filtered = true
+	if db.Dialector.Name() == "postgres" {
+		db = db.Where("jsonb_exists(t.adds, ?)", playerID)
+	} else {
+		db = db.Where("json_type(t.adds, '$.' || ?) IS NOT NULL", playerID)
 	}
-	return db, filtered
+	return db
 }
 
 // GetSleeperTrades returns a paginated list of Sleeper trades ordered by recency,
 // with each trade's adds grouped by roster into named sides.
-// Supports query filters: league_size (int), scoring_format (standard|half_ppr|ppr), draft_type (snake|auction|linear), league_type (redraft|keeper|dynasty), superflex (bool), exclude_picks (bool), sleeper_player_id, days.
+// Supports query filters: league_size (int), scoring_format (standard|half_ppr|ppr), draft_type (snake|auction|linear), league_type (redraft|keeper|dynasty), superflex (bool), exclude_picks (bool), sleeper_player_id, days, from and to (YYYY-MM-DD).
+// A window reaching past the hot window also reads the archive DB (see
+// transactionSources); archived trades carry no total_value.
+// sort=hindsight lists only trades with a hindsight_gap, most lopsided first,
+// and only within the hot window.
 func GetSleeperTrades(c *gin.Context) {
 	page, limit := parsePagination(c)
 	offset := (page - 1) * limit
 	excludePicks := c.Query("exclude_picks") == "true" || c.Query("exclude_picks") == "1"
+	hindsight := c.Query("sort") == "hindsight"
+
+	now := time.Now()
+	window, err := parseTransactionWindow(c, now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"msg": err.Error()})
+		return
+	}
+	sources := transactionSources(window, now)
+	if hindsight && readsArchive(sources) {
+		c.JSON(http.StatusBadRequest, map[string]string{"msg": "sort=hindsight doesn't cover archived trades older than the hot window"})
+		return
+	}
 
 	type tradeRow struct {
 		SleeperTransactionID string          `gorm:"column:sleeper_transaction_id"`
@@ -337,31 +357,64 @@ func GetSleeperTrades(c *gin.Context) {
 	var rows []tradeRow
 	var total int64
 
-	db := database.DB.Table("sleeper_transactions t").
-		Select("t.sleeper_transaction_id, t.sleeper_league_id, l.name as league_name, l.season, t.status, t.adds, t.draft_picks, t.created_at_sleeper, l.ppr, l.is_superflex, l.total_rosters, t.trade_values").
-		Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = t.sleeper_league_id").
-		Where("t.type = ? AND t.status = ?", "trade", "complete")
-	db = applyLeagueFilters(db, c, "l")
-	var hasTradeFilters bool
-	db, hasTradeFilters = applyTradeFilters(db, c)
-	if excludePicks {
-		db = db.Where("t.draft_picks IS NULL OR jsonb_array_length(t.draft_picks) = 0")
+	tradesQuery := func(src transactionSource) *gorm.DB {
+		tradeValues := "t.trade_values"
+		if src.Archive {
+			tradeValues = "NULL AS trade_values"
+		}
+		db := src.DB.Table("sleeper_transactions t").
+			Select("t.sleeper_transaction_id, t.sleeper_league_id, l.name as league_name, l.season, t.status, t.adds, t.draft_picks, t.created_at_sleeper, l.ppr, l.is_superflex, l.total_rosters, "+tradeValues).
+			Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = t.sleeper_league_id").
+			Where("t.type = ? AND t.status = ?", "trade", "complete")
+		db = applyLeagueFilters(db, c, "l")
+		db = applyTradeFilters(db, c)
+		db = src.Window.apply(db)
+		if excludePicks {
+			db = db.Where("t.draft_picks IS NULL OR jsonb_array_length(t.draft_picks) = 0")
+		}
+		if hindsight {
+			db = db.Joins("JOIN trade_retrospectives r ON r.sleeper_transaction_id = t.sleeper_transaction_id").
+				Where("r.hindsight_gap IS NOT NULL")
+		}
+		return db
+	}
+	order := "t.created_at_sleeper DESC"
+	if hindsight {
+		order = "r.hindsight_gap DESC, " + order
 	}
-
 	// When no league-level filters are active, count directly on sleeper_transactions
-	// to avoid a full join across 10M+ rows. The partial index
-	// idx_sleeper_transactions_trade_complete makes this an index-only scan.
-	if hasLeagueFilters(c) || hasTradeFilters {
-		db.Count(&total)
-	} else {
-		countDB := database.DB.Model(&models.SleeperTransaction{}).
-			Where("type = ? AND status = ?", "trade", "complete")
+	// to avoid a full join across 10M+ rows. Without a player filter the partial
+	// index idx_sleeper_transactions_trade_complete makes this an index-only scan.
+	tradesCount := func(src transactionSource) *gorm.DB {
+		if hasLeagueFilters(c) || hindsight {
+			return tradesQuery(src)
+		}
+		db := src.DB.Table("sleeper_transactions t").
+			Where("t.type = ? AND t.status = ?", "trade", "complete")
+		db = applyTradeFilters(db, c)
 		if excludePicks {
-			countDB = countDB.Where("draft_picks IS NULL OR jsonb_array_length(draft_picks) = 0")
+			db = db.Where("t.draft_picks IS NULL OR jsonb_array_length(t.draft_picks) = 0")
+		}
+		return src.Window.apply(db)
+	}
+
+	if window.bounded() {
+		listing := transactionListing[tradeRow]{
+			Query:     tradesQuery,
+			Count:     tradesCount,
+			Order:     order,
+			ID:        func(r tradeRow) string { return r.SleeperTransactionID },
+			CreatedAt: func(r tradeRow) int64 { return r.CreatedAtSleeper },
 		}
-		countDB.Count(&total)
+		if rows, total, err = pageTransactionSources(sources, listing, offset, limit); err != nil {
+			c.JSON(http.StatusInternalServerError, map[string]string{"msg": err.Error()})
+			return
+		}
+	} else {
+		db := tradesQuery(sources[0])
+		tradesCount(sources[0]).Count(&total)
+		db.Order(order).Limit(limit).Offset(offset).Scan(&rows)
 	}
-	db.Order("t.created_at_sleeper DESC").Limit(limit).Offset(offset).Scan(&rows)
 
 	// Decode adds and collect all unique player IDs on this page.
 	addsPerRow := make([]map[string]int, len(rows))
@@ -410,31 +463,35 @@ func GetSleeperTrades(c *gin.Context) {
 		}
 	}
 
+	tradeIDs := make([]string, len(rows))
+	for i, r := range rows {
+		tradeIDs[i] = r.SleeperTransactionID
+	}
+	retrospectives := loadTradeRetrospectives(tradeIDs)
+
 	items := make([]SleeperTradeItem, len(rows))
 	for i, r := range rows {
 		sides := buildTradeSides(addsPerRow[i], playerLookup, r.DraftPicks)
-		if len(r.TradeValues) > 0 {
-			var totals map[string]float64
-			if err := json.Unmarshal(r.TradeValues, &totals); err == nil {
-				for j := range sides {
-					if v, ok := totals[strconv.Itoa(sides[j].RosterID)]; ok {
-						val := v
-						sides[j].TotalValue = &val
-					}
-				}
-			}
+		retro := retrospectives[r.SleeperTransactionID]
+		for j := range sides {
+			rosterID := sides[j].RosterID
+			sides[j].TotalValue = rosterTotal(r.TradeValues, rosterID)
+			sides[j].ValueNow = rosterTotal(retro.ValuesNow, rosterID)
+			sides[j].ValueLater = rosterTotal(retro.ValuesLater, rosterID)
+			sides[j].RealizedPoints = rosterTotal(retro.RealizedPoints, rosterID)
 		}
 		items[i] = SleeperTradeItem{
-			ID:         r.SleeperTransactionID,
-			LeagueID:   r.SleeperLeagueID,
-			LeagueName: r.LeagueName,
-			Season:     r.Season,
-			Scoring:    formatScoring(r.PPR),
-			Superflex:  r.IsSuperflex != nil && *r.IsSuperflex,
-			LeagueSize: formatLeagueSize(r.TotalRosters),
-			Status:     r.Status,
-			Sides:      sides,
-			CreatedAt:  r.CreatedAtSleeper,
+			ID:           r.SleeperTransactionID,
+			LeagueID:     r.SleeperLeagueID,
+			LeagueName:   r.LeagueName,
+			Season:       r.Season,
+			Scoring:      formatScoring(r.PPR),
+			Superflex:    r.IsSuperflex != nil && *r.IsSuperflex,
+			LeagueSize:   formatLeagueSize(r.TotalRosters),
+			Status:       r.Status,
+			Sides:        sides,
+			HindsightGap: retro.HindsightGap,
+			CreatedAt:    r.CreatedAtSleeper,
 		}
 	}
 
@@ -470,11 +527,21 @@ type SleeperTransactionsResponse struct {
 }
 
 // GetSleeperTransactions returns a paginated list of all Sleeper transactions.
-// Supports query filters: type (trade|waiver|free_agent), league_size, scoring_format, draft_type, league_type (redraft|keeper|dynasty).
+// Supports query filters: type (trade|waiver|free_agent), league_size, scoring_format, draft_type, league_type (redraft|keeper|dynasty), days, from and to (YYYY-MM-DD).
+// A window reaching past the hot window also reads the archive DB (see
+// transactionSources).
 func GetSleeperTransactions(c *gin.Context) {
 	page, limit := parsePagination(c)
 	offset := (page - 1) * limit
 
+	now := time.Now()
+	window, err := parseTransactionWindow(c, now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"msg": err.Error()})
+		return
+	}
+	sources := transactionSources(window, now)
+
 	type txRow struct {
 		SleeperTransactionID string          `gorm:"column:sleeper_transaction_id"`
 		SleeperLeagueID      string          `gorm:"column:sleeper_league_id"`
@@ -491,26 +558,47 @@ func GetSleeperTransactions(c *gin.Context) {
 
 	txType := c.Query("type")
 
-	db := database.DB.Table("sleeper_transactions t").
-		Select("t.sleeper_transaction_id, t.sleeper_league_id, l.name as league_name, l.season, t.type, t.status, t.created_at_sleeper, t.adds").
-		Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = t.sleeper_league_id").
-		Where("t.status = ?", "complete")
+	txQuery := func(src transactionSource) *gorm.DB {
+		db := src.DB.Table("sleeper_transactions t").
+			Select("t.sleeper_transaction_id, t.sleeper_league_id, l.name as league_name, l.season, t.type, t.status, t.created_at_sleeper, t.adds").
+			Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = t.sleeper_league_id").
+			Where("t.status = ?", "complete")
 
-	if txType != "" {
-		db = db.Where("t.type = ?", txType)
+		if txType != "" {
+			db = db.Where("t.type = ?", txType)
+		}
+		db = applyLeagueFilters(db, c, "l")
+		return src.Window.apply(db)
 	}
-	db = applyLeagueFilters(db, c, "l")
 
-	if hasLeagueFilters(c) {
-		db.Count(&total)
-	} else {
-		countDB := database.DB.Model(&models.SleeperTransaction{}).Where("status = ?", "complete")
+	txCount := func(src transactionSource) *gorm.DB {
+		if hasLeagueFilters(c) {
+			return txQuery(src)
+		}
+		db := src.DB.Table("sleeper_transactions t").Where("t.status = ?", "complete")
 		if txType != "" {
-			countDB = countDB.Where("type = ?", txType)
+			db = db.Where("t.type = ?", txType)
 		}
-		countDB.Count(&total)
+		return src.Window.apply(db)
+	}
+
+	if window.bounded() {
+		listing := transactionListing[txRow]{
+			Query:     txQuery,
+			Count:     txCount,
+			Order:     "t.created_at_sleeper DESC",
+			ID:        func(r txRow) string { return r.SleeperTransactionID },
+			CreatedAt: func(r txRow) int64 { return r.CreatedAtSleeper },
+		}
+		if rows, total, err = pageTransactionSources(sources, listing, offset, limit); err != nil {
+			c.JSON(http.StatusInternalServerError, map[string]string{"msg": err.Error()})
+			return
+		}
+	} else {
+		db := txQuery(sources[0])
+		txCount(sources[0]).Count(&total)
+		db.Order("t.created_at_sleeper DESC").Limit(limit).Offset(offset).Scan(&rows)
 	}
-	db.Order("t.created_at_sleeper DESC").Limit(limit).Offset(offset).Scan(&rows)
 
 	items := make([]SleeperTransactionItem, len(rows))
 	for i, r := range rows {
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"cmp"
+	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/database"
+	"backend/internal/helpers"
+)
+
+// transactionDateLayout is the format of the from and to query params.
+const transactionDateLayout = "2006-01-02"
+
+// transactionWindow bounds a Sleeper transactions query by
+// created_at_sleeper, in Unix milliseconds: From is inclusive and To
+// exclusive, and either is 0 when that end is open.
+type transactionWindow struct {
+	From int64
+	To   int64
+}
+
+// parseTransactionWindow reads the window from the from and to query params
+// (UTC dates, both inclusive) or, when neither is set, from days, which
+// looks back from now.
+func parseTransactionWindow(c *gin.Context, now time.Time) (transactionWindow, error) {
+	var w transactionWindow
+	from, to := c.Query("from"), c.Query("to")
+	if from == "" && to == "" {
+		if days, err := strconv.Atoi(c.Query("days")); err == nil && days > 0 {
+			w.From = now.Add(-time.Duration(days) * 24 * time.Hour).UnixMilli()
+		}
+		return w, nil
+	}
+	if from != "" {
+		d, err := time.Parse(transactionDateLayout, from)
+		if err != nil {
+			return w, errors.New("from must be a YYYY-MM-DD date")
+		}
+		w.From = d.UnixMilli()
+	}
+	if to != "" {
+		d, err := time.Parse(transactionDateLayout, to)
+		if err != nil {
+			return w, errors.New("to must be a YYYY-MM-DD date")
+		}
+		w.To = d.AddDate(0, 0, 1).UnixMilli()
+	}
+	if w.To != 0 && w.From >= w.To {
+		return w, errors.New("from must not be after to")
+	}
+	return w, nil
+}
+
+func (w transactionWindow) bounded() bool { return w.From != 0 || w.To != 0 }
+
+// apply restricts a query over sleeper_transactions t to the window.
+func (w transactionWindow) apply(db *gorm.DB) *gorm.DB {
+	if w.From != 0 {
+		db = db.Where("t.created_at_sleeper >= ?", w.From)
+	}
+	if w.To != 0 {
+		db = db.Where("t.created_at_sleeper < ?", w.To)
+	}
+	return db
+}
+
+// hotWindowStart is the oldest created_at_sleeper cloud is guaranteed to
+// hold: the scavenger purges archived rows older than
+// SCAVENGER_RETENTION_DAYS, and transactioncron writes rows already that
+// old straight to the archive.
+func hotWindowStart(now time.Time) int64 {
+	days := max(helpers.GetEnv("SCAVENGER_RETENTION_DAYS", 30), 1)
+	return now.UTC().AddDate(0, 0, -days).UnixMilli()
+}
+
+// transactionSource is one database a transactions listing reads and the
+// part of the window it serves. The archive's sleeper_transactions has no
+// trade_values, and its sleeper_leagues is a replica of cloud's.
+type transactionSource struct {
+	DB      *gorm.DB
+	Window  transactionWindow
+	Archive bool
+}
+
+// transactionSources routes w newest first: cloud serves the hot window and
+// the archive, when configured, everything older. Windows without a bound
+// stay on cloud, as does everything when there's no archive. Rows that have
+// aged out of the hot window but not yet replicated are still in cloud:
+// pageTransactionSources reads them into the archive's part of the window.
+func transactionSources(w transactionWindow, now time.Time) []transactionSource {
+	hotStart := hotWindowStart(now)
+	if database.Archive == nil || !w.bounded() || w.From >= hotStart {
+		return []transactionSource{{DB: database.DB, Window: w}}
+	}
+	var sources []transactionSource
+	if w.To == 0 || w.To > hotStart {
+		sources = append(sources, transactionSource{DB: database.DB, Window: transactionWindow{From: hotStart, To: w.To}})
+	}
+	old := transactionWindow{From: w.From, To: hotStart}
+	if w.To != 0 {
+		old.To = min(w.To, hotStart)
+	}
+	return append(sources, transactionSource{DB: database.Archive, Window: old, Archive: true})
+}
+
+// readsArchive reports whether any of sources is the archive.
+func readsArchive(sources []transactionSource) bool {
+	for _, s := range sources {
+		if s.Archive {
+			return true
+		}
+	}
+	return false
+}
+
+// transactionListing is how a handler lists sleeper_transactions t from
+// one source: Query builds the filtered listing and Count the query that
+// counts it, which may skip Query's joins when no filter needs them. ID
+// and CreatedAt read a scanned row's sleeper_transaction_id and
+// created_at_sleeper.
+type transactionListing[T any] struct {
+	Query     func(transactionSource) *gorm.DB
+	Count     func(transactionSource) *gorm.DB
+	Order     string
+	ID        func(T) string
+	CreatedAt func(T) int64
+}
+
+// maxUnreplicatedRows caps how many of cloud's rows past the hot window a
+// listing reads, newest first, to find the ones the archive doesn't hold
+// yet. Replication that far behind is an outage, not a lag: the listing
+// logs it and leaves the older rows until the archive catches up.
+const maxUnreplicatedRows = 500
+
+// pageTransactionSources scans the offset/limit page of a listing ordered
+// newest first across sources, which must themselves be newest first:
+// each source's rows follow all of the previous one's. An archive source
+// also covers cloud's rows in its window that haven't replicated yet (see
+// unreplicatedRows), merged in by created_at_sleeper. It returns the page
+// and the total across every source.
+func pageTransactionSources[T any](sources []transactionSource, l transactionListing[T], offset, limit int) ([]T, int64, error) {
+	rows := []T{}
+	var total int64
+	for _, src := range sources {
+		var n int64
+		if err := l.Count(src).Count(&n).Error; err != nil {
+			return nil, 0, err
+		}
+		var gap []T
+		if src.Archive {
+			var err error
+			if gap, err = unreplicatedRows(src, l); err != nil {
+				return nil, 0, err
+			}
+			n += int64(len(gap))
+		}
+		skip := offset - int(total)
+		total += n
+		remaining := limit - len(rows)
+		if remaining <= 0 || int64(skip) >= n {
+			continue
+		}
+		var page []T
+		var err error
+		if len(gap) > 0 {
+			page, err = pageWithUnreplicated(src, l, gap, max(skip, 0), remaining)
+		} else {
+			err = l.Query(src).Order(l.Order).Limit(remaining).Offset(max(skip, 0)).Scan(&page).Error
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		rows = append(rows, page...)
+	}
+	return rows, total, nil
+}
+
+// unreplicatedRows returns, newest first, cloud's rows in an archive
+// source's window that the archive doesn't hold yet, reading at most
+// maxUnreplicatedRows of them. The scavenger only purges rows it has found
+// in the archive, so these are still in cloud alongside the few it hasn't
+// purged yet.
+func unreplicatedRows[T any](src transactionSource, l transactionListing[T]) ([]T, error) {
+	var cloudRows []T
+	if err := l.Query(transactionSource{DB: database.DB, Window: src.Window}).
+		Order(l.Order).Limit(maxUnreplicatedRows).
+		Scan(&cloudRows).Error; err != nil {
+		return nil, err
+	}
+	if len(cloudRows) == maxUnreplicatedRows {
+		slog.Warn("Archive replication is behind; listing only the newest unreplicated transactions", "limit", maxUnreplicatedRows)
+	}
+	if len(cloudRows) == 0 {
+		return []T{}, nil
+	}
+	ids := make([]string, len(cloudRows))
+	for i, r := range cloudRows {
+		ids[i] = l.ID(r)
+	}
+	var found []string
+	if err := src.DB.Table("sleeper_transactions").
+		Where("sleeper_transaction_id IN ?", ids).
+		Pluck("sleeper_transaction_id", &found).Error; err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(cloudRows, func(r T) bool { return slices.Contains(found, l.ID(r)) }), nil
+}
+
+// pageWithUnreplicated pages an archive source's rows merged with gap, its
+// window's unreplicated cloud rows, newest first (an archive row ahead of a
+// gap row made the same millisecond). An archive row's place is its own
+// index plus the gap rows newer than it, so the page's archive rows lie
+// within len(gap) rows before offset, and one read from there covers them.
+// A gap row's place is its index plus the archive rows no older than it,
+// counted in that read: a gap row newer than all of it or older than all of
+// a full read lands before or after the page either way.
+func pageWithUnreplicated[T any](src transactionSource, l transactionListing[T], gap []T, offset, limit int) ([]T, error) {
+	type placed struct {
+		at  int
+		row T
+	}
+	var page []placed
+
+	start := max(offset-len(gap), 0)
+	var archived []T
+	if err := l.Query(src).Order(l.Order).Limit(offset + limit - start).Offset(start).Scan(&archived).Error; err != nil {
+		return nil, err
+	}
+	inPage := func(at int) bool { return at >= offset && at < offset+limit }
+	for i, r := range archived {
+		at := start + i
+		for _, g := range gap {
+			if l.CreatedAt(g) > l.CreatedAt(r) {
+				at++
+			}
+		}
+		if inPage(at) {
+			page = append(page, placed{at, r})
+		}
+	}
+	for j, g := range gap {
+		at := j + start
+		for _, r := range archived {
+			if l.CreatedAt(r) >= l.CreatedAt(g) {
+				at++
+			}
+		}
+		if inPage(at) {
+			page = append(page, placed{at, g})
+		}
+	}
+
+	slices.SortFunc(page, func(a, b placed) int { return cmp.Compare(a.at, b.at) })
+	rows := make([]T, len(page))
+	for i, p := range page {
+		rows[i] = p.row
+	}
+	return rows, nil
+}
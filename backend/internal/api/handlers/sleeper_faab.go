@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/database"
+	"backend/internal/models"
+)
+
+// SleeperFAABItem is the distribution of one player's winning FAAB bids in
+// the requested week. The *_pct quantiles are bids as a percentage (0-100)
+// of league budget, null when no bid came from a league with a known
+// budget. PlayerID is the internal players.id, as in SleeperADPItem.
+type SleeperFAABItem struct {
+	SleeperPlayerID string   `json:"sleeper_player_id"`
+	Name            string   `json:"name"`
+	Position        string   `json:"position"`
+	NflTeam         string   `json:"nfl_team"`
+	Bids            int      `json:"bids"`
+	MedianBid       float64  `json:"median_bid"`
+	P75Bid          float64  `json:"p75_bid"`
+	P90Bid          float64  `json:"p90_bid"`
+	MedianBidPct    *float64 `json:"median_bid_pct"`
+	P75BidPct       *float64 `json:"p75_bid_pct"`
+	P90BidPct       *float64 `json:"p90_bid_pct"`
+	PlayerID        *string  `json:"player_id,omitempty"`
+}
+
+// SleeperFAABResponse is the paginated response for GET
+// /api/v1/sleeper/faab. Season and Week are the week served — the latest
+// with data in the segment when not requested — and ComputedAt is when the
+// market-pulse job last recomputed it, nil when there's no data.
+type SleeperFAABResponse struct {
+	Players    []SleeperFAABItem `json:"players"`
+	Segment    string            `json:"segment"`
+	Season     string            `json:"season"`
+	Week       int               `json:"week"`
+	ComputedAt *time.Time        `json:"computed_at"`
+	Total      int64             `json:"total"`
+	Page       int               `json:"page"`
+	Limit      int               `json:"limit"`
+	TotalPages int               `json:"total_pages"`
+}
+
+type faabItemRow struct {
+	SleeperPlayerID string    `gorm:"column:sleeper_player_id"`
+	Name            string    `gorm:"column:full_name"`
+	Position        string    `gorm:"column:position"`
+	NflTeam         string    `gorm:"column:nfl_team"`
+	Bids            int       `gorm:"column:bids"`
+	MedianBid       float64   `gorm:"column:median_bid"`
+	P75Bid          float64   `gorm:"column:p75_bid"`
+	P90Bid          float64   `gorm:"column:p90_bid"`
+	MedianBidPct    *float64  `gorm:"column:median_bid_pct"`
+	P75BidPct       *float64  `gorm:"column:p75_bid_pct"`
+	P90BidPct       *float64  `gorm:"column:p90_bid_pct"`
+	ComputedAt      time.Time `gorm:"column:computed_at"`
+}
+
+// GetSleeperFAAB handles GET /api/v1/sleeper/faab: per player, the median,
+// 75th and 90th percentile winning FAAB bid in one week across every scraped
+// Sleeper league, from the market-pulse job's rollup, most-claimed players
+// first. Supports query filters: season and week (default the segment's
+// latest season, then its latest week in that season), position,
+// sleeper_player_id, and the segment filters league_size, scoring_format
+// and superflex as in GetSleeperTrending.
+func GetSleeperFAAB(c *gin.Context) {
+	page, limit := parsePagination(c)
+	offset := (page - 1) * limit
+	segment := populationSegmentQuery(c)
+
+	season := c.Query("season")
+	if season == "" {
+		var latest models.FAABBidStat
+		if err := database.DB.Where("segment = ?", segment).
+			Order("season DESC").Limit(1).Find(&latest).Error; err != nil {
+			slog.Error("Failed to resolve latest FAAB season", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch FAAB bids"})
+			return
+		}
+		season = latest.Season
+	}
+	var week int
+	if w := c.Query("week"); w != "" {
+		parsed, err := strconv.Atoi(w)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "week must be a positive integer"})
+			return
+		}
+		week = parsed
+	} else {
+		var latest models.FAABBidStat
+		if err := database.DB.Where("segment = ? AND season = ?", segment, season).
+			Order("week DESC").Limit(1).Find(&latest).Error; err != nil {
+			slog.Error("Failed to resolve latest FAAB week", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch FAAB bids"})
+			return
+		}
+		week = latest.Week
+	}
+
+	db := database.DB.Table("faab_bid_stats f").
+		Joins("JOIN sleeper_players p ON p.sleeper_player_id = f.sleeper_player_id").
+		Where("f.segment = ? AND f.season = ? AND f.week = ?", segment, season, week)
+	if position := c.Query("position"); position != "" {
+		db = db.Where("p.position = ?", position)
+	}
+	if sleeperPlayerID := c.Query("sleeper_player_id"); sleeperPlayerID != "" {
+		db = db.Where("f.sleeper_player_id = ?", sleeperPlayerID)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		slog.Error("Failed to count FAAB bids", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch FAAB bids"})
+		return
+	}
+	var rows []faabItemRow
+	if err := db.Select("f.sleeper_player_id, p.full_name, p.position, p.nfl_team, f.bids, f.median_bid, f.p75_bid, f.p90_bid, " +
+		"f.median_bid_pct, f.p75_bid_pct, f.p90_bid_pct, f.computed_at").
+		Order("f.bids DESC, f.median_bid DESC, f.sleeper_player_id ASC").
+		Limit(limit).Offset(offset).
+		Scan(&rows).Error; err != nil {
+		slog.Error("Failed to fetch FAAB bids", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch FAAB bids"})
+		return
+	}
+
+	sleeperIDs := make([]string, 0, len(rows))
+	for _, r := range rows {
+		sleeperIDs = append(sleeperIDs, r.SleeperPlayerID)
+	}
+	sleeperMatches, err := models.GetPlayersBySleeperIDs(database.DB, sleeperIDs)
+	if err != nil {
+		slog.Error("Failed to resolve players for FAAB bids", "error", err)
+		sleeperMatches = map[string]models.Player{}
+	}
+
+	response := SleeperFAABResponse{
+		Players:    make([]SleeperFAABItem, len(rows)),
+		Segment:    segment,
+		Season:     season,
+		Week:       week,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+	for i, r := range rows {
+		response.Players[i] = SleeperFAABItem{
+			SleeperPlayerID: r.SleeperPlayerID,
+			Name:            r.Name,
+			Position:        r.Position,
+			NflTeam:         r.NflTeam,
+			Bids:            r.Bids,
+			MedianBid:       r.MedianBid,
+			P75Bid:          r.P75Bid,
+			P90Bid:          r.P90Bid,
+			MedianBidPct:    r.MedianBidPct,
+			P75BidPct:       r.P75BidPct,
+			P90BidPct:       r.P90BidPct,
+		}
+		if player, ok := sleeperMatches[r.SleeperPlayerID]; ok {
+			playerID := strconv.FormatUint(uint64(player.ID), 10)
+			response.Players[i].PlayerID = &playerID
+		}
+	}
+	if len(rows) > 0 {
+		response.ComputedAt = &rows[0].ComputedAt
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+)
+
+func newFAABTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.FAABBidStat{}, &models.SleeperPlayer{}, &models.Player{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	withDraftADPTestDB(t, db)
+	seedADPPlayer(t, db, "6794", "Justin Jefferson", "WR", "MIN")
+	seedADPPlayer(t, db, "9509", "Bijan Robinson", "RB", "ATL")
+
+	computed := time.Date(2025, 10, 14, 9, 0, 0, 0, time.UTC)
+	pct := 12.5
+	for _, s := range []models.FAABBidStat{
+		{Segment: "all", Season: "2025", Week: 6, SleeperPlayerID: "6794", Bids: 4, MedianBid: 20, P75Bid: 30, P90Bid: 36, MedianBidPct: &pct, ComputedAt: computed},
+		{Segment: "all", Season: "2025", Week: 6, SleeperPlayerID: "9509", Bids: 9, MedianBid: 40, P75Bid: 55, P90Bid: 61, ComputedAt: computed},
+		{Segment: "all", Season: "2025", Week: 5, SleeperPlayerID: "6794", Bids: 2, MedianBid: 3, ComputedAt: computed},
+		{Segment: "all", Season: "2024", Week: 17, SleeperPlayerID: "9509", Bids: 1, MedianBid: 1, ComputedAt: computed},
+		{Segment: "12-ppr-sf", Season: "2025", Week: 6, SleeperPlayerID: "6794", Bids: 1, MedianBid: 20, ComputedAt: computed},
+	} {
+		if err := db.Create(&s).Error; err != nil {
+			t.Fatalf("seed faab stat: %v", err)
+		}
+	}
+	return db
+}
+
+func performGetSleeperFAAB(t *testing.T, query string) (*httptest.ResponseRecorder, SleeperFAABResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sleeper/faab", GetSleeperFAAB)
+
+	req := httptest.NewRequest(http.MethodGet, "/sleeper/faab"+query, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp SleeperFAABResponse
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+func TestGetSleeperFAAB_DefaultsToLatestWeek(t *testing.T) {
+	newFAABTestDB(t)
+
+	w, resp := performGetSleeperFAAB(t, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Segment != "all" || resp.Season != "2025" || resp.Week != 6 {
+		t.Errorf("expected the latest week of the whole population, got %+v", resp)
+	}
+	if resp.Total != 2 || resp.Players[0].Name != "Bijan Robinson" || resp.Players[0].P90Bid != 61 {
+		t.Errorf("expected Robinson first by bids, got %+v", resp.Players)
+	}
+	if jj := resp.Players[1]; jj.MedianBidPct == nil || *jj.MedianBidPct != 12.5 || resp.Players[0].MedianBidPct != nil {
+		t.Errorf("expected percentages only where known, got %+v", resp.Players)
+	}
+	if resp.ComputedAt == nil {
+		t.Errorf("expected computed_at from the rollup")
+	}
+}
+
+func TestGetSleeperFAAB_Filters(t *testing.T) {
+	newFAABTestDB(t)
+
+	_, week5 := performGetSleeperFAAB(t, "?week=5")
+	if week5.Week != 5 || len(week5.Players) != 1 || week5.Players[0].MedianBid != 3 {
+		t.Errorf("expected week 5's one player, got %+v", week5)
+	}
+	_, past := performGetSleeperFAAB(t, "?season=2024")
+	if past.Week != 17 || len(past.Players) != 1 {
+		t.Errorf("expected 2024's latest week, got %+v", past)
+	}
+	_, wrs := performGetSleeperFAAB(t, "?position=WR")
+	if len(wrs.Players) != 1 || wrs.Players[0].SleeperPlayerID != "6794" {
+		t.Errorf("expected only the WR, got %+v", wrs.Players)
+	}
+	_, one := performGetSleeperFAAB(t, "?sleeper_player_id=9509")
+	if len(one.Players) != 1 || one.Players[0].Bids != 9 {
+		t.Errorf("expected only Robinson, got %+v", one.Players)
+	}
+	_, seg := performGetSleeperFAAB(t, "?superflex=true")
+	if seg.Segment != "12-ppr-sf" || len(seg.Players) != 1 || seg.Players[0].Bids != 1 {
+		t.Errorf("expected the 12-team PPR superflex segment, got %+v", seg)
+	}
+}
+
+func TestGetSleeperFAAB_RejectsInvalidWeek(t *testing.T) {
+	newFAABTestDB(t)
+
+	if w, _ := performGetSleeperFAAB(t, "?week=abc"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a non-numeric week, got %d", w.Code)
+	}
+}
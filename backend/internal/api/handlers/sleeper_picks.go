@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/database"
+	"backend/internal/models"
+)
+
+// futurePickSeasons is how many seasons past the league's own Sleeper lets
+// owners trade picks for, and so how far ahead the ledger lists them.
+const futurePickSeasons = 3
+
+// SleeperOwnedPick is one draft pick in a roster's hand.
+type SleeperOwnedPick struct {
+	Season           string `json:"season"`
+	Round            int    `json:"round"`
+	OriginalRosterID int    `json:"original_roster_id"`
+	Traded           bool   `json:"traded"`
+}
+
+// SleeperPickOwner is every pick one roster currently owns.
+type SleeperPickOwner struct {
+	RosterID int                `json:"roster_id"`
+	Picks    []SleeperOwnedPick `json:"picks"`
+}
+
+// SleeperLeaguePicksResponse is the response for GET
+// /api/v1/sleeper/leagues/:id/picks. DraftRounds is 0 when the league's
+// draft settings haven't been fetched yet, in which case only traded picks
+// are listed.
+type SleeperLeaguePicksResponse struct {
+	SleeperLeagueID string             `json:"sleeper_league_id"`
+	DraftRounds     int                `json:"draft_rounds"`
+	Seasons         []string           `json:"seasons"`
+	Owners          []SleeperPickOwner `json:"owners"`
+}
+
+// SleeperPickTradeItem is one pick moved by a trade.
+type SleeperPickTradeItem struct {
+	TransactionID         string `json:"transaction_id"`
+	Season                string `json:"season"`
+	Round                 int    `json:"round"`
+	OriginalRosterID      int    `json:"original_roster_id"`
+	PreviousOwnerRosterID int    `json:"previous_owner_roster_id"`
+	OwnerRosterID         int    `json:"owner_roster_id"`
+	CreatedAt             int64  `json:"created_at"`
+}
+
+// SleeperPickTradesResponse is the paginated response for GET
+// /api/v1/sleeper/leagues/:id/pick-trades.
+type SleeperPickTradesResponse struct {
+	Trades     []SleeperPickTradeItem `json:"trades"`
+	Total      int64                  `json:"total"`
+	Page       int                    `json:"page"`
+	Limit      int                    `json:"limit"`
+	TotalPages int                    `json:"total_pages"`
+}
+
+// GetSleeperLeaguePicks handles GET /api/v1/sleeper/leagues/:id/picks: who
+// owns which draft picks in a Sleeper league, from the traded_picks ledger.
+// Every roster's own picks for the listed seasons are included unless traded
+// away. Seasons run from the league's season (only while its draft hasn't
+// happened) through futurePickSeasons ahead, plus any other season a traded
+// pick belongs to.
+func GetSleeperLeaguePicks(c *gin.Context) {
+	leagueID := c.Param("id")
+	var league models.SleeperLeague
+	if err := database.DB.Where("sleeper_league_id = ?", leagueID).First(&league).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "League not found"})
+			return
+		}
+		slog.Error("Failed to fetch Sleeper league for picks", "error", err, "league_id", leagueID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch draft picks"})
+		return
+	}
+
+	var traded []models.SleeperTradedPick
+	if err := database.DB.Where("sleeper_league_id = ?", leagueID).Find(&traded).Error; err != nil {
+		slog.Error("Failed to fetch traded picks", "error", err, "league_id", leagueID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch draft picks"})
+		return
+	}
+
+	response := SleeperLeaguePicksResponse{SleeperLeagueID: leagueID}
+	if league.DraftRounds != nil {
+		response.DraftRounds = *league.DraftRounds
+	}
+	response.Seasons, response.Owners = pickOwnership(league, response.DraftRounds, traded)
+	c.JSON(http.StatusOK, response)
+}
+
+type pickKey struct {
+	season   string
+	round    int
+	rosterID int
+}
+
+// pickOwnership lays out the league's pick grid — every season x round x
+// original roster — and assigns each pick to its owner, traded picks per the
+// ledger and the rest to their original roster. Traded picks outside the grid
+// (a round past draftRounds, say) are still listed.
+func pickOwnership(league models.SleeperLeague, draftRounds int, traded []models.SleeperTradedPick) ([]string, []SleeperPickOwner) {
+	owners := make(map[pickKey]int, len(traded))
+	seasonSet := map[string]bool{}
+	for _, t := range traded {
+		owners[pickKey{t.Season, t.Round, t.RosterID}] = t.OwnerID
+		seasonSet[t.Season] = true
+	}
+	if year, err := strconv.Atoi(league.Season); err == nil {
+		if league.Status == "pre_draft" || league.Status == "drafting" {
+			seasonSet[league.Season] = true
+		}
+		for i := 1; i <= futurePickSeasons; i++ {
+			seasonSet[strconv.Itoa(year+i)] = true
+		}
+	}
+	seasons := make([]string, 0, len(seasonSet))
+	for s := range seasonSet {
+		seasons = append(seasons, s)
+	}
+	sort.Strings(seasons)
+
+	picksByOwner := map[int][]SleeperOwnedPick{}
+	add := func(k pickKey) {
+		owner, ok := owners[k]
+		if !ok {
+			owner = k.rosterID
+		}
+		picksByOwner[owner] = append(picksByOwner[owner], SleeperOwnedPick{
+			Season: k.season, Round: k.round, OriginalRosterID: k.rosterID, Traded: owner != k.rosterID,
+		})
+	}
+	inGrid := map[pickKey]bool{}
+	for _, s := range seasons {
+		for round := 1; round <= draftRounds; round++ {
+			for roster := 1; roster <= league.TotalRosters; roster++ {
+				k := pickKey{s, round, roster}
+				inGrid[k] = true
+				add(k)
+			}
+		}
+	}
+	for _, t := range traded {
+		if k := (pickKey{t.Season, t.Round, t.RosterID}); !inGrid[k] {
+			add(k)
+		}
+	}
+
+	result := make([]SleeperPickOwner, 0, len(picksByOwner))
+	for rosterID, picks := range picksByOwner {
+		sort.Slice(picks, func(i, j int) bool {
+			if picks[i].Season != picks[j].Season {
+				return picks[i].Season < picks[j].Season
+			}
+			if picks[i].Round != picks[j].Round {
+				return picks[i].Round < picks[j].Round
+			}
+			return picks[i].OriginalRosterID < picks[j].OriginalRosterID
+		})
+		result = append(result, SleeperPickOwner{RosterID: rosterID, Picks: picks})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].RosterID < result[j].RosterID })
+	return seasons, result
+}
+
+// GetSleeperLeaguePickTrades handles GET
+// /api/v1/sleeper/leagues/:id/pick-trades: the league's pick trade history,
+// newest first, one entry per pick moved. Supports query filters: season,
+// round.
+func GetSleeperLeaguePickTrades(c *gin.Context) {
+	page, limit := parsePagination(c)
+	offset := (page - 1) * limit
+
+	db := database.DB.Model(&models.SleeperPickTrade{}).Where("sleeper_league_id = ?", c.Param("id"))
+	if season := c.Query("season"); season != "" {
+		db = db.Where("season = ?", season)
+	}
+	if round, err := strconv.Atoi(c.Query("round")); err == nil {
+		db = db.Where("round = ?", round)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		slog.Error("Failed to count pick trades", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch pick trades"})
+		return
+	}
+	var rows []models.SleeperPickTrade
+	if err := db.Order("created_at_sleeper DESC, season ASC, round ASC, roster_id ASC").
+		Limit(limit).Offset(offset).
+		Find(&rows).Error; err != nil {
+		slog.Error("Failed to fetch pick trades", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch pick trades"})
+		return
+	}
+
+	items := make([]SleeperPickTradeItem, len(rows))
+	for i, r := range rows {
+		items[i] = SleeperPickTradeItem{
+			TransactionID:         r.SleeperTransactionID,
+			Season:                r.Season,
+			Round:                 r.Round,
+			OriginalRosterID:      r.RosterID,
+			PreviousOwnerRosterID: r.PreviousOwnerID,
+			OwnerRosterID:         r.OwnerID,
+			CreatedAt:             r.CreatedAtSleeper,
+		}
+	}
+	c.JSON(http.StatusOK, SleeperPickTradesResponse{
+		Trades:     items,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	})
+}
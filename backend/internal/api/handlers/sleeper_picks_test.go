@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/database"
+	"backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGetSleeperLeaguePicks_AssignsTradedAndOwnPicks(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.SleeperLeague{}, &models.SleeperTradedPick{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	rounds := 2
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "dyn", Season: "2026", Status: "in_season", TotalRosters: 2, DraftRounds: &rounds})
+	db.Create(&[]models.SleeperTradedPick{
+		// Roster 1 traded its 2027 1st to roster 2.
+		{SleeperLeagueID: "dyn", Season: "2027", Round: 1, RosterID: 1, PreviousOwnerID: 1, OwnerID: 2},
+		// A traded pick's season joins the grid even past the usual window.
+		{SleeperLeagueID: "dyn", Season: "2031", Round: 1, RosterID: 2, PreviousOwnerID: 2, OwnerID: 1},
+	})
+
+	original := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = original })
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sleeper/leagues/:id/picks", GetSleeperLeaguePicks)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sleeper/leagues/dyn/picks", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SleeperLeaguePicksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	// 2026's draft is done (in_season), so 2027-2029 plus the traded 2031.
+	if want := []string{"2027", "2028", "2029", "2031"}; len(resp.Seasons) != len(want) || resp.Seasons[0] != want[0] || resp.Seasons[3] != want[3] {
+		t.Fatalf("expected seasons %v, got %v", want, resp.Seasons)
+	}
+	if len(resp.Owners) != 2 {
+		t.Fatalf("expected 2 owners, got %+v", resp.Owners)
+	}
+	// 4 seasons x 2 rounds = 8 picks each: roster 1 is down its 2027 1st
+	// and up roster 2's 2031 1st, roster 2 the reverse.
+	one, two := resp.Owners[0], resp.Owners[1]
+	if len(one.Picks) != 8 || len(two.Picks) != 8 {
+		t.Fatalf("expected 8 picks each, got %d and %d", len(one.Picks), len(two.Picks))
+	}
+	if p := two.Picks[0]; p.Season != "2027" || p.Round != 1 || p.OriginalRosterID != 1 || !p.Traded {
+		t.Errorf("expected roster 2 to lead with roster 1's traded 2027 1st, got %+v", p)
+	}
+	var holds2031 bool
+	for _, p := range one.Picks {
+		if p.Season == "2031" && p.Round == 1 {
+			holds2031 = p.OriginalRosterID == 2 && p.Traded
+		}
+	}
+	if !holds2031 {
+		t.Errorf("expected roster 1 to hold roster 2's 2031 1st, got %+v", one.Picks)
+	}
+}
+
+func TestGetSleeperLeaguePicks_UnknownLeague(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.SleeperLeague{}, &models.SleeperTradedPick{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	original := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = original })
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sleeper/leagues/:id/picks", GetSleeperLeaguePicks)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sleeper/leagues/nope/picks", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
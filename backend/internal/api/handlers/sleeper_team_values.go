@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/database"
+	"backend/internal/models"
+	"backend/internal/teamvalue"
+	"backend/internal/valuation"
+)
+
+// teamValueSeriesPoints bounds each team's value series: weekly points back
+// from the latest valuation, about a full season and offseason's worth.
+const teamValueSeriesPoints = 52
+
+// SleeperTeamValuePlayer is one rostered player in a team valuation. Value
+// is nil when the player has no valuation.
+type SleeperTeamValuePlayer struct {
+	SleeperPlayerID string   `json:"sleeper_player_id"`
+	Name            string   `json:"name"`
+	Position        string   `json:"position"`
+	Value           *float64 `json:"value"`
+	Starter         bool     `json:"starter"`
+}
+
+// SleeperTeamValuePoint is one week of a team's value series: the roster it
+// had on ValuationDate, valued as of that date.
+type SleeperTeamValuePoint struct {
+	ValuationDate string  `json:"valuation_date"`
+	TotalValue    float64 `json:"total_value"`
+	StarterValue  float64 `json:"starter_value"`
+	DepthValue    float64 `json:"depth_value"`
+	TotalRank     int     `json:"total_rank"`
+}
+
+// SleeperTeamValue is one roster's valuation as of the response's
+// valuation_date (see teamvalue.Team). Classification is set for dynasty
+// leagues only; Series runs oldest first.
+type SleeperTeamValue struct {
+	RosterID       int                      `json:"roster_id"`
+	OwnerID        string                   `json:"owner_id"`
+	OwnerName      string                   `json:"owner_name"`
+	TotalValue     float64                  `json:"total_value"`
+	StarterValue   float64                  `json:"starter_value"`
+	DepthValue     float64                  `json:"depth_value"`
+	TotalRank      int                      `json:"total_rank"`
+	StarterRank    int                      `json:"starter_rank"`
+	DepthRank      int                      `json:"depth_rank"`
+	WeightedAge    *float64                 `json:"weighted_age"`
+	Classification string                   `json:"classification,omitempty"`
+	Players        []SleeperTeamValuePlayer `json:"players"`
+	Series         []SleeperTeamValuePoint  `json:"series"`
+}
+
+// SleeperTeamValuesResponse is the response for GET
+// /api/v1/sleeper/leagues/:id/team-values, teams in total_rank order.
+type SleeperTeamValuesResponse struct {
+	SleeperLeagueID string             `json:"sleeper_league_id"`
+	Segment         string             `json:"segment"`
+	ValuationDate   string             `json:"valuation_date"`
+	Teams           []SleeperTeamValue `json:"teams"`
+}
+
+// rosterVersion is a roster's players from one snapshot on.
+type rosterVersion struct {
+	takenAt time.Time
+	ownerID string
+	players []string
+}
+
+// rosterAsOf returns the version of a roster (oldest first) in effect at the
+// end of date, or false before its first snapshot.
+func rosterAsOf(versions []rosterVersion, date time.Time) (rosterVersion, bool) {
+	end := date.AddDate(0, 0, 1)
+	i := sort.Search(len(versions), func(i int) bool { return !versions[i].takenAt.Before(end) })
+	if i == 0 {
+		return rosterVersion{}, false
+	}
+	return versions[i-1], true
+}
+
+// teamValueDates returns the segment's valuation dates a team value series
+// samples, oldest first: the latest, then the latest on or before each week
+// before it, back to the first date a roster snapshot covers.
+func teamValueDates(db *gorm.DB, segment string, since time.Time) ([]time.Time, error) {
+	var dates []time.Time
+	if err := db.Table("player_valuations").
+		Distinct("valuation_date").
+		Where("segment = ? AND valuation_date >= ?", segment, since).
+		Order("valuation_date DESC").
+		Pluck("valuation_date", &dates).Error; err != nil {
+		return nil, err
+	}
+	var sampled []time.Time
+	for _, d := range dates {
+		if len(sampled) == teamValueSeriesPoints {
+			break
+		}
+		if len(sampled) == 0 || !d.After(sampled[len(sampled)-1].AddDate(0, 0, -7)) {
+			sampled = append(sampled, d)
+		}
+	}
+	for i, j := 0, len(sampled)-1; i < j; i, j = i+1, j-1 {
+		sampled[i], sampled[j] = sampled[j], sampled[i]
+	}
+	return sampled, nil
+}
+
+// GetSleeperLeagueTeamValues handles GET
+// /api/v1/sleeper/leagues/:id/team-values: every roster in a Sleeper league
+// valued against player_valuations in the league's segment — total market
+// value, the value of its best lineup under the league's roster_positions
+// and the depth behind it, each ranked across the league — plus each
+// team's weekly value series from its roster history. Dynasty teams are
+// classified contender or rebuilder (see teamvalue.Classify). Rosters come
+// from the transaction sync's roster snapshots, so a league is only covered
+// once the sync has visited it.
+func GetSleeperLeagueTeamValues(c *gin.Context) {
+	leagueID := c.Param("id")
+	db := database.DB.WithContext(c.Request.Context())
+	var league models.SleeperLeague
+	if err := db.Where("sleeper_league_id = ?", leagueID).First(&league).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "League not found"})
+			return
+		}
+		slog.Error("Failed to fetch league for team values", "league", leagueID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch team values"})
+		return
+	}
+	segment := valuation.SegmentKeyForLeague(league.PPR, league.IsSuperflex, league.TotalRosters, league.LeagueType)
+	if segment == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no valuation segment covers this league's format"})
+		return
+	}
+	if !hasPlayerValuations(segment) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no valuations for segment"})
+		return
+	}
+
+	var snapshots []models.SleeperRosterSnapshot
+	if err := db.Where("sleeper_league_id = ?", leagueID).
+		Order("roster_id ASC, taken_at ASC").
+		Find(&snapshots).Error; err != nil {
+		slog.Error("Failed to fetch roster snapshots", "league", leagueID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch team values"})
+		return
+	}
+	if len(snapshots) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "league rosters not fetched yet"})
+		return
+	}
+	versions := map[int][]rosterVersion{}
+	var rosterIDs []int
+	since := snapshots[0].TakenAt
+	for _, s := range snapshots {
+		var players []string
+		if err := json.Unmarshal(s.Players, &players); err != nil {
+			slog.Warn("Skipping unreadable roster snapshot", "league", leagueID, "roster", s.RosterID, "error", err)
+			continue
+		}
+		if _, ok := versions[s.RosterID]; !ok {
+			rosterIDs = append(rosterIDs, s.RosterID)
+		}
+		versions[s.RosterID] = append(versions[s.RosterID], rosterVersion{takenAt: s.TakenAt, ownerID: s.OwnerID, players: players})
+		if s.TakenAt.Before(since) {
+			since = s.TakenAt
+		}
+	}
+
+	dates, err := teamValueDates(db, segment, since.Truncate(24*time.Hour))
+	if err != nil {
+		slog.Error("Failed to fetch valuation dates for team values", "segment", segment, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch team values"})
+		return
+	}
+	if len(dates) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no valuations for segment since the league's rosters were fetched"})
+		return
+	}
+
+	idSet := map[string]struct{}{}
+	for _, vs := range versions {
+		for _, d := range dates {
+			if v, ok := rosterAsOf(vs, d); ok {
+				for _, id := range v.players {
+					idSet[id] = struct{}{}
+				}
+			}
+		}
+	}
+	ids := make([]string, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+	var snaps []valuation.Snapshot
+	if err := db.Table("player_valuations").
+		Select("sleeper_player_id, valuation_date, value").
+		Where("segment = ? AND valuation_date IN ? AND sleeper_player_id IN ?", segment, dates, ids).
+		Scan(&snaps).Error; err != nil {
+		slog.Error("Failed to fetch player values for team values", "segment", segment, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch team values"})
+		return
+	}
+	values := map[string]map[string]float64{}
+	for _, s := range snaps {
+		day := s.ValuationDate.Format("2006-01-02")
+		if values[day] == nil {
+			values[day] = map[string]float64{}
+		}
+		values[day][s.SleeperPlayerID] = s.Value
+	}
+	var players []models.SleeperPlayer
+	if err := db.Select("sleeper_player_id, full_name, position, age").
+		Where("sleeper_player_id IN ?", ids).
+		Find(&players).Error; err != nil {
+		slog.Error("Failed to fetch players for team values", "league", leagueID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch team values"})
+		return
+	}
+	playersByID := make(map[string]models.SleeperPlayer, len(players))
+	for _, p := range players {
+		playersByID[p.SleeperPlayerID] = p
+	}
+	var rosterPositions []string
+	if len(league.RosterPositions) > 0 {
+		if err := json.Unmarshal(league.RosterPositions, &rosterPositions); err != nil {
+			slog.Warn("Ignoring unreadable roster_positions", "league", leagueID, "error", err)
+		}
+	}
+
+	// Value every roster at every sampled date; the last date's teams are
+	// the response's headline numbers.
+	series := map[int][]SleeperTeamValuePoint{}
+	var latest []teamvalue.Team
+	for i, d := range dates {
+		day := d.Format("2006-01-02")
+		var teams []teamvalue.Team
+		for _, rosterID := range rosterIDs {
+			v, ok := rosterAsOf(versions[rosterID], d)
+			if !ok {
+				continue
+			}
+			roster := make([]teamvalue.Player, 0, len(v.players))
+			for _, id := range v.players {
+				value, valued := values[day][id]
+				roster = append(roster, teamvalue.Player{
+					SleeperPlayerID: id,
+					Position:        playersByID[id].Position,
+					Age:             playersByID[id].Age,
+					Value:           value,
+					Valued:          valued,
+				})
+			}
+			teams = append(teams, teamvalue.Value(rosterID, rosterPositions, roster))
+		}
+		teamvalue.Rank(teams)
+		for _, t := range teams {
+			series[t.RosterID] = append(series[t.RosterID], SleeperTeamValuePoint{
+				ValuationDate: day,
+				TotalValue:    t.TotalValue,
+				StarterValue:  t.StarterValue,
+				DepthValue:    t.DepthValue,
+				TotalRank:     t.TotalRank,
+			})
+		}
+		if i == len(dates)-1 {
+			latest = teams
+		}
+	}
+
+	asOf := dates[len(dates)-1]
+	day := asOf.Format("2006-01-02")
+	ownerIDs := make([]string, 0, len(latest))
+	for _, t := range latest {
+		v, _ := rosterAsOf(versions[t.RosterID], asOf)
+		ownerIDs = append(ownerIDs, v.ownerID)
+	}
+	var owners []models.SleeperUser
+	if err := db.Select("sleeper_user_id, display_name").
+		Where("sleeper_user_id IN ?", ownerIDs).
+		Find(&owners).Error; err != nil {
+		slog.Error("Failed to fetch owners for team values", "league", leagueID, "error", err)
+	}
+	ownerNames := make(map[string]string, len(owners))
+	for _, o := range owners {
+		ownerNames[o.SleeperUserID] = o.DisplayName
+	}
+
+	response := SleeperTeamValuesResponse{
+		SleeperLeagueID: leagueID,
+		Segment:         segment,
+		ValuationDate:   day,
+		Teams:           make([]SleeperTeamValue, 0, len(latest)),
+	}
+	for _, t := range latest {
+		v, _ := rosterAsOf(versions[t.RosterID], asOf)
+		team := SleeperTeamValue{
+			RosterID:     t.RosterID,
+			OwnerID:      v.ownerID,
+			OwnerName:    ownerNames[v.ownerID],
+			TotalValue:   t.TotalValue,
+			StarterValue: t.StarterValue,
+			DepthValue:   t.DepthValue,
+			TotalRank:    t.TotalRank,
+			StarterRank:  t.StarterRank,
+			DepthRank:    t.DepthRank,
+			WeightedAge:  t.WeightedAge,
+			Players:      make([]SleeperTeamValuePlayer, 0, len(v.players)),
+			Series:       series[t.RosterID],
+		}
+		if league.LeagueType == "dynasty" {
+			team.Classification = teamvalue.Classify(t, len(latest))
+		}
+		starters := make(map[string]bool, len(t.Starters))
+		for _, id := range t.Starters {
+			starters[id] = true
+		}
+		for _, id := range v.players {
+			p := SleeperTeamValuePlayer{
+				SleeperPlayerID: id,
+				Name:            playersByID[id].FullName,
+				Position:        playersByID[id].Position,
+				Starter:         starters[id],
+			}
+			if value, ok := values[day][id]; ok {
+				p.Value = &value
+			}
+			team.Players = append(team.Players, p)
+		}
+		sort.SliceStable(team.Players, func(i, j int) bool {
+			a, b := team.Players[i], team.Players[j]
+			if a.Starter != b.Starter {
+				return a.Starter
+			}
+			av, bv := 0.0, 0.0
+			if a.Value != nil {
+				av = *a.Value
+			}
+			if b.Value != nil {
+				bv = *b.Value
+			}
+			return av > bv
+		})
+		response.Teams = append(response.Teams, team)
+	}
+	sort.Slice(response.Teams, func(i, j int) bool { return response.Teams[i].TotalRank < response.Teams[j].TotalRank })
+	c.JSON(http.StatusOK, response)
+}
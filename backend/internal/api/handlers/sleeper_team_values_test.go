@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+// seedTeamValuesTestDB seeds a 10-team superflex PPR dynasty league with
+// two rosters on a QB/RB/WR/SUPER_FLEX lineup. Roster 1 swapped its WR p3
+// for p4 on 2025-10-28; valuations exist a week apart, 2025-10-24 and
+// 2025-10-31.
+func seedTeamValuesTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := newDraftADPTestDB(t)
+	if err := db.AutoMigrate(&models.SleeperLeague{}, &models.SleeperUser{}, &models.SleeperRosterSnapshot{}, &valuation.Snapshot{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	withDraftADPTestDB(t, db)
+	seedADPPlayer(t, db, "p1", "Quarterback One", "QB", "KC")
+	seedADPPlayer(t, db, "p2", "Running Back", "RB", "DAL")
+	seedADPPlayer(t, db, "p3", "Receiver Old", "WR", "SF")
+	seedADPPlayer(t, db, "p4", "Receiver New", "WR", "MIN")
+	seedADPPlayer(t, db, "p5", "Quarterback Two", "QB", "BUF")
+	seedADPPlayer(t, db, "p6", "Backup Back", "RB", "ATL")
+	seedADPPlayer(t, db, "p7", "Receiver Two", "WR", "CIN")
+	db.Model(&models.SleeperPlayer{}).Where("sleeper_player_id IN ?", []string{"p1", "p2"}).Update("age", 30)
+	db.Model(&models.SleeperPlayer{}).Where("sleeper_player_id = ?", "p4").Update("age", 22)
+
+	ppr, sf := 1.0, true
+	if err := db.Create(&models.SleeperLeague{
+		SleeperLeagueID: "L1",
+		TotalRosters:    10,
+		PPR:             &ppr,
+		IsSuperflex:     &sf,
+		LeagueType:      "dynasty",
+		RosterPositions: json.RawMessage(`["QB","RB","WR","SUPER_FLEX","BN","BN"]`),
+	}).Error; err != nil {
+		t.Fatalf("seed league: %v", err)
+	}
+	db.Create(&models.SleeperUser{SleeperUserID: "u1", DisplayName: "Owner One"})
+
+	first := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+	for _, s := range []models.SleeperRosterSnapshot{
+		{SleeperLeagueID: "L1", RosterID: 1, TakenAt: first, OwnerID: "u1", Players: json.RawMessage(`["p1","p2","p3"]`)},
+		{SleeperLeagueID: "L1", RosterID: 1, TakenAt: first.AddDate(0, 0, 8), OwnerID: "u1", Players: json.RawMessage(`["p1","p2","p4"]`)},
+		{SleeperLeagueID: "L1", RosterID: 2, TakenAt: first, OwnerID: "u2", Players: json.RawMessage(`["p5","p6","p7"]`)},
+		{SleeperLeagueID: "L2", RosterID: 1, TakenAt: first, OwnerID: "u3", Players: json.RawMessage(`["p5"]`)},
+	} {
+		if err := db.Create(&s).Error; err != nil {
+			t.Fatalf("seed snapshot: %v", err)
+		}
+	}
+
+	older := time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC)
+	latest := time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+	for id, v := range map[string][2]float64{
+		"p1": {3000, 3000}, "p2": {1000, 1000}, "p3": {2000, 2000}, "p4": {500, 4000},
+		"p5": {2500, 2500}, "p6": {1500, 1500}, "p7": {1800, 1800},
+	} {
+		for i, d := range []time.Time{older, latest} {
+			if err := db.Create(&valuation.Snapshot{Segment: "ppr-sf-10-dynasty", SleeperPlayerID: id, ValuationDate: d, Value: v[i]}).Error; err != nil {
+				t.Fatalf("seed valuation: %v", err)
+			}
+		}
+	}
+	// A valuation between the weekly samples, which the series skips.
+	db.Create(&valuation.Snapshot{Segment: "ppr-sf-10-dynasty", SleeperPlayerID: "p1", ValuationDate: latest.AddDate(0, 0, -3), Value: 1})
+	return db
+}
+
+func performGetSleeperLeagueTeamValues(t *testing.T, leagueID string) (*httptest.ResponseRecorder, SleeperTeamValuesResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sleeper/leagues/:id/team-values", GetSleeperLeagueTeamValues)
+
+	req := httptest.NewRequest(http.MethodGet, "/sleeper/leagues/"+leagueID+"/team-values", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp SleeperTeamValuesResponse
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+func TestGetSleeperLeagueTeamValues_RanksTeamsWithWeeklySeries(t *testing.T) {
+	seedTeamValuesTestDB(t)
+
+	w, resp := performGetSleeperLeagueTeamValues(t, "L1")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Segment != "ppr-sf-10-dynasty" || resp.ValuationDate != "2025-10-31" || len(resp.Teams) != 2 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	// Roster 1 now: p1 QB 3000 and p2 RB 1000, both 30, and p4 WR 4000,
+	// 22 — all starters, at a value-weighted age of 26.
+	top := resp.Teams[0]
+	if top.RosterID != 1 || top.TotalValue != 8000 || top.StarterValue != 8000 || top.DepthValue != 0 || top.TotalRank != 1 {
+		t.Errorf("expected roster 1 first at 8000, got %+v", top)
+	}
+	if top.OwnerName != "Owner One" || top.Classification != "contender" || top.WeightedAge == nil || *top.WeightedAge != 26 {
+		t.Errorf("expected roster 1's owner, classification and age, got %+v", top)
+	}
+	if len(top.Players) != 3 || top.Players[0].SleeperPlayerID != "p4" || !top.Players[0].Starter || top.Players[0].Name != "Receiver New" {
+		t.Errorf("expected roster 1's players, most valuable starter first, got %+v", top.Players)
+	}
+	// Roster 1 held p3, not p4, on 2025-10-24, and the mid-week valuation
+	// date is skipped.
+	if len(top.Series) != 2 || top.Series[0].ValuationDate != "2025-10-24" || top.Series[0].TotalValue != 6000 || top.Series[1].TotalValue != 8000 {
+		t.Errorf("expected roster 1's weekly series 6000 -> 8000, got %+v", top.Series)
+	}
+
+	second := resp.Teams[1]
+	if second.RosterID != 2 || second.TotalValue != 5800 || second.TotalRank != 2 || second.Classification != "rebuilder" || second.OwnerName != "" {
+		t.Errorf("expected roster 2 second at 5800, got %+v", second)
+	}
+	if len(second.Series) != 2 || second.Series[0].TotalRank != 2 {
+		t.Errorf("expected roster 2 ranked second both weeks, got %+v", second.Series)
+	}
+}
+
+func TestGetSleeperLeagueTeamValues_Errors(t *testing.T) {
+	db := seedTeamValuesTestDB(t)
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "L3", TotalRosters: 10, LeagueType: "dynasty"})
+	ppr, sf := 1.0, false
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "L4", TotalRosters: 10, PPR: &ppr, IsSuperflex: &sf, LeagueType: "redraft"})
+	db.Create(&valuation.Snapshot{Segment: "ppr-1qb-10", SleeperPlayerID: "p1", ValuationDate: time.Now().UTC(), Value: 100})
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "L5", TotalRosters: 12, PPR: &ppr, IsSuperflex: &sf, LeagueType: "redraft"})
+
+	for _, tc := range []struct {
+		league string
+		want   int
+	}{
+		{"missing", http.StatusNotFound},
+		{"L3", http.StatusBadRequest}, // no scoring setting to bucket
+		{"L4", http.StatusNotFound},   // no rosters fetched
+		{"L5", http.StatusNotFound},   // no valuations for ppr-1qb-12
+	} {
+		if w, _ := performGetSleeperLeagueTeamValues(t, tc.league); w.Code != tc.want {
+			t.Errorf("%s: expected %d, got %d: %s", tc.league, tc.want, w.Code, w.Body.String())
+		}
+	}
+}
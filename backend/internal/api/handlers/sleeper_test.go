@@ -4,11 +4,16 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
 
+	"backend/internal/database"
 	"backend/internal/models"
 )
 
@@ -466,3 +471,229 @@ func TestGetSleeperTrades_ReadsPersistedTradeValues(t *testing.T) {
 		t.Errorf("expected roster 8 total_value nil (absent from persisted trade_values), got %+v", side8)
 	}
 }
+
+// seedArchiveRoutingTest seeds cloud with two trades and a waiver in the hot
+// window plus one stale trade the scavenger hasn't purged yet, and the
+// archive with that trade and two older ones, all in league lg1.
+func seedArchiveRoutingTest(t *testing.T, now time.Time) {
+	t.Helper()
+	t.Setenv("SCAVENGER_RETENTION_DAYS", "30")
+	cloud := newAdminTestDB(t)
+	withAdminTestDB(t, cloud)
+	archive, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := archive.AutoMigrate(&models.ArchiveSleeperLeague{}, &models.ArchiveSleeperTransaction{}); err != nil {
+		t.Fatalf("automigrate archive: %v", err)
+	}
+	original := database.Archive
+	database.Archive = archive
+	t.Cleanup(func() { database.Archive = original })
+
+	ppr := 1.0
+	cloud.Create(&models.SleeperLeague{SleeperLeagueID: "lg1", Name: "Cloud League", Season: "2026", PPR: &ppr, TotalRosters: 12})
+	archive.Create(&models.ArchiveSleeperLeague{SleeperLeagueID: "lg1", Name: "Archived League", Season: "2026", PPR: &ppr, TotalRosters: 12})
+	daysAgo := func(d int) int64 { return now.Add(-time.Duration(d) * 24 * time.Hour).UnixMilli() }
+	adds := json.RawMessage(`{"p1": 1, "p2": 2}`)
+	for _, tx := range []models.SleeperTransaction{
+		{SleeperTransactionID: "hot-1", SleeperLeagueID: "lg1", Type: "trade", Status: "complete", CreatedAtSleeper: daysAgo(1), Adds: adds, TradeValues: json.RawMessage(`{"1": 100}`)},
+		{SleeperTransactionID: "hot-2", SleeperLeagueID: "lg1", Type: "trade", Status: "complete", CreatedAtSleeper: daysAgo(10), Adds: adds},
+		{SleeperTransactionID: "hot-waiver", SleeperLeagueID: "lg1", Type: "waiver", Status: "complete", CreatedAtSleeper: daysAgo(2), Adds: json.RawMessage(`{"p3": 1}`)},
+		{SleeperTransactionID: "stale", SleeperLeagueID: "lg1", Type: "trade", Status: "complete", CreatedAtSleeper: daysAgo(35), Adds: adds},
+	} {
+		if err := cloud.Create(&tx).Error; err != nil {
+			t.Fatalf("seed cloud transaction: %v", err)
+		}
+	}
+	for _, tx := range []models.ArchiveSleeperTransaction{
+		{SleeperTransactionID: "stale", SleeperLeagueID: "lg1", Type: "trade", Status: "complete", CreatedAtSleeper: daysAgo(35), Adds: adds},
+		{SleeperTransactionID: "old-1", SleeperLeagueID: "lg1", Type: "trade", Status: "complete", CreatedAtSleeper: daysAgo(60), Adds: adds},
+		{SleeperTransactionID: "old-waiver", SleeperLeagueID: "lg1", Type: "waiver", Status: "complete", CreatedAtSleeper: daysAgo(70), Adds: json.RawMessage(`{"p3": 1}`)},
+		{SleeperTransactionID: "ancient", SleeperLeagueID: "lg1", Type: "trade", Status: "complete", CreatedAtSleeper: daysAgo(400), Adds: adds},
+	} {
+		if err := archive.Create(&tx).Error; err != nil {
+			t.Fatalf("seed archive transaction: %v", err)
+		}
+	}
+}
+
+func performSleeperListRequest(t *testing.T, path string, out any) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sleeper/trades", GetSleeperTrades)
+	r.GET("/sleeper/transactions", GetSleeperTransactions)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+	}
+	return w
+}
+
+func TestGetSleeperTrades_ReadsOlderWindowsFromArchive(t *testing.T) {
+	seedArchiveRoutingTest(t, time.Now().UTC())
+
+	// The hot window reads cloud alone.
+	var resp SleeperTradesResponse
+	performSleeperListRequest(t, "/sleeper/trades?days=20", &resp)
+	if resp.Total != 2 || len(resp.Trades) != 2 || resp.Trades[0].LeagueName != "Cloud League" {
+		t.Errorf("expected the two hot trades from cloud, got %+v", resp)
+	}
+
+	// Pages run through cloud's rows then the archive's, and the stale
+	// cloud row is read from the archive only.
+	var ids []string
+	for page := 1; page <= 2; page++ {
+		resp = SleeperTradesResponse{}
+		w := performSleeperListRequest(t, "/sleeper/trades?days=90&limit=3&page="+strconv.Itoa(page), &resp)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if resp.Total != 4 || resp.TotalPages != 2 {
+			t.Errorf("expected four trades over two pages, got total=%d pages=%d", resp.Total, resp.TotalPages)
+		}
+		for _, trade := range resp.Trades {
+			ids = append(ids, trade.ID)
+		}
+	}
+	if want := []string{"hot-1", "hot-2", "stale", "old-1"}; len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] || ids[3] != want[3] {
+		t.Errorf("expected %v newest first, got %v", want, ids)
+	}
+	if resp.Trades[0].LeagueName != "Archived League" || resp.Trades[0].Sides[0].TotalValue != nil {
+		t.Errorf("expected an archived trade without trade values, got %+v", resp.Trades[0])
+	}
+
+	// An explicit range entirely past the hot window reads the archive alone.
+	from := time.Now().UTC().AddDate(0, 0, -500).Format("2006-01-02")
+	to := time.Now().UTC().AddDate(0, 0, -50).Format("2006-01-02")
+	resp = SleeperTradesResponse{}
+	performSleeperListRequest(t, "/sleeper/trades?from="+from+"&to="+to, &resp)
+	if resp.Total != 2 || len(resp.Trades) != 2 || resp.Trades[0].ID != "old-1" || resp.Trades[1].ID != "ancient" {
+		t.Errorf("expected old-1 and ancient, got %+v", resp.Trades)
+	}
+}
+
+func TestGetSleeperTransactions_ReadsOlderWindowsFromArchive(t *testing.T) {
+	seedArchiveRoutingTest(t, time.Now().UTC())
+
+	var resp SleeperTransactionsResponse
+	performSleeperListRequest(t, "/sleeper/transactions?type=waiver&days=365", &resp)
+	if resp.Total != 2 || len(resp.Transactions) != 2 || resp.Transactions[0].ID != "hot-waiver" || resp.Transactions[1].ID != "old-waiver" {
+		t.Errorf("expected the hot and archived waivers, got %+v", resp)
+	}
+
+	// Without a window the listing stays on cloud.
+	resp = SleeperTransactionsResponse{}
+	performSleeperListRequest(t, "/sleeper/transactions", &resp)
+	if resp.Total != 4 {
+		t.Errorf("expected cloud's four transactions, got %d", resp.Total)
+	}
+}
+
+func TestGetSleeperTrades_ListsCloudRowsNotYetArchived(t *testing.T) {
+	now := time.Now().UTC()
+	seedArchiveRoutingTest(t, now)
+	// Past the hot window but not yet replicated, so the scavenger hasn't
+	// purged them from cloud.
+	daysAgo := func(d int) int64 { return now.Add(-time.Duration(d) * 24 * time.Hour).UnixMilli() }
+	for _, tx := range []models.SleeperTransaction{
+		{SleeperTransactionID: "gap", SleeperLeagueID: "lg1", Type: "trade", Status: "complete", CreatedAtSleeper: daysAgo(45), Adds: json.RawMessage(`{"p1": 1, "p2": 2}`), TradeValues: json.RawMessage(`{"1": 100}`)},
+		{SleeperTransactionID: "gap-waiver", SleeperLeagueID: "lg1", Type: "waiver", Status: "complete", CreatedAtSleeper: daysAgo(50), Adds: json.RawMessage(`{"p3": 1}`)},
+	} {
+		if err := database.DB.Create(&tx).Error; err != nil {
+			t.Fatalf("seed unreplicated transaction: %v", err)
+		}
+	}
+
+	var ids []string
+	var gap *SleeperTradeItem
+	for page := 1; page <= 3; page++ {
+		var resp SleeperTradesResponse
+		w := performSleeperListRequest(t, "/sleeper/trades?days=90&limit=2&page="+strconv.Itoa(page), &resp)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if resp.Total != 5 || resp.TotalPages != 3 {
+			t.Errorf("expected five trades over three pages, got total=%d pages=%d", resp.Total, resp.TotalPages)
+		}
+		for i, trade := range resp.Trades {
+			ids = append(ids, trade.ID)
+			if trade.ID == "gap" {
+				gap = &resp.Trades[i]
+			}
+		}
+	}
+	want := []string{"hot-1", "hot-2", "stale", "gap", "old-1"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("expected %v newest first, got %v", want, ids)
+	}
+	if gap == nil || gap.LeagueName != "Cloud League" || gap.Sides[0].TotalValue == nil {
+		t.Errorf("expected the unreplicated trade read from cloud with its trade values, got %+v", gap)
+	}
+
+	var txs SleeperTransactionsResponse
+	performSleeperListRequest(t, "/sleeper/transactions?type=waiver&days=365", &txs)
+	var txIDs []string
+	for _, tx := range txs.Transactions {
+		txIDs = append(txIDs, tx.ID)
+	}
+	if want := []string{"hot-waiver", "gap-waiver", "old-waiver"}; txs.Total != 3 || !slices.Equal(txIDs, want) {
+		t.Errorf("expected %v, got total=%d %v", want, txs.Total, txIDs)
+	}
+}
+
+func TestGetSleeperTrades_PagesUnreplicatedRowsAtEveryPageSize(t *testing.T) {
+	now := time.Now().UTC()
+	seedArchiveRoutingTest(t, now)
+	daysAgo := func(d int) int64 { return now.Add(-time.Duration(d) * 24 * time.Hour).UnixMilli() }
+	adds := json.RawMessage(`{"p1": 1, "p2": 2}`)
+	for id, d := range map[string]int{"gap-a": 40, "gap-b": 61, "gap-c": 200} {
+		if err := database.DB.Create(&models.SleeperTransaction{SleeperTransactionID: id, SleeperLeagueID: "lg1", Type: "trade", Status: "complete", CreatedAtSleeper: daysAgo(d), Adds: adds}).Error; err != nil {
+			t.Fatalf("seed unreplicated trade: %v", err)
+		}
+	}
+	if err := database.Archive.Create(&models.ArchiveSleeperTransaction{SleeperTransactionID: "old-2", SleeperLeagueID: "lg1", Type: "trade", Status: "complete", CreatedAtSleeper: daysAgo(100), Adds: adds}).Error; err != nil {
+		t.Fatalf("seed archived trade: %v", err)
+	}
+
+	want := []string{"hot-1", "hot-2", "stale", "gap-a", "old-1", "gap-b", "old-2", "gap-c"}
+	for limit := 1; limit <= len(want)+1; limit++ {
+		var ids []string
+		for page := 1; page <= len(want)+1; page++ {
+			var resp SleeperTradesResponse
+			path := "/sleeper/trades?days=365&limit=" + strconv.Itoa(limit) + "&page=" + strconv.Itoa(page)
+			if w := performSleeperListRequest(t, path, &resp); w.Code != http.StatusOK {
+				t.Fatalf("%s: expected 200, got %d: %s", path, w.Code, w.Body.String())
+			}
+			if resp.Total != int64(len(want)) {
+				t.Errorf("%s: expected total %d, got %d", path, len(want), resp.Total)
+			}
+			for _, trade := range resp.Trades {
+				ids = append(ids, trade.ID)
+			}
+		}
+		if !slices.Equal(ids, want) {
+			t.Errorf("limit=%d: expected %v, got %v", limit, want, ids)
+		}
+	}
+}
+
+func TestGetSleeperTrades_RejectsBadWindows(t *testing.T) {
+	seedArchiveRoutingTest(t, time.Now().UTC())
+
+	for _, path := range []string{
+		"/sleeper/trades?from=last-week",
+		"/sleeper/trades?from=2026-02-01&to=2026-01-01",
+		"/sleeper/trades?days=90&sort=hindsight",
+		"/sleeper/transactions?to=2026-13-01",
+	} {
+		if w := performSleeperListRequest(t, path, &struct{}{}); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
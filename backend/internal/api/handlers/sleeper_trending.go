@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/database"
+	"backend/internal/models"
+)
+
+// trendingTypeColumns maps GET /sleeper/trending's type filter to the
+// player_trends column it ranks by.
+var trendingTypeColumns = map[string]string{
+	"add":    "adds",
+	"drop":   "drops",
+	"waiver": "waiver_claims",
+	"trade":  "trades",
+}
+
+// SleeperTrendingItem is one player's activity in the trending list.
+// PlayerID is the internal players.id, as in SleeperADPItem.
+type SleeperTrendingItem struct {
+	SleeperPlayerID string  `json:"sleeper_player_id"`
+	Name            string  `json:"name"`
+	Position        string  `json:"position"`
+	NflTeam         string  `json:"nfl_team"`
+	Adds            int     `json:"adds"`
+	Drops           int     `json:"drops"`
+	WaiverClaims    int     `json:"waiver_claims"`
+	Trades          int     `json:"trades"`
+	PlayerID        *string `json:"player_id,omitempty"`
+}
+
+// SleeperTrendingResponse is the paginated response for GET
+// /api/v1/sleeper/trending. ComputedAt is when the market-pulse job last
+// wrote the rollup, nil before its first run.
+type SleeperTrendingResponse struct {
+	Players    []SleeperTrendingItem `json:"players"`
+	Segment    string                `json:"segment"`
+	Window     string                `json:"window"`
+	Type       string                `json:"type"`
+	ComputedAt *time.Time            `json:"computed_at"`
+	Total      int64                 `json:"total"`
+	Page       int                   `json:"page"`
+	Limit      int                   `json:"limit"`
+	TotalPages int                   `json:"total_pages"`
+}
+
+type trendingItemRow struct {
+	SleeperPlayerID string    `gorm:"column:sleeper_player_id"`
+	Name            string    `gorm:"column:full_name"`
+	Position        string    `gorm:"column:position"`
+	NflTeam         string    `gorm:"column:nfl_team"`
+	Adds            int       `gorm:"column:adds"`
+	Drops           int       `gorm:"column:drops"`
+	WaiverClaims    int       `gorm:"column:waiver_claims"`
+	Trades          int       `gorm:"column:trades"`
+	ComputedAt      time.Time `gorm:"column:computed_at"`
+}
+
+// populationSegmentQuery resolves the league_size, scoring_format and
+// superflex query filters to a segment of a population-wide rollup:
+// models.TrendSegmentAll when none is set, otherwise an ADP segment key with
+// the unset ones taking GetSleeperADP's defaults.
+func populationSegmentQuery(c *gin.Context) string {
+	if c.Query("league_size") == "" && c.Query("scoring_format") == "" && c.Query("superflex") == "" {
+		return models.TrendSegmentAll
+	}
+	return models.ADPSegmentKey(
+		c.DefaultQuery("league_size", "12"),
+		c.DefaultQuery("scoring_format", "ppr"),
+		c.DefaultQuery("superflex", "true") == "true",
+	)
+}
+
+// GetSleeperTrending handles GET /api/v1/sleeper/trending: the players most
+// added, dropped, claimed off waivers or traded across every scraped Sleeper
+// league, from the hourly market-pulse rollup. Supports query filters: type
+// (add|drop|waiver|trade, default add), window (24h|7d, default 24h),
+// position, and the ADP segment filters league_size, scoring_format and
+// superflex — with none of those three the whole population is counted;
+// with any of them, the rest take GetSleeperADP's defaults.
+func GetSleeperTrending(c *gin.Context) {
+	page, limit := parsePagination(c)
+	offset := (page - 1) * limit
+
+	trendType := c.DefaultQuery("type", "add")
+	column, ok := trendingTypeColumns[trendType]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be one of add, drop, waiver, trade"})
+		return
+	}
+	window := c.DefaultQuery("window", "24h")
+	if _, ok := models.TrendWindows[window]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "window must be 24h or 7d"})
+		return
+	}
+	segment := populationSegmentQuery(c)
+
+	db := database.DB.Table("player_trends t").
+		Joins("JOIN sleeper_players p ON p.sleeper_player_id = t.sleeper_player_id").
+		Where("t.segment = ? AND t.time_window = ? AND t."+column+" > 0", segment, window)
+	if position := c.Query("position"); position != "" {
+		db = db.Where("p.position = ?", position)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		slog.Error("Failed to count trending players", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trending players"})
+		return
+	}
+	var rows []trendingItemRow
+	if err := db.Select("t.sleeper_player_id, p.full_name, p.position, p.nfl_team, t.adds, t.drops, t.waiver_claims, t.trades, t.computed_at").
+		Order("t." + column + " DESC, t.sleeper_player_id ASC").
+		Limit(limit).Offset(offset).
+		Scan(&rows).Error; err != nil {
+		slog.Error("Failed to fetch trending players", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trending players"})
+		return
+	}
+
+	sleeperIDs := make([]string, 0, len(rows))
+	for _, r := range rows {
+		sleeperIDs = append(sleeperIDs, r.SleeperPlayerID)
+	}
+	sleeperMatches, err := models.GetPlayersBySleeperIDs(database.DB, sleeperIDs)
+	if err != nil {
+		slog.Error("Failed to resolve players for trending list", "error", err)
+		sleeperMatches = map[string]models.Player{}
+	}
+
+	response := SleeperTrendingResponse{
+		Players:    make([]SleeperTrendingItem, len(rows)),
+		Segment:    segment,
+		Window:     window,
+		Type:       trendType,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+	for i, r := range rows {
+		response.Players[i] = SleeperTrendingItem{
+			SleeperPlayerID: r.SleeperPlayerID,
+			Name:            r.Name,
+			Position:        r.Position,
+			NflTeam:         r.NflTeam,
+			Adds:            r.Adds,
+			Drops:           r.Drops,
+			WaiverClaims:    r.WaiverClaims,
+			Trades:          r.Trades,
+		}
+		if player, ok := sleeperMatches[r.SleeperPlayerID]; ok {
+			playerID := strconv.FormatUint(uint64(player.ID), 10)
+			response.Players[i].PlayerID = &playerID
+		}
+	}
+	if len(rows) > 0 {
+		response.ComputedAt = &rows[0].ComputedAt
+	} else {
+		var latest models.PlayerTrend
+		if err := database.DB.Order("computed_at DESC").Limit(1).Find(&latest).Error; err == nil && !latest.ComputedAt.IsZero() {
+			response.ComputedAt = &latest.ComputedAt
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
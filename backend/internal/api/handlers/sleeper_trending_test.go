@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+)
+
+func newTrendingTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.PlayerTrend{}, &models.SleeperPlayer{}, &models.Player{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	withDraftADPTestDB(t, db)
+	seedADPPlayer(t, db, "4046", "Patrick Mahomes", "QB", "KC")
+	seedADPPlayer(t, db, "6794", "Justin Jefferson", "WR", "MIN")
+	seedADPPlayer(t, db, "9509", "Bijan Robinson", "RB", "ATL")
+
+	computed := time.Date(2025, 10, 14, 9, 0, 0, 0, time.UTC)
+	for _, tr := range []models.PlayerTrend{
+		{Segment: "all", TimeWindow: "24h", SleeperPlayerID: "4046", Adds: 5, Drops: 40, ComputedAt: computed},
+		{Segment: "all", TimeWindow: "24h", SleeperPlayerID: "6794", Adds: 90, WaiverClaims: 60, ComputedAt: computed},
+		{Segment: "all", TimeWindow: "24h", SleeperPlayerID: "9509", Trades: 3, ComputedAt: computed},
+		{Segment: "10-half_ppr-1qb", TimeWindow: "24h", SleeperPlayerID: "4046", Adds: 2, ComputedAt: computed},
+		{Segment: "12-ppr-sf", TimeWindow: "7d", SleeperPlayerID: "9509", Adds: 7, ComputedAt: computed},
+	} {
+		if err := db.Create(&tr).Error; err != nil {
+			t.Fatalf("seed trend: %v", err)
+		}
+	}
+	return db
+}
+
+func performGetSleeperTrending(t *testing.T, query string) (*httptest.ResponseRecorder, SleeperTrendingResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sleeper/trending", GetSleeperTrending)
+
+	req := httptest.NewRequest(http.MethodGet, "/sleeper/trending"+query, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp SleeperTrendingResponse
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+func TestGetSleeperTrending_DefaultsToPopulationAdds(t *testing.T) {
+	newTrendingTestDB(t)
+
+	w, resp := performGetSleeperTrending(t, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Segment != "all" || resp.Window != "24h" || resp.Type != "add" {
+		t.Errorf("unexpected defaults %+v", resp)
+	}
+	// Bijan has no adds in this window, so he's not trending by adds.
+	if resp.Total != 2 || len(resp.Players) != 2 || resp.Players[0].Name != "Justin Jefferson" || resp.Players[0].WaiverClaims != 60 {
+		t.Errorf("expected Jefferson then Mahomes by adds, got %+v", resp.Players)
+	}
+	if resp.ComputedAt == nil || resp.ComputedAt.Hour() != 9 {
+		t.Errorf("expected computed_at from the rollup, got %v", resp.ComputedAt)
+	}
+}
+
+func TestGetSleeperTrending_TypeAndPositionFilters(t *testing.T) {
+	newTrendingTestDB(t)
+
+	_, drops := performGetSleeperTrending(t, "?type=drop")
+	if len(drops.Players) != 1 || drops.Players[0].SleeperPlayerID != "4046" {
+		t.Errorf("expected only Mahomes by drops, got %+v", drops.Players)
+	}
+	_, wrs := performGetSleeperTrending(t, "?position=WR")
+	if len(wrs.Players) != 1 || wrs.Players[0].Position != "WR" {
+		t.Errorf("expected only the WR, got %+v", wrs.Players)
+	}
+}
+
+func TestGetSleeperTrending_SegmentFilters(t *testing.T) {
+	newTrendingTestDB(t)
+
+	_, half := performGetSleeperTrending(t, "?league_size=10&scoring_format=half_ppr&superflex=false")
+	if half.Segment != "10-half_ppr-1qb" || len(half.Players) != 1 || half.Players[0].Adds != 2 {
+		t.Errorf("expected the 10-team half-PPR 1QB segment, got %+v", half)
+	}
+	// Any one segment filter picks a segment, the rest defaulting like ADP.
+	_, sf := performGetSleeperTrending(t, "?superflex=true&window=7d")
+	if sf.Segment != "12-ppr-sf" || len(sf.Players) != 1 || sf.Players[0].SleeperPlayerID != "9509" {
+		t.Errorf("expected the default 12-team PPR superflex segment over 7d, got %+v", sf)
+	}
+}
+
+func TestGetSleeperTrending_RejectsUnknownTypeOrWindow(t *testing.T) {
+	newTrendingTestDB(t)
+
+	if w, _ := performGetSleeperTrending(t, "?type=claim"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown type, got %d", w.Code)
+	}
+	if w, _ := performGetSleeperTrending(t, "?window=30d"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown window, got %d", w.Code)
+	}
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/database"
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+// TradeCalculatorPick is a draft pick on one side of a calculated trade.
+// Slot is the pick's position within its round, or 0 when it isn't known
+// yet — see valuation.PickKey.
+type TradeCalculatorPick struct {
+	Season string `json:"season"`
+	Round  int    `json:"round"`
+	Slot   int    `json:"slot"`
+}
+
+// TradeCalculatorSideRequest is what one team receives.
+type TradeCalculatorSideRequest struct {
+	SleeperPlayerIDs []string              `json:"sleeper_player_ids"`
+	Picks            []TradeCalculatorPick `json:"picks"`
+}
+
+// TradeCalculatorRequest is the body of POST /api/v1/trades/calculate.
+// The valuation segment comes from sleeper_league_id's settings when given,
+// else segment, else defaultPlayerValuationSegment.
+type TradeCalculatorRequest struct {
+	SleeperLeagueID string                       `json:"sleeper_league_id"`
+	Segment         string                       `json:"segment"`
+	Sides           []TradeCalculatorSideRequest `json:"sides"`
+}
+
+// TradeCalculatorAsset is one player or pick in a calculated trade. Value is
+// nil when it has no valuation as of the response's valuation_date;
+// MarketDispersion and ProjectedPAR are nil when the model has no estimate.
+type TradeCalculatorAsset struct {
+	SleeperPlayerID  string               `json:"sleeper_player_id,omitempty"`
+	Name             string               `json:"name,omitempty"`
+	Position         string               `json:"position,omitempty"`
+	Pick             *TradeCalculatorPick `json:"pick,omitempty"`
+	Value            *float64             `json:"value"`
+	MarketDispersion *float64             `json:"market_dispersion"`
+	ProjectedPAR     *float64             `json:"projected_par"`
+}
+
+// TradeCalculatorSide totals one side's valued assets (see
+// valuation.SumSide). Complete is false when any asset is unvalued, so the
+// total understates the side.
+type TradeCalculatorSide struct {
+	Assets       []TradeCalculatorAsset `json:"assets"`
+	Total        float64                `json:"total"`
+	Dispersion   float64                `json:"dispersion"`
+	ProjectedPAR float64                `json:"projected_par"`
+	Complete     bool                   `json:"complete"`
+}
+
+// TradeCalculatorBalancingAdd is the cheapest valued player, outside the
+// trade, whose addition to Side makes the trade even without tipping it
+// past FairnessTolerance the other way.
+type TradeCalculatorBalancingAdd struct {
+	Side            int     `json:"side"`
+	SleeperPlayerID string  `json:"sleeper_player_id"`
+	Name            string  `json:"name"`
+	Position        string  `json:"position"`
+	Value           float64 `json:"value"`
+}
+
+// TradeCalculatorResponse is the response for POST /api/v1/trades/calculate.
+// FairnessGap is side 0's total less side 1's; Even is true when the gap is
+// within valuation.FairnessTolerance, and FavoredSide (the side receiving
+// more) is nil then. BalancingAdd is nil when the trade is even or no single
+// player evens it.
+type TradeCalculatorResponse struct {
+	Segment       string                       `json:"segment"`
+	ValuationDate string                       `json:"valuation_date"`
+	Sides         []TradeCalculatorSide        `json:"sides"`
+	FairnessGap   float64                      `json:"fairness_gap"`
+	Even          bool                         `json:"even"`
+	FavoredSide   *int                         `json:"favored_side"`
+	BalancingAdd  *TradeCalculatorBalancingAdd `json:"balancing_add"`
+}
+
+// CalculateTrade values a proposed two-sided trade against the segment's
+// latest valuations: each player's value, market dispersion and projected
+// PAR, each pick's pick_valuations value, the side totals and the fairness
+// gap between them. When the trade isn't even it also finds the smallest
+// add that would balance it — the lowest-valued player in the segment, not
+// already in the trade, worth at least valuation.BalancingThreshold — as
+// long as adding them leaves the trade even.
+func CalculateTrade(c *gin.Context) {
+	var req TradeCalculatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.Sides) != 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "two sides are required"})
+		return
+	}
+	for _, side := range req.Sides {
+		if len(side.SleeperPlayerIDs) == 0 && len(side.Picks) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "each side needs a player or pick"})
+			return
+		}
+	}
+
+	segment := req.Segment
+	if req.SleeperLeagueID != "" {
+		var league models.SleeperLeague
+		if err := database.DB.Where("sleeper_league_id = ?", req.SleeperLeagueID).First(&league).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+				return
+			}
+			slog.Error("Failed to fetch league for trade calculator", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate trade"})
+			return
+		}
+		segment = valuation.SegmentKeyForLeague(league.PPR, league.IsSuperflex, league.TotalRosters, league.LeagueType)
+		if segment == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "no valuation segment covers this league's format"})
+			return
+		}
+		if !hasPlayerValuations(segment) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "no valuations for segment"})
+			return
+		}
+	} else if segment == "" {
+		segment = defaultPlayerValuationSegment
+	}
+	if _, ok := valuation.ParseSegment(segment); !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown valuation segment"})
+		return
+	}
+
+	db := database.DB.WithContext(c.Request.Context())
+	var latest []valuation.Snapshot
+	if err := db.Table("player_valuations").
+		Select("valuation_date").
+		Where("segment = ?", segment).
+		Order("valuation_date DESC").
+		Limit(1).
+		Scan(&latest).Error; err != nil {
+		slog.Error("Failed to fetch latest valuation date", "segment", segment, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate trade"})
+		return
+	}
+	if len(latest) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no valuations for segment"})
+		return
+	}
+	asOf := latest[0].ValuationDate
+
+	var playerIDs []string
+	var pickKeys []valuation.PickKey
+	for _, side := range req.Sides {
+		playerIDs = append(playerIDs, side.SleeperPlayerIDs...)
+		for _, p := range side.Picks {
+			pickKeys = append(pickKeys, valuation.PickKey{Season: p.Season, Round: p.Round, Slot: p.Slot})
+		}
+	}
+	from := asOf.Add(-valuation.FreshnessWindow)
+	history := valuation.LoadSnapshotHistory(db, segment, playerIDs, from, asOf)
+	pickHistory := valuation.LoadPickHistory(db, segment, pickKeys, from, asOf)
+	names := loadTradeCalculatorPlayers(db, playerIDs)
+
+	resp := TradeCalculatorResponse{
+		Segment:       segment,
+		ValuationDate: asOf.Format("2006-01-02"),
+		Sides:         make([]TradeCalculatorSide, len(req.Sides)),
+	}
+	for i, side := range req.Sides {
+		out := TradeCalculatorSide{Assets: []TradeCalculatorAsset{}, Complete: true}
+		var valued []valuation.Snapshot
+		for _, id := range side.SleeperPlayerIDs {
+			asset := TradeCalculatorAsset{SleeperPlayerID: id, Name: names[id].FullName, Position: names[id].Position}
+			if s, ok := valuation.SnapshotAsOf(history[id], asOf, valuation.FreshnessWindow); ok {
+				asset.Value, asset.MarketDispersion, asset.ProjectedPAR = &s.Value, s.MarketDispersion, s.ProjectedPAR
+				valued = append(valued, s)
+			} else {
+				out.Complete = false
+			}
+			out.Assets = append(out.Assets, asset)
+		}
+		for _, p := range side.Picks {
+			asset := TradeCalculatorAsset{Pick: &p}
+			key := valuation.PickKey{Season: p.Season, Round: p.Round, Slot: p.Slot}
+			if s, ok := valuation.SnapshotAsOf(pickHistory[key], asOf, valuation.FreshnessWindow); ok {
+				asset.Value = &s.Value
+				valued = append(valued, s)
+			} else {
+				out.Complete = false
+			}
+			out.Assets = append(out.Assets, asset)
+		}
+		total := valuation.SumSide(valued)
+		out.Total, out.Dispersion, out.ProjectedPAR = total.Value, total.Dispersion, total.ProjectedPAR
+		resp.Sides[i] = out
+	}
+
+	a, b := resp.Sides[0].Total, resp.Sides[1].Total
+	resp.FairnessGap = a - b
+	resp.Even = valuation.IsEven(a, b)
+	if !resp.Even {
+		favored, lighter := 0, 1
+		if b > a {
+			favored, lighter = 1, 0
+		}
+		resp.FavoredSide = &favored
+		add, err := findBalancingAdd(db, segment, asOf, max(a, b), min(a, b), playerIDs)
+		if err != nil {
+			slog.Error("Failed to find balancing add", "segment", segment, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate trade"})
+			return
+		}
+		if add != nil {
+			add.Side = lighter
+			resp.BalancingAdd = add
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// loadTradeCalculatorPlayers returns the sleeper_players rows for ids, by ID.
+// A failed lookup only leaves names blank.
+func loadTradeCalculatorPlayers(db *gorm.DB, ids []string) map[string]models.SleeperPlayer {
+	out := map[string]models.SleeperPlayer{}
+	if len(ids) == 0 {
+		return out
+	}
+	var players []models.SleeperPlayer
+	if err := db.Select("sleeper_player_id, full_name, position").
+		Where("sleeper_player_id IN ?", ids).
+		Find(&players).Error; err != nil {
+		slog.Error("Failed to fetch players for trade calculator", "error", err)
+		return out
+	}
+	for _, p := range players {
+		out[p.SleeperPlayerID] = p
+	}
+	return out
+}
+
+// findBalancingAdd returns the lowest-valued player in segment's asOf
+// valuations, excluding the trade's own players, whose addition to the
+// lighter side makes the trade even, or nil when nobody does. Only the
+// cheapest player covering valuation.BalancingThreshold can: anyone pricier
+// overshoots further.
+func findBalancingAdd(db *gorm.DB, segment string, asOf time.Time, heavier, lighter float64, exclude []string) (*TradeCalculatorBalancingAdd, error) {
+	q := db.Table("player_valuations v").
+		Select("v.sleeper_player_id, p.full_name AS name, p.position, v.value").
+		Joins("LEFT JOIN sleeper_players p ON p.sleeper_player_id = v.sleeper_player_id").
+		Where("v.segment = ? AND v.valuation_date = ? AND v.value >= ?", segment, asOf, valuation.BalancingThreshold(heavier, lighter))
+	if len(exclude) > 0 {
+		q = q.Where("v.sleeper_player_id NOT IN ?", exclude)
+	}
+	var rows []TradeCalculatorBalancingAdd
+	if err := q.Order("v.value ASC, v.sleeper_player_id").Limit(1).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || !valuation.IsEven(heavier, lighter+rows[0].Value) {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+// seedTradeCalculatorValuations seeds ppr-sf-10 valuations on 2025-09-01
+// (the latest date) for p1..p5 at 5000, 3000, 1800, 700 and 400, plus a
+// stale 9999 for p1 a week earlier and a 2026 round 1 pick at 2500.
+func seedTradeCalculatorValuations(t *testing.T) {
+	t.Helper()
+	db := newDraftADPTestDB(t)
+	if err := db.AutoMigrate(&models.SleeperLeague{}, &valuation.Snapshot{}, &valuation.PickSnapshot{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	withDraftADPTestDB(t, db)
+
+	latest := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	dispersion, par := 400.0, 6.5
+	for i, v := range []float64{5000, 3000, 1800, 700, 400} {
+		id := []string{"p1", "p2", "p3", "p4", "p5"}[i]
+		seedADPPlayer(t, db, id, "Player "+id, "WR", "KC")
+		snap := valuation.Snapshot{Segment: "ppr-sf-10", SleeperPlayerID: id, ValuationDate: latest, Value: v}
+		if id == "p1" {
+			snap.MarketDispersion, snap.ProjectedPAR = &dispersion, &par
+		}
+		db.Create(&snap)
+	}
+	db.Create(&valuation.Snapshot{Segment: "ppr-sf-10", SleeperPlayerID: "p1", ValuationDate: latest.AddDate(0, 0, -7), Value: 9999})
+	db.Create(&valuation.PickSnapshot{Segment: "ppr-sf-10", Season: "2026", Round: 1, ValuationDate: latest, Value: 2500})
+
+	ppr, sf := 1.0, true
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "lg-10", TotalRosters: 10, PPR: &ppr, IsSuperflex: &sf, LeagueType: "redraft"})
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "lg-dynasty", TotalRosters: 10, PPR: &ppr, IsSuperflex: &sf, LeagueType: "dynasty"})
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "lg-9", TotalRosters: 9, PPR: &ppr, IsSuperflex: &sf, LeagueType: "redraft"})
+}
+
+func performCalculateTrade(t *testing.T, body string) (*httptest.ResponseRecorder, TradeCalculatorResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/trades/calculate", CalculateTrade)
+
+	req := httptest.NewRequest(http.MethodPost, "/trades/calculate", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp TradeCalculatorResponse
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+func TestCalculateTrade_TotalsGapAndBalancingAdd(t *testing.T) {
+	seedTradeCalculatorValuations(t)
+
+	w, resp := performCalculateTrade(t, `{
+		"sleeper_league_id": "lg-10",
+		"sides": [
+			{"sleeper_player_ids": ["p1"]},
+			{"sleeper_player_ids": ["p2", "unvalued"], "picks": [{"season": "2026", "round": 1}]}
+		]
+	}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Segment != "ppr-sf-10" || resp.ValuationDate != "2025-09-01" {
+		t.Errorf("expected ppr-sf-10 as of 2025-09-01, got %s as of %s", resp.Segment, resp.ValuationDate)
+	}
+
+	give := resp.Sides[0]
+	if give.Total != 5000 || give.Dispersion != 400 || give.ProjectedPAR != 6.5 || !give.Complete {
+		t.Errorf("expected p1's latest value, dispersion and PAR, got %+v", give)
+	}
+	if a := give.Assets[0]; a.Name != "Player p1" || a.Value == nil || *a.Value != 5000 {
+		t.Errorf("expected a named, valued p1, got %+v", a)
+	}
+
+	get := resp.Sides[1]
+	if get.Total != 5500 || get.Complete {
+		t.Errorf("expected p2 plus the pick, with the unvalued player flagged, got %+v", get)
+	}
+	if a := get.Assets[1]; a.Value != nil {
+		t.Errorf("expected no value for an unvalued player, got %v", *a.Value)
+	}
+	if a := get.Assets[2]; a.Pick == nil || a.Value == nil || *a.Value != 2500 {
+		t.Errorf("expected the pick valued at 2500, got %+v", a)
+	}
+
+	if resp.FairnessGap != -500 || resp.Even || resp.FavoredSide == nil || *resp.FavoredSide != 1 {
+		t.Fatalf("expected side 1 favored by 500, got gap %v favored %v", resp.FairnessGap, resp.FavoredSide)
+	}
+	// 500 less 5% of 5500 leaves 225 to cover: p5 at 400 is the cheapest
+	// player outside the trade that does.
+	if add := resp.BalancingAdd; add == nil || add.Side != 0 || add.SleeperPlayerID != "p5" || add.Value != 400 {
+		t.Errorf("expected p5 added to side 0, got %+v", add)
+	}
+}
+
+func TestCalculateTrade_EvenTradeNeedsNoAdd(t *testing.T) {
+	seedTradeCalculatorValuations(t)
+
+	w, resp := performCalculateTrade(t, `{"segment": "ppr-sf-10", "sides": [{"sleeper_player_ids": ["p2"]}, {"sleeper_player_ids": ["p3", "p4", "p5"]}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.FairnessGap != 100 || !resp.Even || resp.FavoredSide != nil || resp.BalancingAdd != nil {
+		t.Errorf("expected an even trade with nothing to add, got %+v", resp)
+	}
+}
+
+func TestCalculateTrade_NoBalancingAddThatOvershoots(t *testing.T) {
+	seedTradeCalculatorValuations(t)
+
+	// p4 for p2 leaves 2300 less 150 to cover. p1 at 5000 is the cheapest
+	// player outside the trade that covers it, but tips the trade 2700 the
+	// other way.
+	w, resp := performCalculateTrade(t, `{"segment": "ppr-sf-10", "sides": [{"sleeper_player_ids": ["p4"]}, {"sleeper_player_ids": ["p2"]}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Even || resp.BalancingAdd != nil {
+		t.Errorf("expected an uneven trade with no balancing add, got %+v", resp)
+	}
+}
+
+func TestCalculateTrade_RejectsBadRequests(t *testing.T) {
+	seedTradeCalculatorValuations(t)
+
+	for _, c := range []struct {
+		name, body string
+		want       int
+	}{
+		{"one side", `{"sides": [{"sleeper_player_ids": ["p1"]}]}`, http.StatusBadRequest},
+		{"empty side", `{"sides": [{"sleeper_player_ids": ["p1"]}, {}]}`, http.StatusBadRequest},
+		{"unknown segment", `{"segment": "half-1qb-10", "sides": [{"sleeper_player_ids": ["p1"]}, {"sleeper_player_ids": ["p2"]}]}`, http.StatusBadRequest},
+		{"uncovered league", `{"sleeper_league_id": "lg-9", "sides": [{"sleeper_player_ids": ["p1"]}, {"sleeper_player_ids": ["p2"]}]}`, http.StatusBadRequest},
+		{"unknown league", `{"sleeper_league_id": "nope", "sides": [{"sleeper_player_ids": ["p1"]}, {"sleeper_player_ids": ["p2"]}]}`, http.StatusNotFound},
+		{"segment without valuations", `{"segment": "ppr-sf-12", "sides": [{"sleeper_player_ids": ["p1"]}, {"sleeper_player_ids": ["p2"]}]}`, http.StatusNotFound},
+		{"league without valuations", `{"sleeper_league_id": "lg-dynasty", "sides": [{"sleeper_player_ids": ["p1"]}, {"sleeper_player_ids": ["p2"]}]}`, http.StatusNotFound},
+	} {
+		if w, _ := performCalculateTrade(t, c.body); w.Code != c.want {
+			t.Errorf("%s: expected %d, got %d: %s", c.name, c.want, w.Code, w.Body.String())
+		}
+	}
+}
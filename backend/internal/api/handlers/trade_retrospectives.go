@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/database"
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+// TradeAccuracyMeasure is how often the side favored at trade time also
+// came out ahead on one hindsight measure. Trades counts the trades with a
+// favorite at trade time and a winner on the measure; Agreement is the
+// share of them where the two match, nil when Trades is 0.
+type TradeAccuracyMeasure struct {
+	Trades    int      `json:"trades"`
+	Agreement *float64 `json:"agreement"`
+}
+
+// TradeAccuracyResponse is the response for GET
+// /api/v1/sleeper/trades/accuracy: how well trade-time market value
+// predicted each trade's winner, by the points its sides went on to score
+// and by what they're worth later_weeks after the trade and today.
+type TradeAccuracyResponse struct {
+	Segment        string               `json:"segment"`
+	Season         string               `json:"season"`
+	Trades         int                  `json:"trades"`
+	RealizedPoints TradeAccuracyMeasure `json:"realized_points"`
+	ValueLater     TradeAccuracyMeasure `json:"value_later"`
+	ValueNow       TradeAccuracyMeasure `json:"value_now"`
+}
+
+// loadTradeRetrospectives returns the trade_retrospectives rows for ids, by
+// trade ID. A failed lookup only leaves the hindsight fields empty.
+func loadTradeRetrospectives(ids []string) map[string]models.TradeRetrospective {
+	out := map[string]models.TradeRetrospective{}
+	if len(ids) == 0 {
+		return out
+	}
+	var rows []models.TradeRetrospective
+	if err := database.DB.Select("sleeper_transaction_id, values_now, values_later, realized_points, hindsight_gap").
+		Where("sleeper_transaction_id IN ?", ids).
+		Find(&rows).Error; err != nil {
+		slog.Error("Failed to fetch trade retrospectives", "error", err)
+		return out
+	}
+	for _, r := range rows {
+		out[r.SleeperTransactionID] = r
+	}
+	return out
+}
+
+// rosterTotal reads one roster's total out of a trade_values-shaped JSON
+// map, or nil when it's absent.
+func rosterTotal(totals json.RawMessage, rosterID int) *float64 {
+	if len(totals) == 0 {
+		return nil
+	}
+	var m map[string]float64
+	if err := json.Unmarshal(totals, &m); err != nil {
+		return nil
+	}
+	v, ok := m[strconv.Itoa(rosterID)]
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// leadingRoster returns the roster with the highest total in a
+// trade_values-shaped map, provided there are at least two sides and it
+// leads the next by more than valuation.FairnessTolerance when even is set,
+// or at all otherwise.
+func leadingRoster(totals json.RawMessage, even bool) (string, bool) {
+	var m map[string]float64
+	if len(totals) == 0 || json.Unmarshal(totals, &m) != nil || len(m) < 2 {
+		return "", false
+	}
+	type side struct {
+		roster string
+		total  float64
+	}
+	sides := make([]side, 0, len(m))
+	for roster, v := range m {
+		sides = append(sides, side{roster, v})
+	}
+	sort.Slice(sides, func(i, j int) bool { return sides[i].total > sides[j].total })
+	first, second := sides[0].total, sides[1].total
+	if first == second || (even && valuation.IsEven(first, second)) {
+		return "", false
+	}
+	return sides[0].roster, true
+}
+
+// GetSleeperTradeAccuracy measures how well trade-time market value
+// predicted outcomes across trade_retrospectives: for every trade whose
+// values at trade time favored one side (beyond valuation.FairnessTolerance),
+// how often that side went on to score more points, and to be worth more
+// later_weeks after the trade and today. Filters: segment (a valuation
+// segment; default all) and season (default the latest with
+// retrospectives).
+func GetSleeperTradeAccuracy(c *gin.Context) {
+	resp := TradeAccuracyResponse{Segment: c.Query("segment"), Season: c.Query("season")}
+	if resp.Season == "" {
+		var latest *string
+		if err := database.DB.Model(&models.TradeRetrospective{}).Select("MAX(season)").Scan(&latest).Error; err != nil {
+			slog.Error("Failed to fetch latest retrospective season", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to measure trade accuracy"})
+			return
+		}
+		if latest == nil {
+			c.JSON(http.StatusOK, resp)
+			return
+		}
+		resp.Season = *latest
+	}
+
+	q := database.DB.Model(&models.TradeRetrospective{}).
+		Select("values_at_trade, values_now, values_later, realized_points").
+		Where("season = ?", resp.Season)
+	if resp.Segment != "" {
+		q = q.Where("segment = ?", resp.Segment)
+	}
+	var rows []models.TradeRetrospective
+	if err := q.Find(&rows).Error; err != nil {
+		slog.Error("Failed to fetch trade retrospectives", "season", resp.Season, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to measure trade accuracy"})
+		return
+	}
+
+	var points, later, now, pointsHits, laterHits, nowHits int
+	tally := func(favorite string, outcome json.RawMessage, n, hits *int) {
+		if winner, ok := leadingRoster(outcome, false); ok {
+			*n++
+			if winner == favorite {
+				*hits++
+			}
+		}
+	}
+	for _, r := range rows {
+		resp.Trades++
+		favorite, ok := leadingRoster(r.ValuesAtTrade, true)
+		if !ok {
+			continue
+		}
+		tally(favorite, r.RealizedPoints, &points, &pointsHits)
+		tally(favorite, r.ValuesLater, &later, &laterHits)
+		tally(favorite, r.ValuesNow, &now, &nowHits)
+	}
+	resp.RealizedPoints = tradeAccuracyMeasure(points, pointsHits)
+	resp.ValueLater = tradeAccuracyMeasure(later, laterHits)
+	resp.ValueNow = tradeAccuracyMeasure(now, nowHits)
+	c.JSON(http.StatusOK, resp)
+}
+
+func tradeAccuracyMeasure(n, hits int) TradeAccuracyMeasure {
+	m := TradeAccuracyMeasure{Trades: n}
+	if n > 0 {
+		share := float64(hits) / float64(n)
+		m.Agreement = &share
+	}
+	return m
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/models"
+)
+
+// seedTradeRetrospectives seeds three trades in a 2025 ppr-sf-10 league, one
+// hour apart: "lopsided" and "close" with retrospectives (hindsight gaps 3000
+// and 200), and "pending" without one. Both retrospectives favored roster 1
+// at trade time; "lopsided" then went roster 2's way on every measure,
+// "close" only on value today.
+func seedTradeRetrospectives(t *testing.T) {
+	t.Helper()
+	db := newAdminTestDB(t)
+	withAdminTestDB(t, db)
+
+	ppr, sf := 1.0, true
+	db.Create(&models.SleeperLeague{
+		SleeperLeagueID: "lg1", Name: "Test League", Season: "2025",
+		PPR: &ppr, IsSuperflex: &sf, TotalRosters: 10, LeagueType: "redraft",
+	})
+	now := time.Now().UTC()
+	for i, id := range []string{"close", "lopsided", "pending"} {
+		db.Create(&models.SleeperTransaction{
+			SleeperTransactionID: id, SleeperLeagueID: "lg1", Type: "trade", Status: "complete",
+			CreatedAtSleeper: now.Add(-time.Duration(i) * time.Hour).UnixMilli(),
+			Adds:             json.RawMessage(`{"p1": 1, "p2": 2}`),
+			TradeValues:      json.RawMessage(`{"1": 5000, "2": 3000}`), TradeValuesComplete: true,
+		})
+	}
+	lopsidedGap, closeGap := 3000.0, 200.0
+	db.Create(&[]models.TradeRetrospective{
+		{
+			SleeperTransactionID: "lopsided", Segment: "ppr-sf-10", Season: "2025", Leg: 3, LaterWeeks: 4,
+			ValuesAtTrade:  json.RawMessage(`{"1": 5000, "2": 3000}`),
+			ValuesNow:      json.RawMessage(`{"1": 1000, "2": 4000}`),
+			ValuesLater:    json.RawMessage(`{"1": 2500, "2": 3500}`),
+			RealizedPoints: json.RawMessage(`{"1": 40, "2": 90}`),
+			HindsightGap:   &lopsidedGap,
+		},
+		{
+			SleeperTransactionID: "close", Segment: "ppr-sf-10", Season: "2025", Leg: 3, LaterWeeks: 4,
+			ValuesAtTrade:  json.RawMessage(`{"1": 5000, "2": 3000}`),
+			ValuesNow:      json.RawMessage(`{"1": 3000, "2": 3200}`),
+			RealizedPoints: json.RawMessage(`{"1": 80, "2": 50}`),
+			HindsightGap:   &closeGap,
+		},
+	})
+}
+
+func performGetSleeperTrades(t *testing.T, query string) SleeperTradesResponse {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sleeper/trades", GetSleeperTrades)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sleeper/trades"+query, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SleeperTradesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	return resp
+}
+
+func TestGetSleeperTrades_HindsightSortListsMostLopsidedFirst(t *testing.T) {
+	seedTradeRetrospectives(t)
+
+	resp := performGetSleeperTrades(t, "?sort=hindsight")
+	if resp.Total != 2 || len(resp.Trades) != 2 {
+		t.Fatalf("expected only the two trades with retrospectives, got total=%d %+v", resp.Total, resp.Trades)
+	}
+	if resp.Trades[0].ID != "lopsided" || resp.Trades[1].ID != "close" {
+		t.Errorf("expected lopsided before close, got %s, %s", resp.Trades[0].ID, resp.Trades[1].ID)
+	}
+	if gap := resp.Trades[0].HindsightGap; gap == nil || *gap != 3000 {
+		t.Errorf("expected hindsight_gap 3000, got %v", gap)
+	}
+}
+
+func TestGetSleeperTrades_SidesCarryHindsightValues(t *testing.T) {
+	seedTradeRetrospectives(t)
+
+	resp := performGetSleeperTrades(t, "")
+	if len(resp.Trades) != 3 || resp.Trades[0].ID != "close" {
+		t.Fatalf("expected all three trades newest first, got %+v", resp.Trades)
+	}
+	closeTrade, pending := resp.Trades[0], resp.Trades[2]
+	var side1, side2 *TradeSide
+	for i := range closeTrade.Sides {
+		switch s := &closeTrade.Sides[i]; s.RosterID {
+		case 1:
+			side1 = s
+		case 2:
+			side2 = s
+		}
+	}
+	if side1 == nil || side1.ValueNow == nil || *side1.ValueNow != 3000 || side1.RealizedPoints == nil || *side1.RealizedPoints != 80 {
+		t.Errorf("expected roster 1 worth 3000 now with 80 points, got %+v", side1)
+	}
+	if side2 == nil || side2.ValueLater != nil {
+		t.Errorf("expected roster 2 without a value_later yet, got %+v", side2)
+	}
+	if pending.HindsightGap != nil || pending.Sides[0].ValueNow != nil {
+		t.Errorf("expected no hindsight fields without a retrospective, got %+v", pending)
+	}
+}
+
+func TestGetSleeperTradeAccuracy_MeasuresAgreementWithTradeTimeFavorite(t *testing.T) {
+	seedTradeRetrospectives(t)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sleeper/trades/accuracy", GetSleeperTradeAccuracy)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sleeper/trades/accuracy", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp TradeAccuracyResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Season != "2025" || resp.Trades != 2 {
+		t.Fatalf("expected two 2025 trades, got %+v", resp)
+	}
+	for _, c := range []struct {
+		name   string
+		m      TradeAccuracyMeasure
+		trades int
+		want   float64
+	}{
+		{"realized_points", resp.RealizedPoints, 2, 0.5},
+		{"value_later", resp.ValueLater, 1, 0},
+		{"value_now", resp.ValueNow, 2, 0},
+	} {
+		if c.m.Trades != c.trades || c.m.Agreement == nil || *c.m.Agreement != c.want {
+			t.Errorf("%s: expected %d trades at %v agreement, got %+v", c.name, c.trades, c.want, c.m)
+		}
+	}
+}
@@ -0,0 +1,395 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"backend/internal/database"
+	"backend/internal/models"
+	"backend/internal/suggestioncron"
+	"backend/internal/valuation"
+)
+
+const (
+	// TradeSuggestionTTL is how long a team's suggestions stay current:
+	// valuations move daily, so a day-old run is recomputed on request.
+	TradeSuggestionTTL = 24 * time.Hour
+)
+
+// Suggestion request states, as reported by TradeSuggestionRequestState.
+const (
+	suggestionRequestNone     = "none"
+	suggestionRequestQueued   = "queued"
+	suggestionRequestRunning  = "running"
+	suggestionRequestFailed   = "failed"
+	suggestionRequestComplete = "complete"
+)
+
+// TradeSuggestionRequestState is where a team's suggestions stand: Stale
+// when there are none or they're older than TradeSuggestionTTL or the
+// league's latest roster change, and Status is the team's job queue entry
+// (none, queued, running, failed or complete).
+type TradeSuggestionRequestState struct {
+	SleeperLeagueID string     `json:"sleeper_league_id"`
+	RosterID        int        `json:"roster_id"`
+	GeneratedAt     *time.Time `json:"generated_at"`
+	Stale           bool       `json:"stale"`
+	Status          string     `json:"status"`
+	RequestedAt     *time.Time `json:"requested_at"`
+	Error           *string    `json:"error,omitempty"`
+}
+
+// TradeSuggestionPlayer is one player in a suggestion.
+type TradeSuggestionPlayer struct {
+	SleeperPlayerID string `json:"sleeper_player_id"`
+	Name            string `json:"name"`
+	Position        string `json:"position"`
+}
+
+// TradeSuggestionItem is one suggestion, from the requesting team's side:
+// it gives Give to the counterparty for Receive, each cutting its drops to
+// fit the roster cap (see models.TradeSuggestion for the deltas).
+// LineupEntered and LineupExited are the requesting team's starting lineup
+// changes.
+type TradeSuggestionItem struct {
+	ID                       uint                    `json:"id"`
+	CounterpartyRosterID     int                     `json:"counterparty_roster_id"`
+	CounterpartyOwnerName    string                  `json:"counterparty_owner_name"`
+	Rank                     int                     `json:"rank"`
+	Label                    string                  `json:"label"`
+	Give                     []TradeSuggestionPlayer `json:"give"`
+	Receive                  []TradeSuggestionPlayer `json:"receive"`
+	Drops                    []TradeSuggestionPlayer `json:"drops"`
+	CounterpartyDrops        []TradeSuggestionPlayer `json:"counterparty_drops"`
+	FairnessDelta            float64                 `json:"fairness_delta"`
+	UtilityDelta             float64                 `json:"utility_delta"`
+	CounterpartyUtilityDelta float64                 `json:"counterparty_utility_delta"`
+	LineupEntered            []TradeSuggestionPlayer `json:"lineup_entered"`
+	LineupExited             []TradeSuggestionPlayer `json:"lineup_exited"`
+	Segment                  string                  `json:"segment"`
+	ValuationDate            string                  `json:"valuation_date"`
+	GeneratedAt              time.Time               `json:"generated_at"`
+	Status                   string                  `json:"status"`
+	StatusUpdatedAt          *time.Time              `json:"status_updated_at"`
+}
+
+// TradeSuggestionsResponse is the response for GET
+// /api/v1/sleeper/leagues/:id/rosters/:rosterId/trade-suggestions.
+type TradeSuggestionsResponse struct {
+	Request     TradeSuggestionRequestState `json:"request"`
+	Suggestions []TradeSuggestionItem       `json:"suggestions"`
+}
+
+// loadSuggestionTeam resolves the :id league and :rosterId roster of a
+// suggestion request, writing the error response and returning false when
+// either is missing or the league's format has no valuation segment to
+// suggest from.
+func loadSuggestionTeam(c *gin.Context, db *gorm.DB) (string, int, bool) {
+	leagueID := c.Param("id")
+	rosterID, err := strconv.Atoi(c.Param("rosterId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid roster ID"})
+		return "", 0, false
+	}
+	var league models.SleeperLeague
+	if err := db.Where("sleeper_league_id = ?", leagueID).First(&league).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "League not found"})
+			return "", 0, false
+		}
+		slog.Error("Failed to fetch league for trade suggestions", "league", leagueID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trade suggestions"})
+		return "", 0, false
+	}
+	segment := valuation.SegmentKeyForLeague(league.PPR, league.IsSuperflex, league.TotalRosters, league.LeagueType)
+	if segment == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no valuation segment covers this league's format"})
+		return "", 0, false
+	}
+	if !hasPlayerValuations(segment) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no valuations for segment"})
+		return "", 0, false
+	}
+	var rosters int64
+	if err := db.Model(&models.SleeperRosterSnapshot{}).
+		Where("sleeper_league_id = ? AND roster_id = ?", leagueID, rosterID).
+		Count(&rosters).Error; err != nil {
+		slog.Error("Failed to fetch roster for trade suggestions", "league", leagueID, "roster", rosterID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trade suggestions"})
+		return "", 0, false
+	}
+	if rosters == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Roster not found"})
+		return "", 0, false
+	}
+	return leagueID, rosterID, true
+}
+
+// tradeSuggestionState reads a team's suggestion freshness and job queue
+// entry as of now.
+func tradeSuggestionState(db *gorm.DB, leagueID string, rosterID int, now time.Time) (TradeSuggestionRequestState, error) {
+	state := TradeSuggestionRequestState{SleeperLeagueID: leagueID, RosterID: rosterID, Status: suggestionRequestNone}
+	var generated []models.TradeSuggestion
+	if err := db.Select("generated_at").
+		Where("sleeper_league_id = ? AND roster_id = ?", leagueID, rosterID).
+		Order("generated_at DESC").
+		Limit(1).
+		Find(&generated).Error; err != nil {
+		return state, err
+	}
+	var rosterChanges []models.SleeperRosterSnapshot
+	if err := db.Select("taken_at").
+		Where("sleeper_league_id = ?", leagueID).
+		Order("taken_at DESC").
+		Limit(1).
+		Find(&rosterChanges).Error; err != nil {
+		return state, err
+	}
+	state.Stale = true
+	if len(generated) > 0 {
+		at := generated[0].GeneratedAt
+		state.GeneratedAt = &at
+		state.Stale = now.Sub(at) > TradeSuggestionTTL ||
+			(len(rosterChanges) > 0 && rosterChanges[0].TakenAt.After(at))
+	}
+
+	var requests []models.TradeSuggestionRequest
+	if err := db.Where("sleeper_league_id = ? AND roster_id = ?", leagueID, rosterID).
+		Limit(1).
+		Find(&requests).Error; err != nil {
+		return state, err
+	}
+	if len(requests) == 0 {
+		return state, nil
+	}
+	r := requests[0]
+	state.RequestedAt = &r.RequestedAt
+	switch {
+	case !r.Pending() && r.Error != nil:
+		state.Status, state.Error = suggestionRequestFailed, r.Error
+	case !r.Pending():
+		state.Status = suggestionRequestComplete
+	case r.ClaimedAt != nil && now.Sub(*r.ClaimedAt) <= suggestioncron.ClaimTimeout:
+		state.Status = suggestionRequestRunning
+	default:
+		state.Status = suggestionRequestQueued
+	}
+	return state, nil
+}
+
+// RequestTradeSuggestions handles POST
+// /api/v1/sleeper/leagues/:id/rosters/:rosterId/trade-suggestions: it
+// queues a suggestion run for the team when its suggestions are stale and
+// no run is already queued or running, responding 202 with the queue
+// state, or 200 when the current suggestions are fresh. The
+// trade-suggestions cron job (see package suggestioncron) runs the queue.
+func RequestTradeSuggestions(c *gin.Context) {
+	db := database.DB.WithContext(c.Request.Context())
+	leagueID, rosterID, ok := loadSuggestionTeam(c, db)
+	if !ok {
+		return
+	}
+	now := time.Now().UTC()
+	state, err := tradeSuggestionState(db, leagueID, rosterID, now)
+	if err != nil {
+		slog.Error("Failed to fetch trade suggestion state", "league", leagueID, "roster", rosterID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request trade suggestions"})
+		return
+	}
+	if !state.Stale {
+		c.JSON(http.StatusOK, state)
+		return
+	}
+	if state.Status == suggestionRequestQueued || state.Status == suggestionRequestRunning {
+		c.JSON(http.StatusAccepted, state)
+		return
+	}
+
+	request := models.TradeSuggestionRequest{SleeperLeagueID: leagueID, RosterID: rosterID, RequestedAt: now}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "sleeper_league_id"}, {Name: "roster_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"requested_at", "claimed_at", "error"}),
+	}).Create(&request).Error; err != nil {
+		slog.Error("Failed to queue trade suggestions", "league", leagueID, "roster", rosterID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request trade suggestions"})
+		return
+	}
+	state.Status, state.RequestedAt, state.Error = suggestionRequestQueued, &now, nil
+	c.JSON(http.StatusAccepted, state)
+}
+
+// GetTradeSuggestions handles GET
+// /api/v1/sleeper/leagues/:id/rosters/:rosterId/trade-suggestions: the
+// team's suggestions, newest run first and ranked within it, with the
+// fairness and lineup deltas the worker computed and the team's request
+// state. status filters to open (the default), dismissed, proposed or all.
+// Reading never queues a run; POST does.
+func GetTradeSuggestions(c *gin.Context) {
+	status := c.DefaultQuery("status", models.SuggestionOpen)
+	if status != "all" && !slices.Contains([]string{models.SuggestionOpen, models.SuggestionDismissed, models.SuggestionProposed}, status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be open, dismissed, proposed or all"})
+		return
+	}
+	db := database.DB.WithContext(c.Request.Context())
+	leagueID, rosterID, ok := loadSuggestionTeam(c, db)
+	if !ok {
+		return
+	}
+	state, err := tradeSuggestionState(db, leagueID, rosterID, time.Now().UTC())
+	if err != nil {
+		slog.Error("Failed to fetch trade suggestion state", "league", leagueID, "roster", rosterID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trade suggestions"})
+		return
+	}
+
+	q := db.Where("sleeper_league_id = ? AND roster_id = ?", leagueID, rosterID)
+	if status != "all" {
+		q = q.Where("status = ?", status)
+	}
+	var rows []models.TradeSuggestion
+	if err := q.Order("generated_at DESC, rank ASC").Find(&rows).Error; err != nil {
+		slog.Error("Failed to fetch trade suggestions", "league", leagueID, "roster", rosterID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trade suggestions"})
+		return
+	}
+	items, err := tradeSuggestionItems(db, leagueID, rows)
+	if err != nil {
+		slog.Error("Failed to resolve trade suggestion players", "league", leagueID, "roster", rosterID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trade suggestions"})
+		return
+	}
+	c.JSON(http.StatusOK, TradeSuggestionsResponse{Request: state, Suggestions: items})
+}
+
+// tradeSuggestionItems renders rows with their players' names and
+// positions and each counterparty's current owner.
+func tradeSuggestionItems(db *gorm.DB, leagueID string, rows []models.TradeSuggestion) ([]TradeSuggestionItem, error) {
+	decode := func(raw json.RawMessage) []string {
+		var ids []string
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &ids); err != nil {
+				slog.Warn("Ignoring unreadable trade suggestion player list", "league", leagueID, "error", err)
+			}
+		}
+		return ids
+	}
+	type lists struct{ give, receive, drops, counterpartyDrops, entered, exited []string }
+	decoded := make([]lists, len(rows))
+	idSet := map[string]struct{}{}
+	for i, r := range rows {
+		decoded[i] = lists{decode(r.Give), decode(r.Receive), decode(r.Drops), decode(r.CounterpartyDrops), decode(r.LineupEntered), decode(r.LineupExited)}
+		for _, ids := range [][]string{decoded[i].give, decoded[i].receive, decoded[i].drops, decoded[i].counterpartyDrops, decoded[i].entered, decoded[i].exited} {
+			for _, id := range ids {
+				idSet[id] = struct{}{}
+			}
+		}
+	}
+	ids := make([]string, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+	players, err := loadSuggestionPlayers(db, ids)
+	if err != nil {
+		return nil, err
+	}
+	owners, err := rosterOwnerNames(db, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	render := func(ids []string) []TradeSuggestionPlayer {
+		out := make([]TradeSuggestionPlayer, 0, len(ids))
+		for _, id := range ids {
+			out = append(out, TradeSuggestionPlayer{SleeperPlayerID: id, Name: players[id].FullName, Position: players[id].Position})
+		}
+		return out
+	}
+
+	items := make([]TradeSuggestionItem, 0, len(rows))
+	for i, r := range rows {
+		items = append(items, TradeSuggestionItem{
+			ID:                       r.ID,
+			CounterpartyRosterID:     r.CounterpartyRosterID,
+			CounterpartyOwnerName:    owners[r.CounterpartyRosterID],
+			Rank:                     r.Rank,
+			Label:                    r.Label,
+			Give:                     render(decoded[i].give),
+			Receive:                  render(decoded[i].receive),
+			Drops:                    render(decoded[i].drops),
+			CounterpartyDrops:        render(decoded[i].counterpartyDrops),
+			FairnessDelta:            r.FairnessDelta,
+			UtilityDelta:             r.UtilityDelta,
+			CounterpartyUtilityDelta: r.CounterpartyUtilityDelta,
+			LineupEntered:            render(decoded[i].entered),
+			LineupExited:             render(decoded[i].exited),
+			Segment:                  r.Segment,
+			ValuationDate:            r.ValuationDate.Format("2006-01-02"),
+			GeneratedAt:              r.GeneratedAt,
+			Status:                   r.Status,
+			StatusUpdatedAt:          r.StatusUpdatedAt,
+		})
+	}
+	return items, nil
+}
+
+// loadSuggestionPlayers returns the sleeper_players rows for ids, by ID.
+func loadSuggestionPlayers(db *gorm.DB, ids []string) (map[string]models.SleeperPlayer, error) {
+	out := map[string]models.SleeperPlayer{}
+	if len(ids) == 0 {
+		return out, nil
+	}
+	var players []models.SleeperPlayer
+	if err := db.Select("sleeper_player_id, full_name, position").
+		Where("sleeper_player_id IN ?", ids).
+		Find(&players).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range players {
+		out[p.SleeperPlayerID] = p
+	}
+	return out, nil
+}
+
+// rosterOwnerNames returns the display name of each roster's current owner
+// in a league, by roster ID, from its latest roster snapshot.
+func rosterOwnerNames(db *gorm.DB, leagueID string) (map[int]string, error) {
+	var snapshots []models.SleeperRosterSnapshot
+	if err := db.Select("roster_id, taken_at, owner_id").
+		Where("sleeper_league_id = ?", leagueID).
+		Order("taken_at ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	ownerOf := map[int]string{}
+	for _, s := range snapshots {
+		ownerOf[s.RosterID] = s.OwnerID
+	}
+	userIDs := make([]string, 0, len(ownerOf))
+	for _, id := range ownerOf {
+		userIDs = append(userIDs, id)
+	}
+	var users []models.SleeperUser
+	if len(userIDs) > 0 {
+		if err := db.Select("sleeper_user_id, display_name").
+			Where("sleeper_user_id IN ?", userIDs).
+			Find(&users).Error; err != nil {
+			return nil, err
+		}
+	}
+	names := make(map[string]string, len(users))
+	for _, u := range users {
+		names[u.SleeperUserID] = u.DisplayName
+	}
+	out := make(map[int]string, len(ownerOf))
+	for rosterID, userID := range ownerOf {
+		out[rosterID] = names[userID]
+	}
+	return out, nil
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+	"backend/internal/suggestioncron"
+	"backend/internal/valuation"
+)
+
+// seedTradeSuggestionsTestDB seeds a 10-team superflex PPR league, L1, whose
+// rosters 1 (u1) and 2 (u2, "Owner Two") were last snapshotted two days ago,
+// and a valuation in its segment.
+func seedTradeSuggestionsTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := newDraftADPTestDB(t)
+	if err := db.AutoMigrate(&models.SleeperLeague{}, &models.SleeperUser{}, &models.SleeperRosterSnapshot{}, &models.TradeSuggestion{}, &models.TradeSuggestionRequest{}, &valuation.Snapshot{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	withDraftADPTestDB(t, db)
+	seedADPPlayer(t, db, "p1", "Player One", "WR", "KC")
+	seedADPPlayer(t, db, "p2", "Player Two", "RB", "DAL")
+	seedADPPlayer(t, db, "p3", "Player Three", "QB", "BUF")
+	db.Create(&valuation.Snapshot{Segment: "ppr-sf-10", SleeperPlayerID: "p1", ValuationDate: time.Now().UTC(), Value: 4000})
+
+	ppr, sf := 1.0, true
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "L1", TotalRosters: 10, PPR: &ppr, IsSuperflex: &sf, LeagueType: "redraft"})
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "L2", TotalRosters: 10, LeagueType: "redraft"})
+	db.Create(&models.SleeperUser{SleeperUserID: "u2", DisplayName: "Owner Two"})
+	taken := time.Now().UTC().Add(-48 * time.Hour)
+	for _, s := range []models.SleeperRosterSnapshot{
+		{SleeperLeagueID: "L1", RosterID: 1, TakenAt: taken, OwnerID: "u1", Players: json.RawMessage(`["p1"]`)},
+		{SleeperLeagueID: "L1", RosterID: 2, TakenAt: taken, OwnerID: "u2", Players: json.RawMessage(`["p2","p3"]`)},
+		{SleeperLeagueID: "L2", RosterID: 1, TakenAt: taken, OwnerID: "u1", Players: json.RawMessage(`[]`)},
+	} {
+		if err := db.Create(&s).Error; err != nil {
+			t.Fatalf("seed snapshot: %v", err)
+		}
+	}
+	return db
+}
+
+func seedTradeSuggestion(t *testing.T, db *gorm.DB, rank int, status string, generatedAt time.Time) models.TradeSuggestion {
+	t.Helper()
+	s := models.TradeSuggestion{
+		SleeperLeagueID:          "L1",
+		RosterID:                 1,
+		CounterpartyRosterID:     2,
+		Segment:                  "ppr-sf-10",
+		ValuationDate:            generatedAt.Truncate(24 * time.Hour),
+		GeneratedAt:              generatedAt,
+		Rank:                     rank,
+		Label:                    "pareto",
+		Give:                     json.RawMessage(`["p1"]`),
+		Receive:                  json.RawMessage(`["p2","p3"]`),
+		Drops:                    json.RawMessage(`[]`),
+		CounterpartyDrops:        json.RawMessage(`[]`),
+		FairnessDelta:            -25,
+		UtilityDelta:             3.5,
+		CounterpartyUtilityDelta: 1.25,
+		LineupEntered:            json.RawMessage(`["p2","p3"]`),
+		LineupExited:             json.RawMessage(`["p1"]`),
+		Status:                   status,
+	}
+	if err := db.Create(&s).Error; err != nil {
+		t.Fatalf("seed suggestion: %v", err)
+	}
+	return s
+}
+
+func performTradeSuggestionsRequest(t *testing.T, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sleeper/leagues/:id/rosters/:rosterId/trade-suggestions", GetTradeSuggestions)
+	r.POST("/sleeper/leagues/:id/rosters/:rosterId/trade-suggestions", RequestTradeSuggestions)
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func decodeSuggestionState(t *testing.T, w *httptest.ResponseRecorder) TradeSuggestionRequestState {
+	t.Helper()
+	var state TradeSuggestionRequestState
+	if err := json.Unmarshal(w.Body.Bytes(), &state); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	return state
+}
+
+func TestRequestTradeSuggestions_QueuesOnlyWhenStale(t *testing.T) {
+	db := seedTradeSuggestionsTestDB(t)
+	const path = "/sleeper/leagues/L1/rosters/1/trade-suggestions"
+
+	w := performTradeSuggestionsRequest(t, http.MethodPost, path, "")
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	if state := decodeSuggestionState(t, w); state.Status != "queued" || !state.Stale || state.GeneratedAt != nil {
+		t.Errorf("expected a queued request for a team without suggestions, got %+v", state)
+	}
+	var request models.TradeSuggestionRequest
+	db.First(&request, "sleeper_league_id = ? AND roster_id = ?", "L1", 1)
+	requestedAt := request.RequestedAt
+
+	// A queued or running request isn't queued again.
+	claimed := time.Now().UTC()
+	db.Model(&models.TradeSuggestionRequest{}).Where("roster_id = ?", 1).Update("claimed_at", claimed)
+	w = performTradeSuggestionsRequest(t, http.MethodPost, path, "")
+	if state := decodeSuggestionState(t, w); w.Code != http.StatusAccepted || state.Status != "running" || !state.RequestedAt.Equal(requestedAt) {
+		t.Errorf("expected the running request unchanged, got %d %+v", w.Code, state)
+	}
+
+	// Once the run lands, its suggestions are fresh.
+	generated := time.Now().UTC()
+	seedTradeSuggestion(t, db, 1, models.SuggestionOpen, generated)
+	db.Model(&models.TradeSuggestionRequest{}).Where("roster_id = ?", 1).Update("completed_at", generated)
+	w = performTradeSuggestionsRequest(t, http.MethodPost, path, "")
+	if state := decodeSuggestionState(t, w); w.Code != http.StatusOK || state.Stale || state.Status != "complete" {
+		t.Errorf("expected fresh suggestions, got %d %+v", w.Code, state)
+	}
+
+	// A roster change anywhere in the league makes them stale again.
+	db.Create(&models.SleeperRosterSnapshot{SleeperLeagueID: "L1", RosterID: 2, TakenAt: generated.Add(time.Minute), OwnerID: "u2", Players: json.RawMessage(`["p2"]`)})
+	w = performTradeSuggestionsRequest(t, http.MethodPost, path, "")
+	if state := decodeSuggestionState(t, w); w.Code != http.StatusAccepted || state.Status != "queued" {
+		t.Errorf("expected a new request after a roster change, got %d %+v", w.Code, state)
+	}
+	db.First(&request, "sleeper_league_id = ? AND roster_id = ?", "L1", 1)
+	if request.ClaimedAt != nil || !request.RequestedAt.After(requestedAt) {
+		t.Errorf("expected the request re-queued and unclaimed, got %+v", request)
+	}
+}
+
+func TestRequestTradeSuggestions_RunByTheCronJob(t *testing.T) {
+	db := seedTradeSuggestionsTestDB(t)
+	if err := db.AutoMigrate(&models.SleeperPlayerWeekProjection{}, &models.SleeperWeekStatFetch{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	db.Model(&models.SleeperLeague{}).Where("sleeper_league_id = ?", "L1").
+		Updates(map[string]any{"season": "2025", "roster_positions": json.RawMessage(`["WR","RB","BN"]`)})
+	var p1 valuation.Snapshot
+	db.Where("sleeper_player_id = ?", "p1").First(&p1)
+	db.Create(&valuation.Snapshot{Segment: "ppr-sf-10", SleeperPlayerID: "p2", ValuationDate: p1.ValuationDate, Value: 3900})
+	for id, points := range map[string]float64{"p1": 10, "p2": 12, "p3": 20} {
+		db.Create(&models.SleeperPlayerWeekProjection{Season: "2025", Week: 1, SleeperPlayerID: id, PtsPPR: &points})
+	}
+	const path = "/sleeper/leagues/L1/rosters/1/trade-suggestions"
+
+	if w := performTradeSuggestionsRequest(t, http.MethodPost, path, ""); w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	report, err := suggestioncron.RunSuggestions(context.Background(), db)
+	if err != nil || report.Completed != 1 {
+		t.Fatalf("expected the queued request run, got %+v, %v", report, err)
+	}
+
+	// p1 for p2 fills roster 1's empty RB slot: +2 for roster 1, -2 for
+	// roster 2, whose p3 has no QB slot to start in.
+	w := performTradeSuggestionsRequest(t, http.MethodGet, path, "")
+	var resp TradeSuggestionsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Request.Status != "complete" || resp.Request.Stale {
+		t.Errorf("expected a completed request and fresh suggestions, got %+v", resp.Request)
+	}
+	if len(resp.Suggestions) == 0 {
+		t.Fatal("expected suggestions")
+	}
+	s := resp.Suggestions[0]
+	if s.Rank != 1 || s.Label != suggestioncron.LabelOneSided || len(s.Give) != 1 || s.Give[0].Name != "Player One" ||
+		len(s.Receive) != 1 || s.Receive[0].Name != "Player Two" || s.UtilityDelta != 2 || s.CounterpartyUtilityDelta != -2 {
+		t.Errorf("expected p1 for p2 first, got %+v", s)
+	}
+}
+
+func TestGetTradeSuggestions_ListsOpenSuggestionsWithPlayers(t *testing.T) {
+	db := seedTradeSuggestionsTestDB(t)
+	now := time.Now().UTC()
+	seedTradeSuggestion(t, db, 2, models.SuggestionOpen, now)
+	seedTradeSuggestion(t, db, 1, models.SuggestionOpen, now)
+	seedTradeSuggestion(t, db, 1, models.SuggestionDismissed, now.Add(-72*time.Hour))
+
+	w := performTradeSuggestionsRequest(t, http.MethodGet, "/sleeper/leagues/L1/rosters/1/trade-suggestions", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp TradeSuggestionsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Request.Stale || resp.Request.Status != "none" {
+		t.Errorf("expected fresh suggestions and no request, got %+v", resp.Request)
+	}
+	if len(resp.Suggestions) != 2 || resp.Suggestions[0].Rank != 1 {
+		t.Fatalf("expected the two open suggestions in rank order, got %+v", resp.Suggestions)
+	}
+	s := resp.Suggestions[0]
+	if s.CounterpartyOwnerName != "Owner Two" || s.FairnessDelta != -25 || s.UtilityDelta != 3.5 || s.CounterpartyUtilityDelta != 1.25 {
+		t.Errorf("expected the counterparty and deltas, got %+v", s)
+	}
+	if len(s.Receive) != 2 || s.Receive[1].Name != "Player Three" || s.Receive[1].Position != "QB" || len(s.Drops) != 0 || s.LineupExited[0].SleeperPlayerID != "p1" {
+		t.Errorf("expected resolved players, got %+v", s)
+	}
+
+	w = performTradeSuggestionsRequest(t, http.MethodGet, "/sleeper/leagues/L1/rosters/1/trade-suggestions?status=all", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Suggestions) != 3 {
+		t.Errorf("expected all three suggestions, got %+v", resp.Suggestions)
+	}
+}
+
+func TestGetTradeSuggestions_Errors(t *testing.T) {
+	seedTradeSuggestionsTestDB(t)
+
+	for _, tc := range []struct {
+		path string
+		want int
+	}{
+		{"/sleeper/leagues/L1/rosters/1/trade-suggestions?status=stale", http.StatusBadRequest},
+		{"/sleeper/leagues/L1/rosters/x/trade-suggestions", http.StatusBadRequest},
+		{"/sleeper/leagues/L2/rosters/1/trade-suggestions", http.StatusBadRequest}, // no valuation segment
+		{"/sleeper/leagues/L1/rosters/9/trade-suggestions", http.StatusNotFound},
+		{"/sleeper/leagues/missing/rosters/1/trade-suggestions", http.StatusNotFound},
+	} {
+		if w := performTradeSuggestionsRequest(t, http.MethodGet, tc.path, ""); w.Code != tc.want {
+			t.Errorf("%s: expected %d, got %d", tc.path, tc.want, w.Code)
+		}
+	}
+}
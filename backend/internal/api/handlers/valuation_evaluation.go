@@ -0,0 +1,360 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/database"
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+const (
+	// defaultEvaluationWeeks is how many weeks after each snapshot its
+	// projection is scored against by default; maxEvaluationWeeks caps it.
+	defaultEvaluationWeeks = 4
+	maxEvaluationWeeks     = 17
+	// evaluationLastWeek is the last week of the season realized points
+	// are drawn from.
+	evaluationLastWeek = 18
+)
+
+// ValuationEvaluationSummary is how one group of player_valuations
+// snapshots' projected_par compared with the PAR realized over the
+// following weeks. MAE is the mean absolute error and Bias the mean
+// projected less realized, in weekly points above replacement; Coverage
+// and Coverage2x are the shares realized within one and two
+// projection_uncertainty bands — a calibrated band covers about 68% and
+// 95%. All four are nil when Evaluations is 0.
+type ValuationEvaluationSummary struct {
+	Evaluations int      `json:"evaluations"`
+	MAE         *float64 `json:"mae"`
+	Bias        *float64 `json:"bias"`
+	Coverage    *float64 `json:"coverage"`
+	Coverage2x  *float64 `json:"coverage_2x"`
+}
+
+// ValuationEvaluationSegment is the summary for one valuation segment.
+type ValuationEvaluationSegment struct {
+	Segment string `json:"segment"`
+	ValuationEvaluationSummary
+}
+
+// ValuationEvaluationPosition is the summary for one position.
+type ValuationEvaluationPosition struct {
+	Position string `json:"position"`
+	ValuationEvaluationSummary
+}
+
+// ValuationEvaluationWeek is the summary for the snapshots taken after
+// Week's scores landed, before the next week's (week 0 is preseason).
+type ValuationEvaluationWeek struct {
+	Week int `json:"week"`
+	ValuationEvaluationSummary
+}
+
+// ValuationEvaluationResponse is the response for GET
+// /api/v1/players/valuation-evaluation.
+type ValuationEvaluationResponse struct {
+	Season     string                        `json:"season"`
+	Weeks      int                           `json:"weeks"`
+	Overall    ValuationEvaluationSummary    `json:"overall"`
+	BySegment  []ValuationEvaluationSegment  `json:"by_segment"`
+	ByPosition []ValuationEvaluationPosition `json:"by_position"`
+	ByWeek     []ValuationEvaluationWeek     `json:"by_week"`
+}
+
+// evaluationWeekScore is one player's points in one finalized week, under
+// each scoring format.
+type evaluationWeekScore struct {
+	Week            int      `gorm:"column:week"`
+	SleeperPlayerID string   `gorm:"column:sleeper_player_id"`
+	Position        string   `gorm:"column:position"`
+	PtsPPR          *float64 `gorm:"column:pts_ppr"`
+	PtsHalfPPR      *float64 `gorm:"column:pts_half_ppr"`
+	PtsStd          *float64 `gorm:"column:pts_std"`
+}
+
+// points returns the row's points in column (valuation.Segment.PointsColumn),
+// nil when it has none.
+func (r evaluationWeekScore) points(column string) *float64 {
+	switch column {
+	case "pts_half_ppr":
+		return r.PtsHalfPPR
+	case "pts_std":
+		return r.PtsStd
+	}
+	return r.PtsPPR
+}
+
+// evaluationScores is one scoring format's finalized week points: by week
+// for replacement levels, and by player and week for realized PAR.
+type evaluationScores struct {
+	byWeek   map[int][]valuation.WeekScore
+	byPlayer map[string]map[int]float64
+}
+
+// newEvaluationScores collects rows' points in column.
+func newEvaluationScores(rows []evaluationWeekScore, column string) evaluationScores {
+	s := evaluationScores{byWeek: map[int][]valuation.WeekScore{}, byPlayer: map[string]map[int]float64{}}
+	for _, r := range rows {
+		pts := r.points(column)
+		if pts == nil {
+			continue
+		}
+		s.byWeek[r.Week] = append(s.byWeek[r.Week], valuation.WeekScore{SleeperPlayerID: r.SleeperPlayerID, Position: r.Position, Points: *pts})
+		if s.byPlayer[r.SleeperPlayerID] == nil {
+			s.byPlayer[r.SleeperPlayerID] = map[int]float64{}
+		}
+		s.byPlayer[r.SleeperPlayerID][r.Week] = *pts
+	}
+	return s
+}
+
+// GetValuationEvaluation scores the valuation model's projections in
+// hindsight. For each week of a season it takes each segment's last
+// snapshot before the next week's scores landed, and compares every
+// player's projected_par with the mean weekly PAR they realized over the
+// following weeks (default 4, at most 17), scored the way the segment
+// scores — replacement level set per week and position the way the model
+// sets it (see
+// valuation.Segment.ReplacementLevels). Only weeks whose whole horizon is
+// finalized are scored, and a player who didn't score in it is skipped.
+// Results are reported overall and by segment, position and week.
+// Filters: season (default the latest with finalized weeks) and segment
+// (default every valued segment).
+func GetValuationEvaluation(c *gin.Context) {
+	weeks := defaultEvaluationWeeks
+	if w := c.Query("weeks"); w != "" {
+		n, err := strconv.Atoi(w)
+		if err != nil || n < 1 || n > maxEvaluationWeeks {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "weeks must be between 1 and " + strconv.Itoa(maxEvaluationWeeks)})
+			return
+		}
+		weeks = n
+	}
+	var segments []string
+	if s := c.Query("segment"); s != "" {
+		if _, ok := valuation.ParseSegment(s); !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unknown segment"})
+			return
+		}
+		segments = []string{s}
+	}
+	db := database.DB.WithContext(c.Request.Context())
+	resp := ValuationEvaluationResponse{
+		Season:     c.Query("season"),
+		Weeks:      weeks,
+		BySegment:  []ValuationEvaluationSegment{},
+		ByPosition: []ValuationEvaluationPosition{},
+		ByWeek:     []ValuationEvaluationWeek{},
+	}
+	if resp.Season == "" {
+		var seasons []string
+		if err := db.Model(&models.SleeperWeekStatFetch{}).
+			Distinct("season").
+			Where("finalized = ?", true).
+			Order("season DESC").
+			Pluck("season", &seasons).Error; err != nil {
+			slog.Error("Failed to fetch finalized seasons", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate valuations"})
+			return
+		}
+		for _, s := range seasons {
+			if _, ok := valuation.Seasons[s]; ok {
+				resp.Season = s
+				break
+			}
+		}
+		if resp.Season == "" {
+			c.JSON(http.StatusOK, resp)
+			return
+		}
+	}
+	season, ok := valuation.Seasons[resp.Season]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no valuation calendar for season"})
+		return
+	}
+	if segments == nil {
+		var err error
+		if segments, err = valuation.ValuedSegments(db); err != nil {
+			slog.Error("Failed to fetch valued segments", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate valuations"})
+			return
+		}
+	}
+
+	var finalized []int
+	if err := db.Model(&models.SleeperWeekStatFetch{}).
+		Where("season = ? AND finalized = ?", resp.Season, true).
+		Pluck("week", &finalized).Error; err != nil {
+		slog.Error("Failed to fetch finalized weeks", "season", resp.Season, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate valuations"})
+		return
+	}
+	var rows []evaluationWeekScore
+	if len(finalized) > 0 {
+		if err := db.Table("sleeper_player_week_stats s").
+			Select("s.week, s.sleeper_player_id, p.position, s.pts_ppr, s.pts_half_ppr, s.pts_std").
+			Joins("JOIN sleeper_players p ON p.sleeper_player_id = s.sleeper_player_id").
+			Where("s.season = ? AND s.week IN ?", resp.Season, finalized).
+			Scan(&rows).Error; err != nil {
+			slog.Error("Failed to fetch week points for valuation evaluation", "season", resp.Season, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate valuations"})
+			return
+		}
+	}
+	positions := map[string]string{}
+	for _, r := range rows {
+		positions[r.SleeperPlayerID] = r.Position
+	}
+	scoresByColumn := map[string]evaluationScores{}
+
+	// Origin weeks: those whose whole horizon is finalized.
+	var origins []int
+	for w := 0; w+weeks <= evaluationLastWeek; w++ {
+		complete := true
+		for h := w + 1; h <= w+weeks; h++ {
+			if !slices.Contains(finalized, h) {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			origins = append(origins, w)
+		}
+	}
+	if len(origins) == 0 {
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	var overall valuation.Evaluation
+	bySegment := map[string]*valuation.Evaluation{}
+	byPosition := map[string]*valuation.Evaluation{}
+	byWeek := map[int]*valuation.Evaluation{}
+	add := func(m map[string]*valuation.Evaluation, key string, projected, uncertainty, realized float64) {
+		if m[key] == nil {
+			m[key] = &valuation.Evaluation{}
+		}
+		m[key].Add(projected, uncertainty, realized)
+	}
+	// Segments of the same size, scoring and QB format share replacement
+	// levels.
+	replacements := map[valuation.Segment]map[int]map[string]float64{}
+	for _, key := range segments {
+		seg, ok := valuation.ParseSegment(key)
+		if !ok {
+			continue
+		}
+		column := seg.PointsColumn()
+		scores, ok := scoresByColumn[column]
+		if !ok {
+			scores = newEvaluationScores(rows, column)
+			scoresByColumn[column] = scores
+		}
+		levelKey := valuation.Segment{LeagueSize: seg.LeagueSize, ScoringFormat: seg.ScoringFormat, Superflex: seg.Superflex}
+		replacement, ok := replacements[levelKey]
+		if !ok {
+			replacement = map[int]map[string]float64{}
+			for w, s := range scores.byWeek {
+				replacement[w] = seg.ReplacementLevels(s)
+			}
+			replacements[levelKey] = replacement
+		}
+
+		var dates []time.Time
+		if err := db.Table("player_valuations").
+			Distinct("valuation_date").
+			Where("segment = ? AND valuation_date >= ? AND valuation_date < ?", key, season.DraftDate, season.WeekScored(origins[len(origins)-1]+1)).
+			Order("valuation_date ASC").
+			Pluck("valuation_date", &dates).Error; err != nil {
+			slog.Error("Failed to fetch valuation dates for evaluation", "segment", key, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate valuations"})
+			return
+		}
+		// Each origin week's snapshot is the segment's last before the next
+		// week's scores landed.
+		originOf := map[string]int{}
+		var sampled []time.Time
+		for _, w := range origins {
+			from, until := season.DraftDate, season.WeekScored(w+1)
+			if w > 0 {
+				from = season.WeekScored(w)
+			}
+			i := sort.Search(len(dates), func(i int) bool { return !dates[i].Before(until) })
+			if i > 0 && !dates[i-1].Before(from) {
+				sampled = append(sampled, dates[i-1])
+				originOf[dates[i-1].Format("2006-01-02")] = w
+			}
+		}
+		if len(sampled) == 0 {
+			continue
+		}
+
+		var snaps []valuation.Snapshot
+		if err := db.Table("player_valuations").
+			Select("sleeper_player_id, valuation_date, position, projected_par, projection_uncertainty").
+			Where("segment = ? AND valuation_date IN ? AND projected_par IS NOT NULL AND projection_uncertainty IS NOT NULL", key, sampled).
+			Scan(&snaps).Error; err != nil {
+			slog.Error("Failed to fetch valuations for evaluation", "segment", key, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate valuations"})
+			return
+		}
+		for _, s := range snaps {
+			w := originOf[s.ValuationDate.Format("2006-01-02")]
+			horizon := make([]int, 0, weeks)
+			for h := w + 1; h <= w+weeks; h++ {
+				horizon = append(horizon, h)
+			}
+			pos := s.Position
+			if pos == "" {
+				pos = positions[s.SleeperPlayerID]
+			}
+			realized, ok := valuation.RealizedPAR(scores.byPlayer[s.SleeperPlayerID], replacement, pos, horizon)
+			if !ok {
+				continue
+			}
+			overall.Add(*s.ProjectedPAR, *s.ProjectionUncertainty, realized)
+			add(bySegment, key, *s.ProjectedPAR, *s.ProjectionUncertainty, realized)
+			add(byPosition, pos, *s.ProjectedPAR, *s.ProjectionUncertainty, realized)
+			if byWeek[w] == nil {
+				byWeek[w] = &valuation.Evaluation{}
+			}
+			byWeek[w].Add(*s.ProjectedPAR, *s.ProjectionUncertainty, realized)
+		}
+	}
+
+	resp.Overall = valuationEvaluationSummary(overall)
+	for key, e := range bySegment {
+		resp.BySegment = append(resp.BySegment, ValuationEvaluationSegment{Segment: key, ValuationEvaluationSummary: valuationEvaluationSummary(*e)})
+	}
+	sort.Slice(resp.BySegment, func(i, j int) bool { return resp.BySegment[i].Segment < resp.BySegment[j].Segment })
+	for pos, e := range byPosition {
+		resp.ByPosition = append(resp.ByPosition, ValuationEvaluationPosition{Position: pos, ValuationEvaluationSummary: valuationEvaluationSummary(*e)})
+	}
+	sort.Slice(resp.ByPosition, func(i, j int) bool { return resp.ByPosition[i].Position < resp.ByPosition[j].Position })
+	for w, e := range byWeek {
+		resp.ByWeek = append(resp.ByWeek, ValuationEvaluationWeek{Week: w, ValuationEvaluationSummary: valuationEvaluationSummary(*e)})
+	}
+	sort.Slice(resp.ByWeek, func(i, j int) bool { return resp.ByWeek[i].Week < resp.ByWeek[j].Week })
+	c.JSON(http.StatusOK, resp)
+}
+
+func valuationEvaluationSummary(e valuation.Evaluation) ValuationEvaluationSummary {
+	s := ValuationEvaluationSummary{Evaluations: e.Count}
+	if e.Count > 0 {
+		n := float64(e.Count)
+		mae, bias := e.SumAbsError/n, e.SumError/n
+		coverage, coverage2x := float64(e.Within1)/n, float64(e.Within2)/n
+		s.MAE, s.Bias, s.Coverage, s.Coverage2x = &mae, &bias, &coverage, &coverage2x
+	}
+	return s
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/database"
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+// seedValuationEvaluation seeds 2025 weeks 1-3 as finalized (week 4 has
+// points but isn't) for WRs a and b and QBs q and r, and ppr-sf-8
+// snapshots: a preseason pair (2025-09-01, superseded by 2025-09-05) and
+// one after week 1 (2025-09-10). Few enough players score that every
+// week's replacement level is the worst score at the position: b at WR, r
+// at QB.
+func seedValuationEvaluation(t *testing.T) {
+	t.Helper()
+	db := newDraftADPTestDB(t)
+	if err := db.AutoMigrate(&models.SleeperWeekStatFetch{}, &models.SleeperPlayerWeekStat{}, &valuation.Snapshot{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	withDraftADPTestDB(t, db)
+	seedADPPlayer(t, db, "a", "Receiver A", "WR", "KC")
+	seedADPPlayer(t, db, "b", "Receiver B", "WR", "DAL")
+	seedADPPlayer(t, db, "q", "Quarterback Q", "QB", "BUF")
+	seedADPPlayer(t, db, "r", "Quarterback R", "QB", "MIA")
+
+	for week := 1; week <= 4; week++ {
+		db.Create(&models.SleeperWeekStatFetch{Season: "2025", Week: week, Finalized: week <= 3})
+	}
+	for _, p := range []struct {
+		id     string
+		points map[int]float64
+	}{
+		{"a", map[int]float64{1: 20, 2: 14, 3: 16, 4: 99}},
+		{"b", map[int]float64{1: 10, 2: 8, 3: 12, 4: 0}},
+		{"q", map[int]float64{1: 25}},
+		{"r", map[int]float64{1: 15, 2: 15, 3: 15}},
+	} {
+		for week, pts := range p.points {
+			if err := db.Create(&models.SleeperPlayerWeekStat{Season: "2025", Week: week, SleeperPlayerID: p.id, PtsPPR: &pts}).Error; err != nil {
+				t.Fatalf("seed week stat: %v", err)
+			}
+		}
+	}
+
+	f := func(v float64) *float64 { return &v }
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	for _, s := range []valuation.Snapshot{
+		{Segment: "ppr-sf-8", SleeperPlayerID: "a", ValuationDate: day(9, 1), Position: "WR", ProjectedPAR: f(100), ProjectionUncertainty: f(1)},
+		{Segment: "ppr-sf-8", SleeperPlayerID: "a", ValuationDate: day(9, 5), Position: "WR", ProjectedPAR: f(9), ProjectionUncertainty: f(2)},
+		{Segment: "ppr-sf-8", SleeperPlayerID: "b", ValuationDate: day(9, 5), Position: "WR", ProjectedPAR: f(3), ProjectionUncertainty: f(1)},
+		{Segment: "ppr-sf-8", SleeperPlayerID: "q", ValuationDate: day(9, 5), ProjectedPAR: f(4), ProjectionUncertainty: f(4)},
+		{Segment: "ppr-sf-8", SleeperPlayerID: "a", ValuationDate: day(9, 10), Position: "WR", ProjectedPAR: f(5), ProjectionUncertainty: f(1)},
+		{Segment: "ppr-sf-8", SleeperPlayerID: "b", ValuationDate: day(9, 10), Position: "WR"},
+		{Segment: "ppr-sf-8", SleeperPlayerID: "q", ValuationDate: day(9, 10), Position: "QB", ProjectedPAR: f(2), ProjectionUncertainty: f(1)},
+		{Segment: "ppr-sf-10", SleeperPlayerID: "a", ValuationDate: day(9, 5), Position: "WR", ProjectedPAR: f(8), ProjectionUncertainty: f(1)},
+	} {
+		if err := db.Create(&s).Error; err != nil {
+			t.Fatalf("seed valuation: %v", err)
+		}
+	}
+}
+
+func performGetValuationEvaluation(t *testing.T, query string) (*httptest.ResponseRecorder, ValuationEvaluationResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/players/valuation-evaluation", GetValuationEvaluation)
+
+	req := httptest.NewRequest(http.MethodGet, "/players/valuation-evaluation"+query, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp ValuationEvaluationResponse
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+func TestGetValuationEvaluation_ScoresProjectionsAgainstRealizedPAR(t *testing.T) {
+	seedValuationEvaluation(t)
+
+	w, resp := performGetValuationEvaluation(t, "?segment=ppr-sf-8&weeks=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Season != "2025" || resp.Weeks != 2 {
+		t.Errorf("expected the latest finalized season over 2 weeks, got %+v", resp)
+	}
+	// Preseason (weeks 1-2): a realized 8 against 9, b 0 against 3, q 10
+	// (week 1 only) against 4. After week 1 (weeks 2-3): a realized 5
+	// against 5; q didn't score. Weeks 3-4 aren't all finalized.
+	o := resp.Overall
+	if o.Evaluations != 4 || math.Abs(*o.MAE-2.5) > 1e-9 || math.Abs(*o.Bias+0.5) > 1e-9 {
+		t.Errorf("expected 4 evaluations, MAE 2.5 and bias -0.5, got %+v", o)
+	}
+	if *o.Coverage != 0.5 || *o.Coverage2x != 0.75 {
+		t.Errorf("expected coverage 0.5 and 0.75, got %v %v", *o.Coverage, *o.Coverage2x)
+	}
+	if len(resp.ByWeek) != 2 || resp.ByWeek[0].Week != 0 || resp.ByWeek[0].Evaluations != 3 || resp.ByWeek[1].Evaluations != 1 || *resp.ByWeek[1].MAE != 0 {
+		t.Errorf("expected preseason and week 1 results, got %+v", resp.ByWeek)
+	}
+	if len(resp.ByPosition) != 2 || resp.ByPosition[0].Position != "QB" || resp.ByPosition[0].Evaluations != 1 || resp.ByPosition[1].Evaluations != 3 {
+		t.Errorf("expected one QB and three WR evaluations, got %+v", resp.ByPosition)
+	}
+	if len(resp.BySegment) != 1 || resp.BySegment[0].Segment != "ppr-sf-8" {
+		t.Errorf("expected only ppr-sf-8, got %+v", resp.BySegment)
+	}
+}
+
+func TestGetValuationEvaluation_DefaultsToEveryValuedSegment(t *testing.T) {
+	seedValuationEvaluation(t)
+
+	_, resp := performGetValuationEvaluation(t, "?weeks=2")
+	if len(resp.BySegment) != 2 || resp.BySegment[0].Segment != "ppr-sf-10" || resp.BySegment[0].Evaluations != 1 {
+		t.Errorf("expected both valued segments, got %+v", resp.BySegment)
+	}
+	// With the default 4-week horizon no origin week is fully finalized.
+	if _, resp = performGetValuationEvaluation(t, ""); resp.Overall.Evaluations != 0 || resp.Overall.MAE != nil {
+		t.Errorf("expected no evaluations, got %+v", resp.Overall)
+	}
+}
+
+func TestGetValuationEvaluation_ScoresEachSegmentsOwnScoring(t *testing.T) {
+	seedValuationEvaluation(t)
+	db := database.DB
+	for _, p := range []struct {
+		id     string
+		week   int
+		points float64
+	}{{"a", 1, 15}, {"a", 2, 11}, {"b", 1, 8}, {"b", 2, 6}} {
+		db.Model(&models.SleeperPlayerWeekStat{}).
+			Where("season = ? AND week = ? AND sleeper_player_id = ?", "2025", p.week, p.id).
+			Update("pts_half_ppr", p.points)
+	}
+	db.Create(&valuation.Snapshot{Segment: "half_ppr-sf-8", SleeperPlayerID: "a", ValuationDate: time.Date(2025, 9, 5, 0, 0, 0, 0, time.UTC),
+		Position: "WR", ProjectedPAR: func(v float64) *float64 { return &v }(6), ProjectionUncertainty: func(v float64) *float64 { return &v }(1)})
+
+	// Half-PPR, a realized (7 + 5) / 2 = 6 over b in weeks 1-2: exactly
+	// the projection. Under PPR it would have been 8.
+	_, resp := performGetValuationEvaluation(t, "?segment=half_ppr-sf-8&weeks=2")
+	if o := resp.Overall; o.Evaluations != 1 || o.MAE == nil || *o.MAE != 0 {
+		t.Errorf("expected a's half-PPR projection scored exactly, got %+v", o)
+	}
+}
+
+func TestGetValuationEvaluation_RejectsBadFilters(t *testing.T) {
+	seedValuationEvaluation(t)
+
+	for _, q := range []string{"?weeks=0", "?weeks=18", "?segment=nope", "?season=1999"} {
+		if w, _ := performGetValuationEvaluation(t, q); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", q, w.Code)
+		}
+	}
+}
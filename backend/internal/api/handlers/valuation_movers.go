@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/database"
+	"backend/internal/models"
+	"backend/internal/valuation"
+	"backend/internal/valuationcron"
+)
+
+// defaultMoversLimit is how many risers and fallers GET /players/movers
+// returns per position when limit isn't given.
+const defaultMoversLimit = 10
+
+// PlayerMoverItem is one player's market_score move over the response's
+// window. Rank is 1-based within the player's position and direction;
+// ZScore is ScoreChange in units of MarketDispersion, nil when that's
+// unknown.
+type PlayerMoverItem struct {
+	Rank             int      `json:"rank"`
+	SleeperPlayerID  string   `json:"sleeper_player_id"`
+	Name             string   `json:"name"`
+	Position         string   `json:"position"`
+	NflTeam          string   `json:"nfl_team"`
+	MarketScoreFrom  float64  `json:"market_score_from"`
+	MarketScoreTo    float64  `json:"market_score_to"`
+	ScoreChange      float64  `json:"score_change"`
+	MarketDispersion *float64 `json:"market_dispersion"`
+	ZScore           *float64 `json:"z_score"`
+	ValueFrom        *float64 `json:"value_from"`
+	ValueTo          *float64 `json:"value_to"`
+}
+
+// PlayerMoversResponse is the response for GET /api/v1/players/movers.
+// FromDate and ValuationDate are the snapshots compared; they and
+// ComputedAt are empty/nil before the valuation-movers job's first run.
+type PlayerMoversResponse struct {
+	Segment       string            `json:"segment"`
+	Window        string            `json:"window"`
+	FromDate      string            `json:"from_date"`
+	ValuationDate string            `json:"valuation_date"`
+	ComputedAt    *time.Time        `json:"computed_at"`
+	Risers        []PlayerMoverItem `json:"risers"`
+	Fallers       []PlayerMoverItem `json:"fallers"`
+}
+
+type playerMoverRow struct {
+	models.ValuationMover
+	Name    string `gorm:"column:full_name"`
+	NflTeam string `gorm:"column:nfl_team"`
+}
+
+// GetPlayerMovers handles GET /api/v1/players/movers: each position's
+// biggest market_score risers and fallers in one valuation segment, from the
+// daily valuation-movers job. Supports query filters: segment (default
+// defaultPlayerValuationSegment), window (1d|7d|30d, default 7d), position,
+// direction (riser|faller, default both) and limit, the number kept per
+// position and direction (default defaultMoversLimit, at most
+// valuationcron.MoverLimit).
+func GetPlayerMovers(c *gin.Context) {
+	segment := c.DefaultQuery("segment", defaultPlayerValuationSegment)
+	if _, ok := valuation.ParseSegment(segment); !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown valuation segment"})
+		return
+	}
+	window := c.DefaultQuery("window", "7d")
+	if _, ok := models.MoverWindows[window]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "window must be 1d, 7d or 30d"})
+		return
+	}
+	direction := c.Query("direction")
+	if direction != "" && direction != models.MoverRiser && direction != models.MoverFaller {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "direction must be riser or faller"})
+		return
+	}
+	limit := defaultMoversLimit
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= valuationcron.MoverLimit {
+		limit = l
+	}
+
+	db := database.DB.WithContext(c.Request.Context()).Table("valuation_movers m").
+		Joins("LEFT JOIN sleeper_players p ON p.sleeper_player_id = m.sleeper_player_id").
+		Where("m.segment = ? AND m.time_window = ? AND m.rank <= ?", segment, window, limit)
+	if position := c.Query("position"); position != "" {
+		db = db.Where("m.position = ?", position)
+	}
+	if direction != "" {
+		db = db.Where("m.direction = ?", direction)
+	}
+	var rows []playerMoverRow
+	if err := db.Select("m.*, p.full_name, p.nfl_team").
+		Order("m.position ASC, m.rank ASC").
+		Scan(&rows).Error; err != nil {
+		slog.Error("Failed to fetch player movers", "segment", segment, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch player movers"})
+		return
+	}
+
+	response := PlayerMoversResponse{
+		Segment: segment,
+		Window:  window,
+		Risers:  []PlayerMoverItem{},
+		Fallers: []PlayerMoverItem{},
+	}
+	for _, r := range rows {
+		item := PlayerMoverItem{
+			Rank:             r.Rank,
+			SleeperPlayerID:  r.SleeperPlayerID,
+			Name:             r.Name,
+			Position:         r.Position,
+			NflTeam:          r.NflTeam,
+			MarketScoreFrom:  r.MarketScoreFrom,
+			MarketScoreTo:    r.MarketScoreTo,
+			ScoreChange:      r.ScoreChange,
+			MarketDispersion: r.MarketDispersion,
+			ZScore:           r.ZScore,
+			ValueFrom:        r.ValueFrom,
+			ValueTo:          r.ValueTo,
+		}
+		if r.Direction == models.MoverRiser {
+			response.Risers = append(response.Risers, item)
+		} else {
+			response.Fallers = append(response.Fallers, item)
+		}
+	}
+	if len(rows) > 0 {
+		response.FromDate = rows[0].FromDate.Format("2006-01-02")
+		response.ValuationDate = rows[0].ValuationDate.Format("2006-01-02")
+		response.ComputedAt = &rows[0].ComputedAt
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/internal/models"
+)
+
+// seedPlayerMovers seeds ppr-sf-10 7d movers: two WR risers, a WR faller
+// and an RB riser, plus a 1d riser and a ppr-sf-12 riser.
+func seedPlayerMovers(t *testing.T) {
+	t.Helper()
+	db := newDraftADPTestDB(t)
+	if err := db.AutoMigrate(&models.ValuationMover{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	withDraftADPTestDB(t, db)
+	seedADPPlayer(t, db, "6794", "Justin Jefferson", "WR", "MIN")
+	seedADPPlayer(t, db, "7564", "Ja'Marr Chase", "WR", "CIN")
+	seedADPPlayer(t, db, "8146", "Garrett Wilson", "WR", "NYJ")
+	seedADPPlayer(t, db, "9509", "Bijan Robinson", "RB", "ATL")
+
+	from := time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+	computed := time.Date(2025, 10, 31, 9, 0, 0, 0, time.UTC)
+	z := 3.0
+	for _, m := range []models.ValuationMover{
+		{Segment: "ppr-sf-10", TimeWindow: "7d", SleeperPlayerID: "7564", Position: "WR", Direction: models.MoverRiser, Rank: 2, MarketScoreFrom: 100, MarketScoreTo: 130, ScoreChange: 30},
+		{Segment: "ppr-sf-10", TimeWindow: "7d", SleeperPlayerID: "6794", Position: "WR", Direction: models.MoverRiser, Rank: 1, MarketScoreFrom: 100, MarketScoreTo: 160, ScoreChange: 60, ZScore: &z},
+		{Segment: "ppr-sf-10", TimeWindow: "7d", SleeperPlayerID: "8146", Position: "WR", Direction: models.MoverFaller, Rank: 1, MarketScoreFrom: 150, MarketScoreTo: 100, ScoreChange: -50},
+		{Segment: "ppr-sf-10", TimeWindow: "7d", SleeperPlayerID: "9509", Position: "RB", Direction: models.MoverRiser, Rank: 1, MarketScoreFrom: 50, MarketScoreTo: 80, ScoreChange: 30},
+		{Segment: "ppr-sf-10", TimeWindow: "1d", SleeperPlayerID: "8146", Position: "WR", Direction: models.MoverRiser, Rank: 1, MarketScoreFrom: 90, MarketScoreTo: 100, ScoreChange: 10},
+		{Segment: "ppr-sf-12", TimeWindow: "7d", SleeperPlayerID: "6794", Position: "WR", Direction: models.MoverRiser, Rank: 1, MarketScoreFrom: 90, MarketScoreTo: 100, ScoreChange: 10},
+	} {
+		m.FromDate, m.ValuationDate, m.ComputedAt = from, to, computed
+		if err := db.Create(&m).Error; err != nil {
+			t.Fatalf("seed mover: %v", err)
+		}
+	}
+}
+
+func performGetPlayerMovers(t *testing.T, query string) (*httptest.ResponseRecorder, PlayerMoversResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/players/movers", GetPlayerMovers)
+
+	req := httptest.NewRequest(http.MethodGet, "/players/movers"+query, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp PlayerMoversResponse
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+func TestGetPlayerMovers_DefaultsToSevenDaysInDefaultSegment(t *testing.T) {
+	seedPlayerMovers(t)
+
+	w, resp := performGetPlayerMovers(t, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Segment != "ppr-sf-10" || resp.Window != "7d" || resp.FromDate != "2025-10-24" || resp.ValuationDate != "2025-10-31" {
+		t.Errorf("unexpected defaults %+v", resp)
+	}
+	if resp.ComputedAt == nil || resp.ComputedAt.Hour() != 9 {
+		t.Errorf("expected computed_at from the job, got %v", resp.ComputedAt)
+	}
+	// Grouped by position, then ranked: the RB before the two WRs.
+	if len(resp.Risers) != 3 || resp.Risers[0].Name != "Bijan Robinson" || resp.Risers[1].Name != "Justin Jefferson" || resp.Risers[2].Rank != 2 {
+		t.Errorf("expected Robinson, Jefferson, Chase, got %+v", resp.Risers)
+	}
+	if resp.Risers[1].ZScore == nil || *resp.Risers[1].ZScore != 3 || resp.Risers[1].NflTeam != "MIN" {
+		t.Errorf("expected Jefferson's z-score and team, got %+v", resp.Risers[1])
+	}
+	if len(resp.Fallers) != 1 || resp.Fallers[0].SleeperPlayerID != "8146" || resp.Fallers[0].ScoreChange != -50 {
+		t.Errorf("expected Wilson falling 50, got %+v", resp.Fallers)
+	}
+}
+
+func TestGetPlayerMovers_Filters(t *testing.T) {
+	seedPlayerMovers(t)
+
+	_, resp := performGetPlayerMovers(t, "?position=WR&direction=riser&limit=1")
+	if len(resp.Risers) != 1 || resp.Risers[0].SleeperPlayerID != "6794" || len(resp.Fallers) != 0 {
+		t.Errorf("expected only Jefferson, got %+v", resp)
+	}
+	_, resp = performGetPlayerMovers(t, "?window=1d")
+	if len(resp.Risers) != 1 || resp.Risers[0].SleeperPlayerID != "8146" {
+		t.Errorf("expected the 1d window's riser, got %+v", resp.Risers)
+	}
+	_, resp = performGetPlayerMovers(t, "?segment=ppr-sf-12")
+	if len(resp.Risers) != 1 || resp.Risers[0].ScoreChange != 10 {
+		t.Errorf("expected the ppr-sf-12 riser, got %+v", resp.Risers)
+	}
+	_, resp = performGetPlayerMovers(t, "?segment=half_ppr-1qb-14%2B")
+	if len(resp.Risers) != 0 || len(resp.Fallers) != 0 || resp.ComputedAt != nil {
+		t.Errorf("expected an empty ranking for an unranked segment, got %+v", resp)
+	}
+}
+
+func TestGetPlayerMovers_RejectsBadFilters(t *testing.T) {
+	seedPlayerMovers(t)
+
+	for _, q := range []string{"?segment=nope", "?window=90d", "?direction=up"} {
+		if w, _ := performGetPlayerMovers(t, q); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", q, w.Code)
+		}
+	}
+}
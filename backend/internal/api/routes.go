@@ -31,20 +31,43 @@ func SetupRouter(r *gin.Engine) {
 	leagueScoped.GET("/expected-wins/season/:year", handlers.GetSeasonExpectedWins)
 	leagueScoped.GET("/expected-wins/rankings/:year", handlers.GetSeasonRankings)
 	leagueScoped.GET("/expected-wins/luck/:year", handlers.GetLuckDistribution)
+	leagueScoped.GET("/rescore/:year", handlers.GetRescoredSeason)
 
 	v1.GET("/transactions", handlers.GetTransactions)
 
 	players := v1.Group("/players")
 	players.GET("", handlers.GetPlayers)
 	players.GET("/stats", handlers.GetPlayerStats)
+	players.GET("/compare", handlers.GetPlayerComparison)
+	players.GET("/movers", handlers.GetPlayerMovers)
+	players.GET("/valuation-evaluation", handlers.GetValuationEvaluation)
 	players.GET("/:id/valuation-history", handlers.GetPlayerValuationHistory)
+	players.GET("/:id/usage", handlers.GetPlayerUsage)
 	players.GET("/:id", handlers.GetPlayerByID)
 
 	sleeper := v1.Group("/sleeper")
 	sleeper.GET("/stats", handlers.GetSleeperStats)
 	sleeper.GET("/trades", handlers.GetSleeperTrades)
+	sleeper.GET("/trades/accuracy", handlers.GetSleeperTradeAccuracy)
 	sleeper.GET("/transactions", handlers.GetSleeperTransactions)
 	sleeper.GET("/adp", handlers.GetSleeperADP)
+	sleeper.GET("/adp/trend", handlers.GetSleeperADPTrend)
+	sleeper.GET("/trending", handlers.GetSleeperTrending)
+	sleeper.GET("/faab", handlers.GetSleeperFAAB)
+	sleeper.GET("/drafts/:id/assistant", handlers.GetSleeperDraftAssistant)
+	sleeper.GET("/leagues/:id/picks", handlers.GetSleeperLeaguePicks)
+	sleeper.GET("/leagues/:id/pick-trades", handlers.GetSleeperLeaguePickTrades)
+	sleeper.GET("/leagues/:id/team-values", handlers.GetSleeperLeagueTeamValues)
+	sleeper.GET("/leagues/:id/rosters/:rosterId/trade-suggestions", handlers.GetTradeSuggestions)
+	sleeper.POST("/leagues/:id/rosters/:rosterId/trade-suggestions", handlers.RequestTradeSuggestions)
+
+	trades := v1.Group("/trades")
+	trades.POST("/calculate", handlers.CalculateTrade)
+
+	mockDrafts := v1.Group("/mock-drafts")
+	mockDrafts.POST("", handlers.CreateMockDraft)
+	mockDrafts.GET("/:id", handlers.GetMockDraft)
+	mockDrafts.POST("/:id/picks", handlers.MakeMockDraftPick)
 
 	admin := v1.Group("/admin")
 	admin.GET("/transaction-fetch-age-history", handlers.GetAdminTransactionFetchAgeHistory)
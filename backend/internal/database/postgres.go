@@ -44,8 +44,8 @@ func Initialize(cfg *config.Config) error {
 }
 
 // Archive is the global archive-database instance. Nil unless
-// InitializeArchive has been called — only the worker does so, and only when
-// cfg.ArchiveDB.Enabled() (i.e. ARCHIVE_DATABASE_URL is set).
+// InitializeArchive has been called — the worker, cron and server do so, and
+// only when cfg.ArchiveDB.Enabled() (i.e. ARCHIVE_DATABASE_URL is set).
 var Archive *gorm.DB
 
 // InitializeArchive sets up the archive database connection and configures
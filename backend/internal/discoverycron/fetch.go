@@ -138,6 +138,8 @@ type LeagueDetailsUpdate struct {
 	TEPremium       float64
 	IsSuperflex     bool
 	LeagueType      string
+	DraftRounds     int
+	WaiverBudget    *int
 	ScoringSettings json.RawMessage
 	RosterPositions json.RawMessage
 }
@@ -212,6 +214,11 @@ func FetchLeague(ctx context.Context, db *gorm.DB, sleeperClient *sleeper.Client
 			break
 		}
 	}
+	// Only a FAAB league's budget means anything; the rest stay NULL.
+	var waiverBudget *int
+	if league.Settings.WaiverType == 2 {
+		waiverBudget = &league.Settings.WaiverBudget
+	}
 	res.Details = &LeagueDetailsUpdate{
 		Name:            league.Name,
 		Status:          league.Status,
@@ -220,6 +227,8 @@ func FetchLeague(ctx context.Context, db *gorm.DB, sleeperClient *sleeper.Client
 		TEPremium:       league.ScoringSettings["bonus_rec_te"],
 		IsSuperflex:     isSuperflex,
 		LeagueType:      sleeperLeagueType(league.Settings.Type),
+		DraftRounds:     league.Settings.DraftRounds,
+		WaiverBudget:    waiverBudget,
 		ScoringSettings: scoringJSON,
 		RosterPositions: rosterJSON,
 	}
@@ -272,6 +281,8 @@ func FlushLeagueDiscovery(ctx context.Context, tx *gorm.DB, batch []LeagueDiscov
 				"te_premium":       d.TEPremium,
 				"is_superflex":     d.IsSuperflex,
 				"league_type":      d.LeagueType,
+				"draft_rounds":     d.DraftRounds,
+				"waiver_budget":    d.WaiverBudget,
 				"scoring_settings": d.ScoringSettings,
 				"roster_positions": d.RosterPositions,
 				"last_fetched_at":  now,
@@ -0,0 +1,217 @@
+// Package finishes ranks weekly fantasy scores within each position and
+// summarizes a player-season's distribution of those weekly finishes — how
+// often a player is a weekly top-3/12/24/36 at their position, how often
+// they boom or bust, and how volatile their scoring is. It is pure: callers
+// load the week scores (from sleeper_player_week_stats or box_scores) and
+// hand them in, so the same rules apply to both sources.
+package finishes
+
+import (
+	"math"
+	"sort"
+)
+
+// TopNThresholds are the positional finish cutoffs a Profile reports a rate
+// for, in ascending order.
+var TopNThresholds = []int{3, 12, 24, 36}
+
+// startLine is the weekly positional finish that still counts as a starter
+// in a 12-team league: one starter per team at QB/TE/K/DEF, two at RB/WR.
+// Boom and bust are defined relative to it so a TE and a WR are judged
+// against their own position's depth, not one shared top-N.
+var startLine = map[string]int{
+	"QB":  12,
+	"RB":  24,
+	"WR":  24,
+	"TE":  12,
+	"K":   12,
+	"DEF": 12,
+}
+
+// defaultStartLine covers positions not in startLine (IDP, etc.).
+const defaultStartLine = 12
+
+// BoomFinish is the worst weekly finish that still counts as a boom week
+// for position: the top half of its starters.
+func BoomFinish(position string) int {
+	return startLineFor(position) / 2
+}
+
+// BustFinish is the best weekly finish that counts as a bust week for
+// position: anything beyond one and a half times its starter line.
+func BustFinish(position string) int {
+	line := startLineFor(position)
+	return line + line/2 + 1
+}
+
+func startLineFor(position string) int {
+	if line, ok := startLine[position]; ok {
+		return line
+	}
+	return defaultStartLine
+}
+
+// WeekScore is one player's fantasy points for one week. PlayerKey is
+// whatever identifies the player in the source table (a Sleeper player ID
+// or an internal players.id).
+type WeekScore struct {
+	PlayerKey string
+	Position  string
+	Season    string
+	Week      int
+	Points    float64
+}
+
+// RankedWeek is a WeekScore with its finish among every player at the same
+// position in the same season and week (1 = best). Ties share a finish and
+// the next finish skips accordingly (1, 2, 2, 4).
+type RankedWeek struct {
+	WeekScore
+	Finish int
+}
+
+// Rank assigns each score its weekly positional finish. Scores with an
+// empty Position can't be ranked and are dropped.
+func Rank(scores []WeekScore) []RankedWeek {
+	type bucket struct {
+		position string
+		season   string
+		week     int
+	}
+	groups := make(map[bucket][]WeekScore)
+	for _, s := range scores {
+		if s.Position == "" {
+			continue
+		}
+		k := bucket{s.Position, s.Season, s.Week}
+		groups[k] = append(groups[k], s)
+	}
+
+	ranked := make([]RankedWeek, 0, len(scores))
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool { return group[i].Points > group[j].Points })
+		for i, s := range group {
+			finish := i + 1
+			if i > 0 && s.Points == group[i-1].Points {
+				finish = ranked[len(ranked)-1].Finish
+			}
+			ranked = append(ranked, RankedWeek{WeekScore: s, Finish: finish})
+		}
+	}
+	return ranked
+}
+
+// Profile is one player-season's weekly-finish distribution. Rates are
+// fractions of Games in [0, 1].
+type Profile struct {
+	PlayerKey    string
+	Position     string
+	Season       string
+	Games        int
+	TotalPoints  float64
+	AvgPoints    float64
+	AvgFinish    float64
+	MedianFinish float64
+	BestFinish   int
+	// TopNRates is keyed by each TopNThresholds entry.
+	TopNRates map[int]float64
+	BoomRate  float64
+	BustRate  float64
+	// Volatility is the population standard deviation of weekly points;
+	// CoefficientOfVariation is Volatility / AvgPoints (0 when AvgPoints is
+	// not positive), comparable across scoring levels.
+	Volatility             float64
+	CoefficientOfVariation float64
+}
+
+// BuildProfiles groups ranked weeks by player and season and summarizes
+// each group. The result is sorted by season descending, then by
+// PlayerKey, so output is deterministic.
+func BuildProfiles(ranked []RankedWeek) []Profile {
+	type key struct {
+		player string
+		season string
+	}
+	groups := make(map[key][]RankedWeek)
+	for _, r := range ranked {
+		k := key{r.PlayerKey, r.Season}
+		groups[k] = append(groups[k], r)
+	}
+
+	profiles := make([]Profile, 0, len(groups))
+	for k, weeks := range groups {
+		profiles = append(profiles, summarize(k.player, k.season, weeks))
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		if profiles[i].Season != profiles[j].Season {
+			return profiles[i].Season > profiles[j].Season
+		}
+		return profiles[i].PlayerKey < profiles[j].PlayerKey
+	})
+	return profiles
+}
+
+func summarize(player, season string, weeks []RankedWeek) Profile {
+	p := Profile{
+		PlayerKey: player,
+		Season:    season,
+		Games:     len(weeks),
+		TopNRates: make(map[int]float64, len(TopNThresholds)),
+	}
+	if len(weeks) == 0 {
+		return p
+	}
+	p.Position = weeks[0].Position
+	boom, bust := BoomFinish(p.Position), BustFinish(p.Position)
+
+	finishes := make([]int, len(weeks))
+	topN := make(map[int]int, len(TopNThresholds))
+	var booms, busts, finishSum int
+	p.BestFinish = weeks[0].Finish
+	for i, w := range weeks {
+		finishes[i] = w.Finish
+		finishSum += w.Finish
+		p.TotalPoints += w.Points
+		if w.Finish < p.BestFinish {
+			p.BestFinish = w.Finish
+		}
+		for _, n := range TopNThresholds {
+			if w.Finish <= n {
+				topN[n]++
+			}
+		}
+		if w.Finish <= boom {
+			booms++
+		}
+		if w.Finish >= bust {
+			busts++
+		}
+	}
+
+	games := float64(p.Games)
+	p.AvgPoints = p.TotalPoints / games
+	p.AvgFinish = float64(finishSum) / games
+	for _, n := range TopNThresholds {
+		p.TopNRates[n] = float64(topN[n]) / games
+	}
+	p.BoomRate = float64(booms) / games
+	p.BustRate = float64(busts) / games
+
+	sort.Ints(finishes)
+	mid := len(finishes) / 2
+	if len(finishes)%2 == 1 {
+		p.MedianFinish = float64(finishes[mid])
+	} else {
+		p.MedianFinish = float64(finishes[mid-1]+finishes[mid]) / 2
+	}
+
+	var variance float64
+	for _, w := range weeks {
+		variance += (w.Points - p.AvgPoints) * (w.Points - p.AvgPoints)
+	}
+	p.Volatility = math.Sqrt(variance / games)
+	if p.AvgPoints > 0 {
+		p.CoefficientOfVariation = p.Volatility / p.AvgPoints
+	}
+	return p
+}
@@ -0,0 +1,87 @@
+package finishes_test
+
+import (
+	"math"
+	"testing"
+
+	"backend/internal/finishes"
+)
+
+func TestRank_SharesFinishOnTiesAndSeparatesPositions(t *testing.T) {
+	ranked := finishes.Rank([]finishes.WeekScore{
+		{PlayerKey: "a", Position: "WR", Season: "2025", Week: 1, Points: 20},
+		{PlayerKey: "b", Position: "WR", Season: "2025", Week: 1, Points: 15},
+		{PlayerKey: "c", Position: "WR", Season: "2025", Week: 1, Points: 15},
+		{PlayerKey: "d", Position: "WR", Season: "2025", Week: 1, Points: 10},
+		{PlayerKey: "e", Position: "TE", Season: "2025", Week: 1, Points: 5},
+		{PlayerKey: "f", Position: "", Season: "2025", Week: 1, Points: 50},
+	})
+
+	got := make(map[string]int)
+	for _, r := range ranked {
+		got[r.PlayerKey] = r.Finish
+	}
+	want := map[string]int{"a": 1, "b": 2, "c": 2, "d": 4, "e": 1}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d ranked weeks (positionless dropped), got %v", len(want), got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("player %s: expected finish %d, got %d", k, v, got[k])
+		}
+	}
+}
+
+func TestBuildProfiles_Rates(t *testing.T) {
+	weeks := []finishes.RankedWeek{
+		{WeekScore: finishes.WeekScore{PlayerKey: "p", Position: "WR", Season: "2025", Week: 1, Points: 30}, Finish: 2},
+		{WeekScore: finishes.WeekScore{PlayerKey: "p", Position: "WR", Season: "2025", Week: 2, Points: 10}, Finish: 20},
+		{WeekScore: finishes.WeekScore{PlayerKey: "p", Position: "WR", Season: "2025", Week: 3, Points: 14}, Finish: 12},
+		{WeekScore: finishes.WeekScore{PlayerKey: "p", Position: "WR", Season: "2025", Week: 4, Points: 2}, Finish: 60},
+	}
+	profiles := finishes.BuildProfiles(weeks)
+	if len(profiles) != 1 {
+		t.Fatalf("expected 1 profile, got %d", len(profiles))
+	}
+	p := profiles[0]
+	if p.Games != 4 || p.TotalPoints != 56 || p.AvgPoints != 14 {
+		t.Errorf("unexpected totals: %+v", p)
+	}
+	if p.TopNRates[3] != 0.25 || p.TopNRates[12] != 0.5 || p.TopNRates[24] != 0.75 || p.TopNRates[36] != 0.75 {
+		t.Errorf("unexpected top-N rates: %v", p.TopNRates)
+	}
+	// WR boom is a top-12 finish, bust is 37th or worse.
+	if p.BoomRate != 0.5 || p.BustRate != 0.25 {
+		t.Errorf("unexpected boom/bust: boom=%v bust=%v", p.BoomRate, p.BustRate)
+	}
+	if p.BestFinish != 2 || p.MedianFinish != 16 || p.AvgFinish != 23.5 {
+		t.Errorf("unexpected finish summary: best=%d median=%v avg=%v", p.BestFinish, p.MedianFinish, p.AvgFinish)
+	}
+	wantVol := math.Sqrt((16*16 + 4*4 + 0 + 12*12) / 4.0)
+	if math.Abs(p.Volatility-wantVol) > 1e-9 {
+		t.Errorf("expected volatility %v, got %v", wantVol, p.Volatility)
+	}
+	if math.Abs(p.CoefficientOfVariation-wantVol/14) > 1e-9 {
+		t.Errorf("unexpected coefficient of variation %v", p.CoefficientOfVariation)
+	}
+}
+
+func TestBoomBustFinish_PerPosition(t *testing.T) {
+	cases := []struct {
+		position   string
+		boom, bust int
+	}{
+		{"QB", 6, 19},
+		{"RB", 12, 37},
+		{"TE", 6, 19},
+		{"LB", 6, 19},
+	}
+	for _, tc := range cases {
+		if got := finishes.BoomFinish(tc.position); got != tc.boom {
+			t.Errorf("%s boom: expected %d, got %d", tc.position, tc.boom, got)
+		}
+		if got := finishes.BustFinish(tc.position); got != tc.bust {
+			t.Errorf("%s bust: expected %d, got %d", tc.position, tc.bust, got)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package mockdraft
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Recommendation weights. A bench pick is worth benchNeedWeight of a
+// starter; scarcity moves a score by up to scarcityWeight either way; a
+// player certain to last to the seat's following pick keeps
+// 1-survivalWeight of their score, since they can be taken then instead.
+const (
+	benchNeedWeight = 0.5
+	scarcityWeight  = 0.25
+	maxScarcity     = 2
+	survivalWeight  = 0.5
+)
+
+// scarcityWindowRounds is how many rounds of the board, by ADP, count as a
+// position's supply when measuring scarcity.
+const scarcityWindowRounds = 2
+
+// Recommendation is one available player scored for the pick on the clock.
+// Every factor is reported so a client can show why a player ranks where
+// they do.
+type Recommendation struct {
+	PlayerID string
+	Position string
+	ADP      float64
+	// Value is the player's standing on the board, from near 0 (worst
+	// available) to 1 (best): their ADP percentile among available players, averaged with
+	// their market-value percentile when they have a valuation.
+	Value float64
+	// Need is 1 for a player who fills an open starting slot,
+	// benchNeedWeight for a bench pick, and 0 for one the seat has no room
+	// for.
+	Need float64
+	// Scarcity is the position's share of open dedicated starting slots
+	// across the league over its share of the board's next
+	// scarcityWindowRounds rounds, capped at maxScarcity: above 1 the
+	// position is running out faster than it's needed.
+	Scarcity float64
+	// Available is the probability the player is still on the board at the
+	// seat's next pick: 1 when the seat is on the clock.
+	Available float64
+	// Survival is the probability the player lasts from the seat's next
+	// pick to the one after, if passed on; 0 when there's no pick after.
+	Survival float64
+	Score    float64
+}
+
+// Recommend scores every available player for slot's next pick — on the
+// clock or not — highest first, and returns the top limit: Value x Need x
+// Available, scaled by Scarcity and discounted by Survival (see the weights
+// above). values holds market valuations by player ID (those missing are
+// ranked on ADP alone); Available and Survival are estimated over sims
+// simulated drafts. Nil once slot has no picks left.
+func (d *Draft) Recommend(slot int, values map[string]float64, limit, sims int, rng *rand.Rand) []Recommendation {
+	var avail []int
+	for i := range d.Pool {
+		if !d.taken[i] {
+			avail = append(avail, i)
+		}
+	}
+	picks := d.PicksForSlot(slot, d.NextPick()-1)
+	if len(avail) == 0 || d.NextPick() == 0 || len(picks) == 0 {
+		return nil
+	}
+
+	// ADP percentile: avail is already in ADP order.
+	value := make(map[int]float64, len(avail))
+	for r, i := range avail {
+		value[i] = percentile(r, len(avail))
+	}
+	var valued []int
+	for _, i := range avail {
+		if _, ok := values[d.Pool[i].ID]; ok {
+			valued = append(valued, i)
+		}
+	}
+	sort.SliceStable(valued, func(a, b int) bool { return values[d.Pool[valued[a]].ID] > values[d.Pool[valued[b]].ID] })
+	for r, i := range valued {
+		value[i] = (value[i] + percentile(r, len(valued))) / 2
+	}
+
+	scarcity := d.scarcity(avail)
+	need := d.need(d.rosters[slot-1])
+
+	// Survival is conditional on the seat passing on the player at its next
+	// pick, so that pick is a placeholder rather than the model's.
+	available, survival := map[string]float64{}, map[string]float64{}
+	picks = picks[:min(len(picks), 2)]
+	for _, a := range d.simulate(picks, picks[0], len(avail), sims, rng) {
+		available[a.PlayerID] = a.Probabilities[0]
+		if len(picks) > 1 && a.Probabilities[0] > 0 {
+			survival[a.PlayerID] = a.Probabilities[1] / a.Probabilities[0]
+		}
+	}
+
+	recs := make([]Recommendation, len(avail))
+	for k, i := range avail {
+		p := d.Pool[i]
+		rec := Recommendation{
+			PlayerID:  p.ID,
+			Position:  p.Position,
+			ADP:       p.ADP,
+			Value:     value[i],
+			Scarcity:  scarcity[p.Position],
+			Available: available[p.ID],
+			Survival:  survival[p.ID],
+		}
+		switch need(p.Position) {
+		case needStarter:
+			rec.Need = 1
+		case needBench:
+			rec.Need = benchNeedWeight
+		}
+		rec.Score = rec.Value * rec.Need * rec.Available * (1 + scarcityWeight*(rec.Scarcity-1)) * (1 - survivalWeight*rec.Survival)
+		recs[k] = rec
+	}
+	sort.SliceStable(recs, func(a, b int) bool { return recs[a].Score > recs[b].Score })
+	if len(recs) > limit {
+		recs = recs[:limit]
+	}
+	return recs
+}
+
+// percentile maps rank r (0 = best) of n to 1 (best) through 1/n (worst),
+// so the last player on the board still has some value.
+func percentile(r, n int) float64 {
+	return 1 - float64(r)/float64(n)
+}
+
+// scarcity measures each position's scarcity (see Recommendation.Scarcity)
+// given the available pool indexes, in ADP order. With no open dedicated
+// slots anywhere every position is neutral (1).
+func (d *Draft) scarcity(avail []int) map[string]float64 {
+	demand := map[string]int{}
+	totalDemand := 0
+	for _, roster := range d.rosters {
+		for i, open := range d.openSlots(roster) {
+			if open && len(d.slots[i]) == 1 {
+				demand[d.slots[i][0]]++
+				totalDemand++
+			}
+		}
+	}
+	supply := map[string]int{}
+	window := min(len(avail), scarcityWindowRounds*d.Teams)
+	for _, i := range avail[:window] {
+		supply[d.Pool[i].Position]++
+	}
+
+	out := map[string]float64{}
+	for _, i := range avail {
+		pos := d.Pool[i].Position
+		if _, ok := out[pos]; ok {
+			continue
+		}
+		switch {
+		case totalDemand == 0:
+			out[pos] = 1
+		case demand[pos] == 0:
+			out[pos] = 0
+		case supply[pos] == 0:
+			out[pos] = maxScarcity
+		default:
+			ratio := (float64(demand[pos]) / float64(totalDemand)) / (float64(supply[pos]) / float64(window))
+			out[pos] = min(ratio, maxScarcity)
+		}
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package mockdraft_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"backend/internal/mockdraft"
+)
+
+func TestDraft_RecommendWeighsNeedSurvivalAndValue(t *testing.T) {
+	pool := []mockdraft.Player{
+		{ID: "qb1", Position: "QB", ADP: 1, StdDev: 0.01},
+		{ID: "rb1", Position: "RB", ADP: 2, StdDev: 0.01},
+		{ID: "rb2", Position: "RB", ADP: 3, StdDev: 0.01},
+		{ID: "wr1", Position: "WR", ADP: 4, StdDev: 0.01},
+		{ID: "wr2", Position: "WR", ADP: 5, StdDev: 0.01},
+		{ID: "wr3", Position: "WR", ADP: 30, StdDev: 0.01},
+		{ID: "k1", Position: "K", ADP: 40, StdDev: 0.01},
+	}
+	cfg := mockdraft.Config{Teams: 2, RosterPositions: []string{"QB", "RB", "WR", "K"}}
+	// Seat 1 took someone outside the pool, seat 2 took qb1.
+	d, err := mockdraft.New(cfg, pool, []string{"someone", "qb1"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	// Pick 3 is seat 2's too (snake); its next is pick 6, after seat 1
+	// fills its RB and WR slots with rb1 and wr1.
+	recs := d.Recommend(2, nil, 10, 500, rand.New(rand.NewSource(1)))
+	if len(recs) != 6 {
+		t.Fatalf("expected all 6 available players scored, got %d", len(recs))
+	}
+	if recs[0].PlayerID != "rb1" {
+		t.Errorf("expected rb1 (best ADP, gone by pick 6) first, got %+v", recs[0])
+	}
+	byID := map[string]mockdraft.Recommendation{}
+	for _, r := range recs {
+		byID[r.PlayerID] = r
+	}
+	if s := byID["rb2"].Survival; s != 1 {
+		t.Errorf("expected rb2 certain to last to pick 6, got %v", s)
+	}
+	if s := byID["wr1"].Survival; s != 0 {
+		t.Errorf("expected wr1 gone by pick 6, got %v", s)
+	}
+
+	// Seat 1 isn't on the clock: seat 2 takes rb1 before seat 1's pick 4.
+	for _, r := range d.Recommend(1, nil, 10, 200, rand.New(rand.NewSource(1))) {
+		if want := map[string]float64{"rb1": 0, "rb2": 1}[r.PlayerID]; (r.PlayerID == "rb1" || r.PlayerID == "rb2") && r.Available != want {
+			t.Errorf("seat 1: expected %s available with probability %v at pick 4, got %v", r.PlayerID, want, r.Available)
+		}
+	}
+
+	// A valuation lifts wr2 over rb1 and wr1 on value.
+	recs = d.Recommend(2, map[string]float64{"wr2": 100, "rb1": 10, "wr1": 5}, 10, 200, rand.New(rand.NewSource(1)))
+	for _, r := range recs {
+		byID[r.PlayerID] = r
+	}
+	if byID["wr2"].Value <= byID["wr1"].Value {
+		t.Errorf("expected wr2's valuation to lift its value over wr1: %v vs %v", byID["wr2"].Value, byID["wr1"].Value)
+	}
+}
+
+func TestDraft_RecommendZeroesPositionsWithoutRoom(t *testing.T) {
+	pool := []mockdraft.Player{
+		{ID: "qb1", Position: "QB", ADP: 1, StdDev: 0.01},
+		{ID: "qb2", Position: "QB", ADP: 2, StdDev: 0.01},
+		{ID: "qb3", Position: "QB", ADP: 3, StdDev: 0.01},
+		{ID: "k1", Position: "K", ADP: 20, StdDev: 0.01},
+		{ID: "k2", Position: "K", ADP: 21, StdDev: 0.01},
+	}
+	cfg := mockdraft.Config{Teams: 2, RosterPositions: []string{"QB", "K"}}
+	d, err := mockdraft.New(cfg, pool, []string{"qb1", "qb2"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	recs := d.Recommend(2, nil, 10, 100, rand.New(rand.NewSource(1)))
+	// Seat 2 has its QB and no bench: qb3 is worth nothing to it.
+	if len(recs) != 3 || recs[0].Position != "K" || recs[1].Position != "K" {
+		t.Fatalf("expected the kickers ahead of qb3, got %+v", recs)
+	}
+	if recs[2].PlayerID != "qb3" || recs[2].Need != 0 || recs[2].Score != 0 {
+		t.Errorf("expected qb3 last with no need, got %+v", recs[2])
+	}
+	if recs[0].Survival != 0 {
+		t.Errorf("expected no survival on the seat's last pick, got %v", recs[0].Survival)
+	}
+}
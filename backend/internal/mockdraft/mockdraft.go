@@ -0,0 +1,468 @@
+// Package mockdraft simulates snake drafts from ADP distributions. Every
+// seat but the caller's picks by sampling each available player's draft
+// position from their ADP spread and taking the earliest sample that fits
+// the seat's roster needs; Monte Carlo runs of the same model estimate how
+// likely each player is to still be on the board at the caller's upcoming
+// picks. It is pure: callers load the player pool (from draft_adp) and the
+// picks made so far and hand them in.
+package mockdraft
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrDraftComplete is returned by Pick once every pick has been made.
+var ErrDraftComplete = errors.New("draft is complete")
+
+// ErrPlayerUnavailable is returned by Pick for a player who has already been
+// drafted or isn't in the pool.
+var ErrPlayerUnavailable = errors.New("player is not available")
+
+// minStdDev floors a player's pick spread, so a player with a thin or
+// degenerate sample (every pick at the same slot) still moves a little.
+const minStdDev = 1.5
+
+// ciZ is the normal quantile of a two-sided 95% interval: a CI spans
+// 2*ciZ standard deviations.
+const ciZ = 1.96
+
+// Player is one draftable player: their ADP and the standard deviation of
+// the pick they go at.
+type Player struct {
+	ID       string
+	Position string
+	ADP      float64
+	StdDev   float64
+}
+
+// PlayerFromADP builds a Player from a draft_adp row. The spread comes from
+// the 95% pick CI when it has one, else from the min/max range (roughly
+// four standard deviations), floored at minStdDev.
+func PlayerFromADP(id, position string, avgPickNo, ciLow, ciHigh float64, minPickNo, maxPickNo int) Player {
+	stdDev := (ciHigh - ciLow) / (2 * ciZ)
+	if ciHigh <= ciLow {
+		stdDev = float64(maxPickNo-minPickNo) / 4
+	}
+	return Player{ID: id, Position: position, ADP: avgPickNo, StdDev: math.Max(stdDev, minStdDev)}
+}
+
+// slotPositions maps a Sleeper roster slot to the positions that can start
+// in it. Slots not listed (BN, IR, TAXI) aren't starting slots.
+var slotPositions = map[string][]string{
+	"QB":         {"QB"},
+	"RB":         {"RB"},
+	"WR":         {"WR"},
+	"TE":         {"TE"},
+	"K":          {"K"},
+	"DEF":        {"DEF"},
+	"DL":         {"DL"},
+	"LB":         {"LB"},
+	"DB":         {"DB"},
+	"FLEX":       {"RB", "WR", "TE"},
+	"WRRB_FLEX":  {"RB", "WR"},
+	"REC_FLEX":   {"WR", "TE"},
+	"SUPER_FLEX": {"QB", "RB", "WR", "TE"},
+	"IDP_FLEX":   {"DL", "LB", "DB"},
+}
+
+// noBenchPositions are never drafted beyond the slots they can start in: a
+// second kicker or defense is dead weight on a bench.
+var noBenchPositions = []string{"K", "DEF"}
+
+// Config is a draft's shape. Slots are 1-based draft positions; pick numbers
+// are 1-based overall picks. Rounds defaults to the length of
+// RosterPositions when zero. Linear drafts run every round 1..Teams instead
+// of snaking; ReversalRound, when set, flips a snake's order once more from
+// that round on (3 for a third-round reversal). Owners maps the overall
+// pick numbers that were traded to the slot that now makes them.
+type Config struct {
+	Teams           int
+	Rounds          int
+	RosterPositions []string
+	Linear          bool
+	ReversalRound   int
+	Owners          map[int]int
+}
+
+// TotalPicks is the number of picks in the draft.
+func (c Config) TotalPicks() int {
+	return c.Teams * c.Rounds
+}
+
+// SlotForPick returns the draft slot making overall pick pickNo: its owner
+// in Owners when it was traded, otherwise the slot whose turn it is — in a
+// snake draft odd rounds run 1..Teams and even rounds back.
+func (c Config) SlotForPick(pickNo int) int {
+	if slot, ok := c.Owners[pickNo]; ok {
+		return slot
+	}
+	idx := (pickNo - 1) % c.Teams
+	if c.reversed((pickNo-1)/c.Teams + 1) {
+		return c.Teams - idx
+	}
+	return idx + 1
+}
+
+// PickNo returns the overall pick number of slot's own pick in round,
+// whoever owns it now.
+func (c Config) PickNo(round, slot int) int {
+	if c.reversed(round) {
+		slot = c.Teams + 1 - slot
+	}
+	return (round-1)*c.Teams + slot
+}
+
+// reversed reports whether round (1-based) runs Teams..1.
+func (c Config) reversed(round int) bool {
+	if c.Linear {
+		return false
+	}
+	reversed := round%2 == 0
+	if c.ReversalRound > 0 && round >= c.ReversalRound {
+		reversed = !reversed
+	}
+	return reversed
+}
+
+// PicksForSlot returns the overall pick numbers slot makes after pick
+// after, in order.
+func (c Config) PicksForSlot(slot, after int) []int {
+	var picks []int
+	for pickNo := after + 1; pickNo <= c.TotalPicks(); pickNo++ {
+		if c.SlotForPick(pickNo) == slot {
+			picks = append(picks, pickNo)
+		}
+	}
+	return picks
+}
+
+// StarterSlots returns the starting slots in positions, as the positions
+// eligible for each, most specific (fewest eligible positions) first, so a
+// roster fills dedicated slots before flex ones.
+func StarterSlots(positions []string) [][]string {
+	var slots [][]string
+	for _, p := range positions {
+		if eligible, ok := slotPositions[p]; ok {
+			slots = append(slots, eligible)
+		}
+	}
+	sort.SliceStable(slots, func(i, j int) bool { return len(slots[i]) < len(slots[j]) })
+	return slots
+}
+
+// Draft is a snake draft in progress over a player pool.
+type Draft struct {
+	Config
+	// Pool is every draftable player, in ascending ADP order (ties by ID).
+	Pool []Player
+	// Picks are the player IDs drafted so far, in pick order: Picks[i] is
+	// overall pick i+1.
+	Picks []string
+
+	slots   [][]string
+	index   map[string]int
+	taken   []bool
+	rosters [][]string
+}
+
+// New starts a draft over pool and replays picks onto it. A pick of a player
+// outside the pool still uses the pick (and its seat's roster spot, as an
+// unknown position) without removing anyone from the board.
+func New(cfg Config, pool []Player, picks []string) (*Draft, error) {
+	if cfg.Rounds == 0 {
+		cfg.Rounds = len(cfg.RosterPositions)
+	}
+	if cfg.Teams < 2 || cfg.Rounds < 1 {
+		return nil, errors.New("a draft needs at least 2 teams and 1 round")
+	}
+	pool = slices.Clone(pool)
+	slices.SortFunc(pool, func(a, b Player) int {
+		if c := cmp.Compare(a.ADP, b.ADP); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	d := &Draft{
+		Config:  cfg,
+		Pool:    pool,
+		slots:   StarterSlots(cfg.RosterPositions),
+		index:   make(map[string]int, len(pool)),
+		taken:   make([]bool, len(pool)),
+		rosters: make([][]string, cfg.Teams),
+	}
+	for i, p := range pool {
+		d.index[p.ID] = i
+	}
+	for _, id := range picks {
+		if err := d.record(id, true); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// NextPick returns the overall pick number on the clock, or 0 once the draft
+// is complete.
+func (d *Draft) NextPick() int {
+	if len(d.Picks) >= d.TotalPicks() {
+		return 0
+	}
+	return len(d.Picks) + 1
+}
+
+// Roster returns the positions slot has drafted, in pick order.
+func (d *Draft) Roster(slot int) []string {
+	return d.rosters[slot-1]
+}
+
+// Available returns the undrafted players, in ascending ADP order.
+func (d *Draft) Available() []Player {
+	var out []Player
+	for i, p := range d.Pool {
+		if !d.taken[i] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Pick makes the pick on the clock for playerID, who must be an undrafted
+// player in the pool.
+func (d *Draft) Pick(playerID string) error {
+	return d.record(playerID, false)
+}
+
+func (d *Draft) record(playerID string, allowUnknown bool) error {
+	pickNo := d.NextPick()
+	if pickNo == 0 {
+		return ErrDraftComplete
+	}
+	i, ok := d.index[playerID]
+	switch {
+	case ok && !d.taken[i]:
+		d.take(i)
+	case !ok && allowUnknown:
+		slot := d.SlotForPick(pickNo)
+		d.rosters[slot-1] = append(d.rosters[slot-1], "")
+		d.Picks = append(d.Picks, playerID)
+	default:
+		return ErrPlayerUnavailable
+	}
+	return nil
+}
+
+// take makes the pick on the clock for pool index i.
+func (d *Draft) take(i int) {
+	slot := d.SlotForPick(d.NextPick())
+	d.taken[i] = true
+	d.rosters[slot-1] = append(d.rosters[slot-1], d.Pool[i].Position)
+	d.Picks = append(d.Picks, d.Pool[i].ID)
+}
+
+// AutoPick makes picks for every seat but userSlot until userSlot is on the
+// clock or the draft is complete, returning the player IDs picked. rng
+// drives the sampling; seed it the same to replay the same picks.
+func (d *Draft) AutoPick(userSlot int, rng *rand.Rand) []string {
+	var picked []string
+	draws := d.sample(rng)
+	for pickNo := d.NextPick(); pickNo != 0 && d.SlotForPick(pickNo) != userSlot; pickNo = d.NextPick() {
+		i := d.choose(d.SlotForPick(pickNo), draws)
+		if i < 0 {
+			break
+		}
+		d.take(i)
+		picked = append(picked, d.Pool[i].ID)
+	}
+	return picked
+}
+
+// sample draws every pool player's draft position from their ADP spread.
+// One draw covers a whole run of picks: redrawing at every pick would hand
+// high-spread players a fresh chance at an early draw each time, pulling
+// them well ahead of their ADP.
+func (d *Draft) sample(rng *rand.Rand) []float64 {
+	draws := make([]float64, len(d.Pool))
+	for i, p := range d.Pool {
+		draws[i] = p.ADP + p.StdDev*rng.NormFloat64()
+	}
+	return draws
+}
+
+// choose returns the pool index slot picks: the available player with the
+// earliest draw among those its roster needs, falling back to any available
+// player. -1 when the board is empty.
+func (d *Draft) choose(slot int, draws []float64) int {
+	needs := d.needs(d.rosters[slot-1])
+	best, bestAny := -1, -1
+	for i, p := range d.Pool {
+		if d.taken[i] {
+			continue
+		}
+		if bestAny < 0 || draws[i] < draws[bestAny] {
+			bestAny = i
+		}
+		if needs(p.Position) && (best < 0 || draws[i] < draws[best]) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return bestAny
+	}
+	return best
+}
+
+// Need levels: how much a seat wants a position (see Draft.need).
+const (
+	needNone = iota
+	needBench
+	needStarter
+)
+
+// needs reports whether a seat with roster would draft a position at all.
+func (d *Draft) needs(roster []string) func(position string) bool {
+	need := d.need(roster)
+	return func(position string) bool { return need(position) != needNone }
+}
+
+// need returns how much a seat with roster wants a player at a position:
+// needStarter when one fills an open starting slot; otherwise needBench
+// while the seat has more picks left than open starting slots (a bench
+// pick), except for noBenchPositions; else needNone. With no starting slots
+// configured every position is a starter.
+func (d *Draft) need(roster []string) func(position string) int {
+	if len(d.slots) == 0 {
+		return func(string) int { return needStarter }
+	}
+	open := d.openSlots(roster)
+	openCount := 0
+	for _, o := range open {
+		if o {
+			openCount++
+		}
+	}
+	benchPick := d.Rounds-len(roster) > openCount
+	return func(position string) int {
+		startable := false
+		for i, eligible := range d.slots {
+			if slices.Contains(eligible, position) {
+				startable = true
+				if open[i] {
+					return needStarter
+				}
+			}
+		}
+		if benchPick && startable && !slices.Contains(noBenchPositions, position) {
+			return needBench
+		}
+		return needNone
+	}
+}
+
+// openSlots fills d.slots with roster, most specific slot first, and
+// reports which are still open.
+func (d *Draft) openSlots(roster []string) []bool {
+	open := make([]bool, len(d.slots))
+	for i := range open {
+		open[i] = true
+	}
+	for _, pos := range roster {
+		for i, eligible := range d.slots {
+			if open[i] && slices.Contains(eligible, pos) {
+				open[i] = false
+				break
+			}
+		}
+	}
+	return open
+}
+
+// clone copies the draft's mutable state.
+func (d *Draft) clone() *Draft {
+	c := *d
+	c.Picks = slices.Clone(d.Picks)
+	c.taken = slices.Clone(d.taken)
+	c.rosters = make([][]string, len(d.rosters))
+	for i, r := range d.rosters {
+		c.rosters[i] = slices.Clone(r)
+	}
+	return &c
+}
+
+// Availability is how likely one player is to still be available at each of
+// a seat's upcoming picks.
+type Availability struct {
+	PlayerID string
+	// Probabilities[i] is the share of simulations the player was
+	// undrafted when upcoming pick i came up.
+	Probabilities []float64
+}
+
+// SimulateAvailability estimates, over sims runs, how likely each of the
+// limit highest-ADP available players is to be on the board at each of
+// picks — slot's upcoming overall pick numbers (see Config.PicksForSlot),
+// ascending. Every seat, slot's own included, picks by the ADP model in
+// between. A pick already on the clock is certain for everyone available.
+func (d *Draft) SimulateAvailability(picks []int, limit, sims int, rng *rand.Rand) []Availability {
+	return d.simulate(picks, 0, limit, sims, rng)
+}
+
+// simulate is SimulateAvailability, except that pick number pass, if one of
+// picks, is passed on — a placeholder off the board — rather than made by
+// the model, so availability after it is conditional on the seat not
+// taking any tracked player there.
+func (d *Draft) simulate(picks []int, pass, limit, sims int, rng *rand.Rand) []Availability {
+	tracked := make([]int, 0, limit)
+	for i := range d.Pool {
+		if len(tracked) == limit {
+			break
+		}
+		if !d.taken[i] {
+			tracked = append(tracked, i)
+		}
+	}
+	counts := make([][]int, len(tracked))
+	for i := range counts {
+		counts[i] = make([]int, len(picks))
+	}
+
+	for range sims {
+		sim := d.clone()
+		draws := sim.sample(rng)
+		for k, target := range picks {
+			for pickNo := sim.NextPick(); pickNo != 0 && pickNo < target; pickNo = sim.NextPick() {
+				if pickNo == pass {
+					sim.record("", true)
+					continue
+				}
+				i := sim.choose(sim.SlotForPick(pickNo), draws)
+				if i < 0 {
+					break
+				}
+				sim.take(i)
+			}
+			for t, i := range tracked {
+				if !sim.taken[i] {
+					counts[t][k]++
+				}
+			}
+		}
+	}
+
+	out := make([]Availability, len(tracked))
+	for t, i := range tracked {
+		probs := make([]float64, len(picks))
+		for k, n := range counts[t] {
+			if sims > 0 {
+				probs[k] = float64(n) / float64(sims)
+			}
+		}
+		out[t] = Availability{PlayerID: d.Pool[i].ID, Probabilities: probs}
+	}
+	return out
+}
@@ -0,0 +1,174 @@
+package mockdraft_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"backend/internal/mockdraft"
+)
+
+func TestConfig_SnakeOrder(t *testing.T) {
+	cfg := mockdraft.Config{Teams: 4, Rounds: 3}
+	var slots []int
+	for pick := 1; pick <= cfg.TotalPicks(); pick++ {
+		slots = append(slots, cfg.SlotForPick(pick))
+	}
+	if want := []int{1, 2, 3, 4, 4, 3, 2, 1, 1, 2, 3, 4}; !slices.Equal(slots, want) {
+		t.Errorf("snake order = %v, want %v", slots, want)
+	}
+	if got := cfg.PicksForSlot(2, 2); !slices.Equal(got, []int{7, 10}) {
+		t.Errorf("PicksForSlot(2, after 2) = %v, want [7 10]", got)
+	}
+}
+
+func TestConfig_ReversalRoundAndTradedPicks(t *testing.T) {
+	cfg := mockdraft.Config{Teams: 3, Rounds: 4, ReversalRound: 3, Owners: map[int]int{2: 1}}
+	var slots []int
+	for pick := 1; pick <= cfg.TotalPicks(); pick++ {
+		slots = append(slots, cfg.SlotForPick(pick))
+	}
+	if want := []int{1, 1, 3, 3, 2, 1, 3, 2, 1, 1, 2, 3}; !slices.Equal(slots, want) {
+		t.Errorf("order = %v, want %v", slots, want)
+	}
+	if got := cfg.PicksForSlot(1, 0); !slices.Equal(got, []int{1, 2, 6, 9, 10}) {
+		t.Errorf("PicksForSlot(1, after 0) = %v, want [1 2 6 9 10]", got)
+	}
+	if got := cfg.PickNo(3, 1); got != 9 {
+		t.Errorf("PickNo(round 3, slot 1) = %d, want 9", got)
+	}
+}
+
+func TestPlayerFromADP_SpreadFromCIOrRange(t *testing.T) {
+	if p := mockdraft.PlayerFromADP("p1", "RB", 10, 6.08, 13.92, 1, 30); p.StdDev < 1.99 || p.StdDev > 2.01 {
+		t.Errorf("expected a CI of +/-3.92 to give a std dev of 2, got %v", p.StdDev)
+	}
+	if p := mockdraft.PlayerFromADP("p1", "RB", 10, 0, 0, 2, 22); p.StdDev != 5 {
+		t.Errorf("expected a 20-pick range to give a std dev of 5, got %v", p.StdDev)
+	}
+	if p := mockdraft.PlayerFromADP("p1", "RB", 10, 0, 0, 10, 10); p.StdDev != 1.5 {
+		t.Errorf("expected the std dev floor, got %v", p.StdDev)
+	}
+}
+
+// testPool is 40 players in a strict ADP order — QBs, RBs, WRs, TEs and Ks
+// interleaved — with spreads tight enough that bots draft in ADP order
+// unless roster needs say otherwise.
+func testPool() []mockdraft.Player {
+	positions := []string{"RB", "WR", "QB", "TE", "K"}
+	pool := make([]mockdraft.Player, 40)
+	for i := range pool {
+		pool[i] = mockdraft.Player{
+			ID:       fmt.Sprintf("p%d", i+1),
+			Position: positions[i%len(positions)],
+			ADP:      float64(i + 1),
+			StdDev:   0.01,
+		}
+	}
+	return pool
+}
+
+func TestDraft_AutoPickStopsAtUserAndFollowsADP(t *testing.T) {
+	cfg := mockdraft.Config{Teams: 4, RosterPositions: []string{"QB", "RB", "WR", "TE", "FLEX", "K", "BN"}}
+	d, err := mockdraft.New(cfg, testPool(), nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	picked := d.AutoPick(3, rand.New(rand.NewSource(1)))
+	if !slices.Equal(picked, []string{"p1", "p2"}) {
+		t.Errorf("expected seats 1 and 2 to take p1 and p2, got %v", picked)
+	}
+	if d.NextPick() != 3 || d.SlotForPick(d.NextPick()) != 3 {
+		t.Fatalf("expected seat 3 on the clock at pick 3, got pick %d", d.NextPick())
+	}
+
+	if err := d.Pick("p1"); !errors.Is(err, mockdraft.ErrPlayerUnavailable) {
+		t.Errorf("expected ErrPlayerUnavailable for a drafted player, got %v", err)
+	}
+	if err := d.Pick("p3"); err != nil {
+		t.Fatalf("Pick: %v", err)
+	}
+	if got := d.Roster(3); !slices.Equal(got, []string{"QB"}) {
+		t.Errorf("expected seat 3's roster to be [QB], got %v", got)
+	}
+}
+
+func TestDraft_BotsRespectRosterNeeds(t *testing.T) {
+	// Two teams, two rounds, a QB and a K slot and no bench: seat 1 takes
+	// the top QB, then must take a kicker over every better-ranked QB.
+	pool := []mockdraft.Player{
+		{ID: "qb1", Position: "QB", ADP: 1, StdDev: 0.01},
+		{ID: "qb2", Position: "QB", ADP: 2, StdDev: 0.01},
+		{ID: "qb3", Position: "QB", ADP: 3, StdDev: 0.01},
+		{ID: "qb4", Position: "QB", ADP: 4, StdDev: 0.01},
+		{ID: "k1", Position: "K", ADP: 20, StdDev: 0.01},
+		{ID: "k2", Position: "K", ADP: 21, StdDev: 0.01},
+	}
+	d, err := mockdraft.New(mockdraft.Config{Teams: 2, RosterPositions: []string{"QB", "K"}}, pool, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	picked := d.AutoPick(0, rand.New(rand.NewSource(1)))
+	if !slices.Equal(picked, []string{"qb1", "qb2", "k1", "k2"}) {
+		t.Errorf("expected QBs then kickers, got %v", picked)
+	}
+	if d.NextPick() != 0 {
+		t.Errorf("expected the draft to be complete")
+	}
+	if err := d.Pick("qb3"); !errors.Is(err, mockdraft.ErrDraftComplete) {
+		t.Errorf("expected ErrDraftComplete, got %v", err)
+	}
+}
+
+func TestNew_ReplaysPicksIncludingUnknownPlayers(t *testing.T) {
+	cfg := mockdraft.Config{Teams: 4, Rounds: 3}
+	d, err := mockdraft.New(cfg, testPool(), []string{"p2", "retired", "p1"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if d.NextPick() != 4 {
+		t.Errorf("expected pick 4 on the clock, got %d", d.NextPick())
+	}
+	if got := d.Available()[0].ID; got != "p3" {
+		t.Errorf("expected p3 to top the board, got %s", got)
+	}
+	if _, err := mockdraft.New(cfg, testPool(), []string{"p1", "p1"}); !errors.Is(err, mockdraft.ErrPlayerUnavailable) {
+		t.Errorf("expected a duplicate pick to fail with ErrPlayerUnavailable, got %v", err)
+	}
+}
+
+func TestDraft_SimulateAvailability(t *testing.T) {
+	cfg := mockdraft.Config{Teams: 4, Rounds: 3}
+	pool := testPool()
+	// p6 has a wide spread among tight neighbours: it lasts to pick 8 only
+	// when drawn after p8, about a quarter of the time.
+	pool[5].StdDev = 3
+	d, err := mockdraft.New(cfg, pool, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	d.AutoPick(1, rand.New(rand.NewSource(1)))
+	picks := cfg.PicksForSlot(1, 0)
+	avail := d.SimulateAvailability(picks, 10, 2000, rand.New(rand.NewSource(7)))
+	if len(avail) != 10 || avail[0].PlayerID != "p1" {
+		t.Fatalf("expected the top 10 by ADP starting with p1, got %d from %v", len(avail), avail[0].PlayerID)
+	}
+	// Pick 1 is on the clock: everyone is available.
+	for _, a := range avail {
+		if a.Probabilities[0] != 1 {
+			t.Errorf("%s: expected certain availability at the pick on the clock, got %v", a.PlayerID, a.Probabilities[0])
+		}
+	}
+	// By pick 8 seven players are gone: p1-p4 (seat 1 included) for certain.
+	if p := avail[0].Probabilities[1]; p != 0 {
+		t.Errorf("expected p1 gone by pick 8, got %v", p)
+	}
+	if p := avail[9].Probabilities[1]; p != 1 {
+		t.Errorf("expected p10 still there at pick 8, got %v", p)
+	}
+	if p := avail[5].Probabilities[1]; p <= 0.05 || p >= 0.95 {
+		t.Errorf("expected p6's availability at pick 8 to be uncertain, got %v", p)
+	}
+}
@@ -46,6 +46,8 @@ type ArchiveSleeperTransaction struct {
 	Drops                json.RawMessage `gorm:"column:drops;type:jsonb"`
 	DraftPicks           json.RawMessage `gorm:"column:draft_picks;type:jsonb"`
 	WaiverBudget         json.RawMessage `gorm:"column:waiver_budget;type:jsonb"`
+	WaiverBid            *int            `gorm:"column:waiver_bid"`
+	WaiverBidPct         *float64        `gorm:"column:waiver_bid_pct"`
 	CreatedAt            time.Time       `gorm:"column:created_at"`
 }
 
@@ -57,6 +59,9 @@ type ArchiveSleeperDraft struct {
 	Type            string     `gorm:"column:type"`
 	Status          string     `gorm:"column:status"`
 	Season          string     `gorm:"column:season"`
+	Budget          *int       `gorm:"column:budget"`
+	StartTime       *int64     `gorm:"column:start_time"`
+	Rounds          *int       `gorm:"column:rounds"`
 	LastFetchedAt   *time.Time `gorm:"column:last_fetched_at"`
 	CreatedAt       time.Time  `gorm:"column:created_at"`
 	UpdatedAt       time.Time  `gorm:"column:updated_at"`
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// AuctionReferenceBudget is the per-team budget auction prices are
+// normalized to, Sleeper's default. A draft without a recorded budget is
+// assumed to have used it.
+const AuctionReferenceBudget = 200
+
+// AuctionValue is one player's average auction value rollup for a single
+// (segment, season) — the auction counterpart to DraftADP, upserted daily by
+// the ADP rollup Temporal worker from completed redraft Sleeper auction
+// drafts. Every value is a price normalized to AuctionReferenceBudget;
+// PickCount is the number of drafts the player was bought in.
+type AuctionValue struct {
+	Segment         string    `gorm:"primaryKey;column:segment"`
+	Season          string    `gorm:"primaryKey;column:season"`
+	SleeperPlayerID string    `gorm:"primaryKey;column:sleeper_player_id"`
+	AvgValue        float64   `gorm:"column:avg_value"`
+	PickCount       int       `gorm:"column:pick_count"`
+	MinValue        float64   `gorm:"column:min_value"`
+	MaxValue        float64   `gorm:"column:max_value"`
+	P10Value        float64   `gorm:"column:p10_value"`
+	P50Value        float64   `gorm:"column:p50_value"`
+	P90Value        float64   `gorm:"column:p90_value"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (AuctionValue) TableName() string { return "auction_values" }
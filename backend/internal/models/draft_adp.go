@@ -1,10 +1,15 @@
 package models
 
-import "time"
+import (
+	"math/bits"
+	"slices"
+	"strconv"
+	"time"
+)
 
 // DraftADP is one player's average-draft-position rollup for a single
 // (segment, season) — upserted daily by the ADP rollup Temporal worker from
-// completed snake/linear redraft Sleeper drafts.
+// completed snake/linear Sleeper drafts of the segment's format.
 type DraftADP struct {
 	Segment         string    `gorm:"primaryKey;column:segment"`
 	Season          string    `gorm:"primaryKey;column:season"`
@@ -20,6 +25,22 @@ type DraftADP struct {
 
 func (DraftADP) TableName() string { return "draft_adp" }
 
+// DraftADPWeek is one player's ADP over the drafts in a (segment, season)
+// that started in one week — WeekStart is that week's Monday, UTC. The
+// series of weeks shows how a player's ADP moved through the draft season;
+// upserted alongside DraftADP by the ADP rollup.
+type DraftADPWeek struct {
+	Segment         string    `gorm:"primaryKey;column:segment"`
+	Season          string    `gorm:"primaryKey;column:season"`
+	WeekStart       time.Time `gorm:"primaryKey;column:week_start;type:date"`
+	SleeperPlayerID string    `gorm:"primaryKey;column:sleeper_player_id"`
+	AvgPickNo       float64   `gorm:"column:avg_pick_no"`
+	PickCount       int       `gorm:"column:pick_count"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (DraftADPWeek) TableName() string { return "draft_adp_weekly" }
+
 // ADPLeagueSizes are the league_size filter/bucket values ADP is computed
 // for. "14+" buckets every league with total_rosters >= 14.
 var ADPLeagueSizes = []string{"8", "10", "12", "14+"}
@@ -28,19 +49,122 @@ var ADPLeagueSizes = []string{"8", "10", "12", "14+"}
 // computed for, matching sleeper_leagues.ppr: 0, 0.5, 1.
 var ADPScoringFormats = []string{"standard", "half_ppr", "ppr"}
 
-// ADPSegment is one (league_size, scoring_format, superflex) combination.
+// ADP formats: which kind of draft a segment's ADP comes from. Redraft,
+// keeper and the two dynasty formats are separate products — a dynasty
+// startup values players over their careers and a rookie draft only holds
+// rookies, so mixing them into redraft ADP would skew it.
+const (
+	// ADPFormatRedraft is every draft in a redraft league.
+	ADPFormatRedraft = "redraft"
+	// ADPFormatStartup is a dynasty league's startup draft: more than
+	// DynastyRookieMaxRounds rounds.
+	ADPFormatStartup = "startup"
+	// ADPFormatRookie is a dynasty league's rookie draft: at most
+	// DynastyRookieMaxRounds rounds.
+	ADPFormatRookie = "rookie"
+	// ADPFormatKeeper is every draft in a keeper league.
+	ADPFormatKeeper = "keeper"
+)
+
+// ADPFormats are the format filter/bucket values ADP is computed for.
+var ADPFormats = []string{ADPFormatRedraft, ADPFormatStartup, ADPFormatRookie, ADPFormatKeeper}
+
+// DynastyRookieMaxRounds is the most rounds a dynasty league's draft can have
+// and still count as a rookie draft rather than a startup. Rookie drafts run
+// a handful of rounds; startups fill whole rosters.
+const DynastyRookieMaxRounds = 6
+
+// Optional ADP segment dimensions, refining a segment within its league
+// size, scoring and format. Unlike the base dimensions these are only
+// materialized for combinations with enough drafts behind them, so a
+// refined segment may have no rows.
+var (
+	// ADPTEPremiums split on sleeper_leagues.te_premium (any bonus per TE
+	// reception, or none).
+	ADPTEPremiums = []string{"tep", "no_tep"}
+	// ADPPassTDs split on scoring_settings.pass_td; leagues scoring passing
+	// TDs any other way are in neither bucket.
+	ADPPassTDs = []string{"4pt", "6pt"}
+	// ADPLineups split on the number of starting slots in roster_positions
+	// (everything but bench, IR and taxi): small <= 8, standard 9-10,
+	// deep >= 11.
+	ADPLineups = []string{"small", "standard", "deep"}
+)
+
+// ADPSegment is one (league_size, scoring_format, superflex, format)
+// combination, optionally refined by TE premium, passing TD value and lineup
+// shape. An empty Format is redraft; an empty refinement is "any".
 type ADPSegment struct {
 	LeagueSize    string
 	ScoringFormat string
 	Superflex     bool
+	Format        string
+	TEPremium     string
+	PassTD        string
+	Lineup        string
 }
 
-// Key returns the segment's storage/lookup key, e.g. "12-ppr-sf" or "10-half_ppr-1qb".
+// Key returns the segment's storage/lookup key, e.g. "12-ppr-sf" or
+// "10-half_ppr-1qb" for redraft, with the format appended otherwise
+// ("12-ppr-sf-startup"), so existing redraft keys are unchanged. Refinements
+// follow in a fixed order: "12-ppr-sf-tep-6pt-deep".
 func (s ADPSegment) Key() string {
-	return ADPSegmentKey(s.LeagueSize, s.ScoringFormat, s.Superflex)
+	key := ADPSegmentKey(s.LeagueSize, s.ScoringFormat, s.Superflex)
+	if s.Format != "" && s.Format != ADPFormatRedraft {
+		key += "-" + s.Format
+	}
+	for _, dim := range []string{s.TEPremium, s.PassTD, s.Lineup} {
+		if dim != "" {
+			key += "-" + dim
+		}
+	}
+	return key
+}
+
+// Refined reports whether the segment sets any optional dimension.
+func (s ADPSegment) Refined() bool {
+	return s.TEPremium != "" || s.PassTD != "" || s.Lineup != ""
+}
+
+// Base returns the segment with its optional dimensions cleared.
+func (s ADPSegment) Base() ADPSegment {
+	s.TEPremium, s.PassTD, s.Lineup = "", "", ""
+	return s
+}
+
+// Fallbacks returns the segment and every coarser segment obtained by
+// dropping optional dimensions, nearest first: more dimensions kept comes
+// first and, between equals, lineup is dropped before passing TD before TE
+// premium. The base segment is always last.
+func (s ADPSegment) Fallbacks() []ADPSegment {
+	var out []ADPSegment
+	for kept := 3; kept >= 0; kept-- {
+		// Bits of mask are the dimensions kept: TE premium, passing TD,
+		// lineup, from most to least significant — so higher masks keep the
+		// more important dimensions.
+		for mask := 7; mask >= 0; mask-- {
+			if bits.OnesCount(uint(mask)) != kept {
+				continue
+			}
+			f := s.Base()
+			if mask&4 != 0 {
+				f.TEPremium = s.TEPremium
+			}
+			if mask&2 != 0 {
+				f.PassTD = s.PassTD
+			}
+			if mask&1 != 0 {
+				f.Lineup = s.Lineup
+			}
+			if !slices.ContainsFunc(out, func(o ADPSegment) bool { return o == f }) {
+				out = append(out, f)
+			}
+		}
+	}
+	return out
 }
 
-// ADPSegmentKey builds a segment key from bucketed filter values.
+// ADPSegmentKey builds a redraft segment key from bucketed filter values.
 func ADPSegmentKey(leagueSize, scoringFormat string, superflex bool) string {
 	sf := "1qb"
 	if superflex {
@@ -50,19 +174,72 @@ func ADPSegmentKey(leagueSize, scoringFormat string, superflex bool) string {
 }
 
 // AllADPSegments enumerates every ADP segment: the full cross product of
-// ADPLeagueSizes x ADPScoringFormats x {superflex, 1qb} (24 segments).
+// ADPFormats x ADPLeagueSizes x ADPScoringFormats x {superflex, 1qb}
+// (96 segments).
 func AllADPSegments() []ADPSegment {
-	segments := make([]ADPSegment, 0, len(ADPLeagueSizes)*len(ADPScoringFormats)*2)
-	for _, size := range ADPLeagueSizes {
-		for _, scoring := range ADPScoringFormats {
-			for _, superflex := range []bool{true, false} {
-				segments = append(segments, ADPSegment{
-					LeagueSize:    size,
-					ScoringFormat: scoring,
-					Superflex:     superflex,
-				})
+	segments := make([]ADPSegment, 0, len(ADPFormats)*len(ADPLeagueSizes)*len(ADPScoringFormats)*2)
+	for _, format := range ADPFormats {
+		for _, size := range ADPLeagueSizes {
+			for _, scoring := range ADPScoringFormats {
+				for _, superflex := range []bool{true, false} {
+					segments = append(segments, ADPSegment{
+						LeagueSize:    size,
+						ScoringFormat: scoring,
+						Superflex:     superflex,
+						Format:        format,
+					})
+				}
 			}
 		}
 	}
 	return segments
 }
+
+// ADPSegmentForLeague buckets a league's settings into its ADP segment —
+// the Go-side twin of the ADP rollup's SQL predicate. Format is left empty
+// (redraft keys), which is how the trending and FAAB rollups key their
+// populations whatever the league type. ok is false for a
+// league no segment covers: an unbucketed size (9, 11, 13 teams), a ppr value
+// other than 0/0.5/1, or settings not yet fetched.
+func ADPSegmentForLeague(totalRosters int, ppr *float64, isSuperflex *bool) (ADPSegment, bool) {
+	if ppr == nil || isSuperflex == nil {
+		return ADPSegment{}, false
+	}
+	var size string
+	switch {
+	case totalRosters >= 14:
+		size = "14+"
+	case totalRosters == 8 || totalRosters == 10 || totalRosters == 12:
+		size = strconv.Itoa(totalRosters)
+	default:
+		return ADPSegment{}, false
+	}
+	var scoring string
+	switch *ppr {
+	case 0:
+		scoring = "standard"
+	case 0.5:
+		scoring = "half_ppr"
+	case 1:
+		scoring = "ppr"
+	default:
+		return ADPSegment{}, false
+	}
+	return ADPSegment{LeagueSize: size, ScoringFormat: scoring, Superflex: *isSuperflex}, true
+}
+
+// ADPFormatForLeague returns the ADP format of a draft with rounds rounds in
+// a league of leagueType — the Go-side twin of the ADP rollup's format
+// predicate. Unknown league types are redraft.
+func ADPFormatForLeague(leagueType string, rounds int) string {
+	switch leagueType {
+	case "keeper":
+		return ADPFormatKeeper
+	case "dynasty":
+		if rounds > DynastyRookieMaxRounds {
+			return ADPFormatStartup
+		}
+		return ADPFormatRookie
+	}
+	return ADPFormatRedraft
+}
@@ -60,12 +60,28 @@ func TestDraftADP_CIFieldsRoundTrip(t *testing.T) {
 	}
 }
 
-func TestAllADPSegments_Has24UniqueKeys(t *testing.T) {
+func TestADPSegment_KeyAppendsNonRedraftFormat(t *testing.T) {
+	cases := map[string]string{
+		"":                      "12-ppr-sf",
+		models.ADPFormatRedraft: "12-ppr-sf",
+		models.ADPFormatStartup: "12-ppr-sf-startup",
+		models.ADPFormatRookie:  "12-ppr-sf-rookie",
+		models.ADPFormatKeeper:  "12-ppr-sf-keeper",
+	}
+	for format, want := range cases {
+		seg := models.ADPSegment{LeagueSize: "12", ScoringFormat: "ppr", Superflex: true, Format: format}
+		if got := seg.Key(); got != want {
+			t.Errorf("format %q: Key() = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func TestAllADPSegments_Has96UniqueKeys(t *testing.T) {
 	segments := models.AllADPSegments()
-	if len(segments) != 24 {
-		t.Fatalf("expected 24 segments, got %d", len(segments))
+	if len(segments) != 96 {
+		t.Fatalf("expected 96 segments, got %d", len(segments))
 	}
-	seen := make(map[string]bool, 24)
+	seen := make(map[string]bool, 96)
 	for _, s := range segments {
 		key := s.Key()
 		if seen[key] {
@@ -74,3 +90,56 @@ func TestAllADPSegments_Has24UniqueKeys(t *testing.T) {
 		seen[key] = true
 	}
 }
+
+func TestADPSegmentForLeague(t *testing.T) {
+	ppr, half, odd := 1.0, 0.5, 0.25
+	sf, oneQB := true, false
+	cases := []struct {
+		rosters   int
+		ppr       *float64
+		superflex *bool
+		want      string
+	}{
+		{12, &ppr, &sf, "12-ppr-sf"},
+		{10, &half, &oneQB, "10-half_ppr-1qb"},
+		{16, &ppr, &sf, "14+-ppr-sf"},
+		{11, &ppr, &sf, ""},
+		{12, &odd, &sf, ""},
+		{12, nil, &sf, ""},
+		{12, &ppr, nil, ""},
+	}
+	for _, c := range cases {
+		seg, ok := models.ADPSegmentForLeague(c.rosters, c.ppr, c.superflex)
+		if got := seg.Key(); ok != (c.want != "") || (ok && got != c.want) {
+			t.Errorf("ADPSegmentForLeague(%d, ...) = %q ok=%v, want %q", c.rosters, got, ok, c.want)
+		}
+	}
+}
+
+func TestADPSegment_FallbacksNearestFirst(t *testing.T) {
+	seg := models.ADPSegment{LeagueSize: "12", ScoringFormat: "ppr", Superflex: true, TEPremium: "tep", PassTD: "6pt", Lineup: "deep"}
+	want := []string{
+		"12-ppr-sf-tep-6pt-deep",
+		"12-ppr-sf-tep-6pt",
+		"12-ppr-sf-tep-deep",
+		"12-ppr-sf-6pt-deep",
+		"12-ppr-sf-tep",
+		"12-ppr-sf-6pt",
+		"12-ppr-sf-deep",
+		"12-ppr-sf",
+	}
+	got := seg.Fallbacks()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d fallbacks, got %d", len(want), len(got))
+	}
+	for i, f := range got {
+		if f.Key() != want[i] {
+			t.Errorf("fallback %d = %q, want %q", i, f.Key(), want[i])
+		}
+	}
+
+	partial := models.ADPSegment{LeagueSize: "10", ScoringFormat: "half_ppr", Lineup: "small"}
+	if got := partial.Fallbacks(); len(got) != 2 || got[0].Key() != "10-half_ppr-1qb-small" || got[1].Key() != "10-half_ppr-1qb" {
+		t.Errorf("unexpected fallbacks for a lineup-only segment: %v", got)
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// FAABBidStat is the distribution of one player's winning FAAB bids in one
+// week (a transaction leg) of one season, for one segment (an ADPSegment key
+// or TrendSegmentAll). Quantiles interpolate linearly between bids, like
+// Postgres's percentile_cont. The *BidPct quantiles are over bids as a
+// percentage of their league's budget, and are nil when no bid that week
+// came from a league with a known budget. Recomputed by the market-pulse job
+// — see trendingcron.RunFAABRollup.
+type FAABBidStat struct {
+	Segment         string    `gorm:"primaryKey;column:segment"`
+	Season          string    `gorm:"primaryKey;column:season"`
+	Week            int       `gorm:"primaryKey;column:week"`
+	SleeperPlayerID string    `gorm:"primaryKey;column:sleeper_player_id"`
+	Bids            int       `gorm:"column:bids"`
+	MedianBid       float64   `gorm:"column:median_bid"`
+	P75Bid          float64   `gorm:"column:p75_bid"`
+	P90Bid          float64   `gorm:"column:p90_bid"`
+	MedianBidPct    *float64  `gorm:"column:median_bid_pct"`
+	P75BidPct       *float64  `gorm:"column:p75_bid_pct"`
+	P90BidPct       *float64  `gorm:"column:p90_bid_pct"`
+	ComputedAt      time.Time `gorm:"column:computed_at"`
+}
+
+func (FAABBidStat) TableName() string { return "faab_bid_stats" }
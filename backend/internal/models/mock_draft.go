@@ -0,0 +1,28 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MockDraft is one mock draft in progress: a snake draft over a segment's
+// ADP pool where UserSlot is picked through the API and every other seat is
+// simulated (see internal/mockdraft). Picks holds the drafted Sleeper player
+// IDs in pick order; Seed, with the pick number, seeds each run of simulated
+// picks so they replay identically. ID is random hex, not a sequence: it's
+// the draft's only access token.
+type MockDraft struct {
+	ID              string          `gorm:"primaryKey;column:id"`
+	Segment         string          `gorm:"column:segment"`
+	Season          string          `gorm:"column:season"`
+	Teams           int             `gorm:"column:teams"`
+	Rounds          int             `gorm:"column:rounds"`
+	UserSlot        int             `gorm:"column:user_slot"`
+	RosterPositions json.RawMessage `gorm:"column:roster_positions;type:jsonb"`
+	Picks           json.RawMessage `gorm:"column:picks;type:jsonb"`
+	Seed            int64           `gorm:"column:seed"`
+	CreatedAt       time.Time       `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time       `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (MockDraft) TableName() string { return "mock_drafts" }
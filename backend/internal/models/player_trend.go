@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// TrendSegmentAll is the player_trends segment counting every scraped league,
+// whatever its format — alongside the per-ADPSegment rows.
+const TrendSegmentAll = "all"
+
+// TrendWindows are the rolling windows player_trends is computed over, keyed
+// by the time_window value stored for each.
+var TrendWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+}
+
+// PlayerTrend is one player's transaction activity across the scraped
+// Sleeper population over one rolling window, for one segment (an
+// ADPSegment key or TrendSegmentAll). Rewritten wholesale by the hourly
+// market-pulse job — see trendingcron.RunMarketPulse. Adds counts free-agent
+// and waiver pickups, of which WaiverClaims were waiver claims; Trades counts
+// completed trades that moved the player.
+type PlayerTrend struct {
+	Segment         string    `gorm:"primaryKey;column:segment"`
+	TimeWindow      string    `gorm:"primaryKey;column:time_window"`
+	SleeperPlayerID string    `gorm:"primaryKey;column:sleeper_player_id"`
+	Adds            int       `gorm:"column:adds"`
+	Drops           int       `gorm:"column:drops"`
+	WaiverClaims    int       `gorm:"column:waiver_claims"`
+	Trades          int       `gorm:"column:trades"`
+	ComputedAt      time.Time `gorm:"column:computed_at"`
+}
+
+func (PlayerTrend) TableName() string { return "player_trends" }
@@ -31,12 +31,15 @@ type SleeperLeague struct {
 	IsSuperflex               *bool           `gorm:"column:is_superflex"`
 	DraftType                 string          `gorm:"column:draft_type"`
 	LeagueType                string          `gorm:"column:league_type"`
+	DraftRounds               *int            `gorm:"column:draft_rounds"`
+	WaiverBudget              *int            `gorm:"column:waiver_budget"`
 	ScoringSettings           json.RawMessage `gorm:"column:scoring_settings;type:jsonb"`
 	RosterPositions           json.RawMessage `gorm:"column:roster_positions;type:jsonb"`
 	LastFetchedAt             *time.Time      `gorm:"column:last_fetched_at"`
 	LastDraftsFetchedAt       *time.Time      `gorm:"column:last_drafts_fetched_at"`
 	LastTransactionsFetchedAt *time.Time      `gorm:"column:last_transactions_fetched_at"`
 	LastTransactionLegFetched *int            `gorm:"column:last_transaction_leg_fetched"`
+	LastRostersFetchedAt      *time.Time      `gorm:"column:last_rosters_fetched_at"`
 	ClaimedAt                 *time.Time      `gorm:"column:claimed_at"`
 	DraftsClaimedAt           *time.Time      `gorm:"column:drafts_claimed_at"`
 	DiscoveryClaimedAt        *time.Time      `gorm:"column:discovery_claimed_at"`
@@ -76,6 +79,9 @@ type SleeperDraft struct {
 	Type            string     `gorm:"column:type"`
 	Status          string     `gorm:"column:status"`
 	Season          string     `gorm:"column:season"`
+	Budget          *int       `gorm:"column:budget"`
+	StartTime       *int64     `gorm:"column:start_time"`
+	Rounds          *int       `gorm:"column:rounds"`
 	LastFetchedAt   *time.Time `gorm:"column:last_fetched_at"`
 	CreatedAt       time.Time  `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt       time.Time  `gorm:"column:updated_at;autoUpdateTime"`
@@ -114,12 +120,65 @@ type SleeperTransaction struct {
 	// pending); ReconcileTradeValues gates on this field, not on
 	// TradeValues being null, so a partially-resolved trade keeps getting
 	// retried instead of being treated as settled.
-	TradeValuesComplete bool      `gorm:"column:trade_values_complete"`
-	CreatedAt           time.Time `gorm:"column:created_at;autoCreateTime"`
+	TradeValuesComplete bool `gorm:"column:trade_values_complete"`
+	// WaiverBid is the winning FAAB bid on a complete waiver claim, and
+	// WaiverBidPct that bid as a percentage (0-100) of the league's
+	// budget; both nil on every other transaction, and WaiverBidPct also
+	// when the league's budget isn't known.
+	WaiverBid    *int      `gorm:"column:waiver_bid"`
+	WaiverBidPct *float64  `gorm:"column:waiver_bid_pct"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
 }
 
 func (SleeperTransaction) TableName() string { return "sleeper_transactions" }
 
+// SleeperTradedPick is the current owner of one draft pick that has left its
+// original roster, as of the league's last traded_picks fetch. A pick with no
+// row is still owned by its original roster (RosterID). Rewritten wholesale
+// for the league on each fetch — see transactioncron.FlushLeagueTransactions.
+type SleeperTradedPick struct {
+	SleeperLeagueID string    `gorm:"primaryKey;column:sleeper_league_id"`
+	Season          string    `gorm:"primaryKey;column:season"`
+	Round           int       `gorm:"primaryKey;column:round"`
+	RosterID        int       `gorm:"primaryKey;column:roster_id"`
+	OwnerID         int       `gorm:"column:owner_id"`
+	PreviousOwnerID int       `gorm:"column:previous_owner_id"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (SleeperTradedPick) TableName() string { return "sleeper_traded_picks" }
+
+// SleeperPickTrade is one pick moving between rosters in a completed trade —
+// the pick trade history the ledger's current ownership is the sum of.
+// SleeperTransactionID groups the picks exchanged in one deal (and matches
+// the trade's sleeper_transactions row, when it has one).
+type SleeperPickTrade struct {
+	SleeperTransactionID string `gorm:"primaryKey;column:sleeper_transaction_id"`
+	Season               string `gorm:"primaryKey;column:season"`
+	Round                int    `gorm:"primaryKey;column:round"`
+	RosterID             int    `gorm:"primaryKey;column:roster_id"`
+	SleeperLeagueID      string `gorm:"column:sleeper_league_id"`
+	CreatedAtSleeper     int64  `gorm:"column:created_at_sleeper"`
+	PreviousOwnerID      int    `gorm:"column:previous_owner_id"`
+	OwnerID              int    `gorm:"column:owner_id"`
+}
+
+func (SleeperPickTrade) TableName() string { return "sleeper_pick_trades" }
+
+// SleeperRosterSnapshot is one roster's owner and players from TakenAt until
+// the roster's next snapshot. Players is a sorted JSON array of sleeper
+// player IDs. A row is only appended when the owner or players change — see
+// transactioncron.FlushLeagueTransactions.
+type SleeperRosterSnapshot struct {
+	SleeperLeagueID string          `gorm:"primaryKey;column:sleeper_league_id"`
+	RosterID        int             `gorm:"primaryKey;column:roster_id"`
+	TakenAt         time.Time       `gorm:"primaryKey;column:taken_at"`
+	OwnerID         string          `gorm:"column:owner_id"`
+	Players         json.RawMessage `gorm:"column:players;type:jsonb"`
+}
+
+func (SleeperRosterSnapshot) TableName() string { return "sleeper_roster_snapshots" }
+
 type SleeperPlayerWeekStat struct {
 	Season          string          `gorm:"primaryKey;column:season"`
 	Week            int             `gorm:"primaryKey;column:week"`
@@ -143,6 +202,148 @@ type SleeperWeekStatFetch struct {
 
 func (SleeperWeekStatFetch) TableName() string { return "sleeper_week_stat_fetches" }
 
+// SleeperPlayerWeekProjection is one player's Sleeper projection for a week,
+// the projected counterpart of SleeperPlayerWeekStat: projected points under
+// each scoring type plus the raw projected stat object. Refetched until the
+// week is finalized (see SleeperWeekProjectionFetch), so the stored row is
+// the last projection Sleeper published before the week ended.
+type SleeperPlayerWeekProjection struct {
+	Season          string          `gorm:"primaryKey;column:season"`
+	Week            int             `gorm:"primaryKey;column:week"`
+	SleeperPlayerID string          `gorm:"primaryKey;column:sleeper_player_id"`
+	PtsPPR          *float64        `gorm:"column:pts_ppr"`
+	PtsHalfPPR      *float64        `gorm:"column:pts_half_ppr"`
+	PtsStd          *float64        `gorm:"column:pts_std"`
+	Stats           json.RawMessage `gorm:"column:stats;type:jsonb"`
+	CreatedAt       time.Time       `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time       `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (SleeperPlayerWeekProjection) TableName() string { return "sleeper_player_week_projections" }
+
+type SleeperWeekProjectionFetch struct {
+	Season        string     `gorm:"primaryKey;column:season"`
+	Week          int        `gorm:"primaryKey;column:week"`
+	LastFetchedAt *time.Time `gorm:"column:last_fetched_at"`
+	Finalized     bool       `gorm:"column:finalized"`
+}
+
+func (SleeperWeekProjectionFetch) TableName() string { return "sleeper_week_projection_fetches" }
+
+// SleeperPlayerWeekStatLine is the typed projection of one
+// sleeper_player_week_stats.stats object, so usage (targets, snaps,
+// attempts) can be queried in SQL instead of unmarshalled row by row. JSON
+// tags are Sleeper's stat keys; a key Sleeper omits — it leaves out zeros —
+// decodes to 0. Written alongside the raw row by FetchWeekStats; rows
+// predating the table were backfilled by migration 035.
+type SleeperPlayerWeekStatLine struct {
+	Season          string `gorm:"primaryKey;column:season" json:"-"`
+	Week            int    `gorm:"primaryKey;column:week" json:"-"`
+	SleeperPlayerID string `gorm:"primaryKey;column:sleeper_player_id" json:"-"`
+
+	GamesPlayed float64 `gorm:"column:gp;not null;default:0" json:"gp"`
+
+	PassAtt float64 `gorm:"column:pass_att;not null;default:0" json:"pass_att"`
+	PassCmp float64 `gorm:"column:pass_cmp;not null;default:0" json:"pass_cmp"`
+	PassYd  float64 `gorm:"column:pass_yd;not null;default:0" json:"pass_yd"`
+	PassTD  float64 `gorm:"column:pass_td;not null;default:0" json:"pass_td"`
+	PassInt float64 `gorm:"column:pass_int;not null;default:0" json:"pass_int"`
+
+	RushAtt float64 `gorm:"column:rush_att;not null;default:0" json:"rush_att"`
+	RushYd  float64 `gorm:"column:rush_yd;not null;default:0" json:"rush_yd"`
+	RushTD  float64 `gorm:"column:rush_td;not null;default:0" json:"rush_td"`
+
+	RecTgt float64 `gorm:"column:rec_tgt;not null;default:0" json:"rec_tgt"`
+	Rec    float64 `gorm:"column:rec;not null;default:0" json:"rec"`
+	RecYd  float64 `gorm:"column:rec_yd;not null;default:0" json:"rec_yd"`
+	RecTD  float64 `gorm:"column:rec_td;not null;default:0" json:"rec_td"`
+
+	FumLost float64 `gorm:"column:fum_lost;not null;default:0" json:"fum_lost"`
+
+	OffSnp   float64 `gorm:"column:off_snp;not null;default:0" json:"off_snp"`
+	TmOffSnp float64 `gorm:"column:tm_off_snp;not null;default:0" json:"tm_off_snp"`
+	DefSnp   float64 `gorm:"column:def_snp;not null;default:0" json:"def_snp"`
+	TmDefSnp float64 `gorm:"column:tm_def_snp;not null;default:0" json:"tm_def_snp"`
+	StSnp    float64 `gorm:"column:st_snp;not null;default:0" json:"st_snp"`
+
+	FGA       float64 `gorm:"column:fga;not null;default:0" json:"fga"`
+	FGM       float64 `gorm:"column:fgm;not null;default:0" json:"fgm"`
+	FGM0to19  float64 `gorm:"column:fgm_0_19;not null;default:0" json:"fgm_0_19"`
+	FGM20to29 float64 `gorm:"column:fgm_20_29;not null;default:0" json:"fgm_20_29"`
+	FGM30to39 float64 `gorm:"column:fgm_30_39;not null;default:0" json:"fgm_30_39"`
+	FGM40to49 float64 `gorm:"column:fgm_40_49;not null;default:0" json:"fgm_40_49"`
+	FGM50Plus float64 `gorm:"column:fgm_50p;not null;default:0" json:"fgm_50p"`
+	XPA       float64 `gorm:"column:xpa;not null;default:0" json:"xpa"`
+	XPM       float64 `gorm:"column:xpm;not null;default:0" json:"xpm"`
+
+	DefSack   float64 `gorm:"column:sack;not null;default:0" json:"sack"`
+	DefInt    float64 `gorm:"column:int;not null;default:0" json:"int"`
+	DefFumRec float64 `gorm:"column:fum_rec;not null;default:0" json:"fum_rec"`
+	DefTD     float64 `gorm:"column:def_td;not null;default:0" json:"def_td"`
+	DefSafety float64 `gorm:"column:safe;not null;default:0" json:"safe"`
+	PtsAllow  float64 `gorm:"column:pts_allow;not null;default:0" json:"pts_allow"`
+	YdsAllow  float64 `gorm:"column:yds_allow;not null;default:0" json:"yds_allow"`
+
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime" json:"-"`
+}
+
+func (SleeperPlayerWeekStatLine) TableName() string { return "sleeper_player_week_stat_lines" }
+
+// ParseSleeperPlayerWeekStatLine decodes a Sleeper weekly stats object into
+// its typed stat line. Keys the line doesn't model are ignored, and so are
+// the non-numeric values Sleeper mixes into the object, the way
+// scoring.ParseStats drops them — one odd field mustn't cost the whole line.
+func ParseSleeperPlayerWeekStatLine(season string, week int, sleeperPlayerID string, raw json.RawMessage) (SleeperPlayerWeekStatLine, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return SleeperPlayerWeekStatLine{}, err
+	}
+	numeric := make(map[string]float64, len(fields))
+	for k, v := range fields {
+		var f float64
+		if err := json.Unmarshal(v, &f); err == nil {
+			numeric[k] = f
+		}
+	}
+	b, err := json.Marshal(numeric)
+	if err != nil {
+		return SleeperPlayerWeekStatLine{}, err
+	}
+	var line SleeperPlayerWeekStatLine
+	if err := json.Unmarshal(b, &line); err != nil {
+		return SleeperPlayerWeekStatLine{}, err
+	}
+	line.Season, line.Week, line.SleeperPlayerID = season, week, sleeperPlayerID
+	return line, nil
+}
+
+// ScoringWeekPoints is one player's points for one week under one Sleeper
+// scoring map, identified by scoring.Rules.Hash rather than by league so
+// every league sharing a ruleset shares one cached table. Written and read
+// by scoring.WeekPoints.
+type ScoringWeekPoints struct {
+	ScoringHash     string  `gorm:"primaryKey;column:scoring_hash"`
+	Season          string  `gorm:"primaryKey;column:season"`
+	Week            int     `gorm:"primaryKey;column:week"`
+	SleeperPlayerID string  `gorm:"primaryKey;column:sleeper_player_id"`
+	Points          float64 `gorm:"column:points"`
+}
+
+func (ScoringWeekPoints) TableName() string { return "scoring_week_points" }
+
+// ScoringWeekPointsBuild records when a (scoring_hash, season, week) points
+// table was last built. A build older than the week's
+// sleeper_week_stat_fetches.last_fetched_at is stale — the stats were
+// refetched (e.g. a stat correction) after the points were computed.
+type ScoringWeekPointsBuild struct {
+	ScoringHash string    `gorm:"primaryKey;column:scoring_hash"`
+	Season      string    `gorm:"primaryKey;column:season"`
+	Week        int       `gorm:"primaryKey;column:week"`
+	BuiltAt     time.Time `gorm:"column:built_at"`
+}
+
+func (ScoringWeekPointsBuild) TableName() string { return "scoring_week_points_builds" }
+
 // SleeperLifetimeCount is one hourly snapshot row of data-scraping table
 // sizes, written by cmd/cron's "lifetime-counts" job (internal/statscron).
 // It exists because sleeper_transactions and sleeper_drafts are trimmed to a
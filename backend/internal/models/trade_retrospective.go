@@ -0,0 +1,32 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TradeRetrospective re-values one historical trade after the fact (see
+// migration 045 and transactioncron.RunTradeRetrospectives). ValuesAtTrade,
+// ValuesNow, ValuesLater and RealizedPoints all map roster_id to a side
+// total, like SleeperTransaction.TradeValues. ValuesLater stays nil until
+// LaterWeeks have passed; HindsightGap is nil until every side of ValuesNow
+// resolves.
+type TradeRetrospective struct {
+	SleeperTransactionID string          `gorm:"primaryKey;column:sleeper_transaction_id"`
+	Segment              string          `gorm:"column:segment"`
+	Season               string          `gorm:"column:season"`
+	Leg                  int             `gorm:"column:leg"`
+	TradedAt             time.Time       `gorm:"column:traded_at"`
+	Adds                 json.RawMessage `gorm:"column:adds;type:jsonb"`
+	DraftPicks           json.RawMessage `gorm:"column:draft_picks;type:jsonb"`
+	ValuesAtTrade        json.RawMessage `gorm:"column:values_at_trade;type:jsonb"`
+	LaterWeeks           int             `gorm:"column:later_weeks"`
+	ValuationDate        *time.Time      `gorm:"column:valuation_date"`
+	ValuesNow            json.RawMessage `gorm:"column:values_now;type:jsonb"`
+	ValuesLater          json.RawMessage `gorm:"column:values_later;type:jsonb"`
+	RealizedPoints       json.RawMessage `gorm:"column:realized_points;type:jsonb"`
+	HindsightGap         *float64        `gorm:"column:hindsight_gap"`
+	UpdatedAt            time.Time       `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (TradeRetrospective) TableName() string { return "trade_retrospectives" }
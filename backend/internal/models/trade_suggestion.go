@@ -0,0 +1,64 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Trade suggestion statuses: runs write open ones; dismissed and proposed
+// are for manager triage (see migration 048).
+const (
+	SuggestionOpen      = "open"
+	SuggestionDismissed = "dismissed"
+	SuggestionProposed  = "proposed"
+)
+
+// TradeSuggestion is one suggested trade for a team in a Sleeper league,
+// written by the trade-suggestions cron job (see migration 048): RosterID gives Give to
+// CounterpartyRosterID for Receive. FairnessDelta is RosterID's outlay less
+// the counterparty's, in market value; the utility deltas are each team's
+// change in optimized starting-lineup projected points. The player lists
+// are JSON arrays of Sleeper player IDs.
+type TradeSuggestion struct {
+	ID                       uint            `gorm:"primaryKey;column:id"`
+	SleeperLeagueID          string          `gorm:"column:sleeper_league_id"`
+	RosterID                 int             `gorm:"column:roster_id"`
+	CounterpartyRosterID     int             `gorm:"column:counterparty_roster_id"`
+	Segment                  string          `gorm:"column:segment"`
+	ValuationDate            time.Time       `gorm:"column:valuation_date;type:date"`
+	GeneratedAt              time.Time       `gorm:"column:generated_at"`
+	Rank                     int             `gorm:"column:rank"`
+	Label                    string          `gorm:"column:label"`
+	Give                     json.RawMessage `gorm:"column:give;type:jsonb"`
+	Receive                  json.RawMessage `gorm:"column:receive;type:jsonb"`
+	Drops                    json.RawMessage `gorm:"column:drops;type:jsonb"`
+	CounterpartyDrops        json.RawMessage `gorm:"column:counterparty_drops;type:jsonb"`
+	FairnessDelta            float64         `gorm:"column:fairness_delta"`
+	UtilityDelta             float64         `gorm:"column:utility_delta"`
+	CounterpartyUtilityDelta float64         `gorm:"column:counterparty_utility_delta"`
+	LineupEntered            json.RawMessage `gorm:"column:lineup_entered;type:jsonb"`
+	LineupExited             json.RawMessage `gorm:"column:lineup_exited;type:jsonb"`
+	Status                   string          `gorm:"column:status;default:open"`
+	StatusUpdatedAt          *time.Time      `gorm:"column:status_updated_at"`
+}
+
+func (TradeSuggestion) TableName() string { return "trade_suggestions" }
+
+// TradeSuggestionRequest is a team's entry in the suggestion job queue. It
+// is pending while CompletedAt is nil or before RequestedAt, and running
+// once ClaimedAt is set.
+type TradeSuggestionRequest struct {
+	SleeperLeagueID string     `gorm:"primaryKey;column:sleeper_league_id"`
+	RosterID        int        `gorm:"primaryKey;column:roster_id"`
+	RequestedAt     time.Time  `gorm:"column:requested_at"`
+	ClaimedAt       *time.Time `gorm:"column:claimed_at"`
+	CompletedAt     *time.Time `gorm:"column:completed_at"`
+	Error           *string    `gorm:"column:error"`
+}
+
+func (TradeSuggestionRequest) TableName() string { return "trade_suggestion_requests" }
+
+// Pending reports whether the request is still waiting on a run.
+func (r TradeSuggestionRequest) Pending() bool {
+	return r.CompletedAt == nil || r.CompletedAt.Before(r.RequestedAt)
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// MoverWindows are the lookbacks valuation_movers is computed over, keyed by
+// the time_window value stored for each.
+var MoverWindows = map[string]int{
+	"1d":  1,
+	"7d":  7,
+	"30d": 30,
+}
+
+// Mover directions.
+const (
+	MoverRiser  = "riser"
+	MoverFaller = "faller"
+)
+
+// ValuationMover is one of a segment's biggest market_score moves over one
+// window, within the player's position — see valuationcron.RunMovers, which
+// rewrites the table wholesale each day. FromDate is the snapshot compared
+// against, the latest at or before the window's start; ZScore is
+// ScoreChange over MarketDispersion, nil when the dispersion is unknown.
+type ValuationMover struct {
+	Segment          string    `gorm:"primaryKey;column:segment"`
+	TimeWindow       string    `gorm:"primaryKey;column:time_window"`
+	SleeperPlayerID  string    `gorm:"primaryKey;column:sleeper_player_id"`
+	Position         string    `gorm:"column:position"`
+	Direction        string    `gorm:"column:direction"`
+	Rank             int       `gorm:"column:rank"`
+	FromDate         time.Time `gorm:"column:from_date;type:date"`
+	ValuationDate    time.Time `gorm:"column:valuation_date;type:date"`
+	MarketScoreFrom  float64   `gorm:"column:market_score_from"`
+	MarketScoreTo    float64   `gorm:"column:market_score_to"`
+	ScoreChange      float64   `gorm:"column:score_change"`
+	MarketDispersion *float64  `gorm:"column:market_dispersion"`
+	ZScore           *float64  `gorm:"column:z_score"`
+	ValueFrom        *float64  `gorm:"column:value_from"`
+	ValueTo          *float64  `gorm:"column:value_to"`
+	ComputedAt       time.Time `gorm:"column:computed_at"`
+}
+
+func (ValuationMover) TableName() string { return "valuation_movers" }
@@ -0,0 +1,162 @@
+package scoring
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"backend/internal/models"
+)
+
+// ErrNoScoringSettings is returned by LeagueWeekPoints for a league whose
+// scoring_settings were never captured.
+var ErrNoScoringSettings = errors.New("league has no scoring settings")
+
+// LeagueWeekPoints returns every player's points for one week under a
+// Sleeper league's own scoring map, keyed by Sleeper player ID. See
+// WeekPoints for caching.
+func LeagueWeekPoints(ctx context.Context, db *gorm.DB, sleeperLeagueID, season string, week int) (map[string]float64, error) {
+	var league models.SleeperLeague
+	if err := db.WithContext(ctx).Select("scoring_settings").
+		Where("sleeper_league_id = ?", sleeperLeagueID).
+		First(&league).Error; err != nil {
+		return nil, err
+	}
+	if len(league.ScoringSettings) == 0 || string(league.ScoringSettings) == "null" {
+		return nil, ErrNoScoringSettings
+	}
+	rules, err := ParseRules(league.ScoringSettings)
+	if err != nil {
+		return nil, err
+	}
+	return WeekPoints(ctx, db, rules, season, week)
+}
+
+// WeekPoints returns every player's points for one week under rules, keyed
+// by Sleeper player ID. The table is cached in scoring_week_points under
+// rules.Hash(): the first read for a (ruleset, season, week) builds it from
+// sleeper_player_week_stats, and later reads serve the cache until the
+// week's stats are refetched, at which point the next read rebuilds it.
+func WeekPoints(ctx context.Context, db *gorm.DB, rules Rules, season string, week int) (map[string]float64, error) {
+	hash := rules.Hash()
+	fresh, err := weekPointsFresh(ctx, db, hash, season, week)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return buildWeekPoints(ctx, db, rules, hash, season, week)
+	}
+
+	var rows []models.ScoringWeekPoints
+	if err := db.WithContext(ctx).
+		Where("scoring_hash = ? AND season = ? AND week = ?", hash, season, week).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	points := make(map[string]float64, len(rows))
+	for _, r := range rows {
+		points[r.SleeperPlayerID] = r.Points
+	}
+	return points, nil
+}
+
+// weekPointsFresh reports whether a build exists for the key and postdates
+// the week's last stats fetch.
+func weekPointsFresh(ctx context.Context, db *gorm.DB, hash, season string, week int) (bool, error) {
+	var builds []models.ScoringWeekPointsBuild
+	if err := db.WithContext(ctx).
+		Where("scoring_hash = ? AND season = ? AND week = ?", hash, season, week).
+		Limit(1).Find(&builds).Error; err != nil {
+		return false, err
+	}
+	if len(builds) == 0 {
+		return false, nil
+	}
+	var fetches []models.SleeperWeekStatFetch
+	if err := db.WithContext(ctx).
+		Where("season = ? AND week = ?", season, week).
+		Limit(1).Find(&fetches).Error; err != nil {
+		return false, err
+	}
+	if len(fetches) > 0 && fetches[0].LastFetchedAt != nil && fetches[0].LastFetchedAt.After(builds[0].BuiltAt) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// ScoreWeekPoints scores every player's stats for one week under rules
+// without reading or writing the cache, for rulesets too ad hoc to be worth
+// a cache key of their own. Rows whose stats fail to decode are skipped
+// rather than failing the whole week.
+func ScoreWeekPoints(ctx context.Context, db *gorm.DB, rules Rules, season string, week int) (map[string]float64, error) {
+	var stats []struct {
+		SleeperPlayerID string `gorm:"column:sleeper_player_id"`
+		Position        string `gorm:"column:position"`
+		Stats           []byte `gorm:"column:stats"`
+	}
+	if err := db.WithContext(ctx).Table("sleeper_player_week_stats ws").
+		Select("ws.sleeper_player_id, sp.position, ws.stats").
+		Joins("LEFT JOIN sleeper_players sp ON sp.sleeper_player_id = ws.sleeper_player_id").
+		Where("ws.season = ? AND ws.week = ? AND ws.stats IS NOT NULL", season, week).
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	points := make(map[string]float64, len(stats))
+	for _, s := range stats {
+		parsed, err := ParseStats(s.Stats)
+		if err != nil {
+			continue
+		}
+		points[s.SleeperPlayerID] = rules.Points(parsed, s.Position)
+	}
+	return points, nil
+}
+
+// buildWeekPoints scores the week (see ScoreWeekPoints) and replaces the
+// cached table in one transaction.
+func buildWeekPoints(ctx context.Context, db *gorm.DB, rules Rules, hash, season string, week int) (map[string]float64, error) {
+	builtAt := time.Now().UTC()
+	points, err := ScoreWeekPoints(ctx, db, rules, season, week)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]models.ScoringWeekPoints, 0, len(points))
+	for id, p := range points {
+		rows = append(rows, models.ScoringWeekPoints{
+			ScoringHash:     hash,
+			Season:          season,
+			Week:            week,
+			SleeperPlayerID: id,
+			Points:          p,
+		})
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("scoring_hash = ? AND season = ? AND week = ?", hash, season, week).
+			Delete(&models.ScoringWeekPoints{}).Error; err != nil {
+			return err
+		}
+		if len(rows) > 0 {
+			// OnConflict covers a concurrent build of the same key committing
+			// between this transaction's delete and insert.
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "scoring_hash"}, {Name: "season"}, {Name: "week"}, {Name: "sleeper_player_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"points"}),
+			}).CreateInBatches(rows, 500).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "scoring_hash"}, {Name: "season"}, {Name: "week"}},
+			DoUpdates: clause.AssignmentColumns([]string{"built_at"}),
+		}).Create(&models.ScoringWeekPointsBuild{ScoringHash: hash, Season: season, Week: week, BuiltAt: builtAt}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return points, nil
+}
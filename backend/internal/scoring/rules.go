@@ -0,0 +1,139 @@
+package scoring
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Rules is a Sleeper league's scoring_settings map: each key is a Sleeper
+// stat key (pass_yd, rec, rec_fd, pts_allow_0, ...) or a bonus key, and each
+// value is the points it is worth. It covers everything ScoringSettings
+// can't express — TE premium, first-down points, yardage bonuses, IDP and
+// team-defense scoring.
+type Rules map[string]float64
+
+// ParseRules decodes a sleeper_leagues.scoring_settings object.
+func ParseRules(raw json.RawMessage) (Rules, error) {
+	var rules Rules
+	if err := json.Unmarshal(raw, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ParseStats decodes a sleeper_player_week_stats.stats object into numeric
+// stats. Sleeper mixes a few non-numeric fields into the object; they can't
+// be scored and are dropped.
+func ParseStats(raw json.RawMessage) (map[string]float64, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	stats := make(map[string]float64, len(fields))
+	for k, v := range fields {
+		var f float64
+		if err := json.Unmarshal(v, &f); err == nil {
+			stats[k] = f
+		}
+	}
+	return stats, nil
+}
+
+// Hash identifies rules by content: two leagues with identical scoring maps
+// share a hash, which is what lets WeekPoints cache one points table per
+// distinct ruleset instead of one per league. Zero-valued rules don't score
+// and are left out, so a map that spells out "rec": 0 hashes the same as
+// one that omits it.
+func (r Rules) Hash() string {
+	h := sha256.New()
+	for _, k := range r.keys() {
+		h.Write([]byte(k))
+		h.Write([]byte{'='})
+		h.Write([]byte(strconv.FormatFloat(r[k], 'g', -1, 64)))
+		h.Write([]byte{';'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// keys returns r's non-zero keys in sorted order, so Points sums in a fixed
+// order and identical inputs produce bit-identical totals.
+func (r Rules) keys() []string {
+	keys := make([]string, 0, len(r))
+	for k, v := range r {
+		if v != 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var (
+	// positionReceptionBonus matches per-reception premiums restricted to
+	// one position, e.g. bonus_rec_te (TE premium).
+	positionReceptionBonus = regexp.MustCompile(`^bonus_rec_(qb|rb|wr|te)$`)
+	// positionFirstDownBonus matches per-first-down bonuses restricted to one
+	// position, e.g. bonus_fd_wr.
+	positionFirstDownBonus = regexp.MustCompile(`^bonus_fd_(qb|rb|wr|te)$`)
+	// thresholdBonus matches one-time bonuses for reaching a stat total,
+	// e.g. bonus_pass_yd_300 or bonus_rush_rec_yd_200.
+	thresholdBonus = regexp.MustCompile(`^bonus_(.+)_(\d+)p?$`)
+)
+
+// thresholdBases are the stats a threshold bonus is recomputed from. A
+// bonus on any other base falls back to the stats object's own value for
+// the bonus key, when Sleeper provides one.
+var thresholdBases = map[string]func(stats map[string]float64) float64{
+	"pass_yd":     func(s map[string]float64) float64 { return s["pass_yd"] },
+	"rush_yd":     func(s map[string]float64) float64 { return s["rush_yd"] },
+	"rec_yd":      func(s map[string]float64) float64 { return s["rec_yd"] },
+	"rush_rec_yd": func(s map[string]float64) float64 { return s["rush_yd"] + s["rec_yd"] },
+	"pass_cmp":    func(s map[string]float64) float64 { return s["pass_cmp"] },
+	"rush_att":    func(s map[string]float64) float64 { return s["rush_att"] },
+	"rec":         func(s map[string]float64) float64 { return s["rec"] },
+}
+
+var positionKeys = map[string]string{"qb": "QB", "rb": "RB", "wr": "WR", "te": "TE"}
+
+// Points scores one player's week of stats under r. position is the
+// player's Sleeper position and only matters for position-restricted
+// bonuses.
+func (r Rules) Points(stats map[string]float64, position string) float64 {
+	var total float64
+	for _, key := range r.keys() {
+		total += r[key] * ruleUnits(key, stats, position)
+	}
+	return total
+}
+
+// ruleUnits is how many times rule key applies to stats: the stat's value
+// for a plain stat key, the player's receptions or first downs for a
+// position bonus, and 0 or 1 for a threshold bonus.
+func ruleUnits(key string, stats map[string]float64, position string) float64 {
+	if m := positionReceptionBonus.FindStringSubmatch(key); m != nil {
+		if positionKeys[m[1]] != position {
+			return 0
+		}
+		return stats["rec"]
+	}
+	if m := positionFirstDownBonus.FindStringSubmatch(key); m != nil {
+		if positionKeys[m[1]] != position {
+			return 0
+		}
+		return stats["pass_fd"] + stats["rush_fd"] + stats["rec_fd"]
+	}
+	if m := thresholdBonus.FindStringSubmatch(key); m != nil {
+		if base, ok := thresholdBases[m[1]]; ok {
+			threshold, _ := strconv.ParseFloat(m[2], 64)
+			if base(stats) >= threshold {
+				return 1
+			}
+			return 0
+		}
+	}
+	return stats[key]
+}
@@ -0,0 +1,121 @@
+package scoring_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"backend/internal/models"
+	"backend/internal/scoring"
+)
+
+func TestRulesPoints_BonusesPremiumsAndFirstDowns(t *testing.T) {
+	rules := scoring.Rules{
+		"rec":               1,
+		"rec_yd":            0.1,
+		"rec_td":            6,
+		"rec_fd":            0.5,
+		"bonus_rec_te":      0.5,
+		"bonus_fd_te":       0.25,
+		"bonus_rec_yd_100":  3,
+		"bonus_rec_yd_200":  3,
+		"pts_allow_0":       10,
+		"bonus_def_made_up": 2,
+	}
+	stats := map[string]float64{"rec": 8, "rec_yd": 104, "rec_td": 1, "rec_fd": 6, "rush_fd": 1}
+
+	// 8 + 10.4 + 6 + 3 (rec_fd) + 3 (100-yd bonus), no 200-yd bonus.
+	wr := 8 + 10.4 + 6 + 3 + 3.0
+	if got := rules.Points(stats, "WR"); math.Abs(got-wr) > 1e-9 {
+		t.Errorf("WR: expected %v, got %v", wr, got)
+	}
+	// TE adds 0.5/rec premium and 0.25 per first down (rush + rec).
+	te := wr + 4 + 1.75
+	if got := rules.Points(stats, "TE"); math.Abs(got-te) > 1e-9 {
+		t.Errorf("TE: expected %v, got %v", te, got)
+	}
+
+	// Unknown bonus bases fall back to the stats object's own value.
+	def := map[string]float64{"pts_allow_0": 1, "bonus_def_made_up": 1}
+	if got := rules.Points(def, "DEF"); got != 12 {
+		t.Errorf("DEF: expected 12, got %v", got)
+	}
+}
+
+func TestRulesHash_IgnoresZeroRulesAndOrder(t *testing.T) {
+	a, err := scoring.ParseRules(json.RawMessage(`{"rec": 1, "pass_td": 4, "rush_td": 0}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	b := scoring.Rules{"pass_td": 4, "rec": 1}
+	if a.Hash() != b.Hash() {
+		t.Error("expected equal rulesets to hash equal")
+	}
+	if a.Hash() == (scoring.Rules{"pass_td": 6, "rec": 1}).Hash() {
+		t.Error("expected different rulesets to hash differently")
+	}
+}
+
+func TestParseStats_DropsNonNumeric(t *testing.T) {
+	stats, err := scoring.ParseStats(json.RawMessage(`{"rec": 3, "note": "x", "rec_yd": 41.5}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(stats) != 2 || stats["rec"] != 3 || stats["rec_yd"] != 41.5 {
+		t.Errorf("unexpected stats %v", stats)
+	}
+}
+
+func TestLeagueWeekPoints_CachesUntilStatsRefetched(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.SleeperLeague{}, &models.SleeperPlayer{}, &models.SleeperPlayerWeekStat{},
+		&models.SleeperWeekStatFetch{}, &models.ScoringWeekPoints{}, &models.ScoringWeekPointsBuild{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "lg1", ScoringSettings: json.RawMessage(`{"rec": 1, "bonus_rec_te": 0.5}`)})
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "lg2", ScoringSettings: json.RawMessage(`{"bonus_rec_te": 0.5, "rec": 1}`)})
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "lg3"})
+	db.Create(&models.SleeperPlayer{SleeperPlayerID: "te1", Position: "TE"})
+	db.Create(&models.SleeperPlayerWeekStat{Season: "2025", Week: 1, SleeperPlayerID: "te1", Stats: json.RawMessage(`{"rec": 4}`)})
+
+	ctx := context.Background()
+	points, err := scoring.LeagueWeekPoints(ctx, db, "lg1", "2025", 1)
+	if err != nil {
+		t.Fatalf("LeagueWeekPoints: %v", err)
+	}
+	if points["te1"] != 6 {
+		t.Fatalf("expected 6 points (4 rec + 2 TE premium), got %v", points)
+	}
+
+	// A stat correction lands without a refetch stamp: the cache still serves
+	// the old number, and a league with the same rules shares it.
+	db.Model(&models.SleeperPlayerWeekStat{}).Where("sleeper_player_id = ?", "te1").Update("stats", json.RawMessage(`{"rec": 5}`))
+	if points, _ = scoring.LeagueWeekPoints(ctx, db, "lg2", "2025", 1); points["te1"] != 6 {
+		t.Errorf("expected cached 6 points for an identical ruleset, got %v", points)
+	}
+	var cached int64
+	db.Model(&models.ScoringWeekPoints{}).Count(&cached)
+	if cached != 1 {
+		t.Errorf("expected one shared cached row, got %d", cached)
+	}
+
+	// Stamping a refetch after the build invalidates it.
+	later := time.Now().UTC().Add(time.Minute)
+	db.Create(&models.SleeperWeekStatFetch{Season: "2025", Week: 1, LastFetchedAt: &later})
+	if points, _ = scoring.LeagueWeekPoints(ctx, db, "lg1", "2025", 1); points["te1"] != 7.5 {
+		t.Errorf("expected rebuilt 7.5 points after refetch, got %v", points)
+	}
+
+	if _, err := scoring.LeagueWeekPoints(ctx, db, "lg3", "2025", 1); err != scoring.ErrNoScoringSettings {
+		t.Errorf("expected ErrNoScoringSettings, got %v", err)
+	}
+}
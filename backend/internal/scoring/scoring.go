@@ -0,0 +1,137 @@
+// Package scoring recomputes fantasy points from raw stat lines instead of
+// trusting the points a platform reported. It has two rulesets: our own
+// leagues' ScoringSettings, used to re-score historical weeks under an
+// alternate ruleset (RescoreSeason), and Sleeper's open-ended
+// scoring_settings map (Rules), used to score any Sleeper league's weeks
+// under its real rules (WeekPoints). RescoreSeason converts its settings to
+// Rules so both share WeekPoints' cache, though only a league's own
+// settings and named presets are cached; ad-hoc overrides are scored in
+// memory. Stat lines come from Sleeper's weekly stats
+// (sleeper_player_week_stats.stats), falling back to BoxScore.GameStats for
+// our leagues.
+package scoring
+
+import (
+	"backend/internal/models"
+)
+
+// StatLine is one player's scoring-relevant counting stats for one week,
+// read from a box score's GameStats. Field goals are bucketed by distance
+// the way ScoringSettings prices them.
+type StatLine struct {
+	PassYards       float64
+	PassTDs         float64
+	Interceptions   float64
+	RushYards       float64
+	RushTDs         float64
+	Receptions      float64
+	RecYards        float64
+	RecTDs          float64
+	FumblesLost     float64
+	FG0to39         float64
+	FG40to49        float64
+	FG50Plus        float64
+	ExtraPointsMade float64
+}
+
+// StatLineFromGameStats converts a box score's GameStats, reporting false
+// when none of its fields are populated (the ETL leaves them zero for bye
+// weeks and for imports that predate stat capture). GameStats doesn't record
+// field goal distance, so every made field goal is priced as 0-39 yards.
+func StatLineFromGameStats(s models.PlayerStats) (StatLine, bool) {
+	line := StatLine{
+		PassYards:       s.PassingYards,
+		PassTDs:         s.PassingTDs,
+		Interceptions:   s.Interceptions,
+		RushYards:       s.RushingYards,
+		RushTDs:         s.RushingTDs,
+		Receptions:      s.Receptions,
+		RecYards:        s.ReceivingYards,
+		RecTDs:          s.ReceivingTDs,
+		FumblesLost:     s.Fumbles,
+		FG0to39:         s.FieldGoals,
+		ExtraPointsMade: s.ExtraPoints,
+	}
+	return line, line != StatLine{}
+}
+
+// Points scores line under settings.
+func Points(line StatLine, s models.ScoringSettings) float64 {
+	return line.PassYards*s.PassingYards +
+		line.PassTDs*s.PassingTD +
+		line.Interceptions*s.Interception +
+		line.RushYards*s.RushingYards +
+		line.RushTDs*s.RushingTD +
+		line.Receptions*s.Reception +
+		line.RecYards*s.ReceivingYards +
+		line.RecTDs*s.ReceivingTD +
+		line.FumblesLost*s.Fumble +
+		line.FG0to39*s.FieldGoal0to39 +
+		line.FG40to49*s.FieldGoal40to49 +
+		line.FG50Plus*s.FieldGoal50plus +
+		line.ExtraPointsMade*s.ExtraPoint
+}
+
+// receptionPresets are the named reception values ApplyPreset accepts.
+var receptionPresets = map[string]float64{
+	"standard": 0,
+	"half_ppr": 0.5,
+	"ppr":      1,
+}
+
+// ApplyPreset returns base with its reception value replaced by the named
+// preset (standard, half_ppr, ppr). It reports false for unknown names.
+func ApplyPreset(base models.ScoringSettings, preset string) (models.ScoringSettings, bool) {
+	reception, ok := receptionPresets[preset]
+	if !ok {
+		return base, false
+	}
+	base.Reception = reception
+	return base, true
+}
+
+// SettingsFromRules maps the part of a Sleeper scoring map that
+// ScoringSettings can express. Sleeper splits field goals finer than
+// ScoringSettings' 0-39 bucket; the 30-39 value stands in for it, which is
+// what nearly every league uses across the whole range.
+func SettingsFromRules(r Rules) models.ScoringSettings {
+	return models.ScoringSettings{
+		PassingYards:    r["pass_yd"],
+		PassingTD:       r["pass_td"],
+		Interception:    r["pass_int"],
+		RushingYards:    r["rush_yd"],
+		RushingTD:       r["rush_td"],
+		Reception:       r["rec"],
+		ReceivingYards:  r["rec_yd"],
+		ReceivingTD:     r["rec_td"],
+		Fumble:          r["fum_lost"],
+		FieldGoal0to39:  r["fgm_30_39"],
+		FieldGoal40to49: r["fgm_40_49"],
+		FieldGoal50plus: r["fgm_50p"],
+		ExtraPoint:      r["xpm"],
+	}
+}
+
+// RulesFromSettings is SettingsFromRules' inverse: the Sleeper scoring map
+// that scores a Sleeper stats object exactly as Points scores its StatLine,
+// which is what lets RescoreSeason serve Sleeper-sourced points from the
+// WeekPoints cache.
+func RulesFromSettings(s models.ScoringSettings) Rules {
+	return Rules{
+		"pass_yd":   s.PassingYards,
+		"pass_td":   s.PassingTD,
+		"pass_int":  s.Interception,
+		"rush_yd":   s.RushingYards,
+		"rush_td":   s.RushingTD,
+		"rec":       s.Reception,
+		"rec_yd":    s.ReceivingYards,
+		"rec_td":    s.ReceivingTD,
+		"fum_lost":  s.Fumble,
+		"fgm_0_19":  s.FieldGoal0to39,
+		"fgm_20_29": s.FieldGoal0to39,
+		"fgm_30_39": s.FieldGoal0to39,
+		"fgm_40_49": s.FieldGoal40to49,
+		"fgm_50p":   s.FieldGoal50plus,
+		"xpm":       s.ExtraPoint,
+	}
+}
@@ -0,0 +1,185 @@
+package scoring_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"backend/internal/models"
+	"backend/internal/scoring"
+)
+
+// espnDefaults mirrors ScoringSettings' gorm defaults: standard scoring,
+// 4-pt passing TDs.
+var espnDefaults = models.ScoringSettings{
+	PassingYards: 0.04, PassingTD: 4, Interception: -2,
+	RushingYards: 0.1, RushingTD: 6,
+	Reception: 0, ReceivingYards: 0.1, ReceivingTD: 6,
+	Fumble:         -2,
+	FieldGoal0to39: 3, FieldGoal40to49: 4, FieldGoal50plus: 5, ExtraPoint: 1,
+}
+
+func TestRulesFromSettings_ScoresSleeperStats(t *testing.T) {
+	stats, err := scoring.ParseStats(json.RawMessage(
+		`{"pass_yd": 250, "pass_td": 2, "pass_int": 1, "rush_yd": 20, "rec": 3, "fgm_20_29": 1, "fgm_50p": 1, "pts_ppr": 99}`))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	// 10 + 8 - 2 + 2 + 0 (standard) + 3 + 5
+	if got := scoring.RulesFromSettings(espnDefaults).Points(stats, "QB"); math.Abs(got-26) > 1e-9 {
+		t.Errorf("expected 26 standard points, got %v", got)
+	}
+	ppr, _ := scoring.ApplyPreset(espnDefaults, "ppr")
+	ppr.PassingTD = 6
+	if got := scoring.RulesFromSettings(ppr).Points(stats, "QB"); math.Abs(got-33) > 1e-9 {
+		t.Errorf("expected 33 ppr/6pt-td points, got %v", got)
+	}
+}
+
+func TestStatLineFromGameStats_ReportsUnpopulated(t *testing.T) {
+	if _, ok := scoring.StatLineFromGameStats(models.PlayerStats{}); ok {
+		t.Error("expected empty GameStats to report unpopulated")
+	}
+	line, ok := scoring.StatLineFromGameStats(models.PlayerStats{Receptions: 5, ReceivingYards: 60})
+	if !ok || line.Receptions != 5 || line.RecYards != 60 {
+		t.Errorf("unexpected line %+v ok=%v", line, ok)
+	}
+}
+
+func TestApplyPreset_RejectsUnknown(t *testing.T) {
+	if _, ok := scoring.ApplyPreset(espnDefaults, "superflex"); ok {
+		t.Error("expected unknown preset rejected")
+	}
+}
+
+// TestRescoreSeason_FlipsResultUnderPPR builds a two-team season where the
+// away team's receiver-heavy lineup loses under standard scoring but wins
+// the regular-season game and the final under full PPR.
+func newRescoreTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.League{}, &models.Team{}, &models.Player{}, &models.Matchup{},
+		&models.BoxScore{}, &models.SleeperPlayer{}, &models.SleeperPlayerWeekStat{}, &models.SleeperWeekStatFetch{},
+		&models.ScoringWeekPoints{}, &models.ScoringWeekPointsBuild{}, &models.TeamNameHistory{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return db
+}
+
+func TestRescoreSeason_FlipsResultUnderPPR(t *testing.T) {
+	db := newRescoreTestDB(t)
+
+	home := models.Team{Name: "Home", ESPNID: 1, LeagueID: 1}
+	away := models.Team{Name: "Away", ESPNID: 2, LeagueID: 1}
+	for _, team := range []*models.Team{&home, &away} {
+		if err := db.Create(team).Error; err != nil {
+			t.Fatalf("create team: %v", err)
+		}
+	}
+	rb := models.Player{Name: "RB", SleeperID: "rb1", Position: "RB"}
+	wr := models.Player{Name: "WR", Position: "WR"}
+	dst := models.Player{Name: "DST", Position: "D/ST"}
+	db.Create(&rb)
+	db.Create(&wr)
+	db.Create(&dst)
+
+	for _, week := range []uint{1, 2} {
+		gameType := "NONE"
+		if week == 2 {
+			gameType = "WINNERS_BRACKET"
+		}
+		m := models.Matchup{LeagueID: 1, Week: week, Year: 2025, HomeTeamID: home.ID, AwayTeamID: away.ID,
+			HomeTeamFinalScore: 12, AwayTeamFinalScore: 10, Completed: true, GameType: gameType}
+		db.Create(&m)
+		// Home: a 120-yard RB scored from Sleeper stats (12 standard / PPR).
+		db.Create(&models.BoxScore{MatchupID: m.ID, PlayerID: rb.ID, TeamID: home.ID, SlotPosition: "RB", ActualPoints: 12})
+		stats := json.RawMessage(`{"rush_yd": 120}`)
+		db.Create(&models.SleeperPlayerWeekStat{Season: "2025", Week: int(week), SleeperPlayerID: "rb1", Stats: stats})
+		// Away: 8 catches for 60 yards from GameStats (6 standard, 14 PPR)
+		// plus a D/ST with no stat line, kept at its original 4.
+		db.Create(&models.BoxScore{MatchupID: m.ID, PlayerID: wr.ID, TeamID: away.ID, SlotPosition: "WR", ActualPoints: 6,
+			GameStats: models.PlayerStats{Receptions: 8, ReceivingYards: 60}})
+		db.Create(&models.BoxScore{MatchupID: m.ID, PlayerID: dst.ID, TeamID: away.ID, SlotPosition: "D/ST", ActualPoints: 4})
+		// Bench points never count.
+		db.Create(&models.BoxScore{MatchupID: m.ID, PlayerID: wr.ID, TeamID: home.ID, SlotPosition: "BE", ActualPoints: 50})
+	}
+
+	ppr, _ := scoring.ApplyPreset(espnDefaults, "ppr")
+	result, err := scoring.RescoreSeason(context.Background(), db, 1, 2025, ppr, true)
+	if err != nil {
+		t.Fatalf("RescoreSeason: %v", err)
+	}
+
+	if len(result.Matchups) != 2 {
+		t.Fatalf("expected 2 matchups, got %d", len(result.Matchups))
+	}
+	for _, m := range result.Matchups {
+		if math.Abs(m.RescoredHome-12) > 1e-9 || math.Abs(m.RescoredAway-18) > 1e-9 || !m.WinnerChanged {
+			t.Errorf("unexpected rescored matchup %+v", m)
+		}
+	}
+	if result.Coverage != (scoring.Coverage{SleeperStats: 2, GameStats: 2, Unchanged: 2}) {
+		t.Errorf("unexpected coverage %+v", result.Coverage)
+	}
+	// Sleeper-sourced points are served from the WeekPoints cache.
+	var builds int64
+	db.Model(&models.ScoringWeekPointsBuild{}).Where("scoring_hash = ?", scoring.RulesFromSettings(ppr).Hash()).Count(&builds)
+	if builds != 2 {
+		t.Errorf("expected both weeks cached under the rescoring rules, got %d", builds)
+	}
+	if result.ActualStandings[0].TeamID != home.ID || result.RescoredStandings[0].TeamID != away.ID {
+		t.Errorf("expected standings to flip: actual=%+v rescored=%+v", result.ActualStandings, result.RescoredStandings)
+	}
+	if result.ActualChampionID == nil || *result.ActualChampionID != home.ID {
+		t.Errorf("expected home as actual champion, got %v", result.ActualChampionID)
+	}
+	if result.RescoredChampionID == nil || *result.RescoredChampionID != away.ID {
+		t.Errorf("expected away as rescored champion, got %v", result.RescoredChampionID)
+	}
+}
+
+// TestRescoreSeason_KeepsLinkedDefenses re-scores a season whose D/ST is
+// linked to a Sleeper ID with a stat line: ScoringSettings can't price
+// defensive stats, so the D/ST keeps its original points.
+func TestRescoreSeason_KeepsLinkedDefenses(t *testing.T) {
+	db := newRescoreTestDB(t)
+
+	home := models.Team{Name: "Home", ESPNID: 1, LeagueID: 1}
+	away := models.Team{Name: "Away", ESPNID: 2, LeagueID: 1}
+	for _, team := range []*models.Team{&home, &away} {
+		if err := db.Create(team).Error; err != nil {
+			t.Fatalf("create team: %v", err)
+		}
+	}
+	dst := models.Player{Name: "Cowboys D/ST", ESPNID: -16006, SleeperID: "DAL", Position: "D/ST"}
+	rb := models.Player{Name: "RB", SleeperID: "rb1", Position: "RB"}
+	db.Create(&dst)
+	db.Create(&rb)
+
+	m := models.Matchup{LeagueID: 1, Week: 1, Year: 2025, HomeTeamID: home.ID, AwayTeamID: away.ID,
+		HomeTeamFinalScore: 9, AwayTeamFinalScore: 10, Completed: true, GameType: "NONE"}
+	db.Create(&m)
+	db.Create(&models.BoxScore{MatchupID: m.ID, PlayerID: dst.ID, TeamID: home.ID, SlotPosition: "D/ST", ActualPoints: 9})
+	db.Create(&models.BoxScore{MatchupID: m.ID, PlayerID: rb.ID, TeamID: away.ID, SlotPosition: "RB", ActualPoints: 10})
+	db.Create(&models.SleeperPlayerWeekStat{Season: "2025", Week: 1, SleeperPlayerID: "DAL", Stats: json.RawMessage(`{"sack": 3, "def_td": 1}`)})
+	db.Create(&models.SleeperPlayerWeekStat{Season: "2025", Week: 1, SleeperPlayerID: "rb1", Stats: json.RawMessage(`{"rush_yd": 100}`)})
+
+	result, err := scoring.RescoreSeason(context.Background(), db, 1, 2025, espnDefaults, true)
+	if err != nil {
+		t.Fatalf("RescoreSeason: %v", err)
+	}
+	if len(result.Matchups) != 1 || math.Abs(result.Matchups[0].RescoredHome-9) > 1e-9 || math.Abs(result.Matchups[0].RescoredAway-10) > 1e-9 {
+		t.Errorf("expected the D/ST to keep its 9 points, got %+v", result.Matchups)
+	}
+	if result.Coverage != (scoring.Coverage{SleeperStats: 1, Unchanged: 1}) {
+		t.Errorf("unexpected coverage %+v", result.Coverage)
+	}
+}
@@ -0,0 +1,391 @@
+package scoring
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+	"backend/internal/utils"
+)
+
+// PointSource records where a re-scored starter's points came from.
+type PointSource string
+
+const (
+	// PointSourceSleeper: re-scored from sleeper_player_week_stats.stats.
+	PointSourceSleeper PointSource = "sleeper_stats"
+	// PointSourceGameStats: re-scored from the box score's GameStats.
+	PointSourceGameStats PointSource = "game_stats"
+	// PointSourceUnchanged: a team defense, which neither ruleset scores,
+	// or a player we have no stat line for, so the original ActualPoints
+	// are kept.
+	PointSourceUnchanged PointSource = "unchanged"
+)
+
+// Coverage counts how many starter box scores were re-scored from each
+// source — a season mostly PointSourceUnchanged is barely re-scored at all.
+type Coverage struct {
+	SleeperStats int `json:"sleeper_stats"`
+	GameStats    int `json:"game_stats"`
+	Unchanged    int `json:"unchanged"`
+}
+
+// MatchupResult is one completed matchup's original and re-scored totals.
+type MatchupResult struct {
+	MatchupID     uint    `json:"matchup_id"`
+	Week          uint    `json:"week"`
+	GameType      string  `json:"game_type"`
+	HomeTeamID    uint    `json:"home_team_id"`
+	AwayTeamID    uint    `json:"away_team_id"`
+	HomeScore     float64 `json:"home_score"`
+	AwayScore     float64 `json:"away_score"`
+	RescoredHome  float64 `json:"rescored_home_score"`
+	RescoredAway  float64 `json:"rescored_away_score"`
+	WinnerChanged bool    `json:"winner_changed"`
+}
+
+// Standing is one team's regular-season record. Rank orders by wins, then
+// points for, matching GetCurrentSeasonStandings.
+type Standing struct {
+	TeamID        uint    `json:"team_id"`
+	Rank          int     `json:"rank"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	Ties          int     `json:"ties"`
+	PointsFor     float64 `json:"points_for"`
+	PointsAgainst float64 `json:"points_against"`
+}
+
+// SeasonResult is a full season re-scored under one ScoringSettings.
+// Champion IDs are nil when the season has no decided championship game;
+// RescoredChampionID also when the replayed bracket can't be decided (see
+// ReplayChampion).
+type SeasonResult struct {
+	Matchups           []MatchupResult `json:"matchups"`
+	ActualStandings    []Standing      `json:"actual_standings"`
+	RescoredStandings  []Standing      `json:"rescored_standings"`
+	ActualChampionID   *uint           `json:"actual_champion_id"`
+	RescoredChampionID *uint           `json:"rescored_champion_id"`
+	Coverage           Coverage        `json:"coverage"`
+}
+
+// isStarter mirrors GetMatchup's lineup split: bench and IR slots don't
+// score.
+func isStarter(slot string) bool {
+	return slot != "BE" && slot != "IR" && slot != ""
+}
+
+// isTeamDefense reports whether a box score is a team defense, by its slot
+// or by the player's position under ESPN's or Sleeper's name. Defenses get
+// Sleeper IDs like any player (SyncPlayerIdentities links them), but
+// ScoringSettings has no defensive stats to re-score them with.
+func isTeamDefense(bs models.BoxScore) bool {
+	if bs.SlotPosition == "D/ST" {
+		return true
+	}
+	return bs.Player != nil && (bs.Player.Position == "D/ST" || bs.Player.Position == "DEF")
+}
+
+// RescoreSeason recomputes every completed matchup of a league's season
+// under settings by re-scoring each starter's stat line and re-summing the
+// lineups, then rebuilds regular-season standings from the new totals and
+// replays the playoff bracket on them (see ReplayChampion). Matchup weeks are assumed to line up with NFL weeks (one
+// scoring period per week), which holds for every league we import.
+// cached says whether settings' weekly points go through the WeekPoints
+// cache; callers pass false for ad-hoc settings, which are scored in memory
+// (see ScoreWeekPoints) so they can't grow scoring_week_points.
+func RescoreSeason(ctx context.Context, db *gorm.DB, leagueID, year uint, settings models.ScoringSettings, cached bool) (*SeasonResult, error) {
+	var matchups []models.Matchup
+	if err := db.WithContext(ctx).Where("league_id = ? AND year = ?", leagueID, year).
+		Order("week ASC, id ASC").
+		Find(&matchups).Error; err != nil {
+		return nil, err
+	}
+
+	matchupIDs := make([]uint, 0, len(matchups))
+	for _, m := range matchups {
+		if m.Completed {
+			matchupIDs = append(matchupIDs, m.ID)
+		}
+	}
+
+	var boxScores []models.BoxScore
+	if len(matchupIDs) > 0 {
+		if err := db.WithContext(ctx).Preload("Player").Where("matchup_id IN ?", matchupIDs).Find(&boxScores).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	weekOf := make(map[uint]uint, len(matchups))
+	for _, m := range matchups {
+		weekOf[m.ID] = m.Week
+	}
+	sleeperPoints, err := loadSleeperWeekPoints(ctx, db, year, settings, matchups, cached)
+	if err != nil {
+		return nil, err
+	}
+
+	type teamWeek struct {
+		matchupID uint
+		teamID    uint
+	}
+	totals := make(map[teamWeek]float64)
+	result := &SeasonResult{}
+	for _, bs := range boxScores {
+		if !isStarter(bs.SlotPosition) {
+			continue
+		}
+		points := bs.ActualPoints
+		source := PointSourceUnchanged
+		defense := isTeamDefense(bs)
+		if !defense && bs.Player != nil && bs.Player.SleeperID != "" {
+			if p, ok := sleeperPoints[weekOf[bs.MatchupID]][bs.Player.SleeperID]; ok {
+				points, source = p, PointSourceSleeper
+			}
+		}
+		if !defense && source == PointSourceUnchanged {
+			if line, ok := StatLineFromGameStats(bs.GameStats); ok {
+				points, source = Points(line, settings), PointSourceGameStats
+			}
+		}
+		switch source {
+		case PointSourceSleeper:
+			result.Coverage.SleeperStats++
+		case PointSourceGameStats:
+			result.Coverage.GameStats++
+		default:
+			result.Coverage.Unchanged++
+		}
+		totals[teamWeek{bs.MatchupID, bs.TeamID}] += points
+	}
+
+	rescored := make([]models.Matchup, len(matchups))
+	for i, m := range matchups {
+		rescored[i] = m
+		if !m.Completed {
+			continue
+		}
+		rescored[i].HomeTeamFinalScore = totals[teamWeek{m.ID, m.HomeTeamID}]
+		rescored[i].AwayTeamFinalScore = totals[teamWeek{m.ID, m.AwayTeamID}]
+		result.Matchups = append(result.Matchups, MatchupResult{
+			MatchupID:     m.ID,
+			Week:          m.Week,
+			GameType:      m.GameType,
+			HomeTeamID:    m.HomeTeamID,
+			AwayTeamID:    m.AwayTeamID,
+			HomeScore:     m.HomeTeamFinalScore,
+			AwayScore:     m.AwayTeamFinalScore,
+			RescoredHome:  rescored[i].HomeTeamFinalScore,
+			RescoredAway:  rescored[i].AwayTeamFinalScore,
+			WinnerChanged: winnerOf(m) != winnerOf(rescored[i]),
+		})
+	}
+
+	result.ActualStandings = Standings(matchups)
+	result.RescoredStandings = Standings(rescored)
+	result.ActualChampionID = Champion(matchups)
+	result.RescoredChampionID = ReplayChampion(matchups, rescored)
+	return result, nil
+}
+
+// loadSleeperWeekPoints returns every Sleeper player's points under
+// settings for each week with a completed matchup, keyed by week then
+// Sleeper player ID, served from the WeekPoints cache when cached is set.
+func loadSleeperWeekPoints(ctx context.Context, db *gorm.DB, year uint, settings models.ScoringSettings, matchups []models.Matchup, cached bool) (map[uint]map[string]float64, error) {
+	rules := RulesFromSettings(settings)
+	score := ScoreWeekPoints
+	if cached {
+		score = WeekPoints
+	}
+	season := strconv.FormatUint(uint64(year), 10)
+	points := make(map[uint]map[string]float64)
+	for _, m := range matchups {
+		if !m.Completed {
+			continue
+		}
+		if _, ok := points[m.Week]; ok {
+			continue
+		}
+		week, err := score(ctx, db, rules, season, int(m.Week))
+		if err != nil {
+			return nil, err
+		}
+		points[m.Week] = week
+	}
+	return points, nil
+}
+
+// winnerOf returns the winning team ID, or 0 for a tie.
+func winnerOf(m models.Matchup) uint {
+	switch {
+	case m.HomeTeamFinalScore > m.AwayTeamFinalScore:
+		return m.HomeTeamID
+	case m.AwayTeamFinalScore > m.HomeTeamFinalScore:
+		return m.AwayTeamID
+	default:
+		return 0
+	}
+}
+
+// Standings builds regular-season (GameType "NONE") standings from the
+// completed matchups, ranked by wins then points for.
+func Standings(matchups []models.Matchup) []Standing {
+	byTeam := make(map[uint]*Standing)
+	var order []uint
+	get := func(teamID uint) *Standing {
+		if s, ok := byTeam[teamID]; ok {
+			return s
+		}
+		byTeam[teamID] = &Standing{TeamID: teamID}
+		order = append(order, teamID)
+		return byTeam[teamID]
+	}
+
+	for _, m := range matchups {
+		if !m.Completed || m.GameType != "NONE" {
+			continue
+		}
+		home, away := get(m.HomeTeamID), get(m.AwayTeamID)
+		home.PointsFor += m.HomeTeamFinalScore
+		home.PointsAgainst += m.AwayTeamFinalScore
+		away.PointsFor += m.AwayTeamFinalScore
+		away.PointsAgainst += m.HomeTeamFinalScore
+		switch winnerOf(m) {
+		case m.HomeTeamID:
+			home.Wins++
+			away.Losses++
+		case m.AwayTeamID:
+			away.Wins++
+			home.Losses++
+		default:
+			home.Ties++
+			away.Ties++
+		}
+	}
+
+	standings := make([]Standing, 0, len(order))
+	for _, id := range order {
+		standings = append(standings, *byTeam[id])
+	}
+	slices.SortStableFunc(standings, func(a, b Standing) int {
+		if a.Wins != b.Wins {
+			return b.Wins - a.Wins
+		}
+		if a.PointsFor != b.PointsFor {
+			if a.PointsFor < b.PointsFor {
+				return 1
+			}
+			return -1
+		}
+		return 0
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+	return standings
+}
+
+// Champion returns the winner of the season's completed championship game,
+// or nil when there isn't one (season in progress, or a tied final).
+func Champion(matchups []models.Matchup) *uint {
+	for _, m := range matchups {
+		if !m.Completed || utils.GetPlayoffGameType(m, matchups) != utils.PlayoffGameTypeChampionship {
+			continue
+		}
+		if winner := winnerOf(m); winner != 0 {
+			return &winner
+		}
+	}
+	return nil
+}
+
+// ReplayChampion re-seeds the playoff field from rescored's regular-season
+// standings and replays the real winners bracket on the rescored weekly
+// totals. The bracket keeps its real shape: each game pairs whoever the
+// replay advanced along the two seeds' paths the real game joined, and its
+// winner carries on along the real winner's path. A tied replayed game goes
+// to the better rescored seed. It returns nil when the season has no
+// completed winners bracket, when the final is tied, or when a replayed
+// game needs a week a team never played (a team that had a real bye).
+func ReplayChampion(actual, rescored []models.Matchup) *uint {
+	var bracket []models.Matchup
+	for _, m := range actual {
+		if m.GameType == "WINNERS_BRACKET" {
+			if !m.Completed {
+				return nil
+			}
+			bracket = append(bracket, m)
+		}
+	}
+	if len(bracket) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(bracket, func(a, b models.Matchup) int { return int(a.Week) - int(b.Week) })
+
+	actualSeed := make(map[uint]int)
+	for _, s := range Standings(actual) {
+		actualSeed[s.TeamID] = s.Rank
+	}
+	rescoredStandings := Standings(rescored)
+	rescoredSeed := make(map[uint]int, len(rescoredStandings))
+	for _, s := range rescoredStandings {
+		rescoredSeed[s.TeamID] = s.Rank
+	}
+	type teamWeek struct {
+		week   uint
+		teamID uint
+	}
+	scores := make(map[teamWeek]float64)
+	for _, m := range rescored {
+		if m.Completed {
+			scores[teamWeek{m.Week, m.HomeTeamID}] = m.HomeTeamFinalScore
+			scores[teamWeek{m.Week, m.AwayTeamID}] = m.AwayTeamFinalScore
+		}
+	}
+
+	// holders maps each real seed to the team the replay has advanced along
+	// that seed's path; a path nobody has entered yet is held by the team
+	// with that rescored seed.
+	holders := make(map[int]uint)
+	holder := func(seed int) uint {
+		if team, ok := holders[seed]; ok {
+			return team
+		}
+		if seed < 1 || seed > len(rescoredStandings) {
+			return 0
+		}
+		return rescoredStandings[seed-1].TeamID
+	}
+
+	var champion *uint
+	for _, g := range bracket {
+		homeSeed, awaySeed := actualSeed[g.HomeTeamID], actualSeed[g.AwayTeamID]
+		home, away := holder(homeSeed), holder(awaySeed)
+		homeScore, okHome := scores[teamWeek{g.Week, home}]
+		awayScore, okAway := scores[teamWeek{g.Week, away}]
+		if home == 0 || away == 0 || !okHome || !okAway {
+			return nil
+		}
+		winner := home
+		if awayScore > homeScore || (awayScore == homeScore && rescoredSeed[away] < rescoredSeed[home]) {
+			winner = away
+		}
+
+		path := homeSeed
+		if real := winnerOf(g); real == g.AwayTeamID || (real == 0 && awaySeed < homeSeed) {
+			path = awaySeed
+		}
+		holders[path] = winner
+
+		if utils.GetPlayoffGameType(g, actual) == utils.PlayoffGameTypeChampionship && champion == nil {
+			if homeScore == awayScore {
+				return nil
+			}
+			champion = &winner
+		}
+	}
+	return champion
+}
@@ -189,6 +189,50 @@ func (c *Client) GetLeagueUsers(ctx context.Context, leagueID string) ([]LeagueU
 	return users, nil
 }
 
+func (c *Client) GetLeagueRosters(ctx context.Context, leagueID string) ([]Roster, error) {
+	var rosters []Roster
+	if err := c.get(ctx, "/v1/league/"+leagueID+"/rosters", &rosters); err != nil {
+		return nil, err
+	}
+	return rosters, nil
+}
+
+// GetLeagueMatchups fetches every roster's matchup entry for one week.
+func (c *Client) GetLeagueMatchups(ctx context.Context, leagueID string, week int) ([]MatchupEntry, error) {
+	var entries []MatchupEntry
+	path := fmt.Sprintf("/v1/league/%s/matchups/%d", leagueID, week)
+	if err := c.get(ctx, path, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (c *Client) GetWinnersBracket(ctx context.Context, leagueID string) ([]BracketMatch, error) {
+	var bracket []BracketMatch
+	if err := c.get(ctx, "/v1/league/"+leagueID+"/winners_bracket", &bracket); err != nil {
+		return nil, err
+	}
+	return bracket, nil
+}
+
+func (c *Client) GetLosersBracket(ctx context.Context, leagueID string) ([]BracketMatch, error) {
+	var bracket []BracketMatch
+	if err := c.get(ctx, "/v1/league/"+leagueID+"/losers_bracket", &bracket); err != nil {
+		return nil, err
+	}
+	return bracket, nil
+}
+
+// GetTradedPicks fetches every draft pick in a league that has changed hands,
+// across the current and future seasons.
+func (c *Client) GetTradedPicks(ctx context.Context, leagueID string) ([]TradedPick, error) {
+	var picks []TradedPick
+	if err := c.get(ctx, "/v1/league/"+leagueID+"/traded_picks", &picks); err != nil {
+		return nil, err
+	}
+	return picks, nil
+}
+
 func (c *Client) GetLeagueDrafts(ctx context.Context, leagueID string) ([]Draft, error) {
 	var drafts []Draft
 	if err := c.get(ctx, "/v1/league/"+leagueID+"/drafts", &drafts); err != nil {
@@ -197,6 +241,16 @@ func (c *Client) GetLeagueDrafts(ctx context.Context, leagueID string) ([]Draft,
 	return drafts, nil
 }
 
+// GetDraft fetches one draft, including its slot order — which
+// GetLeagueDrafts' list omits until the draft is underway.
+func (c *Client) GetDraft(ctx context.Context, draftID string) (*Draft, error) {
+	var d Draft
+	if err := c.get(ctx, "/v1/draft/"+draftID, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
 func (c *Client) GetDraftPicks(ctx context.Context, draftID string) ([]DraftPick, error) {
 	var picks []DraftPick
 	if err := c.get(ctx, "/v1/draft/"+draftID+"/picks", &picks); err != nil {
@@ -205,6 +259,16 @@ func (c *Client) GetDraftPicks(ctx context.Context, draftID string) ([]DraftPick
 	return picks, nil
 }
 
+// GetDraftTradedPicks fetches the picks in one draft that have changed
+// hands, including any traded while it's underway.
+func (c *Client) GetDraftTradedPicks(ctx context.Context, draftID string) ([]TradedPick, error) {
+	var picks []TradedPick
+	if err := c.get(ctx, "/v1/draft/"+draftID+"/traded_picks", &picks); err != nil {
+		return nil, err
+	}
+	return picks, nil
+}
+
 func (c *Client) GetTransactions(ctx context.Context, leagueID string, round int) ([]Transaction, error) {
 	var txns []Transaction
 	path := fmt.Sprintf("/v1/league/%s/transactions/%d", leagueID, round)
@@ -234,6 +298,18 @@ func (c *Client) GetWeekStats(ctx context.Context, season string, week int) (map
 	return stats, nil
 }
 
+// GetWeekProjections fetches per-player weekly projections for season/week.
+// The response has the same shape as GetWeekStats — projected stat values
+// plus pts_ppr, pts_half_ppr and pts_std — keyed by sleeper_player_id.
+func (c *Client) GetWeekProjections(ctx context.Context, season string, week int) (map[string]json.RawMessage, error) {
+	var projections map[string]json.RawMessage
+	path := fmt.Sprintf("/v1/projections/nfl/regular/%s/%d", season, week)
+	if err := c.get(ctx, path, &projections); err != nil {
+		return nil, err
+	}
+	return projections, nil
+}
+
 // GetNFLState fetches the current NFL season/week/season_type.
 func (c *Client) GetNFLState(ctx context.Context) (*NFLState, error) {
 	var s NFLState
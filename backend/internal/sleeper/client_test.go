@@ -381,3 +381,30 @@ func TestGet_ReusesConnectionAcrossRetries(t *testing.T) {
 		t.Errorf("expected connection reuse across retries (1 new conn), got %d new conns", got)
 	}
 }
+
+func TestGetLeagueMatchups_DecodesByeAndCustomPoints(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/league/L1/matchups/3" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"roster_id": 1, "matchup_id": 2, "points": 101.5, "custom_points": 99,
+			"starters": ["4046"], "players": ["4046", "0"], "players_points": {"4046": 20.5}},
+			{"roster_id": 5, "matchup_id": null, "points": 0}]`))
+	}))
+	defer srv.Close()
+
+	c := sleeper.NewWithBaseURL(srv.URL)
+	entries, err := c.GetLeagueMatchups(context.Background(), "L1", 3)
+	if err != nil {
+		t.Fatalf("GetLeagueMatchups error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if e := entries[0]; e.MatchupID == nil || *e.MatchupID != 2 || e.CustomPoints == nil || *e.CustomPoints != 99 || e.PlayersPoints["4046"] != 20.5 {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if entries[1].MatchupID != nil {
+		t.Errorf("expected nil matchup_id for a bye, got %d", *entries[1].MatchupID)
+	}
+}
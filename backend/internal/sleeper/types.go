@@ -34,6 +34,23 @@ type User struct {
 type LeagueSettings struct {
 	// Type encodes the league format: 0=redraft, 1=keeper, 2=dynasty.
 	Type int `json:"type"`
+	// DraftRounds is the number of rounds in the league's rookie/startup
+	// draft, i.e. how many picks per season each roster starts with.
+	DraftRounds int `json:"draft_rounds"`
+	// PlayoffWeekStart is the first playoff week; earlier weeks are regular
+	// season.
+	PlayoffWeekStart int `json:"playoff_week_start"`
+	// PlayoffRoundType: 0=one week per round, 1=two-week championship,
+	// 2=two weeks per round.
+	PlayoffRoundType int `json:"playoff_round_type"`
+	// Leg is the league's current scoring week; LastScoredLeg the last week
+	// whose scores are final.
+	Leg           int `json:"leg"`
+	LastScoredLeg int `json:"last_scored_leg"`
+	// WaiverType: 0=rolling waivers, 1=reverse standings, 2=FAAB. Only a
+	// FAAB league's WaiverBudget is meaningful.
+	WaiverType   int `json:"waiver_type"`
+	WaiverBudget int `json:"waiver_budget"`
 }
 
 type League struct {
@@ -46,29 +63,114 @@ type League struct {
 	Settings        LeagueSettings     `json:"settings"`
 	ScoringSettings map[string]float64 `json:"scoring_settings"`
 	RosterPositions []string           `json:"roster_positions"`
+	// PreviousLeagueID links a renewed league to last season's league; empty
+	// (or "0") for a league's first season.
+	PreviousLeagueID string `json:"previous_league_id"`
 }
 
 type LeagueUser struct {
-	UserID      string `json:"user_id"`
-	Username    string `json:"username"`
-	DisplayName string `json:"display_name"`
-	Avatar      string `json:"avatar"`
+	UserID      string             `json:"user_id"`
+	Username    string             `json:"username"`
+	DisplayName string             `json:"display_name"`
+	Avatar      string             `json:"avatar"`
+	Metadata    LeagueUserMetadata `json:"metadata"`
+}
+
+// LeagueUserMetadata carries the user's per-league team name, when set.
+type LeagueUserMetadata struct {
+	TeamName string `json:"team_name"`
+}
+
+// Roster is one entry from GET /v1/league/<id>/rosters. OwnerID matches a
+// LeagueUser's UserID and is empty for an orphaned roster.
+type Roster struct {
+	RosterID int            `json:"roster_id"`
+	OwnerID  string         `json:"owner_id"`
+	Players  []string       `json:"players"`
+	Starters []string       `json:"starters"`
+	Settings RosterSettings `json:"settings"`
+}
+
+// RosterSettings is a roster's season record. Sleeper splits points into a
+// whole part and hundredths (fpts 123, fpts_decimal 45 = 123.45).
+type RosterSettings struct {
+	Wins               int `json:"wins"`
+	Losses             int `json:"losses"`
+	Ties               int `json:"ties"`
+	Fpts               int `json:"fpts"`
+	FptsDecimal        int `json:"fpts_decimal"`
+	FptsAgainst        int `json:"fpts_against"`
+	FptsAgainstDecimal int `json:"fpts_against_decimal"`
+}
+
+// MatchupEntry is one roster's side of a week's matchup, from GET
+// /v1/league/<id>/matchups/<week>. The two rosters sharing a MatchupID play
+// each other; a nil MatchupID means the roster had no game that week (e.g.
+// eliminated from the playoffs). Starters are in roster_positions order, with
+// "0" for an empty slot. CustomPoints, when set, is a commissioner override
+// of Points.
+type MatchupEntry struct {
+	RosterID      int                `json:"roster_id"`
+	MatchupID     *int               `json:"matchup_id"`
+	Points        float64            `json:"points"`
+	CustomPoints  *float64           `json:"custom_points"`
+	Starters      []string           `json:"starters"`
+	Players       []string           `json:"players"`
+	PlayersPoints map[string]float64 `json:"players_points"`
+}
+
+// BracketMatch is one game from GET /v1/league/<id>/winners_bracket or
+// losers_bracket. Round is 1-based from playoff_week_start. T1/T2 are roster
+// IDs, nil until the feeder games are decided. Placement is set on
+// placement games — 1 for the final, 3 for third place, and so on.
+type BracketMatch struct {
+	Round     int  `json:"r"`
+	Match     int  `json:"m"`
+	T1        *int `json:"t1"`
+	T2        *int `json:"t2"`
+	Winner    *int `json:"w"`
+	Loser     *int `json:"l"`
+	Placement *int `json:"p"`
 }
 
 type Draft struct {
-	DraftID  string `json:"draft_id"`
-	Type     string `json:"type"`
-	Status   string `json:"status"`
-	Season   string `json:"season"`
+	DraftID  string        `json:"draft_id"`
+	LeagueID string        `json:"league_id"`
+	Type     string        `json:"type"`
+	Status   string        `json:"status"`
+	Season   string        `json:"season"`
+	Settings DraftSettings `json:"settings"`
+	// StartTime is when the draft started (or is scheduled to), in Unix
+	// milliseconds; 0 when unscheduled.
+	StartTime int64 `json:"start_time"`
+	// DraftOrder maps each user ID to their draft slot; nil until the order
+	// is set.
+	DraftOrder map[string]int `json:"draft_order"`
+	// SlotToRosterID maps each draft slot ("1".."teams") to the roster whose
+	// own pick it is; nil until the order is set.
+	SlotToRosterID map[string]int `json:"slot_to_roster_id"`
+}
+
+// DraftSettings is the subset of a draft's settings we read. Budget is each
+// team's auction budget, set only on auction drafts; Rounds is the number of
+// rounds, which tells a dynasty startup from a rookie draft; Teams is the
+// number of draft slots; ReversalRound, when set, is the round from which a
+// snake draft's order flips again (3 for a third-round reversal).
+type DraftSettings struct {
+	Budget        int `json:"budget"`
+	Rounds        int `json:"rounds"`
+	Teams         int `json:"teams"`
+	ReversalRound int `json:"reversal_round"`
 }
 
 type DraftPick struct {
-	Round    int                    `json:"round"`
-	PickNo   int                    `json:"pick_no"`
-	RosterID int                    `json:"roster_id"`
-	PickedBy string                 `json:"picked_by"`
-	PlayerID string                 `json:"player_id"`
-	Metadata map[string]interface{} `json:"metadata"`
+	Round     int                    `json:"round"`
+	PickNo    int                    `json:"pick_no"`
+	DraftSlot int                    `json:"draft_slot"`
+	RosterID  int                    `json:"roster_id"`
+	PickedBy  string                 `json:"picked_by"`
+	PlayerID  string                 `json:"player_id"`
+	Metadata  map[string]interface{} `json:"metadata"`
 }
 
 type Transaction struct {
@@ -82,6 +184,27 @@ type Transaction struct {
 	DraftPicks    []interface{}  `json:"draft_picks"`
 	WaiverBudget  []interface{}  `json:"waiver_budget"`
 	RosterIDs     []int          `json:"roster_ids"`
+	// Settings is nil on most transactions; a FAAB league's waiver claims
+	// carry the bid.
+	Settings *TransactionSettings `json:"settings"`
+}
+
+// TransactionSettings is a transaction's settings object. WaiverBid is the
+// FAAB amount bid on a waiver claim — the winning bid on a complete one.
+type TransactionSettings struct {
+	WaiverBid *int `json:"waiver_bid"`
+}
+
+// TradedPick is one entry from GET /v1/league/{id}/traded_picks (or
+// /v1/draft/{id}/traded_picks), and the
+// shape of each element of a trade's draft_picks. RosterID is the pick's
+// original owner; OwnerID its current (or, in a trade, receiving) owner.
+type TradedPick struct {
+	Season          string `json:"season"`
+	Round           int    `json:"round"`
+	RosterID        int    `json:"roster_id"`
+	PreviousOwnerID int    `json:"previous_owner_id"`
+	OwnerID         int    `json:"owner_id"`
 }
 
 // Player is one entry from the map returned by GET /v1/players/nfl.
@@ -0,0 +1,565 @@
+// Package sleeperimport maps Sleeper leagues into the same League, Team,
+// Matchup and BoxScore tables internal/etl fills from ESPN exports, so
+// standings, expected wins, schedules and box scores work unchanged for
+// Sleeper leagues.
+//
+// One models.League covers a Sleeper league's whole history: Sleeper issues
+// a new league_id every season and links it back via previous_league_id, so
+// Register walks that chain and imports each season into the same League;
+// Sync follows it forward by finding the league whose previous_league_id is
+// the stored one. League.ExternalID always holds the newest season's
+// league_id. Sleeper
+// roster IDs are stable across a league's seasons and go in Team.ESPNID, the
+// column the ETL uses for the platform's team ID.
+package sleeperimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+	"backend/internal/scoring"
+	"backend/internal/sleeper"
+)
+
+// Platform is models.League.Platform for imported Sleeper leagues.
+const Platform = "Sleeper"
+
+// maxSeasons bounds the previous_league_id walk, guarding against a cycle in
+// Sleeper's data.
+const maxSeasons = 25
+
+// lastWeek is the last NFL week a Sleeper league can schedule.
+const lastWeek = 18
+
+// ErrNotSleeperLeague is returned by Sync for a league on another platform.
+var ErrNotSleeperLeague = errors.New("league is not a Sleeper league")
+
+// Importer imports Sleeper leagues. DB is written to directly; it is not
+// necessarily database.DB.
+type Importer struct {
+	DB      *gorm.DB
+	Sleeper *sleeper.Client
+}
+
+// Result counts what one Register or Sync call wrote.
+type Result struct {
+	Seasons   int
+	Teams     int
+	Matchups  int
+	BoxScores int
+	// UnresolvedPlayers counts lineup entries skipped because no players row
+	// has the Sleeper ID yet. PlayerDatabaseSyncWorkflow creates those rows
+	// weekly, and the next Sync fills the gaps.
+	UnresolvedPlayers int
+}
+
+// Register imports a Sleeper league and every earlier season linked to it,
+// oldest first. Registering a league whose earlier season is already
+// registered — i.e. the league renewed — extends that League instead of
+// creating a second one, and moves its ExternalID to the new season.
+func (im *Importer) Register(ctx context.Context, sleeperLeagueID string) (models.League, Result, error) {
+	var chain []*sleeper.League
+	for id := sleeperLeagueID; id != "" && id != "0" && len(chain) < maxSeasons; {
+		sl, err := im.Sleeper.GetLeague(ctx, id)
+		if err != nil {
+			return models.League{}, Result{}, fmt.Errorf("fetch sleeper league %s: %w", id, err)
+		}
+		chain = append(chain, sl)
+		id = sl.PreviousLeagueID
+	}
+
+	ids := make([]string, len(chain))
+	for i, sl := range chain {
+		ids[i] = sl.LeagueID
+	}
+	var league models.League
+	err := im.DB.WithContext(ctx).
+		Where("platform = ? AND external_id IN ?", Platform, ids).
+		First(&league).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		league = models.League{Platform: Platform, ExternalID: sleeperLeagueID, Name: chain[0].Name}
+		err = im.DB.WithContext(ctx).Create(&league).Error
+	}
+	if err != nil {
+		return models.League{}, Result{}, err
+	}
+
+	var res Result
+	for i := len(chain) - 1; i >= 0; i-- {
+		if err := im.importSeason(ctx, &league, chain[i], i == 0, &res); err != nil {
+			return league, res, fmt.Errorf("import sleeper league %s: %w", chain[i].LeagueID, err)
+		}
+	}
+	return league, res, nil
+}
+
+// Sync re-imports the current season of a registered Sleeper league: team
+// names, the weeks played so far, and the playoff bracket. Once that season
+// is complete, Sync also looks for its renewal and, when there is one,
+// finishes the old season, imports the new one and moves ExternalID to it.
+func (im *Importer) Sync(ctx context.Context, leagueID uint) (Result, error) {
+	var league models.League
+	if err := im.DB.WithContext(ctx).First(&league, leagueID).Error; err != nil {
+		return Result{}, err
+	}
+	if league.Platform != Platform {
+		return Result{}, ErrNotSleeperLeague
+	}
+	sl, err := im.Sleeper.GetLeague(ctx, league.ExternalID)
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch sleeper league %s: %w", league.ExternalID, err)
+	}
+	var res Result
+	for range maxSeasons {
+		next, err := im.successor(ctx, sl)
+		if err != nil {
+			return res, err
+		}
+		if next == nil {
+			break
+		}
+		if err := im.importSeason(ctx, &league, sl, false, &res); err != nil {
+			return res, fmt.Errorf("import sleeper league %s: %w", sl.LeagueID, err)
+		}
+		sl = next
+	}
+	if err := im.importSeason(ctx, &league, sl, true, &res); err != nil {
+		return res, fmt.Errorf("import sleeper league %s: %w", sl.LeagueID, err)
+	}
+	return res, nil
+}
+
+// successor finds the league that renewed sl, or nil if it hasn't been
+// renewed. Sleeper has no forward link, so this searches the next season's
+// leagues of sl's members for one whose previous_league_id is sl. Leagues
+// only renew after their season ends, so an unfinished sl is skipped
+// without asking Sleeper anything.
+func (im *Importer) successor(ctx context.Context, sl *sleeper.League) (*sleeper.League, error) {
+	if sl.Status != "complete" {
+		return nil, nil
+	}
+	year, err := strconv.Atoi(sl.Season)
+	if err != nil {
+		return nil, fmt.Errorf("parse season %q: %w", sl.Season, err)
+	}
+	users, err := im.Sleeper.GetLeagueUsers(ctx, sl.LeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch sleeper league %s users: %w", sl.LeagueID, err)
+	}
+	next := strconv.Itoa(year + 1)
+	for _, u := range users {
+		leagues, err := im.Sleeper.GetUserLeagues(ctx, u.UserID, "nfl", next)
+		if err != nil {
+			return nil, fmt.Errorf("fetch sleeper user %s leagues: %w", u.UserID, err)
+		}
+		for _, l := range leagues {
+			if l.PreviousLeagueID != sl.LeagueID {
+				continue
+			}
+			renewed, err := im.Sleeper.GetLeague(ctx, l.LeagueID)
+			if err != nil {
+				return nil, fmt.Errorf("fetch sleeper league %s: %w", l.LeagueID, err)
+			}
+			return renewed, nil
+		}
+	}
+	return nil, nil
+}
+
+// importSeason imports one season's teams and matchups into league. latest
+// marks the newest season, whose settings become the League's own.
+func (im *Importer) importSeason(ctx context.Context, league *models.League, sl *sleeper.League, latest bool, res *Result) error {
+	year, err := strconv.Atoi(sl.Season)
+	if err != nil {
+		return fmt.Errorf("parse season %q: %w", sl.Season, err)
+	}
+
+	winners, err := im.bracket(ctx, sl, im.Sleeper.GetWinnersBracket)
+	if err != nil {
+		return err
+	}
+	losers, err := im.bracket(ctx, sl, im.Sleeper.GetLosersBracket)
+	if err != nil {
+		return err
+	}
+
+	if latest {
+		applyLeagueSettings(league, sl, year, winners)
+		if err := im.DB.WithContext(ctx).Save(league).Error; err != nil {
+			return err
+		}
+	}
+
+	teamIDs, err := im.importTeams(ctx, league.ID, sl.LeagueID, res)
+	if err != nil {
+		return err
+	}
+
+	weeks := min(max(sl.Settings.LastScoredLeg, sl.Settings.Leg), lastWeek)
+	for week := 1; week <= weeks; week++ {
+		entries, err := im.Sleeper.GetLeagueMatchups(ctx, sl.LeagueID, week)
+		if err != nil {
+			return fmt.Errorf("fetch week %d matchups: %w", week, err)
+		}
+		if err := im.importWeek(ctx, league.ID, uint(year), uint(week), sl, entries, teamIDs, winners, losers, res); err != nil {
+			return fmt.Errorf("import week %d: %w", week, err)
+		}
+	}
+	res.Seasons++
+	return nil
+}
+
+// bracket fetches a playoff bracket, treating a league without playoffs (or
+// whose bracket Sleeper hasn't generated yet) as an empty bracket.
+func (im *Importer) bracket(ctx context.Context, sl *sleeper.League, fetch func(context.Context, string) ([]sleeper.BracketMatch, error)) ([]sleeper.BracketMatch, error) {
+	if sl.Settings.PlayoffWeekStart == 0 {
+		return nil, nil
+	}
+	matches, err := fetch(ctx, sl.LeagueID)
+	var nfe *sleeper.NotFoundError
+	if errors.As(err, &nfe) {
+		return nil, nil
+	}
+	return matches, err
+}
+
+// applyLeagueSettings copies the newest season's settings onto league.
+func applyLeagueSettings(league *models.League, sl *sleeper.League, year int, winners []sleeper.BracketMatch) {
+	rules := scoring.Rules(sl.ScoringSettings)
+	league.Name = sl.Name
+	league.ExternalID = sl.LeagueID
+	league.Season = year
+	league.CurrentWeek = sl.Settings.Leg
+	league.ScoringSettings = scoring.SettingsFromRules(rules)
+	switch rules["rec"] {
+	case 1:
+		league.ScoringType = "PPR"
+	case 0.5:
+		league.ScoringType = "Half-PPR"
+	default:
+		league.ScoringType = "Standard"
+	}
+	league.RosterSettings = rosterSettings(sl.RosterPositions)
+
+	rounds := 0
+	for _, m := range winners {
+		rounds = max(rounds, m.Round)
+	}
+	if sl.Settings.PlayoffWeekStart > 0 && rounds > 0 {
+		league.PlayoffWeeks = rounds
+		if sl.Settings.PlayoffRoundType == 2 {
+			league.PlayoffWeeks *= 2
+		} else if sl.Settings.PlayoffRoundType == 1 {
+			league.PlayoffWeeks++
+		}
+		league.TotalWeeks = sl.Settings.PlayoffWeekStart - 1 + league.PlayoffWeeks
+	}
+}
+
+// rosterSettings counts roster_positions into RosterSettings. Every flex
+// variant (FLEX, WRRB_FLEX, REC_FLEX, SUPER_FLEX) counts as a FLEX spot.
+func rosterSettings(positions []string) models.RosterSettings {
+	var rs models.RosterSettings
+	for _, p := range positions {
+		switch p {
+		case "QB":
+			rs.QB++
+		case "RB":
+			rs.RB++
+		case "WR":
+			rs.WR++
+		case "TE":
+			rs.TE++
+		case "FLEX", "WRRB_FLEX", "REC_FLEX", "SUPER_FLEX":
+			rs.FLEX++
+		case "K":
+			rs.K++
+		case "DEF":
+			rs.DST++
+		case "BN":
+			rs.BN++
+		case "IR":
+			rs.IR++
+		}
+	}
+	return rs
+}
+
+// importTeams upserts one Team per roster and returns roster ID -> team ID.
+// The team name is the owner's per-league team name, falling back to their
+// display name; an orphaned roster is named after its roster ID.
+func (im *Importer) importTeams(ctx context.Context, leagueID uint, sleeperLeagueID string, res *Result) (map[int]uint, error) {
+	users, err := im.Sleeper.GetLeagueUsers(ctx, sleeperLeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch league users: %w", err)
+	}
+	rosters, err := im.Sleeper.GetLeagueRosters(ctx, sleeperLeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch league rosters: %w", err)
+	}
+	usersByID := make(map[string]sleeper.LeagueUser, len(users))
+	for _, u := range users {
+		usersByID[u.UserID] = u
+	}
+
+	teamIDs := make(map[int]uint, len(rosters))
+	for _, r := range rosters {
+		name := fmt.Sprintf("Team %d", r.RosterID)
+		owner := ""
+		if u, ok := usersByID[r.OwnerID]; ok {
+			owner = u.DisplayName
+			name = u.DisplayName
+			if u.Metadata.TeamName != "" {
+				name = u.Metadata.TeamName
+			}
+		}
+
+		var team models.Team
+		err := im.DB.WithContext(ctx).
+			Where("espn_id = ? AND league_id = ?", r.RosterID, leagueID).
+			First(&team).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			team = models.Team{LeagueID: leagueID, ESPNID: uint(r.RosterID), Name: name, Owner: owner}
+			if err := im.DB.WithContext(ctx).Create(&team).Error; err != nil {
+				return nil, fmt.Errorf("create team for roster %d: %w", r.RosterID, err)
+			}
+		case err != nil:
+			return nil, err
+		case team.Name != name || team.Owner != owner:
+			team.Name, team.Owner = name, owner
+			if err := im.DB.WithContext(ctx).Save(&team).Error; err != nil {
+				return nil, fmt.Errorf("update team for roster %d: %w", r.RosterID, err)
+			}
+		}
+		teamIDs[r.RosterID] = team.ID
+		res.Teams++
+	}
+	return teamIDs, nil
+}
+
+// importWeek pairs the week's matchup entries into games and upserts each
+// game's Matchup and both lineups' BoxScores.
+func (im *Importer) importWeek(ctx context.Context, leagueID, year, week uint, sl *sleeper.League, entries []sleeper.MatchupEntry, teamIDs map[int]uint, winners, losers []sleeper.BracketMatch, res *Result) error {
+	games := make(map[int][]sleeper.MatchupEntry)
+	var sleeperIDs []string
+	for _, e := range entries {
+		if e.MatchupID == nil {
+			continue
+		}
+		games[*e.MatchupID] = append(games[*e.MatchupID], e)
+		sleeperIDs = append(sleeperIDs, e.Players...)
+	}
+	players, err := models.GetPlayersBySleeperIDs(im.DB.WithContext(ctx), sleeperIDs)
+	if err != nil {
+		return err
+	}
+
+	gameIDs := make([]int, 0, len(games))
+	for id := range games {
+		gameIDs = append(gameIDs, id)
+	}
+	sort.Ints(gameIDs)
+	for _, id := range gameIDs {
+		pair := games[id]
+		if len(pair) != 2 {
+			continue
+		}
+		// Sleeper has no home/away; the lower roster ID is home so re-syncs
+		// find the same row.
+		if pair[0].RosterID > pair[1].RosterID {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+		home, away := pair[0], pair[1]
+		homeTeamID, ok := teamIDs[home.RosterID]
+		if !ok {
+			return fmt.Errorf("no team for roster %d", home.RosterID)
+		}
+		awayTeamID, ok := teamIDs[away.RosterID]
+		if !ok {
+			return fmt.Errorf("no team for roster %d", away.RosterID)
+		}
+
+		gameType := gameTypeFor(int(week), sl.Settings, home.RosterID, away.RosterID, winners, losers)
+		homeScore, awayScore := entryPoints(home), entryPoints(away)
+		matchup := models.Matchup{
+			LeagueID:           leagueID,
+			Week:               week,
+			Year:               year,
+			HomeTeamID:         homeTeamID,
+			AwayTeamID:         awayTeamID,
+			GameType:           gameType,
+			HomeTeamFinalScore: homeScore,
+			AwayTeamFinalScore: awayScore,
+			// Same rule as the ESPN ETL: a 0-0 game hasn't been played.
+			Completed: int(week) <= sl.Settings.LastScoredLeg && !(homeScore == 0 && awayScore == 0),
+			IsPlayoff: gameType != "NONE",
+		}
+
+		err := im.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var existing models.Matchup
+			err := tx.Where("league_id = ? AND year = ? AND week = ? AND home_team_id = ? AND away_team_id = ?",
+				leagueID, year, week, homeTeamID, awayTeamID).First(&existing).Error
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				if err := tx.Create(&matchup).Error; err != nil {
+					return err
+				}
+			case err != nil:
+				return err
+			default:
+				matchup.ID, matchup.CreatedAt = existing.ID, existing.CreatedAt
+				if err := tx.Save(&matchup).Error; err != nil {
+					return err
+				}
+			}
+			for _, side := range []struct {
+				entry  sleeper.MatchupEntry
+				teamID uint
+			}{{home, homeTeamID}, {away, awayTeamID}} {
+				if err := upsertLineup(tx, matchup.ID, side.teamID, side.entry, sl.RosterPositions, players, res); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		res.Matchups++
+	}
+	return nil
+}
+
+// entryPoints is a roster's score for the week, honoring a commissioner's
+// override.
+func entryPoints(e sleeper.MatchupEntry) float64 {
+	if e.CustomPoints != nil {
+		return *e.CustomPoints
+	}
+	return e.Points
+}
+
+// gameTypeFor maps a game onto the ESPN game types the rest of the app
+// understands (see utils.GetPlayoffGameType): regular-season weeks are
+// "NONE", winners-bracket games for first place "WINNERS_BRACKET", other
+// winners-bracket placement games "WINNERS_CONSOLATION_LADDER", and
+// losers-bracket games — or playoff-week games in neither bracket —
+// "LOSERS_CONSOLATION_LADDER".
+func gameTypeFor(week int, settings sleeper.LeagueSettings, t1, t2 int, winners, losers []sleeper.BracketMatch) string {
+	if settings.PlayoffWeekStart == 0 || week < settings.PlayoffWeekStart {
+		return "NONE"
+	}
+	if m := findBracketMatch(week, settings, t1, t2, winners); m != nil {
+		if m.Placement != nil && *m.Placement > 1 {
+			return "WINNERS_CONSOLATION_LADDER"
+		}
+		return "WINNERS_BRACKET"
+	}
+	return "LOSERS_CONSOLATION_LADDER"
+}
+
+// findBracketMatch finds the bracket game between t1 and t2 in the round
+// week falls in. Multi-week rounds (playoff_round_type 1 or 2) don't map
+// rounds to weeks one-to-one, so for those any round's game between the
+// pair matches. The losers bracket is only consulted through the
+// fallthrough in gameTypeFor, so it isn't searched here.
+func findBracketMatch(week int, settings sleeper.LeagueSettings, t1, t2 int, bracket []sleeper.BracketMatch) *sleeper.BracketMatch {
+	round := week - settings.PlayoffWeekStart + 1
+	for i, m := range bracket {
+		if m.T1 == nil || m.T2 == nil {
+			continue
+		}
+		if !(*m.T1 == t1 && *m.T2 == t2) && !(*m.T1 == t2 && *m.T2 == t1) {
+			continue
+		}
+		if settings.PlayoffRoundType != 0 || m.Round == round {
+			return &bracket[i]
+		}
+	}
+	return nil
+}
+
+// upsertLineup writes one roster's BoxScores for a matchup, keyed by
+// (matchup, player) — unlike the ESPN ETL's cross-league (player, week) key,
+// since a Sleeper league's points are under its own scoring — and removes
+// rows for players no longer on the lineup (a re-sync after a lineup
+// change).
+func upsertLineup(tx *gorm.DB, matchupID, teamID uint, entry sleeper.MatchupEntry, rosterPositions []string, players map[string]models.Player, res *Result) error {
+	slots := make(map[string]string, len(entry.Starters))
+	for i, id := range entry.Starters {
+		if id == "0" || i >= len(rosterPositions) {
+			continue
+		}
+		slots[id] = slotPosition(rosterPositions[i])
+	}
+
+	keep := make([]uint, 0, len(entry.Players))
+	for _, sleeperID := range entry.Players {
+		player, ok := players[sleeperID]
+		if !ok {
+			res.UnresolvedPlayers++
+			continue
+		}
+		slot, started := slots[sleeperID]
+		if !started {
+			slot = "BE"
+		}
+		box := models.BoxScore{
+			MatchupID:    matchupID,
+			PlayerID:     player.ID,
+			TeamID:       teamID,
+			StartedFlag:  started,
+			SlotPosition: slot,
+			ActualPoints: entry.PlayersPoints[sleeperID],
+		}
+		var existing models.BoxScore
+		err := tx.Where("matchup_id = ? AND player_id = ?", matchupID, player.ID).First(&existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := tx.Create(&box).Error; err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			box.ID, box.CreatedAt = existing.ID, existing.CreatedAt
+			if err := tx.Save(&box).Error; err != nil {
+				return err
+			}
+		}
+		keep = append(keep, player.ID)
+		res.BoxScores++
+	}
+
+	stale := tx.Where("matchup_id = ? AND team_id = ?", matchupID, teamID)
+	if len(keep) > 0 {
+		stale = stale.Where("player_id NOT IN ?", keep)
+	}
+	return stale.Delete(&models.BoxScore{}).Error
+}
+
+// slotPosition maps a Sleeper roster slot to the ESPN slot name the rest of
+// the app reads (see isStarter in internal/scoring).
+func slotPosition(slot string) string {
+	switch slot {
+	case "FLEX":
+		return "RB/WR/TE"
+	case "WRRB_FLEX":
+		return "RB/WR"
+	case "REC_FLEX":
+		return "WR/TE"
+	case "SUPER_FLEX":
+		return "OP"
+	case "DEF":
+		return "D/ST"
+	default:
+		return slot
+	}
+}
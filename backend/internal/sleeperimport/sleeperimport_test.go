@@ -0,0 +1,273 @@
+package sleeperimport_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"backend/internal/models"
+	"backend/internal/sleeper"
+	"backend/internal/sleeperimport"
+)
+
+// fakeSleeper serves canned JSON bodies by path; anything else is a 404.
+type fakeSleeper struct {
+	mu     sync.Mutex
+	bodies map[string]string
+}
+
+func (f *fakeSleeper) set(path, body string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.bodies[path] = body
+}
+
+func (f *fakeSleeper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	body, ok := f.bodies[r.URL.Path]
+	f.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(body))
+}
+
+// newFakeSleeper builds a two-season league: "L24" (2024, two rosters, one
+// regular-season week) renewed as "L25" (2025, four rosters, week 1 regular
+// season and week 2 the first playoff round).
+func newFakeSleeper() *fakeSleeper {
+	return &fakeSleeper{bodies: map[string]string{
+		"/v1/league/L24": `{"league_id": "L24", "name": "Old Name", "season": "2024",
+			"settings": {"leg": 1, "last_scored_leg": 1},
+			"scoring_settings": {"rec": 0.5, "pass_td": 4},
+			"roster_positions": ["QB", "FLEX", "BN"]}`,
+		"/v1/league/L24/users":   `[{"user_id": "u1", "display_name": "alice"}, {"user_id": "u2", "display_name": "bob"}]`,
+		"/v1/league/L24/rosters": `[{"roster_id": 1, "owner_id": "u1"}, {"roster_id": 2, "owner_id": "u2"}]`,
+		"/v1/league/L24/matchups/1": `[
+			{"roster_id": 2, "matchup_id": 1, "points": 90, "starters": ["p2", "0"], "players": ["p2"], "players_points": {"p2": 90}},
+			{"roster_id": 1, "matchup_id": 1, "points": 80, "starters": ["p1", "0"], "players": ["p1"], "players_points": {"p1": 80}}]`,
+
+		"/v1/league/L25": `{"league_id": "L25", "name": "The League", "season": "2025", "previous_league_id": "L24",
+			"settings": {"playoff_week_start": 2, "leg": 2, "last_scored_leg": 2},
+			"scoring_settings": {"rec": 1, "pass_td": 6},
+			"roster_positions": ["QB", "FLEX", "BN"]}`,
+		"/v1/league/L25/users": `[{"user_id": "u1", "display_name": "alice", "metadata": {"team_name": "Alice's Aces"}},
+			{"user_id": "u2", "display_name": "bob"}]`,
+		"/v1/league/L25/rosters": `[{"roster_id": 1, "owner_id": "u1"}, {"roster_id": 2, "owner_id": "u2"},
+			{"roster_id": 3, "owner_id": ""}, {"roster_id": 4, "owner_id": ""}]`,
+		"/v1/league/L25/matchups/1": `[
+			{"roster_id": 1, "matchup_id": 1, "points": 100, "starters": ["p9", "p1"], "players": ["p1", "p2", "p9"], "players_points": {"p1": 60, "p2": 5, "p9": 40}},
+			{"roster_id": 2, "matchup_id": 1, "points": 95, "custom_points": 97},
+			{"roster_id": 3, "matchup_id": 2, "points": 70},
+			{"roster_id": 4, "matchup_id": 2, "points": 75}]`,
+		"/v1/league/L25/matchups/2": `[
+			{"roster_id": 1, "matchup_id": 1, "points": 110},
+			{"roster_id": 2, "matchup_id": 1, "points": 105},
+			{"roster_id": 3, "matchup_id": 2, "points": 0},
+			{"roster_id": 4, "matchup_id": 2, "points": 0}]`,
+		"/v1/league/L25/winners_bracket": `[{"r": 1, "m": 1, "t1": 2, "t2": 1, "w": 1, "l": 2, "p": 1}]`,
+		"/v1/league/L25/losers_bracket":  `[{"r": 1, "m": 1, "t1": 3, "t2": 4}]`,
+	}}
+}
+
+func setup(t *testing.T) (*sleeperimport.Importer, *fakeSleeper, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.League{}, &models.Team{}, &models.TeamNameHistory{},
+		&models.Matchup{}, &models.BoxScore{}, &models.Player{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	for _, p := range []models.Player{{SleeperID: "p1", Name: "One"}, {SleeperID: "p2", Name: "Two"}} {
+		if err := db.Create(&p).Error; err != nil {
+			t.Fatalf("seed player: %v", err)
+		}
+	}
+
+	fake := newFakeSleeper()
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return &sleeperimport.Importer{DB: db, Sleeper: sleeper.NewWithBaseURL(srv.URL)}, fake, db
+}
+
+func TestRegister_ImportsHistoryIntoOneLeague(t *testing.T) {
+	im, _, db := setup(t)
+
+	league, res, err := im.Register(context.Background(), "L25")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if league.ExternalID != "L25" || league.Season != 2025 || league.Name != "The League" || league.Platform != "Sleeper" {
+		t.Errorf("unexpected league %+v", league)
+	}
+	if league.ScoringType != "PPR" || league.ScoringSettings.PassingTD != 6 {
+		t.Errorf("expected 2025 PPR scoring with 6-pt TDs, got %q %+v", league.ScoringType, league.ScoringSettings)
+	}
+	if league.RosterSettings.QB != 1 || league.RosterSettings.FLEX != 1 || league.RosterSettings.BN != 1 {
+		t.Errorf("unexpected roster settings %+v", league.RosterSettings)
+	}
+	if res.Seasons != 2 || res.Matchups != 5 || res.UnresolvedPlayers != 1 {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	var teams []models.Team
+	db.Where("league_id = ?", league.ID).Order("espn_id").Find(&teams)
+	if len(teams) != 4 || teams[0].Name != "Alice's Aces" || teams[0].Owner != "alice" || teams[1].Name != "bob" || teams[2].Name != "Team 3" {
+		t.Errorf("unexpected teams %+v", teams)
+	}
+	teamByRoster := map[uint]uint{}
+	for _, tm := range teams {
+		teamByRoster[tm.ESPNID] = tm.ID
+	}
+
+	var m2024 models.Matchup
+	if err := db.Where("league_id = ? AND year = 2024", league.ID).First(&m2024).Error; err != nil {
+		t.Fatalf("2024 matchup: %v", err)
+	}
+	// Lower roster ID is home regardless of Sleeper's entry order.
+	if m2024.HomeTeamID != teamByRoster[1] || m2024.HomeTeamFinalScore != 80 || m2024.AwayTeamFinalScore != 90 || !m2024.Completed {
+		t.Errorf("unexpected 2024 matchup %+v", m2024)
+	}
+
+	var week1 models.Matchup
+	db.Where("league_id = ? AND year = 2025 AND week = 1 AND home_team_id = ?", league.ID, teamByRoster[1]).First(&week1)
+	if week1.GameType != "NONE" || week1.IsPlayoff || week1.AwayTeamFinalScore != 97 {
+		t.Errorf("unexpected week 1 matchup %+v", week1)
+	}
+
+	var playoffs []models.Matchup
+	db.Where("league_id = ? AND year = 2025 AND week = 2", league.ID).Order("home_team_id").Find(&playoffs)
+	if len(playoffs) != 2 || playoffs[0].GameType != "WINNERS_BRACKET" || playoffs[1].GameType != "LOSERS_CONSOLATION_LADDER" {
+		t.Fatalf("unexpected playoff matchups %+v", playoffs)
+	}
+	if !playoffs[0].Completed || !playoffs[0].IsPlayoff || playoffs[1].Completed {
+		t.Errorf("expected scored game completed and 0-0 game not, got %+v", playoffs)
+	}
+
+	var boxes []models.BoxScore
+	db.Where("matchup_id = ?", week1.ID).Order("player_id").Find(&boxes)
+	if len(boxes) != 2 {
+		t.Fatalf("expected 2 box scores (p9 unresolved), got %+v", boxes)
+	}
+	if !boxes[0].StartedFlag || boxes[0].SlotPosition != "RB/WR/TE" || boxes[0].ActualPoints != 60 {
+		t.Errorf("expected p1 started at FLEX, got %+v", boxes[0])
+	}
+	if boxes[1].StartedFlag || boxes[1].SlotPosition != "BE" {
+		t.Errorf("expected p2 benched, got %+v", boxes[1])
+	}
+}
+
+func TestRegister_RenewedLeagueExtendsExistingLeague(t *testing.T) {
+	im, _, db := setup(t)
+	ctx := context.Background()
+
+	first, _, err := im.Register(ctx, "L24")
+	if err != nil {
+		t.Fatalf("register 2024: %v", err)
+	}
+	if first.ExternalID != "L24" || first.ScoringType != "Half-PPR" {
+		t.Errorf("unexpected 2024 league %+v", first)
+	}
+	renewed, _, err := im.Register(ctx, "L25")
+	if err != nil {
+		t.Fatalf("register 2025: %v", err)
+	}
+	if renewed.ID != first.ID || renewed.ExternalID != "L25" {
+		t.Errorf("expected league %d moved to L25, got %+v", first.ID, renewed)
+	}
+
+	var leagues, matchups int64
+	db.Model(&models.League{}).Count(&leagues)
+	db.Model(&models.Matchup{}).Count(&matchups)
+	if leagues != 1 || matchups != 5 {
+		t.Errorf("expected 1 league and 5 matchups after re-import, got %d and %d", leagues, matchups)
+	}
+}
+
+func TestSync_RemovesDroppedPlayers(t *testing.T) {
+	im, fake, db := setup(t)
+	ctx := context.Background()
+
+	league, _, err := im.Register(ctx, "L25")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	fake.set("/v1/league/L25/matchups/1", `[
+		{"roster_id": 1, "matchup_id": 1, "points": 100, "starters": ["p1", "0"], "players": ["p1"], "players_points": {"p1": 60}},
+		{"roster_id": 2, "matchup_id": 1, "points": 95},
+		{"roster_id": 3, "matchup_id": 2, "points": 70},
+		{"roster_id": 4, "matchup_id": 2, "points": 75}]`)
+
+	res, err := im.Sync(ctx, league.ID)
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if res.Seasons != 1 || res.Matchups != 4 {
+		t.Errorf("expected only the current season re-imported, got %+v", res)
+	}
+
+	var boxes []models.BoxScore
+	db.Joins("JOIN matchups ON matchups.id = box_scores.matchup_id").
+		Where("matchups.year = 2025 AND matchups.week = 1").Find(&boxes)
+	if len(boxes) != 1 || boxes[0].SlotPosition != "QB" {
+		t.Errorf("expected only p1 at QB after sync, got %+v", boxes)
+	}
+}
+
+func TestSync_FollowsRenewalToTheNewSeason(t *testing.T) {
+	im, fake, db := setup(t)
+	ctx := context.Background()
+
+	league, _, err := im.Register(ctx, "L24")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	fake.set("/v1/league/L24", `{"league_id": "L24", "name": "Old Name", "season": "2024", "status": "complete",
+		"settings": {"leg": 1, "last_scored_leg": 1},
+		"scoring_settings": {"rec": 0.5, "pass_td": 4},
+		"roster_positions": ["QB", "FLEX", "BN"]}`)
+	fake.set("/v1/user/u1/leagues/nfl/2025", `[
+		{"league_id": "X25", "season": "2025", "previous_league_id": "X24"},
+		{"league_id": "L25", "season": "2025", "previous_league_id": "L24"}]`)
+
+	res, err := im.Sync(ctx, league.ID)
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if res.Seasons != 2 || res.Matchups != 5 {
+		t.Errorf("expected 2024 finished and 2025 imported, got %+v", res)
+	}
+
+	var synced models.League
+	db.First(&synced, league.ID)
+	if synced.ExternalID != "L25" || synced.Season != 2025 || synced.Name != "The League" {
+		t.Errorf("expected league moved to L25, got %+v", synced)
+	}
+	var leagues, matchups int64
+	db.Model(&models.League{}).Count(&leagues)
+	db.Model(&models.Matchup{}).Where("league_id = ?", league.ID).Count(&matchups)
+	if leagues != 1 || matchups != 5 {
+		t.Errorf("expected 1 league with 5 matchups, got %d and %d", leagues, matchups)
+	}
+}
+
+func TestSync_RejectsOtherPlatforms(t *testing.T) {
+	im, _, db := setup(t)
+	espn := models.League{Name: "ESPN", Platform: "ESPN", ExternalID: "1"}
+	if err := db.Create(&espn).Error; err != nil {
+		t.Fatalf("seed league: %v", err)
+	}
+	if _, err := im.Sync(context.Background(), espn.ID); err != sleeperimport.ErrNotSleeperLeague {
+		t.Errorf("expected ErrNotSleeperLeague, got %v", err)
+	}
+}
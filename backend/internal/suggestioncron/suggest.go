@@ -0,0 +1,247 @@
+package suggestioncron
+
+import (
+	"cmp"
+	"slices"
+
+	"backend/internal/teamvalue"
+	"backend/internal/valuation"
+)
+
+// Suggestion labels: Pareto when both teams' lineups improve, one-sided
+// when the trade is fair but only one does.
+const (
+	LabelPareto   = "pareto"
+	LabelOneSided = "one-sided"
+)
+
+// MaxPackage is the most players either side gives in one suggestion.
+const MaxPackage = 2
+
+// reserveSlots are roster positions that don't count toward the roster cap.
+var reserveSlots = []string{"IR", "TAXI"}
+
+// Settings is the league a suggestion is made in: its roster_positions,
+// whose starting slots the lineups fill, and the roster cap they imply.
+type Settings struct {
+	RosterPositions []string
+	RosterSize      int
+}
+
+// NewSettings reads a league's roster_positions; every slot but IR and
+// TAXI counts toward the roster cap.
+func NewSettings(rosterPositions []string) Settings {
+	size := 0
+	for _, p := range rosterPositions {
+		if !slices.Contains(reserveSlots, p) {
+			size++
+		}
+	}
+	return Settings{RosterPositions: rosterPositions, RosterSize: size}
+}
+
+// Market is what suggestions are priced and scored with: each player's
+// market value, weekly projected points and position.
+type Market struct {
+	Values      map[string]float64
+	Projections map[string]float64
+	Positions   map[string]string
+}
+
+// Suggestion is one package between team A and team B, from A's side: A
+// gives Give for Receive, each cutting its drops (the cheapest bench
+// players) to fit the roster cap. FairnessDelta is A's outlay less B's,
+// drops included, in market value; the utility deltas are each team's
+// change in optimized starting-lineup projected points, and Entered and
+// Exited are A's starting lineup changes.
+type Suggestion struct {
+	Give                     []string
+	Receive                  []string
+	Drops                    []string
+	CounterpartyDrops        []string
+	FairnessDelta            float64
+	UtilityDelta             float64
+	CounterpartyUtilityDelta float64
+	Entered                  []string
+	Exited                   []string
+	Label                    string
+}
+
+// Suggest enumerates 1-for-1 through MaxPackage-for-MaxPackage packages
+// between rosters a and b, keeping those whose outlays are even (see
+// valuation.IsEven) that improve at least one team's lineup, ordered by
+// Compare. It mirrors analysis/src/suggestions.py's suggest_trades with
+// fairness_tolerance set to valuation.FairnessTolerance, so a suggestion is
+// fair by the same bar as the trade calculator, except that a roster
+// already over the cap (a taxi squad, say) only has to stay as small as it
+// is.
+func Suggest(a, b []string, m Market, settings Settings) []Suggestion {
+	lineupA0, pointsA0 := m.lineup(a, settings)
+	_, pointsB0 := m.lineup(b, settings)
+
+	var out []Suggestion
+	for _, give := range packages(a) {
+		for _, receive := range packages(b) {
+			appliedA, ok := m.apply(a, give, receive, settings)
+			if !ok {
+				continue
+			}
+			appliedB, ok := m.apply(b, receive, give, settings)
+			if !ok {
+				continue
+			}
+
+			outlayA := m.sum(give) + m.sum(appliedA.drops)
+			outlayB := m.sum(receive) + m.sum(appliedB.drops)
+			if max(outlayA, outlayB) <= 0 || !valuation.IsEven(outlayA, outlayB) {
+				continue
+			}
+			deltaA, deltaB := appliedA.points-pointsA0, appliedB.points-pointsB0
+			if max(deltaA, deltaB) <= 0 {
+				continue
+			}
+
+			label := LabelOneSided
+			if deltaA > 0 && deltaB > 0 {
+				label = LabelPareto
+			}
+			out = append(out, Suggestion{
+				Give:                     give,
+				Receive:                  receive,
+				Drops:                    appliedA.drops,
+				CounterpartyDrops:        appliedB.drops,
+				FairnessDelta:            outlayA - outlayB,
+				UtilityDelta:             deltaA,
+				CounterpartyUtilityDelta: deltaB,
+				Entered:                  without(appliedA.lineup, lineupA0),
+				Exited:                   without(lineupA0, appliedA.lineup),
+				Label:                    label,
+			})
+		}
+	}
+	slices.SortStableFunc(out, Compare)
+	return out
+}
+
+// Compare orders suggestions Pareto first, then by the teams' combined
+// lineup gain, then by package.
+func Compare(x, y Suggestion) int {
+	if c := cmp.Compare(labelRank(x.Label), labelRank(y.Label)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(y.UtilityDelta+y.CounterpartyUtilityDelta, x.UtilityDelta+x.CounterpartyUtilityDelta); c != 0 {
+		return c
+	}
+	if c := slices.Compare(x.Give, y.Give); c != 0 {
+		return c
+	}
+	return slices.Compare(x.Receive, y.Receive)
+}
+
+func labelRank(label string) int {
+	if label == LabelPareto {
+		return 0
+	}
+	return 1
+}
+
+// lineup returns roster's best starting lineup by projected points (see
+// teamvalue.Lineup), sorted, and its points.
+func (m Market) lineup(roster []string, settings Settings) ([]string, float64) {
+	players := make([]teamvalue.Player, 0, len(roster))
+	for _, id := range roster {
+		players = append(players, teamvalue.Player{SleeperPlayerID: id, Position: m.Positions[id], Value: m.Projections[id], Valued: true})
+	}
+	var starters []string
+	var points float64
+	for _, p := range teamvalue.Lineup(settings.RosterPositions, players) {
+		starters = append(starters, p.SleeperPlayerID)
+		points += p.Value
+	}
+	slices.Sort(starters)
+	return starters, points
+}
+
+// appliedPackage is one roster after a package: its drops and new lineup.
+type appliedPackage struct {
+	drops  []string
+	lineup []string
+	points float64
+}
+
+// apply swaps out for in on roster, then drops the cheapest bench players
+// until it fits the cap. It reports false when only a starter is left to
+// cut: the trade can't be made as valued.
+func (m Market) apply(roster, out, in []string, settings Settings) (appliedPackage, bool) {
+	limit := max(settings.RosterSize, len(roster))
+	next := make([]string, 0, len(roster)+len(in))
+	for _, id := range roster {
+		if !slices.Contains(out, id) {
+			next = append(next, id)
+		}
+	}
+	next = append(next, in...)
+
+	var drops []string
+	for len(next) > limit {
+		lineup, _ := m.lineup(next, settings)
+		cut := ""
+		for _, id := range next {
+			if slices.Contains(lineup, id) {
+				continue
+			}
+			if cut == "" || m.Values[id] < m.Values[cut] || (m.Values[id] == m.Values[cut] && id < cut) {
+				cut = id
+			}
+		}
+		if cut == "" {
+			return appliedPackage{}, false
+		}
+		next = slices.DeleteFunc(next, func(id string) bool { return id == cut })
+		drops = append(drops, cut)
+	}
+	lineup, points := m.lineup(next, settings)
+	return appliedPackage{drops: drops, lineup: lineup, points: points}, true
+}
+
+func (m Market) sum(ids []string) float64 {
+	var total float64
+	for _, id := range ids {
+		total += m.Values[id]
+	}
+	return total
+}
+
+// packages returns every sorted combination of 1 to MaxPackage of roster's
+// players.
+func packages(roster []string) [][]string {
+	sorted := slices.Clone(roster)
+	slices.Sort(sorted)
+	var out [][]string
+	var pick func(start int, chosen []string)
+	pick = func(start int, chosen []string) {
+		if len(chosen) > 0 {
+			out = append(out, slices.Clone(chosen))
+		}
+		if len(chosen) == MaxPackage {
+			return
+		}
+		for i := start; i < len(sorted); i++ {
+			pick(i+1, append(chosen, sorted[i]))
+		}
+	}
+	pick(0, nil)
+	slices.SortStableFunc(out, func(x, y []string) int { return cmp.Compare(len(x), len(y)) })
+	return out
+}
+
+// without returns the IDs in ids that aren't in other, in order.
+func without(ids, other []string) []string {
+	out := []string{}
+	for _, id := range ids {
+		if !slices.Contains(other, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
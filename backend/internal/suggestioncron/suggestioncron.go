@@ -0,0 +1,381 @@
+// Package suggestioncron implements cmd/cron's "trade-suggestions" job: it
+// drains trade_suggestion_requests, which POST
+// /api/v1/sleeper/leagues/:id/rosters/:rosterId/trade-suggestions queues,
+// suggesting trades between the requesting team and every other roster in
+// its league (see Suggest) and writing them to trade_suggestions, where
+// GET on the same route reads them back. Teams are priced with their
+// segment's latest player_valuations and scored with the mean of Sleeper's
+// weekly projections over the season's unfinalized weeks.
+package suggestioncron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+// MaxSuggestions is how many suggestions one run keeps for a team, across
+// every counterparty.
+const MaxSuggestions = 25
+
+// ClaimTimeout is how long a run may hold a request before it's treated as
+// abandoned and claimed again (see migration 048).
+const ClaimTimeout = time.Hour
+
+// errClaimLost is returned when a request's claim was taken over before
+// its run finished: the run's results are discarded.
+var errClaimLost = errors.New("claim lost")
+
+// Report summarizes one RunSuggestions call.
+type Report struct {
+	Claimed     int
+	Completed   int
+	Failed      int
+	Suggestions int
+}
+
+// RunSuggestions claims and runs queued requests, oldest first, until the
+// queue is empty. A request that can't be run (its league has no valued
+// segment, say) is finished with its error; only database errors while
+// claiming or finishing one, or ctx ending, stop the job.
+func RunSuggestions(ctx context.Context, db *gorm.DB) (Report, error) {
+	return runSuggestions(ctx, db, time.Now().UTC().Truncate(time.Microsecond))
+}
+
+func runSuggestions(ctx context.Context, db *gorm.DB, now time.Time) (Report, error) {
+	var report Report
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		req, ok, err := claimNext(ctx, db, now)
+		if err != nil {
+			return report, fmt.Errorf("claim trade suggestion request: %w", err)
+		}
+		if !ok {
+			return report, nil
+		}
+		report.Claimed++
+
+		n, err := suggestForTeam(ctx, db, req, now)
+		switch {
+		case errors.Is(err, errClaimLost):
+			continue
+		case err != nil && ctx.Err() != nil:
+			// Left claimed: it's retried once the claim times out.
+			return report, ctx.Err()
+		case err != nil:
+			log.Printf("trade-suggestions: league %s roster %d: %v", req.SleeperLeagueID, req.RosterID, err)
+			if err := finishRequest(db.WithContext(ctx), req, now, err); err != nil && !errors.Is(err, errClaimLost) {
+				return report, fmt.Errorf("record failed trade suggestion request: %w", err)
+			}
+			report.Failed++
+		default:
+			report.Completed++
+			report.Suggestions += n
+		}
+	}
+}
+
+// claimNext claims the oldest pending request that isn't claimed, or whose
+// claim is older than ClaimTimeout. The claim is a conditional update, so
+// concurrent runs never take the same request.
+func claimNext(ctx context.Context, db *gorm.DB, now time.Time) (models.TradeSuggestionRequest, bool, error) {
+	claimable := "(completed_at IS NULL OR completed_at < requested_at) AND (claimed_at IS NULL OR claimed_at < ?)"
+	expired := now.Add(-ClaimTimeout)
+	for {
+		var pending []models.TradeSuggestionRequest
+		if err := db.WithContext(ctx).Where(claimable, expired).
+			Order("requested_at ASC").
+			Limit(1).
+			Find(&pending).Error; err != nil {
+			return models.TradeSuggestionRequest{}, false, err
+		}
+		if len(pending) == 0 {
+			return models.TradeSuggestionRequest{}, false, nil
+		}
+		req := pending[0]
+		res := db.WithContext(ctx).Model(&models.TradeSuggestionRequest{}).
+			Where("sleeper_league_id = ? AND roster_id = ?", req.SleeperLeagueID, req.RosterID).
+			Where(claimable, expired).
+			Update("claimed_at", now)
+		if res.Error != nil {
+			return models.TradeSuggestionRequest{}, false, res.Error
+		}
+		if res.RowsAffected == 1 {
+			req.ClaimedAt = &now
+			return req, true, nil
+		}
+	}
+}
+
+// finishRequest marks a claimed request complete, recording runErr when
+// the run failed. It returns errClaimLost when another run has claimed
+// the request since.
+func finishRequest(tx *gorm.DB, req models.TradeSuggestionRequest, now time.Time, runErr error) error {
+	var message *string
+	if runErr != nil {
+		m := runErr.Error()
+		message = &m
+	}
+	res := tx.Model(&models.TradeSuggestionRequest{}).
+		Where("sleeper_league_id = ? AND roster_id = ? AND claimed_at = ?", req.SleeperLeagueID, req.RosterID, *req.ClaimedAt).
+		Updates(map[string]any{"completed_at": now, "error": message})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errClaimLost
+	}
+	return nil
+}
+
+// rankedSuggestion is one of a team's suggestions with its counterparty.
+type rankedSuggestion struct {
+	counterparty int
+	Suggestion
+}
+
+// suggestForTeam runs one request: it suggests trades against every other
+// roster in the league, keeps the best MaxSuggestions that don't repeat a
+// dismissed or proposed package, and replaces the team's open suggestions
+// with them, finishing the request in the same transaction. It returns how
+// many suggestions it wrote.
+func suggestForTeam(ctx context.Context, db *gorm.DB, req models.TradeSuggestionRequest, now time.Time) (int, error) {
+	db = db.WithContext(ctx)
+	var league models.SleeperLeague
+	if err := db.Where("sleeper_league_id = ?", req.SleeperLeagueID).First(&league).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errors.New("league not found")
+		}
+		return 0, err
+	}
+	seg, ok := valuation.SegmentForLeague(league.PPR, league.IsSuperflex, league.TotalRosters, league.LeagueType)
+	if !ok {
+		return 0, errors.New("no valuation segment covers this league's format")
+	}
+	segment := seg.Key()
+
+	var latest []valuation.Snapshot
+	if err := db.Table("player_valuations").
+		Select("valuation_date").
+		Where("segment = ?", segment).
+		Order("valuation_date DESC").
+		Limit(1).
+		Scan(&latest).Error; err != nil {
+		return 0, err
+	}
+	if len(latest) == 0 {
+		return 0, errors.New("no valuations for segment")
+	}
+	valuationDate := latest[0].ValuationDate
+
+	rosters, err := latestRosters(db, req.SleeperLeagueID)
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := rosters[req.RosterID]; !ok {
+		return 0, errors.New("roster not found")
+	}
+	var ids []string
+	for _, players := range rosters {
+		ids = append(ids, players...)
+	}
+	market, err := loadMarket(db, segment, valuationDate, league.Season, seg.PointsColumn(), ids)
+	if err != nil {
+		return 0, err
+	}
+	var rosterPositions []string
+	if len(league.RosterPositions) > 0 {
+		if err := json.Unmarshal(league.RosterPositions, &rosterPositions); err != nil {
+			return 0, fmt.Errorf("read roster_positions: %w", err)
+		}
+	}
+	settings := NewSettings(rosterPositions)
+
+	var kept []models.TradeSuggestion
+	if err := db.Select("counterparty_roster_id, give, receive").
+		Where("sleeper_league_id = ? AND roster_id = ? AND status <> ?", req.SleeperLeagueID, req.RosterID, models.SuggestionOpen).
+		Find(&kept).Error; err != nil {
+		return 0, err
+	}
+	keptPackages := map[string]bool{}
+	for _, k := range kept {
+		var give, receive []string
+		if json.Unmarshal(k.Give, &give) == nil && json.Unmarshal(k.Receive, &receive) == nil {
+			keptPackages[packageKey(k.CounterpartyRosterID, give, receive)] = true
+		}
+	}
+
+	counterparties := make([]int, 0, len(rosters))
+	for rosterID := range rosters {
+		if rosterID != req.RosterID {
+			counterparties = append(counterparties, rosterID)
+		}
+	}
+	slices.Sort(counterparties)
+	var ranked []rankedSuggestion
+	for _, c := range counterparties {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		for _, s := range Suggest(rosters[req.RosterID], rosters[c], market, settings) {
+			if !keptPackages[packageKey(c, s.Give, s.Receive)] {
+				ranked = append(ranked, rankedSuggestion{counterparty: c, Suggestion: s})
+			}
+		}
+	}
+	slices.SortStableFunc(ranked, func(x, y rankedSuggestion) int { return Compare(x.Suggestion, y.Suggestion) })
+	ranked = ranked[:min(len(ranked), MaxSuggestions)]
+
+	rows := make([]models.TradeSuggestion, 0, len(ranked))
+	for i, s := range ranked {
+		rows = append(rows, models.TradeSuggestion{
+			SleeperLeagueID:          req.SleeperLeagueID,
+			RosterID:                 req.RosterID,
+			CounterpartyRosterID:     s.counterparty,
+			Segment:                  segment,
+			ValuationDate:            valuationDate,
+			GeneratedAt:              now,
+			Rank:                     i + 1,
+			Label:                    s.Label,
+			Give:                     idList(s.Give),
+			Receive:                  idList(s.Receive),
+			Drops:                    idList(s.Drops),
+			CounterpartyDrops:        idList(s.CounterpartyDrops),
+			FairnessDelta:            s.FairnessDelta,
+			UtilityDelta:             s.UtilityDelta,
+			CounterpartyUtilityDelta: s.CounterpartyUtilityDelta,
+			LineupEntered:            idList(s.Entered),
+			LineupExited:             idList(s.Exited),
+			Status:                   models.SuggestionOpen,
+		})
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("sleeper_league_id = ? AND roster_id = ? AND status = ?", req.SleeperLeagueID, req.RosterID, models.SuggestionOpen).
+			Delete(&models.TradeSuggestion{}).Error; err != nil {
+			return err
+		}
+		if len(rows) > 0 {
+			if err := tx.Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+		return finishRequest(tx, req, now, nil)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+// latestRosters returns each of a league's rosters' players as of its
+// latest snapshot, by roster ID.
+func latestRosters(db *gorm.DB, leagueID string) (map[int][]string, error) {
+	var snapshots []models.SleeperRosterSnapshot
+	if err := db.Where("sleeper_league_id = ?", leagueID).
+		Order("roster_id ASC, taken_at ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	rosters := map[int][]string{}
+	for _, s := range snapshots {
+		var players []string
+		if err := json.Unmarshal(s.Players, &players); err != nil {
+			return nil, fmt.Errorf("read roster %d snapshot: %w", s.RosterID, err)
+		}
+		rosters[s.RosterID] = players
+	}
+	return rosters, nil
+}
+
+// loadMarket reads ids' market values on valuationDate, positions, and
+// mean weekly projected points (column, see valuation.Segment.PointsColumn)
+// over season's weeks whose stats aren't finalized. A player without a
+// projection in one of those weeks counts zero for it.
+func loadMarket(db *gorm.DB, segment string, valuationDate time.Time, season, column string, ids []string) (Market, error) {
+	market := Market{Values: map[string]float64{}, Projections: map[string]float64{}, Positions: map[string]string{}}
+	if len(ids) == 0 {
+		return market, nil
+	}
+	var snaps []valuation.Snapshot
+	if err := db.Table("player_valuations").
+		Select("sleeper_player_id, value").
+		Where("segment = ? AND valuation_date = ? AND sleeper_player_id IN ?", segment, valuationDate, ids).
+		Scan(&snaps).Error; err != nil {
+		return market, err
+	}
+	for _, s := range snaps {
+		market.Values[s.SleeperPlayerID] = s.Value
+	}
+	var players []models.SleeperPlayer
+	if err := db.Select("sleeper_player_id, position").
+		Where("sleeper_player_id IN ?", ids).
+		Find(&players).Error; err != nil {
+		return market, err
+	}
+	for _, p := range players {
+		market.Positions[p.SleeperPlayerID] = p.Position
+	}
+
+	var finalized []int
+	if err := db.Model(&models.SleeperWeekStatFetch{}).
+		Where("season = ? AND finalized = ?", season, true).
+		Pluck("week", &finalized).Error; err != nil {
+		return market, err
+	}
+	var projections []struct {
+		Week            int     `gorm:"column:week"`
+		SleeperPlayerID string  `gorm:"column:sleeper_player_id"`
+		Points          float64 `gorm:"column:points"`
+	}
+	q := db.Model(&models.SleeperPlayerWeekProjection{}).
+		Select("week, sleeper_player_id, "+column+" AS points").
+		Where("season = ? AND sleeper_player_id IN ? AND "+column+" IS NOT NULL", season, ids)
+	if len(finalized) > 0 {
+		q = q.Where("week NOT IN ?", finalized)
+	}
+	if err := q.Scan(&projections).Error; err != nil {
+		return market, err
+	}
+	weeks := map[int]bool{}
+	for _, p := range projections {
+		weeks[p.Week] = true
+		market.Projections[p.SleeperPlayerID] += p.Points
+	}
+	if len(weeks) == 0 {
+		return market, errors.New("no projections for the rest of the season")
+	}
+	for id := range market.Projections {
+		market.Projections[id] /= float64(len(weeks))
+	}
+	return market, nil
+}
+
+// packageKey identifies a package with a counterparty, for matching a
+// suggestion against the team's dismissed and proposed ones.
+func packageKey(counterparty int, give, receive []string) string {
+	give, receive = slices.Clone(give), slices.Clone(receive)
+	slices.Sort(give)
+	slices.Sort(receive)
+	return fmt.Sprintf("%d|%s|%s", counterparty, strings.Join(give, ","), strings.Join(receive, ","))
+}
+
+// idList encodes player IDs as a JSON array, empty rather than null.
+func idList(ids []string) json.RawMessage {
+	if ids == nil {
+		ids = []string{}
+	}
+	raw, _ := json.Marshal(ids)
+	return raw
+}
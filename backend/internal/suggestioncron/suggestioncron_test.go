@@ -0,0 +1,249 @@
+package suggestioncron
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+// The handoff's scenario (see analysis/tests/test_suggestions.py): team A
+// holds a bench QB worth 5,000, team B a bench WR worth 5,100; swapping
+// them improves A's starters by 3 points and B's by 2.
+var (
+	testRosterA = []string{"qbA1", "qbA2", "wrA1", "wrA2", "wrA3"}
+	testRosterB = []string{"qbB1", "wrB1", "wrB2", "wrB3", "wrB4"}
+	testMarket  = Market{
+		Values: map[string]float64{
+			"qbA1": 6000, "qbA2": 5000, "wrA1": 3000, "wrA2": 2000, "wrA3": 1000,
+			"qbB1": 4000, "wrB1": 5100, "wrB2": 3500, "wrB3": 3300, "wrB4": 3100,
+		},
+		Projections: map[string]float64{
+			"qbA1": 20, "qbA2": 18, "wrA1": 12, "wrA2": 10, "wrA3": 8,
+			"qbB1": 16, "wrB1": 13, "wrB2": 15, "wrB3": 14, "wrB4": 9,
+		},
+		Positions: map[string]string{
+			"qbA1": "QB", "qbA2": "QB", "qbB1": "QB",
+			"wrA1": "WR", "wrA2": "WR", "wrA3": "WR",
+			"wrB1": "WR", "wrB2": "WR", "wrB3": "WR", "wrB4": "WR",
+		},
+	}
+	testRosterPositions = []string{"QB", "WR", "WR", "BN", "BN", "BN", "IR"}
+)
+
+func TestSuggest_RanksParetoSwapsAboveOneSidedOnes(t *testing.T) {
+	settings := NewSettings(testRosterPositions)
+	if settings.RosterSize != 6 {
+		t.Fatalf("expected IR not to count toward the roster cap, got %d", settings.RosterSize)
+	}
+	got := Suggest(testRosterA, testRosterB, testMarket, settings)
+	index := func(give, receive []string) int {
+		return slices.IndexFunc(got, func(s Suggestion) bool {
+			return slices.Equal(s.Give, give) && slices.Equal(s.Receive, receive)
+		})
+	}
+	find := func(give, receive []string) (int, Suggestion) {
+		i := index(give, receive)
+		if i < 0 {
+			t.Fatalf("no suggestion %v for %v in %+v", give, receive, got)
+		}
+		return i, got[i]
+	}
+
+	paretoAt, swap := find([]string{"qbA2"}, []string{"wrB1"})
+	if swap.Label != LabelPareto || swap.UtilityDelta != 3 || swap.CounterpartyUtilityDelta != 2 || swap.FairnessDelta != -100 {
+		t.Errorf("expected a +3/+2 Pareto swap at a 100 discount, got %+v", swap)
+	}
+	if !slices.Equal(swap.Entered, []string{"wrB1"}) || !slices.Equal(swap.Exited, []string{"wrA2"}) {
+		t.Errorf("expected wrB1 to start over wrA2, got entered %v exited %v", swap.Entered, swap.Exited)
+	}
+	// qbA1 and wrA1 for qbB1 and wrB1 is even (9,000 for 9,100) but costs A
+	// a point.
+	oneSidedAt, oneSided := find([]string{"qbA1", "wrA1"}, []string{"qbB1", "wrB1"})
+	if oneSided.Label != LabelOneSided || paretoAt > oneSidedAt {
+		t.Errorf("expected the one-sided trade ranked below the Pareto swap, got %+v at %d", oneSided, oneSidedAt)
+	}
+	// 3,000 for 3,300 is 9% apart: past valuation.FairnessTolerance.
+	if i := index([]string{"wrA1"}, []string{"wrB3"}); i >= 0 {
+		t.Errorf("expected wrA1 for wrB3 rejected as uneven, got %+v", got[i])
+	}
+	for i, s := range got {
+		if max(s.UtilityDelta, s.CounterpartyUtilityDelta) <= 0 {
+			t.Errorf("expected only suggestions that improve someone, got %+v", s)
+		}
+		if i > 0 && Compare(got[i-1], s) > 0 {
+			t.Errorf("expected suggestions in Compare order, got %+v before %+v", got[i-1], s)
+		}
+	}
+}
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.SleeperLeague{}, &models.SleeperRosterSnapshot{}, &models.SleeperPlayer{}, &valuation.Snapshot{},
+		&models.SleeperPlayerWeekProjection{}, &models.SleeperWeekStatFetch{}, &models.TradeSuggestion{}, &models.TradeSuggestionRequest{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return db
+}
+
+var (
+	testValuationDate = time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+	testNow           = time.Date(2025, 10, 31, 12, 0, 0, 0, time.UTC)
+)
+
+// seedSuggestionLeague seeds league L1 (10-team 1QB PPR redraft, 2025)
+// with rosters 1 and 2 holding the handoff scenario's teams, their
+// valuations, and week 9 (finalized) and week 10 projections: week 9's are
+// zero, so only week 10's count.
+func seedSuggestionLeague(t *testing.T, db *gorm.DB) string {
+	t.Helper()
+	ppr, sf := 1.0, false
+	positions, _ := json.Marshal(testRosterPositions)
+	league := models.SleeperLeague{SleeperLeagueID: "L1", Season: "2025", TotalRosters: 10, PPR: &ppr, IsSuperflex: &sf, LeagueType: "redraft", RosterPositions: positions}
+	if err := db.Create(&league).Error; err != nil {
+		t.Fatalf("seed league: %v", err)
+	}
+	segment := valuation.SegmentKeyForLeague(league.PPR, league.IsSuperflex, league.TotalRosters, league.LeagueType)
+	for rosterID, roster := range map[int][]string{1: testRosterA, 2: testRosterB} {
+		players, _ := json.Marshal(roster)
+		db.Create(&models.SleeperRosterSnapshot{SleeperLeagueID: "L1", RosterID: rosterID, TakenAt: testValuationDate, Players: players})
+	}
+	db.Create(&models.SleeperWeekStatFetch{Season: "2025", Week: 9, Finalized: true})
+	zero := 0.0
+	for id, value := range testMarket.Values {
+		db.Create(&models.SleeperPlayer{SleeperPlayerID: id, Position: testMarket.Positions[id]})
+		db.Create(&valuation.Snapshot{Segment: segment, SleeperPlayerID: id, ValuationDate: testValuationDate, Position: testMarket.Positions[id], Value: value})
+		projected := testMarket.Projections[id]
+		db.Create(&models.SleeperPlayerWeekProjection{Season: "2025", Week: 9, SleeperPlayerID: id, PtsPPR: &zero})
+		db.Create(&models.SleeperPlayerWeekProjection{Season: "2025", Week: 10, SleeperPlayerID: id, PtsPPR: &projected})
+	}
+	return segment
+}
+
+func queueRequest(t *testing.T, db *gorm.DB, leagueID string, rosterID int, claimedAt *time.Time) {
+	t.Helper()
+	if err := db.Create(&models.TradeSuggestionRequest{SleeperLeagueID: leagueID, RosterID: rosterID, RequestedAt: testNow.Add(-time.Minute), ClaimedAt: claimedAt}).Error; err != nil {
+		t.Fatalf("queue request: %v", err)
+	}
+}
+
+func loadRequest(t *testing.T, db *gorm.DB, leagueID string, rosterID int) models.TradeSuggestionRequest {
+	t.Helper()
+	var r models.TradeSuggestionRequest
+	if err := db.First(&r, "sleeper_league_id = ? AND roster_id = ?", leagueID, rosterID).Error; err != nil {
+		t.Fatalf("load request: %v", err)
+	}
+	return r
+}
+
+func TestRunSuggestions_WritesSuggestionsAndCompletesTheRequest(t *testing.T) {
+	db := newTestDB(t)
+	segment := seedSuggestionLeague(t, db)
+	queueRequest(t, db, "L1", 1, nil)
+	// A dismissed package isn't suggested again, and survives the run; an
+	// open one from the last run is replaced.
+	for _, s := range []models.TradeSuggestion{
+		{Give: json.RawMessage(`["qbA2"]`), Receive: json.RawMessage(`["wrB1"]`), Status: models.SuggestionDismissed},
+		{Give: json.RawMessage(`["wrA3"]`), Receive: json.RawMessage(`["wrB4"]`), Status: models.SuggestionOpen},
+	} {
+		s.SleeperLeagueID, s.RosterID, s.CounterpartyRosterID, s.Segment, s.Label = "L1", 1, 2, segment, LabelPareto
+		s.ValuationDate, s.GeneratedAt = testValuationDate, testNow.Add(-48*time.Hour)
+		db.Create(&s)
+	}
+
+	report, err := runSuggestions(context.Background(), db, testNow)
+	if err != nil {
+		t.Fatalf("runSuggestions: %v", err)
+	}
+	if report.Claimed != 1 || report.Completed != 1 || report.Failed != 0 || report.Suggestions == 0 {
+		t.Fatalf("expected one completed request with suggestions, got %+v", report)
+	}
+	if r := loadRequest(t, db, "L1", 1); r.Pending() || r.Error != nil {
+		t.Errorf("expected the request completed without error, got %+v", r)
+	}
+
+	var rows []models.TradeSuggestion
+	db.Where("status = ?", models.SuggestionOpen).Order("rank ASC").Find(&rows)
+	if len(rows) != report.Suggestions {
+		t.Fatalf("expected the run's %d suggestions to replace the open one, got %d", report.Suggestions, len(rows))
+	}
+	want := Suggest(testRosterA, testRosterB, testMarket, NewSettings(testRosterPositions))
+	want = slices.DeleteFunc(want, func(s Suggestion) bool {
+		return slices.Equal(s.Give, []string{"qbA2"}) && slices.Equal(s.Receive, []string{"wrB1"})
+	})
+	top := rows[0]
+	if top.Rank != 1 || top.CounterpartyRosterID != 2 || string(top.Give) != string(idList(want[0].Give)) || string(top.Receive) != string(idList(want[0].Receive)) {
+		t.Errorf("expected the best package after the dismissed one first, got %+v", top)
+	}
+	if top.UtilityDelta != want[0].UtilityDelta || top.Segment != segment || !top.GeneratedAt.Equal(testNow) {
+		t.Errorf("expected the top row scored from week 10's projections, got %+v", top)
+	}
+	for _, r := range rows {
+		if string(r.Give) == `["qbA2"]` && string(r.Receive) == `["wrB1"]` {
+			t.Errorf("expected the dismissed package not suggested again, got %+v", r)
+		}
+	}
+	var dismissed int64
+	db.Model(&models.TradeSuggestion{}).Where("status = ?", models.SuggestionDismissed).Count(&dismissed)
+	if dismissed != 1 {
+		t.Errorf("expected the dismissed suggestion kept, got %d", dismissed)
+	}
+}
+
+func TestRunSuggestions_FinishesUnrunnableRequestsWithTheirError(t *testing.T) {
+	db := newTestDB(t)
+	seedSuggestionLeague(t, db)
+	queueRequest(t, db, "L1", 7, nil)
+	queueRequest(t, db, "gone", 1, nil)
+
+	report, err := runSuggestions(context.Background(), db, testNow)
+	if err != nil {
+		t.Fatalf("runSuggestions: %v", err)
+	}
+	if report.Claimed != 2 || report.Failed != 2 || report.Completed != 0 {
+		t.Fatalf("expected both requests to fail, got %+v", report)
+	}
+	for _, c := range []struct {
+		leagueID string
+		rosterID int
+		err      string
+	}{{"L1", 7, "roster not found"}, {"gone", 1, "league not found"}} {
+		if r := loadRequest(t, db, c.leagueID, c.rosterID); r.Pending() || r.Error == nil || *r.Error != c.err {
+			t.Errorf("%s/%d: expected a finished request with error %q, got %+v", c.leagueID, c.rosterID, c.err, r)
+		}
+	}
+}
+
+func TestRunSuggestions_LeavesRunningClaimsAndRetakesAbandonedOnes(t *testing.T) {
+	db := newTestDB(t)
+	seedSuggestionLeague(t, db)
+	running, abandoned := testNow.Add(-10*time.Minute), testNow.Add(-2*time.Hour)
+	queueRequest(t, db, "L1", 1, &running)
+	queueRequest(t, db, "L1", 2, &abandoned)
+
+	report, err := runSuggestions(context.Background(), db, testNow)
+	if err != nil {
+		t.Fatalf("runSuggestions: %v", err)
+	}
+	if report.Claimed != 1 || report.Completed != 1 {
+		t.Fatalf("expected only the abandoned request run, got %+v", report)
+	}
+	if r := loadRequest(t, db, "L1", 1); !r.Pending() {
+		t.Errorf("expected the running request left alone, got %+v", r)
+	}
+	if r := loadRequest(t, db, "L1", 2); r.Pending() {
+		t.Errorf("expected the abandoned request run, got %+v", r)
+	}
+}
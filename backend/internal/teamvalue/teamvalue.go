@@ -0,0 +1,133 @@
+// Package teamvalue values whole fantasy rosters from player valuations: a
+// roster's total market value, the value of its best starting lineup under
+// the league's roster_positions, and the depth behind it, ranked across a
+// league. It is pure: callers load rosters and values (see GET
+// /api/v1/sleeper/leagues/:id/team-values) and hand them in.
+package teamvalue
+
+import (
+	"cmp"
+	"slices"
+
+	"backend/internal/mockdraft"
+)
+
+// Dynasty team classifications.
+const (
+	Contender = "contender"
+	Rebuilder = "rebuilder"
+)
+
+// Player is one rostered player. Valued is false when the player has no
+// valuation: they add nothing to any total, but can still fill a lineup
+// slot nobody valued can (a kicker, say).
+type Player struct {
+	SleeperPlayerID string
+	Position        string
+	Age             int
+	Value           float64
+	Valued          bool
+}
+
+// Team is one roster's valuation. Starters are the lineup's player IDs;
+// DepthValue is TotalValue less StarterValue. WeightedAge is the roster's
+// mean age weighted by value, nil when no valued player has a known age.
+// The ranks are 1-based within the league, set by Rank.
+type Team struct {
+	RosterID     int
+	TotalValue   float64
+	StarterValue float64
+	DepthValue   float64
+	Starters     []string
+	WeightedAge  *float64
+	TotalRank    int
+	StarterRank  int
+	DepthRank    int
+}
+
+// Lineup picks the highest-valued starting lineup for rosterPositions from
+// players: each starting slot, most specific first (see
+// mockdraft.StarterSlots), takes the most valuable player left who can
+// play it. Greedy filling is optimal whenever the flex slots nest, as FLEX
+// within SUPER_FLEX does. Slots nobody can fill stay empty.
+func Lineup(rosterPositions []string, players []Player) []Player {
+	ranked := slices.Clone(players)
+	slices.SortStableFunc(ranked, func(a, b Player) int {
+		if c := cmp.Compare(b.Value, a.Value); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.SleeperPlayerID, b.SleeperPlayerID)
+	})
+	used := make([]bool, len(ranked))
+	var starters []Player
+	for _, eligible := range mockdraft.StarterSlots(rosterPositions) {
+		for i, p := range ranked {
+			if !used[i] && slices.Contains(eligible, p.Position) {
+				used[i] = true
+				starters = append(starters, p)
+				break
+			}
+		}
+	}
+	return starters
+}
+
+// Value values one roster under rosterPositions.
+func Value(rosterID int, rosterPositions []string, players []Player) Team {
+	team := Team{RosterID: rosterID, Starters: []string{}}
+	var ageWeight, weightedAge float64
+	for _, p := range players {
+		if !p.Valued {
+			continue
+		}
+		team.TotalValue += p.Value
+		if p.Age > 0 && p.Value > 0 {
+			ageWeight += p.Value
+			weightedAge += p.Value * float64(p.Age)
+		}
+	}
+	for _, p := range Lineup(rosterPositions, players) {
+		team.StarterValue += p.Value
+		team.Starters = append(team.Starters, p.SleeperPlayerID)
+	}
+	team.DepthValue = team.TotalValue - team.StarterValue
+	if ageWeight > 0 {
+		age := weightedAge / ageWeight
+		team.WeightedAge = &age
+	}
+	return team
+}
+
+// Rank sets each team's TotalRank, StarterRank and DepthRank, 1 for the
+// highest value, breaking ties by roster ID.
+func Rank(teams []Team) {
+	rank := func(value func(Team) float64, set func(*Team, int)) {
+		order := make([]int, len(teams))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			if c := cmp.Compare(value(teams[b]), value(teams[a])); c != 0 {
+				return c
+			}
+			return cmp.Compare(teams[a].RosterID, teams[b].RosterID)
+		})
+		for r, i := range order {
+			set(&teams[i], r+1)
+		}
+	}
+	rank(func(t Team) float64 { return t.TotalValue }, func(t *Team, r int) { t.TotalRank = r })
+	rank(func(t Team) float64 { return t.StarterValue }, func(t *Team, r int) { t.StarterRank = r })
+	rank(func(t Team) float64 { return t.DepthValue }, func(t *Team, r int) { t.DepthRank = r })
+}
+
+// Classify labels a ranked dynasty team, in a league of teams rosters, a
+// Contender when its starting lineup ranks in the top half, else a
+// Rebuilder: whatever its total value, a team whose lineup can't win now
+// is building for later.
+func Classify(t Team, teams int) string {
+	if t.StarterRank > 0 && t.StarterRank <= (teams+1)/2 {
+		return Contender
+	}
+	return Rebuilder
+}
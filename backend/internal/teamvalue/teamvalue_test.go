@@ -0,0 +1,81 @@
+package teamvalue_test
+
+import (
+	"slices"
+	"testing"
+
+	"backend/internal/teamvalue"
+)
+
+var superflexLineup = []string{"QB", "RB", "RB", "WR", "WR", "TE", "FLEX", "SUPER_FLEX", "K", "BN", "BN"}
+
+func player(id, position string, value float64) teamvalue.Player {
+	return teamvalue.Player{SleeperPlayerID: id, Position: position, Value: value, Valued: true}
+}
+
+func TestLineup_FillsSpecificSlotsBeforeFlex(t *testing.T) {
+	players := []teamvalue.Player{
+		player("qb1", "QB", 8000), player("qb2", "QB", 6000), player("qb3", "QB", 1000),
+		player("rb1", "RB", 5000), player("rb2", "RB", 3000), player("rb3", "RB", 2500),
+		player("wr1", "WR", 7000), player("wr2", "WR", 2000), player("wr3", "WR", 1500),
+		player("te1", "TE", 900),
+		{SleeperPlayerID: "k1", Position: "K"},
+	}
+
+	var ids []string
+	for _, p := range teamvalue.Lineup(superflexLineup, players) {
+		ids = append(ids, p.SleeperPlayerID)
+	}
+	slices.Sort(ids)
+	// The second QB takes SUPER_FLEX over rb3, who takes FLEX over wr3; the
+	// unvalued kicker still starts.
+	want := []string{"k1", "qb1", "qb2", "rb1", "rb2", "rb3", "te1", "wr1", "wr2"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("Lineup = %v, want %v", ids, want)
+	}
+}
+
+func TestValue_SplitsStartersFromDepth(t *testing.T) {
+	players := []teamvalue.Player{
+		{SleeperPlayerID: "qb1", Position: "QB", Value: 6000, Valued: true, Age: 30},
+		{SleeperPlayerID: "qb2", Position: "QB", Value: 2000, Valued: true, Age: 22},
+		{SleeperPlayerID: "rb1", Position: "RB", Value: 4000, Valued: true},
+		{SleeperPlayerID: "rb2", Position: "RB"},
+	}
+
+	team := teamvalue.Value(7, []string{"QB", "RB", "BN", "BN"}, players)
+	if team.RosterID != 7 || team.TotalValue != 12000 || team.StarterValue != 10000 || team.DepthValue != 2000 {
+		t.Errorf("unexpected values %+v", team)
+	}
+	if !slices.Equal(team.Starters, []string{"qb1", "rb1"}) {
+		t.Errorf("expected qb1 and rb1 to start, got %v", team.Starters)
+	}
+	// (6000*30 + 2000*22) / 8000; rb1 has no known age.
+	if team.WeightedAge == nil || *team.WeightedAge != 28 {
+		t.Errorf("expected a value-weighted age of 28, got %v", team.WeightedAge)
+	}
+}
+
+func TestRankAndClassify(t *testing.T) {
+	teams := []teamvalue.Team{
+		{RosterID: 1, TotalValue: 30000, StarterValue: 12000, DepthValue: 18000},
+		{RosterID: 2, TotalValue: 25000, StarterValue: 20000, DepthValue: 5000},
+		{RosterID: 3, TotalValue: 25000, StarterValue: 15000, DepthValue: 10000},
+		{RosterID: 4, TotalValue: 10000, StarterValue: 9000, DepthValue: 1000},
+	}
+	teamvalue.Rank(teams)
+
+	want := [][3]int{{1, 3, 1}, {2, 1, 3}, {3, 2, 2}, {4, 4, 4}}
+	for i, tm := range teams {
+		if got := [3]int{tm.TotalRank, tm.StarterRank, tm.DepthRank}; got != want[i] {
+			t.Errorf("roster %d ranks = %v, want %v", tm.RosterID, got, want[i])
+		}
+	}
+	// The most valuable roster rebuilds: its lineup is in the bottom half.
+	classes := []string{teamvalue.Rebuilder, teamvalue.Contender, teamvalue.Contender, teamvalue.Rebuilder}
+	for i, tm := range teams {
+		if got := teamvalue.Classify(tm, len(teams)); got != classes[i] {
+			t.Errorf("roster %d classified %s, want %s", tm.RosterID, got, classes[i])
+		}
+	}
+}
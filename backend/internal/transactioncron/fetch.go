@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"time"
 
 	"gorm.io/gorm"
@@ -21,12 +24,22 @@ type ClaimLeaguesForTransactionsParams struct {
 	BatchSize int
 }
 
-// LeagueTransactionState carries the league ID, season, and leg cursor for one
-// claimed league, as returned by ClaimLeaguesForTransactions.
+// RosterRefreshInterval is how often a league's rosters are re-fetched for
+// its roster history (sleeper_roster_snapshots). Team values are compared
+// day to day, so a roster change shows up by the next day's valuations.
+const RosterRefreshInterval = 24 * time.Hour
+
+// LeagueTransactionState carries the league ID, season, type, leg cursor and
+// FAAB budget for one claimed league, as returned by
+// ClaimLeaguesForTransactions. RostersDue is set when the league's rosters
+// were last fetched more than RosterRefreshInterval ago, or never.
 type LeagueTransactionState struct {
 	LeagueID       string
 	Season         string
+	LeagueType     string
 	LastLegFetched *int
+	WaiverBudget   *int
+	RostersDue     bool
 }
 
 // LeagueTransactionFetchResult is FetchLeagueTransactions's result for one
@@ -42,6 +55,20 @@ type LeagueTransactionFetchResult struct {
 	// Sleeper-reported week is unknown or hasn't advanced past the stored
 	// watermark. Derived from the NFL state, never from transaction presence.
 	WeekWatermark int
+	// PickTrades are the pick moves in this fetch's completed trades, for
+	// the draft pick ledger. Written to cloud only, whatever the trade's age.
+	PickTrades []models.SleeperPickTrade
+	// TradedPicks is the league's fresh traded_picks snapshot, valid only
+	// when TradedPicksFetched — see FetchLeagueTransactions for when it's
+	// fetched. An empty snapshot with TradedPicksFetched set still replaces
+	// the stored one (every pick back with its original owner).
+	TradedPicks        []models.SleeperTradedPick
+	TradedPicksFetched bool
+	// Rosters is the league's fresh rosters snapshot, valid only when
+	// RostersFetched (see LeagueTransactionState.RostersDue). Flushing it
+	// appends only the rosters that changed.
+	Rosters        []models.SleeperRosterSnapshot
+	RostersFetched bool
 }
 
 // claimLeaguesForTransactionsSQL atomically claims up to batchSize stale
@@ -61,7 +88,7 @@ WHERE sleeper_league_id IN (
     LIMIT ?
     FOR UPDATE SKIP LOCKED
 )
-RETURNING sleeper_league_id, season, last_transaction_leg_fetched`
+RETURNING sleeper_league_id, season, league_type, last_transaction_leg_fetched, waiver_budget, last_rosters_fetched_at`
 
 // ClaimLeaguesForTransactions claims up to BatchSize leagues with stale
 // transaction data and returns their sync state. Postgres-only (SKIP LOCKED).
@@ -69,7 +96,10 @@ func ClaimLeaguesForTransactions(ctx context.Context, db *gorm.DB, params ClaimL
 	var rows []struct {
 		SleeperLeagueID           string
 		Season                    string
+		LeagueType                string
 		LastTransactionLegFetched *int
+		WaiverBudget              *int
+		LastRostersFetchedAt      *time.Time
 	}
 	if err := db.WithContext(ctx).Raw(claimLeaguesForTransactionsSQL, params.BatchSize).Scan(&rows).Error; err != nil {
 		return nil, err
@@ -79,7 +109,10 @@ func ClaimLeaguesForTransactions(ctx context.Context, db *gorm.DB, params ClaimL
 		states[i] = LeagueTransactionState{
 			LeagueID:       r.SleeperLeagueID,
 			Season:         r.Season,
+			LeagueType:     r.LeagueType,
 			LastLegFetched: r.LastTransactionLegFetched,
+			WaiverBudget:   r.WaiverBudget,
+			RostersDue:     r.LastRostersFetchedAt == nil || time.Since(*r.LastRostersFetchedAt) >= RosterRefreshInterval,
 		}
 	}
 	return states, nil
@@ -120,6 +153,18 @@ func archiveRoutingCutoff() time.Time {
 // closed and final, the watermark week itself was active and is re-fetched on
 // every visit (even when it keeps coming back empty). A nil watermark means
 // never visited — backfill from leg 1.
+//
+// Completed trades' pick moves are collected into PickTrades for the draft
+// pick ledger. A traded pick in the league's own season gets its slot stamped
+// into draft_picks once the league's draft order is set (see
+// stampPickSlots), so it's valued at that slot rather than the round average.
+// The league's
+// traded_picks snapshot is refreshed whenever this fetch saw a pick move, and
+// on a dynasty or keeper league's first visit, whose picks may have changed
+// hands in trades made under an earlier season's league ID.
+//
+// Complete waiver claims carry their winning FAAB bid (see waiverBid). When
+// lg.RostersDue, the league's rosters are fetched too (see rosterSnapshots).
 func FetchLeagueTransactions(ctx context.Context, dfa *activities.DataFetchActivities, lg LeagueTransactionState, state *sleeper.NFLState) (LeagueTransactionFetchResult, error) {
 	maxLeg := MaxLegForLeague(lg.Season, state)
 	startLeg := 1
@@ -146,13 +191,18 @@ func FetchLeagueTransactions(ctx context.Context, dfa *activities.DataFetchActiv
 			dropsJSON, _ := json.Marshal(t.Drops)
 			picksJSON, _ := json.Marshal(t.DraftPicks)
 			waiverJSON, _ := json.Marshal(t.WaiverBudget)
-			// Picks/FAAB trades are never valued by the valuation model (see
-			// activities.IsPlayerOnlyTransaction) and aren't useful trade
-			// history either, so they're dropped at ingest time rather than
-			// written anywhere.
-			if !activities.IsPlayerOnlyTransaction(picksJSON, waiverJSON) {
+			if t.Type == "trade" && t.Status == "complete" {
+				res.PickTrades = append(res.PickTrades, pickTrades(lg.LeagueID, t, picksJSON)...)
+			}
+			// FAAB trades aren't useful trade history, so they're dropped at
+			// ingest time rather than written to sleeper_transactions (see
+			// activities.IsIngestedTransaction). Trades with picks are kept
+			// and valued like any other; their pick moves were also captured
+			// for the ledger above.
+			if !activities.IsIngestedTransaction(waiverJSON) {
 				continue
 			}
+			bid, bidPct := waiverBid(t, lg.WaiverBudget)
 			rows = append(rows, models.SleeperTransaction{
 				SleeperTransactionID: t.TransactionID,
 				SleeperLeagueID:      lg.LeagueID,
@@ -164,6 +214,8 @@ func FetchLeagueTransactions(ctx context.Context, dfa *activities.DataFetchActiv
 				Drops:                dropsJSON,
 				DraftPicks:           picksJSON,
 				WaiverBudget:         waiverJSON,
+				WaiverBid:            bid,
+				WaiverBidPct:         bidPct,
 			})
 		}
 		if dfa.Archive != nil {
@@ -171,7 +223,7 @@ func FetchLeagueTransactions(ctx context.Context, dfa *activities.DataFetchActiv
 			for _, r := range rows {
 				if time.UnixMilli(r.CreatedAtSleeper).UTC().Before(cutoff) {
 					// Old rows route straight to archive, not cloud. Filtering
-					// out picks/FAAB rows already happened unconditionally
+					// out FAAB rows already happened unconditionally
 					// above (before the cloud/archive split), so no re-check
 					// here.
 					res.ArchiveRows = append(res.ArchiveRows, r)
@@ -183,6 +235,58 @@ func FetchLeagueTransactions(ctx context.Context, dfa *activities.DataFetchActiv
 			res.CloudRows = append(res.CloudRows, rows...)
 		}
 	}
+	if tradesCurrentSeasonPicks(res.PickTrades, lg.Season) {
+		drafts, err := dfa.Sleeper.GetLeagueDrafts(ctx, lg.LeagueID)
+		var nfe *sleeper.NotFoundError
+		switch {
+		case errors.As(err, &nfe):
+			// No draft yet; the picks stay at slot 0.
+		case err != nil:
+			return LeagueTransactionFetchResult{}, fmt.Errorf("drafts: %w", err)
+		default:
+			if d := orderedDraft(drafts, lg.Season); d != nil {
+				stampPickSlots(res.CloudRows, *d)
+				stampPickSlots(res.ArchiveRows, *d)
+			}
+		}
+	}
+	if len(res.PickTrades) > 0 || (lg.LastLegFetched == nil && (lg.LeagueType == "dynasty" || lg.LeagueType == "keeper")) {
+		picks, err := dfa.Sleeper.GetTradedPicks(ctx, lg.LeagueID)
+		var nfe *sleeper.NotFoundError
+		switch {
+		case errors.As(err, &nfe):
+			// No snapshot to take; the stored one (if any) stays.
+		case err != nil:
+			return LeagueTransactionFetchResult{}, fmt.Errorf("traded picks: %w", err)
+		default:
+			res.TradedPicksFetched = true
+			for _, p := range picks {
+				res.TradedPicks = append(res.TradedPicks, models.SleeperTradedPick{
+					SleeperLeagueID: lg.LeagueID,
+					Season:          p.Season,
+					Round:           p.Round,
+					RosterID:        p.RosterID,
+					OwnerID:         p.OwnerID,
+					PreviousOwnerID: p.PreviousOwnerID,
+				})
+			}
+		}
+	}
+
+	if lg.RostersDue {
+		rosters, err := dfa.Sleeper.GetLeagueRosters(ctx, lg.LeagueID)
+		var nfe *sleeper.NotFoundError
+		switch {
+		case errors.As(err, &nfe):
+			// Nothing to snapshot; the next visit retries.
+		case err != nil:
+			return LeagueTransactionFetchResult{}, fmt.Errorf("rosters: %w", err)
+		default:
+			res.RostersFetched = true
+			res.Rosters = rosterSnapshots(lg.LeagueID, rosters, time.Now().UTC())
+		}
+	}
+
 	// Every leg through maxLeg fetched successfully: advance the watermark to
 	// the Sleeper-reported week, but only when that week is actually known
 	// (nil state means the 18-leg sweep was a fallback, not evidence of the
@@ -194,6 +298,130 @@ func FetchLeagueTransactions(ctx context.Context, dfa *activities.DataFetchActiv
 	return res, nil
 }
 
+// waiverBid returns a complete waiver claim's winning FAAB bid, and that bid
+// as a percentage of budget when the league's budget is known. Both are nil
+// for any other transaction, and for claims in leagues without FAAB.
+func waiverBid(t sleeper.Transaction, budget *int) (*int, *float64) {
+	if t.Type != "waiver" || t.Status != "complete" || t.Settings == nil || t.Settings.WaiverBid == nil {
+		return nil, nil
+	}
+	bid := *t.Settings.WaiverBid
+	if budget == nil || *budget <= 0 {
+		return &bid, nil
+	}
+	pct := 100 * float64(bid) / float64(*budget)
+	return &bid, &pct
+}
+
+// pickTrades decodes a completed trade's draft_picks into ledger rows,
+// skipping entries missing the season, round or rosters that identify a pick
+// move.
+func pickTrades(leagueID string, t sleeper.Transaction, picksJSON json.RawMessage) []models.SleeperPickTrade {
+	var picks []sleeper.TradedPick
+	if err := json.Unmarshal(picksJSON, &picks); err != nil {
+		return nil
+	}
+	var rows []models.SleeperPickTrade
+	for _, p := range picks {
+		if p.Season == "" || p.Round == 0 || p.RosterID == 0 || p.OwnerID == 0 {
+			continue
+		}
+		rows = append(rows, models.SleeperPickTrade{
+			SleeperTransactionID: t.TransactionID,
+			Season:               p.Season,
+			Round:                p.Round,
+			RosterID:             p.RosterID,
+			SleeperLeagueID:      leagueID,
+			CreatedAtSleeper:     t.Created,
+			PreviousOwnerID:      p.PreviousOwnerID,
+			OwnerID:              p.OwnerID,
+		})
+	}
+	return rows
+}
+
+// tradesCurrentSeasonPicks reports whether any of moves is a pick in the
+// league's own season — the only draft whose order a league's drafts
+// endpoint can tell us.
+func tradesCurrentSeasonPicks(moves []models.SleeperPickTrade, season string) bool {
+	for _, m := range moves {
+		if m.Season == season {
+			return true
+		}
+	}
+	return false
+}
+
+// orderedDraft returns the league's season draft once its order is set, or
+// nil. Auction drafts have no slots.
+func orderedDraft(drafts []sleeper.Draft, season string) *sleeper.Draft {
+	for i, d := range drafts {
+		if d.Season == season && d.Type != "auction" && len(d.SlotToRosterID) > 0 {
+			return &drafts[i]
+		}
+	}
+	return nil
+}
+
+// stampPickSlots adds a "slot" to each of d's picks in the complete trades'
+// draft_picks: the position within its round the pick's original roster
+// drafts at, for valuation.GroupPicksByRoster to key its value by. Entries
+// for other seasons, or whose roster has no slot, are left as they are.
+func stampPickSlots(rows []models.SleeperTransaction, d sleeper.Draft) {
+	slotByRoster := make(map[int]int, len(d.SlotToRosterID))
+	for slot, rosterID := range d.SlotToRosterID {
+		if n, err := strconv.Atoi(slot); err == nil && rosterID != 0 {
+			slotByRoster[rosterID] = n
+		}
+	}
+	for i, r := range rows {
+		if r.Type != "trade" || r.Status != "complete" || len(r.DraftPicks) == 0 {
+			continue
+		}
+		var picks []sleeper.TradedPick
+		var entries []map[string]interface{}
+		if json.Unmarshal(r.DraftPicks, &picks) != nil || json.Unmarshal(r.DraftPicks, &entries) != nil {
+			continue
+		}
+		stamped := false
+		for j, p := range picks {
+			slot, ok := slotByRoster[p.RosterID]
+			if p.Season != d.Season || !ok || p.Round == 0 {
+				continue
+			}
+			entries[j]["slot"] = pickSlotInRound(d, slot, p.Round)
+			stamped = true
+		}
+		if !stamped {
+			continue
+		}
+		if raw, err := json.Marshal(entries); err == nil {
+			rows[i].DraftPicks = raw
+		}
+	}
+}
+
+// pickSlotInRound is the position within round that draft slot picks at:
+// the slot itself in a linear draft, reversed every other round in a snake,
+// and flipped once more from the reversal round on.
+func pickSlotInRound(d sleeper.Draft, slot, round int) int {
+	teams := d.Settings.Teams
+	if teams == 0 {
+		teams = len(d.SlotToRosterID)
+	}
+	if d.Type == "linear" {
+		return slot
+	}
+	reversed := round%2 == 0
+	if d.Settings.ReversalRound > 0 && round >= d.Settings.ReversalRound {
+		reversed = !reversed
+	}
+	if reversed {
+		return teams + 1 - slot
+	}
+	return slot
+}
+
 // leagueValuationSettings is the subset of a league's settings needed to
 // resolve its valuation segment (valuation.SegmentKeyForLeague).
 type leagueValuationSettings struct {
@@ -205,8 +433,8 @@ type leagueValuationSettings struct {
 }
 
 // attachTradeValues sets TradeValues and TradeValuesComplete on each
-// complete trade row in rows whose league is in a covered valuation
-// segment. A partially-valued trade (one side resolved, another still
+// complete trade row in rows whose league is in a segment with
+// player_valuations (valuation.HasValuations). A partially-valued trade (one side resolved, another still
 // pending) gets its resolved side's total persisted immediately with
 // TradeValuesComplete left false, so ReconcileTradeValues keeps retrying it
 // once the pending side's player gets a valuation — see
@@ -227,6 +455,7 @@ func attachTradeValues(ctx context.Context, tx *gorm.DB, leagueIDs []string, row
 		settingsByLeague[l.SleeperLeagueID] = l
 	}
 
+	valued := map[string]bool{}
 	inputs := make([]tradeValuationInput, 0, len(rows))
 	rowIndexByID := make(map[string]int, len(rows))
 	for i, r := range rows {
@@ -241,6 +470,16 @@ func attachTradeValues(ctx context.Context, tx *gorm.DB, leagueIDs []string, row
 		if seg == "" {
 			continue
 		}
+		if _, ok := valued[seg]; !ok {
+			has, err := valuation.HasValuations(tx.WithContext(ctx), seg)
+			if err != nil {
+				return
+			}
+			valued[seg] = has
+		}
+		if !valued[seg] {
+			continue
+		}
 		var adds map[string]int
 		if len(r.Adds) > 0 {
 			if err := json.Unmarshal(r.Adds, &adds); err != nil {
@@ -249,7 +488,7 @@ func attachTradeValues(ctx context.Context, tx *gorm.DB, leagueIDs []string, row
 		}
 		inputs = append(inputs, tradeValuationInput{
 			ID: r.SleeperTransactionID, TradeTime: time.UnixMilli(r.CreatedAtSleeper).UTC(),
-			Adds: adds, Segment: seg,
+			Adds: adds, Picks: valuation.GroupPicksByRoster(r.DraftPicks), Segment: seg,
 		})
 		rowIndexByID[r.SleeperTransactionID] = i
 	}
@@ -264,6 +503,67 @@ func attachTradeValues(ctx context.Context, tx *gorm.DB, leagueIDs []string, row
 	}
 }
 
+// rosterSnapshots converts a league's rosters into snapshots taken at
+// takenAt, with each roster's players sorted so unchanged rosters compare
+// equal.
+func rosterSnapshots(leagueID string, rosters []sleeper.Roster, takenAt time.Time) []models.SleeperRosterSnapshot {
+	snaps := make([]models.SleeperRosterSnapshot, 0, len(rosters))
+	for _, r := range rosters {
+		players := append([]string{}, r.Players...)
+		sort.Strings(players)
+		playersJSON, _ := json.Marshal(players)
+		snaps = append(snaps, models.SleeperRosterSnapshot{
+			SleeperLeagueID: leagueID,
+			RosterID:        r.RosterID,
+			TakenAt:         takenAt,
+			OwnerID:         r.OwnerID,
+			Players:         playersJSON,
+		})
+	}
+	return snaps
+}
+
+// appendRosterSnapshots writes the snapshots in fresh whose roster has no
+// snapshot yet, or whose owner or players differ from its latest one.
+// Players are compared decoded: Postgres reads JSONB back in its own
+// formatting, never byte for byte what json.Marshal wrote.
+func appendRosterSnapshots(ctx context.Context, tx *gorm.DB, leagueID string, fresh []models.SleeperRosterSnapshot) error {
+	var stored []models.SleeperRosterSnapshot
+	if err := tx.WithContext(ctx).
+		Where("sleeper_league_id = ?", leagueID).
+		Order("taken_at ASC").
+		Find(&stored).Error; err != nil {
+		return err
+	}
+	latest := make(map[int]models.SleeperRosterSnapshot, len(stored))
+	for _, s := range stored {
+		latest[s.RosterID] = s
+	}
+	var changed []models.SleeperRosterSnapshot
+	for _, s := range fresh {
+		prev, ok := latest[s.RosterID]
+		if ok && prev.OwnerID == s.OwnerID && samePlayers(prev.Players, s.Players) {
+			continue
+		}
+		changed = append(changed, s)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&changed).Error
+}
+
+// samePlayers reports whether two snapshots' players decode to the same
+// list. A list that fails to decode never matches, so its roster gets a
+// fresh snapshot.
+func samePlayers(a, b json.RawMessage) bool {
+	var x, y []string
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return slices.Equal(x, y)
+}
+
 // FlushLeagueTransactions is the batch-write counterpart to
 // FetchLeagueTransactions: one bulk insert for all of the batch's cloud rows
 // (against tx, the transaction fdb.RunPool already opened for this batch),
@@ -272,17 +572,21 @@ func attachTradeValues(ctx context.Context, tx *gorm.DB, leagueIDs []string, row
 // bulk claim-clearing update covering every league in the batch, then a
 // per-league watermark update only where WeekWatermark > 0 (that value
 // genuinely varies per league, unlike the claim-clear), guarded so a stale
-// result can never move the watermark backwards. The archive write's
+// result can never move the watermark backwards. Draft pick ledger rows
+// (pick trades, and each refreshed traded_picks snapshot) and changed roster
+// snapshots go to cloud in the same transaction. The archive write's
 // error is not swallowed: if it fails, this whole batch's flush fails, so
 // fdb rolls tx back and drops the batch for retry rather than committing a
 // claim-clear whose archive copy never landed.
 func FlushLeagueTransactions(ctx context.Context, dfa *activities.DataFetchActivities, tx *gorm.DB, batch []LeagueTransactionFetchResult) error {
 	var cloudRows, archiveRows []models.SleeperTransaction
+	var pickTrades []models.SleeperPickTrade
 	leagueIDs := make([]string, len(batch))
 	for i, r := range batch {
 		leagueIDs[i] = r.LeagueID
 		cloudRows = append(cloudRows, r.CloudRows...)
 		archiveRows = append(archiveRows, r.ArchiveRows...)
+		pickTrades = append(pickTrades, r.PickTrades...)
 	}
 
 	if len(cloudRows) > 0 {
@@ -293,6 +597,46 @@ func FlushLeagueTransactions(ctx context.Context, dfa *activities.DataFetchActiv
 			return fmt.Errorf("cloud upsert: %w", err)
 		}
 	}
+	if len(pickTrades) > 0 {
+		if err := tx.WithContext(ctx).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			CreateInBatches(pickTrades, 500).Error; err != nil {
+			return fmt.Errorf("pick trade insert: %w", err)
+		}
+	}
+	for _, r := range batch {
+		if !r.TradedPicksFetched {
+			continue
+		}
+		if err := tx.WithContext(ctx).
+			Where("sleeper_league_id = ?", r.LeagueID).
+			Delete(&models.SleeperTradedPick{}).Error; err != nil {
+			return fmt.Errorf("traded picks clear for %s: %w", r.LeagueID, err)
+		}
+		if len(r.TradedPicks) > 0 {
+			if err := tx.WithContext(ctx).Create(&r.TradedPicks).Error; err != nil {
+				return fmt.Errorf("traded picks insert for %s: %w", r.LeagueID, err)
+			}
+		}
+	}
+	var rosterLeagues []string
+	for _, r := range batch {
+		if !r.RostersFetched {
+			continue
+		}
+		if err := appendRosterSnapshots(ctx, tx, r.LeagueID, r.Rosters); err != nil {
+			return fmt.Errorf("roster snapshots for %s: %w", r.LeagueID, err)
+		}
+		rosterLeagues = append(rosterLeagues, r.LeagueID)
+	}
+	if len(rosterLeagues) > 0 {
+		if err := tx.WithContext(ctx).
+			Model(&models.SleeperLeague{}).
+			Where("sleeper_league_id IN ?", rosterLeagues).
+			Update("last_rosters_fetched_at", time.Now().UTC()).Error; err != nil {
+			return fmt.Errorf("rosters fetched update: %w", err)
+		}
+	}
 	if dfa.Archive != nil && len(archiveRows) > 0 {
 		if err := upsertArchiveTransactions(ctx, dfa.Archive, archiveRows); err != nil {
 			return fmt.Errorf("archive upsert: %w", err)
@@ -332,6 +676,7 @@ func upsertArchiveTransactions(ctx context.Context, archive *gorm.DB, rows []mod
 			SleeperTransactionID: r.SleeperTransactionID, SleeperLeagueID: r.SleeperLeagueID,
 			Type: r.Type, Status: r.Status, CreatedAtSleeper: r.CreatedAtSleeper, Leg: r.Leg,
 			Adds: r.Adds, Drops: r.Drops, DraftPicks: r.DraftPicks, WaiverBudget: r.WaiverBudget,
+			WaiverBid: r.WaiverBid, WaiverBidPct: r.WaiverBidPct,
 			CreatedAt: time.Now().UTC(),
 		}
 	}
@@ -34,7 +34,8 @@ func newTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("unwrap sql.DB: %v", err)
 	}
 	sqlDB.SetMaxOpenConns(1)
-	if err := db.AutoMigrate(&models.SleeperLeague{}, &models.SleeperTransaction{}, &valuation.Snapshot{}); err != nil {
+	if err := db.AutoMigrate(&models.SleeperLeague{}, &models.SleeperTransaction{}, &valuation.Snapshot{}, &valuation.PickSnapshot{},
+		&models.SleeperPickTrade{}, &models.SleeperTradedPick{}, &models.SleeperRosterSnapshot{}); err != nil {
 		t.Fatalf("automigrate: %v", err)
 	}
 	return db
@@ -317,7 +318,7 @@ func TestFetchLeagueTransactions_SplitsOldRowsToArchiveByAge(t *testing.T) {
 	}
 }
 
-func TestFetchLeagueTransactions_ArchiveExcludesTransactionsWithDraftPicksOrFAAB(t *testing.T) {
+func TestFetchLeagueTransactions_ArchiveExcludesTransactionsWithFAAB(t *testing.T) {
 	cloud := newTestDB(t)
 	archive := newArchiveTestDB(t)
 
@@ -338,20 +339,19 @@ func TestFetchLeagueTransactions_ArchiveExcludesTransactionsWithDraftPicksOrFAAB
 	if err != nil {
 		t.Fatalf("FetchLeagueTransactions error: %v", err)
 	}
-	if len(res.ArchiveRows) != 1 || res.ArchiveRows[0].SleeperTransactionID != "tx-clean" {
-		t.Errorf("expected only tx-clean in ArchiveRows, got %+v", res.ArchiveRows)
+	if len(res.ArchiveRows) != 2 || res.ArchiveRows[0].SleeperTransactionID != "tx-clean" || res.ArchiveRows[1].SleeperTransactionID != "tx-picks" {
+		t.Errorf("expected tx-clean and tx-picks in ArchiveRows, got %+v", res.ArchiveRows)
 	}
 	if len(res.CloudRows) != 0 {
 		t.Errorf("expected no CloudRows (all old), got %+v", res.CloudRows)
 	}
 }
 
-// TestFetchLeagueTransactions_ExcludesDraftPicksOrFAABEvenWhenCloudBound
-// guards the fix from #191: isPlayerOnlyTransaction must apply
-// unconditionally to every fetched row, not only the ones old enough to
-// route to archive — otherwise picks/FAAB trades leak into the live
-// sleeper_transactions table via CloudRows.
-func TestFetchLeagueTransactions_ExcludesDraftPicksOrFAABEvenWhenCloudBound(t *testing.T) {
+// TestFetchLeagueTransactions_ExcludesFAABEvenWhenCloudBound guards the fix
+// from #191: IsIngestedTransaction must apply unconditionally to every
+// fetched row, not only the ones old enough to route to archive — otherwise
+// FAAB trades leak into the live sleeper_transactions table via CloudRows.
+func TestFetchLeagueTransactions_ExcludesFAABEvenWhenCloudBound(t *testing.T) {
 	cloud := newTestDB(t)
 	archive := newArchiveTestDB(t)
 
@@ -372,8 +372,8 @@ func TestFetchLeagueTransactions_ExcludesDraftPicksOrFAABEvenWhenCloudBound(t *t
 	if err != nil {
 		t.Fatalf("FetchLeagueTransactions error: %v", err)
 	}
-	if len(res.CloudRows) != 1 || res.CloudRows[0].SleeperTransactionID != "tx-clean" {
-		t.Errorf("expected only tx-clean in CloudRows, got %+v", res.CloudRows)
+	if len(res.CloudRows) != 2 || res.CloudRows[0].SleeperTransactionID != "tx-clean" || res.CloudRows[1].SleeperTransactionID != "tx-picks" {
+		t.Errorf("expected tx-clean and tx-picks in CloudRows, got %+v", res.CloudRows)
 	}
 	if len(res.ArchiveRows) != 0 {
 		t.Errorf("expected no ArchiveRows (all recent), got %+v", res.ArchiveRows)
@@ -401,6 +401,56 @@ func TestFetchLeagueTransactions_AllRowsToCloudWhenArchiveNil(t *testing.T) {
 	}
 }
 
+func TestFetchLeagueTransactions_CapturesWinningFAABBid(t *testing.T) {
+	cloud := newTestDB(t)
+	nowMs := time.Now().UTC().UnixMilli()
+	bid, losing := 25, 40
+	srv := batchTestServer(t, map[string][]sleeper.Transaction{
+		"lg1/2": {
+			{TransactionID: "won", Type: "waiver", Status: "complete", Leg: 2, Created: nowMs,
+				Settings: &sleeper.TransactionSettings{WaiverBid: &bid}},
+			// A losing bid isn't a market price.
+			{TransactionID: "lost", Type: "waiver", Status: "failed", Leg: 2, Created: nowMs,
+				Settings: &sleeper.TransactionSettings{WaiverBid: &losing}},
+			{TransactionID: "fa", Type: "free_agent", Status: "complete", Leg: 2, Created: nowMs},
+		},
+	}, nil)
+	defer srv.Close()
+
+	budget := 200
+	dfa := &activities.DataFetchActivities{DB: cloud, Sleeper: sleeper.NewWithBaseURL(srv.URL)}
+	res, err := transactioncron.FetchLeagueTransactions(context.Background(), dfa,
+		transactioncron.LeagueTransactionState{LeagueID: "lg1", Season: "2026", WaiverBudget: &budget}, week3())
+	if err != nil {
+		t.Fatalf("FetchLeagueTransactions error: %v", err)
+	}
+	byID := map[string]models.SleeperTransaction{}
+	for _, r := range res.CloudRows {
+		byID[r.SleeperTransactionID] = r
+	}
+	if won := byID["won"]; won.WaiverBid == nil || *won.WaiverBid != 25 || won.WaiverBidPct == nil || *won.WaiverBidPct != 12.5 {
+		t.Errorf("expected the winning claim's bid 25 at 12.5%% of budget, got %+v", won)
+	}
+	if lost := byID["lost"]; lost.WaiverBid != nil || lost.WaiverBidPct != nil {
+		t.Errorf("expected no bid on the failed claim, got %+v", lost)
+	}
+	if fa := byID["fa"]; fa.WaiverBid != nil {
+		t.Errorf("expected no bid on a free-agent add, got %+v", fa)
+	}
+
+	// Without a known budget the bid is still captured, its percentage not.
+	res, err = transactioncron.FetchLeagueTransactions(context.Background(), dfa,
+		transactioncron.LeagueTransactionState{LeagueID: "lg1", Season: "2026"}, week3())
+	if err != nil {
+		t.Fatalf("FetchLeagueTransactions error: %v", err)
+	}
+	for _, r := range res.CloudRows {
+		if r.SleeperTransactionID == "won" && (r.WaiverBid == nil || r.WaiverBidPct != nil) {
+			t.Errorf("expected the bid without a percentage, got %+v", r)
+		}
+	}
+}
+
 func TestFlushLeagueTransactions_StampsClearsClaimsAndWritesRows(t *testing.T) {
 	db := newTestDB(t)
 	claimedLeague(t, db, "lg1")
@@ -525,7 +575,7 @@ func TestFlushLeagueTransactions_ErrorLeavesStateUntouched(t *testing.T) {
 	}
 }
 
-func TestFetchLeagueTransactions_ExcludesDraftPicksWhenArchiveNil(t *testing.T) {
+func TestFetchLeagueTransactions_KeepsDraftPickTradesWhenArchiveNil(t *testing.T) {
 	cloud := newTestDB(t)
 
 	recentMs := time.Now().UTC().Add(-1 * time.Hour).UnixMilli()
@@ -543,8 +593,8 @@ func TestFetchLeagueTransactions_ExcludesDraftPicksWhenArchiveNil(t *testing.T)
 	if err != nil {
 		t.Fatalf("FetchLeagueTransactions error: %v", err)
 	}
-	if len(res.CloudRows) != 1 || res.CloudRows[0].SleeperTransactionID != "tx-clean" {
-		t.Errorf("expected only tx-clean in CloudRows (no archive configured), got %+v", res.CloudRows)
+	if len(res.CloudRows) != 2 || res.CloudRows[1].SleeperTransactionID != "tx-picks" {
+		t.Errorf("expected tx-picks kept in CloudRows (no archive configured), got %+v", res.CloudRows)
 	}
 }
 
@@ -671,3 +721,30 @@ func TestFlushLeagueTransactions_LeavesTradeValuesNullWhenUnvalued(t *testing.T)
 		t.Error("expected trade_values_complete=false — roster 7 never resolved")
 	}
 }
+
+// pick_valuations come from ADP, not the model, so a segment can have them
+// before it has any player_valuations; its trades wait for the model.
+func TestFlushLeagueTransactions_SkipsSegmentWithoutValuations(t *testing.T) {
+	db := newTestDB(t)
+	ppr10League(t, db, "lg1")
+	db.Create(&valuation.PickSnapshot{Segment: "ppr-sf-10", Season: "2026", Round: 1, ValuationDate: time.Now().UTC().Add(-6 * time.Hour), Value: 2500})
+
+	batch := []transactioncron.LeagueTransactionFetchResult{{
+		LeagueID: "lg1",
+		CloudRows: []models.SleeperTransaction{{
+			SleeperTransactionID: "tx1", SleeperLeagueID: "lg1", Type: "trade", Status: "complete",
+			CreatedAtSleeper: time.Now().UTC().UnixMilli(),
+			DraftPicks:       json.RawMessage(`[{"season": "2026", "round": 1, "roster_id": 7, "previous_owner_id": 7, "owner_id": 8}]`),
+		}},
+	}}
+	dfa := &activities.DataFetchActivities{DB: db}
+	if err := transactioncron.FlushLeagueTransactions(context.Background(), dfa, db, batch); err != nil {
+		t.Fatalf("FlushLeagueTransactions error: %v", err)
+	}
+
+	var tx models.SleeperTransaction
+	db.First(&tx, "sleeper_transaction_id = ?", "tx1")
+	if len(tx.TradeValues) != 0 || tx.TradeValuesComplete {
+		t.Errorf("expected an unvalued segment's trade left for reconcile, got %s (complete %v)", tx.TradeValues, tx.TradeValuesComplete)
+	}
+}
@@ -0,0 +1,176 @@
+package transactioncron_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"backend/internal/activities"
+	"backend/internal/models"
+	"backend/internal/sleeper"
+	"backend/internal/transactioncron"
+	"backend/internal/valuation"
+)
+
+// pickLedgerServer serves lg1's leg-2 transactions and its traded_picks
+// snapshot, counting traded_picks calls.
+func pickLedgerServer(t *testing.T, txns []sleeper.Transaction, picks []sleeper.TradedPick, pickCalls *atomic.Int64) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/league/lg1/traded_picks":
+			pickCalls.Add(1)
+			json.NewEncoder(w).Encode(picks)
+		case r.URL.Path == "/v1/league/lg1/transactions/2":
+			json.NewEncoder(w).Encode(txns)
+		case strings.Contains(r.URL.Path, "/transactions/"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestFetchLeagueTransactions_CollectsPickTradesAndSnapshot(t *testing.T) {
+	db := newTestDB(t)
+	recentMs := time.Now().UTC().Add(-time.Hour).UnixMilli()
+	pick := map[string]interface{}{"season": "2027", "round": float64(1), "roster_id": float64(3), "previous_owner_id": float64(3), "owner_id": float64(5)}
+	var pickCalls atomic.Int64
+	srv := pickLedgerServer(t, []sleeper.Transaction{
+		{TransactionID: "tx-picks", Type: "trade", Status: "complete", Leg: 2, Created: recentMs,
+			Adds: map[string]int{"4046": 3}, DraftPicks: []interface{}{pick}},
+		{TransactionID: "tx-failed", Type: "trade", Status: "failed", Leg: 2, Created: recentMs,
+			DraftPicks: []interface{}{pick}},
+	}, []sleeper.TradedPick{{Season: "2027", Round: 1, RosterID: 3, PreviousOwnerID: 3, OwnerID: 5}}, &pickCalls)
+	defer srv.Close()
+
+	dfa := &activities.DataFetchActivities{DB: db, Sleeper: sleeper.NewWithBaseURL(srv.URL)}
+	res, err := transactioncron.FetchLeagueTransactions(context.Background(), dfa,
+		transactioncron.LeagueTransactionState{LeagueID: "lg1", Season: "2026"}, week3())
+	if err != nil {
+		t.Fatalf("FetchLeagueTransactions error: %v", err)
+	}
+	if len(res.CloudRows) != 2 {
+		t.Errorf("expected pick-bearing trades written to sleeper_transactions, got %+v", res.CloudRows)
+	}
+	if len(res.PickTrades) != 1 || res.PickTrades[0].SleeperTransactionID != "tx-picks" || res.PickTrades[0].OwnerID != 5 {
+		t.Fatalf("expected one pick move from the completed trade, got %+v", res.PickTrades)
+	}
+	if !res.TradedPicksFetched || len(res.TradedPicks) != 1 || pickCalls.Load() != 1 {
+		t.Errorf("expected one traded_picks fetch, got fetched=%v picks=%+v calls=%d", res.TradedPicksFetched, res.TradedPicks, pickCalls.Load())
+	}
+}
+
+func TestFetchLeagueTransactions_SnapshotsDynastyLeagueOnFirstVisitOnly(t *testing.T) {
+	db := newTestDB(t)
+	var pickCalls atomic.Int64
+	srv := pickLedgerServer(t, nil, []sleeper.TradedPick{}, &pickCalls)
+	defer srv.Close()
+	dfa := &activities.DataFetchActivities{DB: db, Sleeper: sleeper.NewWithBaseURL(srv.URL)}
+
+	res, err := transactioncron.FetchLeagueTransactions(context.Background(), dfa,
+		transactioncron.LeagueTransactionState{LeagueID: "lg1", Season: "2026", LeagueType: "dynasty"}, week3())
+	if err != nil {
+		t.Fatalf("first visit: %v", err)
+	}
+	if !res.TradedPicksFetched || pickCalls.Load() != 1 {
+		t.Errorf("expected a snapshot on a dynasty league's first visit, got fetched=%v calls=%d", res.TradedPicksFetched, pickCalls.Load())
+	}
+
+	watermark := 3
+	res, err = transactioncron.FetchLeagueTransactions(context.Background(), dfa,
+		transactioncron.LeagueTransactionState{LeagueID: "lg1", Season: "2026", LeagueType: "dynasty", LastLegFetched: &watermark}, week3())
+	if err != nil {
+		t.Fatalf("later visit: %v", err)
+	}
+	if res.TradedPicksFetched || pickCalls.Load() != 1 {
+		t.Errorf("expected no snapshot without pick trades on a later visit, got calls=%d", pickCalls.Load())
+	}
+}
+
+func TestFlushLeagueTransactions_ReplacesTradedPickSnapshot(t *testing.T) {
+	db := newTestDB(t)
+	claimedLeague(t, db, "lg1")
+	claimedLeague(t, db, "lg2")
+	db.Create(&[]models.SleeperTradedPick{
+		{SleeperLeagueID: "lg1", Season: "2027", Round: 2, RosterID: 1, OwnerID: 4},
+		{SleeperLeagueID: "lg2", Season: "2027", Round: 1, RosterID: 1, OwnerID: 2},
+	})
+
+	trade := models.SleeperPickTrade{SleeperTransactionID: "tx1", Season: "2027", Round: 1, RosterID: 3, SleeperLeagueID: "lg1", OwnerID: 5}
+	batch := []transactioncron.LeagueTransactionFetchResult{
+		{
+			LeagueID:           "lg1",
+			PickTrades:         []models.SleeperPickTrade{trade},
+			TradedPicks:        []models.SleeperTradedPick{{SleeperLeagueID: "lg1", Season: "2027", Round: 1, RosterID: 3, OwnerID: 5}},
+			TradedPicksFetched: true,
+		},
+		{LeagueID: "lg2"}, // no snapshot this run — lg2's stored one stays
+	}
+	dfa := &activities.DataFetchActivities{DB: db}
+	for i := 0; i < 2; i++ { // a replayed batch must not duplicate history
+		if err := transactioncron.FlushLeagueTransactions(context.Background(), dfa, db, batch); err != nil {
+			t.Fatalf("FlushLeagueTransactions error: %v", err)
+		}
+	}
+
+	var lg1 []models.SleeperTradedPick
+	db.Where("sleeper_league_id = ?", "lg1").Find(&lg1)
+	if len(lg1) != 1 || lg1[0].Round != 1 || lg1[0].OwnerID != 5 {
+		t.Errorf("expected lg1's snapshot replaced, got %+v", lg1)
+	}
+	var lg2Count, tradeCount int64
+	db.Model(&models.SleeperTradedPick{}).Where("sleeper_league_id = ?", "lg2").Count(&lg2Count)
+	db.Model(&models.SleeperPickTrade{}).Count(&tradeCount)
+	if lg2Count != 1 || tradeCount != 1 {
+		t.Errorf("expected lg2 untouched and 1 pick trade, got %d and %d", lg2Count, tradeCount)
+	}
+}
+
+func TestFetchLeagueTransactions_StampsSlotsOnceDraftOrderIsSet(t *testing.T) {
+	db := newTestDB(t)
+	recentMs := time.Now().UTC().Add(-time.Hour).UnixMilli()
+	// Roster 3 drafts from slot 2 of a 10-team snake with a third-round
+	// reversal, so its 3rd-rounder is the round's 9th pick.
+	draft := sleeper.Draft{DraftID: "d1", Type: "snake", Season: "2026",
+		Settings:       sleeper.DraftSettings{Teams: 10, ReversalRound: 3},
+		SlotToRosterID: map[string]int{"1": 7, "2": 3}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/league/lg1/transactions/2":
+			json.NewEncoder(w).Encode([]sleeper.Transaction{{TransactionID: "tx-picks", Type: "trade", Status: "complete", Leg: 2, Created: recentMs,
+				DraftPicks: []interface{}{
+					map[string]interface{}{"season": "2026", "round": float64(3), "roster_id": float64(3), "previous_owner_id": float64(3), "owner_id": float64(5)},
+					map[string]interface{}{"season": "2027", "round": float64(1), "roster_id": float64(3), "previous_owner_id": float64(3), "owner_id": float64(5)},
+				}}})
+		case "/v1/league/lg1/drafts":
+			json.NewEncoder(w).Encode([]sleeper.Draft{draft})
+		case "/v1/league/lg1/traded_picks":
+			json.NewEncoder(w).Encode([]sleeper.TradedPick{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	dfa := &activities.DataFetchActivities{DB: db, Sleeper: sleeper.NewWithBaseURL(srv.URL)}
+	res, err := transactioncron.FetchLeagueTransactions(context.Background(), dfa,
+		transactioncron.LeagueTransactionState{LeagueID: "lg1", Season: "2026"}, week3())
+	if err != nil {
+		t.Fatalf("FetchLeagueTransactions error: %v", err)
+	}
+	if len(res.CloudRows) != 1 {
+		t.Fatalf("expected the trade kept, got %+v", res.CloudRows)
+	}
+	got := valuation.GroupPicksByRoster(res.CloudRows[0].DraftPicks)[5]
+	want := []valuation.PickKey{{Season: "2026", Round: 3, Slot: 9}, {Season: "2027", Round: 1}}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
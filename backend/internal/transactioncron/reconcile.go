@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"gorm.io/gorm"
@@ -14,10 +15,12 @@ import (
 
 // ReconcileTradeValues fills in trade_values for up to limit trades that
 // aren't yet complete, newest first, restricted at the query level to
-// leagues in a covered valuation format (matching
-// valuation.SegmentKeyForLeague's own conditions) so a permanently-uncovered
-// league's trades can never crowd the LIMIT window and starve reconcilable
-// ones. This is the sole backfill mechanism for pre-existing trades — see
+// leagues in a segment with player_valuations (valuation.ValuedSegments,
+// matched by valuation.LeagueCondition) so a permanently-uncovered league's
+// trades can never crowd the LIMIT window and starve reconcilable ones. The
+// segments are rediscovered every run, so once the model starts producing a
+// new segment its leagues' existing trades are backfilled here with no code
+// change. This is the sole backfill mechanism for pre-existing trades — see
 // docs/superpowers/specs/2026-08-10-trade-valuation-totals-design.md — and
 // must stay cheap: RunTransactionSync runs it concurrently with, not after,
 // each tick's fetch/flush, under its own short deadline, so it can never
@@ -34,18 +37,27 @@ func ReconcileTradeValues(ctx context.Context, db *gorm.DB, limit int) error {
 	type row struct {
 		SleeperTransactionID string          `gorm:"column:sleeper_transaction_id"`
 		Adds                 json.RawMessage `gorm:"column:adds"`
+		DraftPicks           json.RawMessage `gorm:"column:draft_picks"`
 		CreatedAtSleeper     int64           `gorm:"column:created_at_sleeper"`
 		PPR                  *float64        `gorm:"column:ppr"`
 		IsSuperflex          *bool           `gorm:"column:is_superflex"`
 		TotalRosters         int             `gorm:"column:total_rosters"`
 		LeagueType           string          `gorm:"column:league_type"`
 	}
+	segments, err := valuation.ValuedSegments(db.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("valued segments: %w", err)
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+	covered, coveredArgs := valuation.LeagueCondition(segments)
 	var rows []row
 	if err := db.WithContext(ctx).Table("sleeper_transactions t").
-		Select("t.sleeper_transaction_id, t.adds, t.created_at_sleeper, l.ppr, l.is_superflex, l.total_rosters, l.league_type").
+		Select("t.sleeper_transaction_id, t.adds, t.draft_picks, t.created_at_sleeper, l.ppr, l.is_superflex, l.total_rosters, l.league_type").
 		Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = t.sleeper_league_id").
-		Where("t.type = ? AND t.status = ? AND t.trade_values_complete = ? AND l.ppr = ? AND l.is_superflex = ? AND l.league_type = ? AND l.total_rosters IN (8, 10, 12)",
-			"trade", "complete", false, 1.0, true, "redraft").
+		Where("t.type = ? AND t.status = ? AND t.trade_values_complete = ?", "trade", "complete", false).
+		Where(covered, coveredArgs...).
 		Order("t.created_at_sleeper DESC").
 		Limit(limit).
 		Scan(&rows).Error; err != nil {
@@ -58,7 +70,7 @@ func ReconcileTradeValues(ctx context.Context, db *gorm.DB, limit int) error {
 	inputs := make([]tradeValuationInput, 0, len(rows))
 	for _, r := range rows {
 		seg := valuation.SegmentKeyForLeague(r.PPR, r.IsSuperflex, r.TotalRosters, r.LeagueType)
-		if seg == "" {
+		if seg == "" || !slices.Contains(segments, seg) {
 			continue
 		}
 		var adds map[string]int
@@ -69,7 +81,7 @@ func ReconcileTradeValues(ctx context.Context, db *gorm.DB, limit int) error {
 		}
 		inputs = append(inputs, tradeValuationInput{
 			ID: r.SleeperTransactionID, TradeTime: time.UnixMilli(r.CreatedAtSleeper).UTC(),
-			Adds: adds, Segment: seg,
+			Adds: adds, Picks: valuation.GroupPicksByRoster(r.DraftPicks), Segment: seg,
 		})
 	}
 	if len(inputs) == 0 {
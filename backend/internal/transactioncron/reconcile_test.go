@@ -159,3 +159,89 @@ func TestReconcileTradeValues_RespectsLimit(t *testing.T) {
 		t.Errorf("expected exactly 1 row filled with limit=1, got %d", filled)
 	}
 }
+
+// TestReconcileTradeValues_BackfillsNewlyValuedSegment covers a format
+// outside the original ppr-sf redraft segments: its trades are left alone
+// (and can't crowd the limit) while it has no valuations, then backfilled
+// once the model starts producing its segment.
+func TestReconcileTradeValues_BackfillsNewlyValuedSegment(t *testing.T) {
+	db := newTestDB(t)
+	ppr10League(t, db, "lg1")
+	half, oneQB := 0.5, false
+	db.Create(&models.SleeperLeague{
+		SleeperLeagueID: "lg-half", Season: "2025",
+		PPR: &half, IsSuperflex: &oneQB, TotalRosters: 16, LeagueType: "dynasty",
+	})
+	base := time.Now().UTC()
+	db.Create(&models.SleeperTransaction{
+		SleeperTransactionID: "tx-half", SleeperLeagueID: "lg-half", Type: "trade", Status: "complete",
+		CreatedAtSleeper: base.UnixMilli(), Adds: json.RawMessage(`{"p1": 3}`),
+	})
+	db.Create(&models.SleeperTransaction{
+		SleeperTransactionID: "tx-ppr", SleeperLeagueID: "lg1", Type: "trade", Status: "complete",
+		CreatedAtSleeper: base.Add(-time.Hour).UnixMilli(), Adds: json.RawMessage(`{"p1": 7}`),
+	})
+	db.Create(&valuation.Snapshot{Segment: "ppr-sf-10", SleeperPlayerID: "p1", ValuationDate: base.Add(-6 * time.Hour), Value: 4200})
+
+	ctx := context.Background()
+	if err := transactioncron.ReconcileTradeValues(ctx, db, 1); err != nil {
+		t.Fatalf("ReconcileTradeValues error: %v", err)
+	}
+	var ppr, halfTx models.SleeperTransaction
+	db.First(&ppr, "sleeper_transaction_id = ?", "tx-ppr")
+	db.First(&halfTx, "sleeper_transaction_id = ?", "tx-half")
+	if !ppr.TradeValuesComplete {
+		t.Error("expected the valued segment's older trade to be filled despite limit=1")
+	}
+	if len(halfTx.TradeValues) != 0 {
+		t.Errorf("expected the unvalued segment's trade to stay null, got %s", halfTx.TradeValues)
+	}
+
+	db.Create(&valuation.Snapshot{Segment: "half_ppr-1qb-14+-dynasty", SleeperPlayerID: "p1", ValuationDate: base.Add(-6 * time.Hour), Value: 6100})
+	if err := transactioncron.ReconcileTradeValues(ctx, db, 200); err != nil {
+		t.Fatalf("ReconcileTradeValues error: %v", err)
+	}
+	db.First(&halfTx, "sleeper_transaction_id = ?", "tx-half")
+	var totals map[string]float64
+	json.Unmarshal(halfTx.TradeValues, &totals)
+	if !halfTx.TradeValuesComplete || totals["3"] != 6100 {
+		t.Errorf("expected the newly valued segment's trade backfilled to {3:6100}, got %s", halfTx.TradeValues)
+	}
+}
+
+// TestReconcileTradeValues_SettlesPicksThatPredatePickValuations covers
+// trades made before the segment's first pick_valuations rollup: a side
+// holding a pick can never be priced, so the trade is settled with the sides
+// it has, while a side of unvalued players alone still keeps its trade open.
+func TestReconcileTradeValues_SettlesPicksThatPredatePickValuations(t *testing.T) {
+	db := newTestDB(t)
+	ppr10League(t, db, "lg1")
+
+	now := time.Now().UTC()
+	tradeTime := now.AddDate(0, 0, -30)
+	db.Create(&valuation.Snapshot{Segment: "ppr-sf-10", SleeperPlayerID: "p1", ValuationDate: tradeTime.Add(-6 * time.Hour), Value: 4200})
+	db.Create(&valuation.PickSnapshot{Segment: "ppr-sf-10", Season: "2026", Round: 1, ValuationDate: now.AddDate(0, 0, -1), Value: 3000})
+	picks := json.RawMessage(`[{"season": "2026", "round": 1, "roster_id": 8, "previous_owner_id": 7, "owner_id": 8}]`)
+	db.Create(&[]models.SleeperTransaction{
+		{SleeperTransactionID: "tx-picks", SleeperLeagueID: "lg1", Type: "trade", Status: "complete",
+			CreatedAtSleeper: tradeTime.UnixMilli(), Adds: json.RawMessage(`{"p1": 7}`), DraftPicks: picks},
+		{SleeperTransactionID: "tx-players", SleeperLeagueID: "lg1", Type: "trade", Status: "complete",
+			CreatedAtSleeper: tradeTime.UnixMilli(), Adds: json.RawMessage(`{"p1": 7, "p2": 9}`), DraftPicks: picks},
+	})
+
+	if err := transactioncron.ReconcileTradeValues(context.Background(), db, 200); err != nil {
+		t.Fatalf("ReconcileTradeValues error: %v", err)
+	}
+
+	var settled, pending models.SleeperTransaction
+	db.First(&settled, "sleeper_transaction_id = ?", "tx-picks")
+	db.First(&pending, "sleeper_transaction_id = ?", "tx-players")
+	var totals map[string]float64
+	json.Unmarshal(settled.TradeValues, &totals)
+	if !settled.TradeValuesComplete || totals["7"] != 4200 || len(totals) != 1 {
+		t.Errorf("expected tx-picks settled with only roster 7's total, got complete=%v %s", settled.TradeValuesComplete, settled.TradeValues)
+	}
+	if pending.TradeValuesComplete {
+		t.Error("expected tx-players left open — roster 9's player may still be valued")
+	}
+}
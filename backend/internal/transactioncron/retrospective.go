@@ -0,0 +1,306 @@
+package transactioncron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+// RunTradeRetrospectives keeps trade_retrospectives current, touching at
+// most limit trades per phase: it first seeds a retrospective for each
+// fully-valued trade in a valued segment (valuation.ValuedSegments) that
+// lacks one (newest first), then re-values the retrospectives whose valuation_date trails their
+// segment's latest player_valuations date. Like ReconcileTradeValues it runs
+// beside each tick's fetch/flush under its own deadline, so a large backlog
+// is worked through a bounded slice per tick.
+func RunTradeRetrospectives(ctx context.Context, db *gorm.DB, limit, laterWeeks int) error {
+	segments, err := valuation.ValuedSegments(db.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("valued segments: %w", err)
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+	if err := seedTradeRetrospectives(ctx, db, segments, limit, laterWeeks); err != nil {
+		return err
+	}
+	for _, seg := range segments {
+		n, err := refreshTradeRetrospectives(ctx, db, seg, limit)
+		if err != nil {
+			return err
+		}
+		if limit -= n; limit <= 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
+// seedTradeRetrospectives copies up to limit complete, fully-valued trades
+// without a retrospective into trade_retrospectives, restricted to leagues
+// in segments the same way ReconcileTradeValues is.
+func seedTradeRetrospectives(ctx context.Context, db *gorm.DB, segments []string, limit, laterWeeks int) error {
+	type row struct {
+		SleeperTransactionID string          `gorm:"column:sleeper_transaction_id"`
+		Adds                 json.RawMessage `gorm:"column:adds"`
+		DraftPicks           json.RawMessage `gorm:"column:draft_picks"`
+		TradeValues          json.RawMessage `gorm:"column:trade_values"`
+		CreatedAtSleeper     int64           `gorm:"column:created_at_sleeper"`
+		Leg                  int             `gorm:"column:leg"`
+		Season               string          `gorm:"column:season"`
+		PPR                  *float64        `gorm:"column:ppr"`
+		IsSuperflex          *bool           `gorm:"column:is_superflex"`
+		TotalRosters         int             `gorm:"column:total_rosters"`
+		LeagueType           string          `gorm:"column:league_type"`
+	}
+	covered, coveredArgs := valuation.LeagueCondition(segments)
+	var rows []row
+	if err := db.WithContext(ctx).Table("sleeper_transactions t").
+		Select("t.sleeper_transaction_id, t.adds, t.draft_picks, t.trade_values, t.created_at_sleeper, t.leg, l.season, l.ppr, l.is_superflex, l.total_rosters, l.league_type").
+		Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = t.sleeper_league_id").
+		Joins("LEFT JOIN trade_retrospectives r ON r.sleeper_transaction_id = t.sleeper_transaction_id").
+		Where("t.type = ? AND t.status = ? AND t.trade_values_complete = ? AND t.trade_values IS NOT NULL AND r.sleeper_transaction_id IS NULL", "trade", "complete", true).
+		Where(covered, coveredArgs...).
+		Order("t.created_at_sleeper DESC").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return fmt.Errorf("select trades without retrospectives: %w", err)
+	}
+
+	retros := make([]models.TradeRetrospective, 0, len(rows))
+	for _, r := range rows {
+		seg := valuation.SegmentKeyForLeague(r.PPR, r.IsSuperflex, r.TotalRosters, r.LeagueType)
+		if seg == "" {
+			continue
+		}
+		retros = append(retros, models.TradeRetrospective{
+			SleeperTransactionID: r.SleeperTransactionID,
+			Segment:              seg,
+			Season:               r.Season,
+			Leg:                  r.Leg,
+			TradedAt:             time.UnixMilli(r.CreatedAtSleeper).UTC(),
+			Adds:                 r.Adds,
+			DraftPicks:           r.DraftPicks,
+			ValuesAtTrade:        r.TradeValues,
+			LaterWeeks:           laterWeeks,
+		})
+	}
+	if len(retros) == 0 {
+		return nil
+	}
+	if err := db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&retros).Error; err != nil {
+		return fmt.Errorf("insert trade retrospectives: %w", err)
+	}
+	return nil
+}
+
+// refreshTradeRetrospectives re-values up to limit of segment's stale
+// retrospectives as of its latest valuation date, returning how many it
+// updated. values_later is only filled once its date has a valuation, and
+// then left alone; realized points are recomputed every time, since a
+// season in progress keeps adding to them.
+func refreshTradeRetrospectives(ctx context.Context, db *gorm.DB, segment string, limit int) (int, error) {
+	seg, ok := valuation.ParseSegment(segment)
+	if !ok {
+		// No league buckets into a key that doesn't parse, so it has no
+		// retrospectives.
+		return 0, nil
+	}
+	var latest []valuation.Snapshot
+	if err := db.WithContext(ctx).Table("player_valuations").
+		Select("valuation_date").
+		Where("segment = ?", segment).
+		Order("valuation_date DESC").
+		Limit(1).
+		Scan(&latest).Error; err != nil {
+		return 0, fmt.Errorf("latest valuation date for %s: %w", segment, err)
+	}
+	if len(latest) == 0 {
+		return 0, nil
+	}
+	asOf := latest[0].ValuationDate
+
+	var retros []models.TradeRetrospective
+	if err := db.WithContext(ctx).
+		Where("segment = ? AND (valuation_date IS NULL OR valuation_date < ?)", segment, asOf).
+		Order("traded_at DESC").
+		Limit(limit).
+		Find(&retros).Error; err != nil {
+		return 0, fmt.Errorf("select stale trade retrospectives for %s: %w", segment, err)
+	}
+	if len(retros) == 0 {
+		return 0, nil
+	}
+
+	adds := make([]map[int][]string, len(retros))
+	picks := make([]map[int][]valuation.PickKey, len(retros))
+	playerSet := map[string]struct{}{}
+	pickSet := map[valuation.PickKey]struct{}{}
+	seasonSet := map[string]struct{}{}
+	var laterFrom, laterTo time.Time
+	for i, r := range retros {
+		var a map[string]int
+		if len(r.Adds) > 0 {
+			_ = json.Unmarshal(r.Adds, &a)
+		}
+		adds[i] = valuation.GroupPlayersByRoster(a)
+		picks[i] = valuation.GroupPicksByRoster(r.DraftPicks)
+		for id := range a {
+			playerSet[id] = struct{}{}
+		}
+		for _, ps := range picks[i] {
+			for _, p := range ps {
+				pickSet[p] = struct{}{}
+			}
+		}
+		seasonSet[r.Season] = struct{}{}
+		if later, due := retrospectiveLaterTime(r, asOf); due {
+			if laterFrom.IsZero() || later.Before(laterFrom) {
+				laterFrom = later
+			}
+			if later.After(laterTo) {
+				laterTo = later
+			}
+		}
+	}
+	playerIDs := make([]string, 0, len(playerSet))
+	for id := range playerSet {
+		playerIDs = append(playerIDs, id)
+	}
+	pickKeys := make([]valuation.PickKey, 0, len(pickSet))
+	for k := range pickSet {
+		pickKeys = append(pickKeys, k)
+	}
+
+	rdb := db.WithContext(ctx)
+	nowHistory := valuation.LoadSnapshotHistory(rdb, segment, playerIDs, asOf.Add(-valuation.FreshnessWindow), asOf)
+	nowPickHistory := valuation.LoadPickHistory(rdb, segment, pickKeys, asOf.Add(-valuation.FreshnessWindow), asOf)
+	laterHistory := map[string][]valuation.Snapshot{}
+	laterPickHistory := map[valuation.PickKey][]valuation.Snapshot{}
+	if !laterTo.IsZero() {
+		laterHistory = valuation.LoadSnapshotHistory(rdb, segment, playerIDs, laterFrom.Add(-valuation.FreshnessWindow), laterTo)
+		laterPickHistory = valuation.LoadPickHistory(rdb, segment, pickKeys, laterFrom.Add(-valuation.FreshnessWindow), laterTo)
+	}
+	points, err := loadRetrospectivePoints(rdb, seg.PointsColumn(), playerIDs, seasonSet)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, r := range retros {
+		valuesNow, complete := valuation.ComputeTradeValues(adds[i], picks[i], asOf, nowHistory, nowPickHistory)
+		updates := map[string]interface{}{
+			"valuation_date":  asOf,
+			"values_now":      valuesNow,
+			"realized_points": realizedPoints(adds[i], points, r.Season, r.Leg),
+			"hindsight_gap":   nil,
+		}
+		if complete {
+			if gap, ok := tradeValueSpread(valuesNow); ok {
+				updates["hindsight_gap"] = gap
+			}
+		}
+		if later, due := retrospectiveLaterTime(r, asOf); due {
+			if valuesLater, _ := valuation.ComputeTradeValues(adds[i], picks[i], later, laterHistory, laterPickHistory); valuesLater != nil {
+				updates["values_later"] = valuesLater
+			}
+		}
+		if err := rdb.Model(&models.TradeRetrospective{}).
+			Where("sleeper_transaction_id = ?", r.SleeperTransactionID).
+			Updates(updates).Error; err != nil {
+			return 0, fmt.Errorf("update trade retrospective %s: %w", r.SleeperTransactionID, err)
+		}
+	}
+	return len(retros), nil
+}
+
+// retrospectiveLaterTime returns when a retrospective's values_later is
+// taken, and whether it's due: unset, and no later than asOf.
+func retrospectiveLaterTime(r models.TradeRetrospective, asOf time.Time) (time.Time, bool) {
+	later := r.TradedAt.AddDate(0, 0, 7*r.LaterWeeks)
+	return later, r.ValuesLater == nil && !later.After(asOf)
+}
+
+// retrospectiveWeekPoints is one player's points for one week.
+type retrospectiveWeekPoints struct {
+	SleeperPlayerID string  `gorm:"column:sleeper_player_id"`
+	Season          string  `gorm:"column:season"`
+	Week            int     `gorm:"column:week"`
+	Points          float64 `gorm:"column:points"`
+}
+
+// loadRetrospectivePoints reads the players' weekly points in seasons from
+// column — the segment's valuation.Segment.PointsColumn — grouped by player.
+func loadRetrospectivePoints(db *gorm.DB, column string, playerIDs []string, seasonSet map[string]struct{}) (map[string][]retrospectiveWeekPoints, error) {
+	out := map[string][]retrospectiveWeekPoints{}
+	if len(playerIDs) == 0 {
+		return out, nil
+	}
+	seasons := make([]string, 0, len(seasonSet))
+	for s := range seasonSet {
+		seasons = append(seasons, s)
+	}
+	var rows []retrospectiveWeekPoints
+	if err := db.Table("sleeper_player_week_stats").
+		Select("sleeper_player_id, season, week, "+column+" AS points").
+		Where("sleeper_player_id IN ? AND season IN ? AND "+column+" IS NOT NULL", playerIDs, seasons).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("week points for trade retrospectives: %w", err)
+	}
+	for _, r := range rows {
+		out[r.SleeperPlayerID] = append(out[r.SleeperPlayerID], r)
+	}
+	return out, nil
+}
+
+// realizedPoints sums each roster's received players' points in season from
+// week leg on — the trade's own week counts, since Sleeper advances the leg
+// before that week's games. Picks score nothing, so a picks-only side is
+// absent.
+func realizedPoints(rosterPlayers map[int][]string, points map[string][]retrospectiveWeekPoints, season string, leg int) json.RawMessage {
+	totals := map[string]float64{}
+	for rosterID, playerIDs := range rosterPlayers {
+		if len(playerIDs) == 0 {
+			continue
+		}
+		var total float64
+		for _, pid := range playerIDs {
+			for _, w := range points[pid] {
+				if w.Season == season && w.Week >= leg {
+					total += w.Points
+				}
+			}
+		}
+		totals[strconv.Itoa(rosterID)] = total
+	}
+	if len(totals) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(totals)
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+// tradeValueSpread is the gap between a trade's highest- and lowest-valued
+// sides; false for fewer than two sides.
+func tradeValueSpread(values json.RawMessage) (float64, bool) {
+	var totals map[string]float64
+	if err := json.Unmarshal(values, &totals); err != nil || len(totals) < 2 {
+		return 0, false
+	}
+	hi, lo := math.Inf(-1), math.Inf(1)
+	for _, v := range totals {
+		hi, lo = math.Max(hi, v), math.Min(lo, v)
+	}
+	return hi - lo, true
+}
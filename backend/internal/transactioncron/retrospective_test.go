@@ -0,0 +1,175 @@
+package transactioncron_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+	"backend/internal/transactioncron"
+	"backend/internal/valuation"
+)
+
+// seedRetrospectiveTrade seeds a complete week-2 trade in a ppr-sf-10 league
+// on 2025-09-10 (p1 to roster 7, p2 to roster 3, valued 5000 to 3000 at the
+// time), valuations four weeks later and on 2025-11-01, and week points for
+// both players either side of the trade.
+func seedRetrospectiveTrade(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := newTestDB(t)
+	if err := db.AutoMigrate(&models.TradeRetrospective{}, &models.SleeperPlayerWeekStat{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	ppr10League(t, db, "lg1")
+
+	tradedAt := time.Date(2025, 9, 10, 18, 0, 0, 0, time.UTC)
+	db.Create(&models.SleeperTransaction{
+		SleeperTransactionID: "tx1", SleeperLeagueID: "lg1", Type: "trade", Status: "complete", Leg: 2,
+		CreatedAtSleeper: tradedAt.UnixMilli(), Adds: json.RawMessage(`{"p1": 7, "p2": 3}`),
+		TradeValues: json.RawMessage(`{"7": 5000, "3": 3000}`), TradeValuesComplete: true,
+	})
+	// Not fully valued at trade time: never gets a retrospective.
+	db.Create(&models.SleeperTransaction{
+		SleeperTransactionID: "tx2", SleeperLeagueID: "lg1", Type: "trade", Status: "complete", Leg: 2,
+		CreatedAtSleeper: tradedAt.UnixMilli(), Adds: json.RawMessage(`{"p1": 7, "p9": 3}`),
+		TradeValues: json.RawMessage(`{"7": 5000}`),
+	})
+
+	later := tradedAt.AddDate(0, 0, 28).Truncate(24 * time.Hour)
+	latest := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	for _, s := range []valuation.Snapshot{
+		{Segment: "ppr-sf-10", SleeperPlayerID: "p1", ValuationDate: later, Value: 4000},
+		{Segment: "ppr-sf-10", SleeperPlayerID: "p2", ValuationDate: later, Value: 3500},
+		{Segment: "ppr-sf-10", SleeperPlayerID: "p1", ValuationDate: latest, Value: 2000},
+		{Segment: "ppr-sf-10", SleeperPlayerID: "p2", ValuationDate: latest, Value: 4500},
+	} {
+		db.Create(&s)
+	}
+	for _, w := range []struct {
+		id   string
+		week int
+		pts  float64
+	}{{"p1", 1, 30}, {"p1", 2, 10}, {"p2", 2, 20}, {"p2", 3, 25}} {
+		pts := w.pts
+		db.Create(&models.SleeperPlayerWeekStat{Season: "2025", Week: w.week, SleeperPlayerID: w.id, PtsPPR: &pts})
+	}
+	return db
+}
+
+func TestRunTradeRetrospectives_RevaluesTradeInHindsight(t *testing.T) {
+	db := seedRetrospectiveTrade(t)
+
+	if err := transactioncron.RunTradeRetrospectives(context.Background(), db, 200, 4); err != nil {
+		t.Fatalf("RunTradeRetrospectives error: %v", err)
+	}
+
+	var retros []models.TradeRetrospective
+	db.Find(&retros)
+	if len(retros) != 1 || retros[0].SleeperTransactionID != "tx1" {
+		t.Fatalf("expected a retrospective for tx1 only, got %+v", retros)
+	}
+	r := retros[0]
+	if r.Segment != "ppr-sf-10" || r.Season != "2025" || r.Leg != 2 || r.LaterWeeks != 4 {
+		t.Errorf("unexpected retrospective metadata: %+v", r)
+	}
+	if r.ValuationDate == nil || !r.ValuationDate.Equal(time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected valuation_date 2025-11-01, got %v", r.ValuationDate)
+	}
+
+	totals := func(name string, raw json.RawMessage) map[string]float64 {
+		var m map[string]float64
+		if err := json.Unmarshal(raw, &m); err != nil {
+			t.Fatalf("%s: unmarshal %s: %v", name, raw, err)
+		}
+		return m
+	}
+	if m := totals("values_now", r.ValuesNow); m["7"] != 2000 || m["3"] != 4500 {
+		t.Errorf("expected values_now {7:2000 3:4500}, got %+v", m)
+	}
+	if m := totals("values_later", r.ValuesLater); m["7"] != 4000 || m["3"] != 3500 {
+		t.Errorf("expected values_later {7:4000 3:3500}, got %+v", m)
+	}
+	// p1's week-1 points predate the trade and don't count.
+	if m := totals("realized_points", r.RealizedPoints); m["7"] != 10 || m["3"] != 45 {
+		t.Errorf("expected realized_points {7:10 3:45}, got %+v", m)
+	}
+	if r.HindsightGap == nil || *r.HindsightGap != 2500 {
+		t.Errorf("expected hindsight_gap 2500, got %v", r.HindsightGap)
+	}
+}
+
+func TestRunTradeRetrospectives_KeepsValuesLaterOnceSet(t *testing.T) {
+	db := seedRetrospectiveTrade(t)
+	ctx := context.Background()
+	if err := transactioncron.RunTradeRetrospectives(ctx, db, 200, 4); err != nil {
+		t.Fatalf("RunTradeRetrospectives error: %v", err)
+	}
+
+	newer := time.Date(2025, 11, 8, 0, 0, 0, 0, time.UTC)
+	db.Create(&valuation.Snapshot{Segment: "ppr-sf-10", SleeperPlayerID: "p1", ValuationDate: newer, Value: 1000})
+	db.Create(&valuation.Snapshot{Segment: "ppr-sf-10", SleeperPlayerID: "p2", ValuationDate: newer, Value: 5000})
+	if err := transactioncron.RunTradeRetrospectives(ctx, db, 200, 4); err != nil {
+		t.Fatalf("RunTradeRetrospectives error: %v", err)
+	}
+
+	var r models.TradeRetrospective
+	db.First(&r, "sleeper_transaction_id = ?", "tx1")
+	var now, later map[string]float64
+	json.Unmarshal(r.ValuesNow, &now)
+	json.Unmarshal(r.ValuesLater, &later)
+	if now["7"] != 1000 || now["3"] != 5000 {
+		t.Errorf("expected values_now re-valued as of 2025-11-08, got %+v", now)
+	}
+	if later["7"] != 4000 || later["3"] != 3500 {
+		t.Errorf("expected values_later left as first taken, got %+v", later)
+	}
+	if r.HindsightGap == nil || *r.HindsightGap != 4000 {
+		t.Errorf("expected hindsight_gap 4000, got %v", r.HindsightGap)
+	}
+}
+
+func TestRunTradeRetrospectives_WaitsForLaterWeeks(t *testing.T) {
+	db := seedRetrospectiveTrade(t)
+
+	// Twelve weeks after the trade is past every seeded valuation.
+	if err := transactioncron.RunTradeRetrospectives(context.Background(), db, 200, 12); err != nil {
+		t.Fatalf("RunTradeRetrospectives error: %v", err)
+	}
+
+	var r models.TradeRetrospective
+	db.First(&r, "sleeper_transaction_id = ?", "tx1")
+	if len(r.ValuesLater) != 0 {
+		t.Errorf("expected values_later to stay null, got %s", r.ValuesLater)
+	}
+	if len(r.ValuesNow) == 0 {
+		t.Error("expected values_now to be filled in")
+	}
+}
+
+func TestRunTradeRetrospectives_ScoresRealizedPointsLikeTheSegment(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.AutoMigrate(&models.TradeRetrospective{}, &models.SleeperPlayerWeekStat{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	db.Create(&models.TradeRetrospective{
+		SleeperTransactionID: "tx1", Segment: "half_ppr-sf-10", Season: "2025", Leg: 1, LaterWeeks: 4,
+		TradedAt: time.Date(2025, 9, 3, 18, 0, 0, 0, time.UTC), Adds: json.RawMessage(`{"p1": 7}`),
+	})
+	db.Create(&valuation.Snapshot{Segment: "half_ppr-sf-10", SleeperPlayerID: "p1", ValuationDate: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), Value: 3000})
+	ppr, half := 20.0, 17.0
+	db.Create(&models.SleeperPlayerWeekStat{Season: "2025", Week: 1, SleeperPlayerID: "p1", PtsPPR: &ppr, PtsHalfPPR: &half})
+
+	if err := transactioncron.RunTradeRetrospectives(context.Background(), db, 200, 4); err != nil {
+		t.Fatalf("RunTradeRetrospectives error: %v", err)
+	}
+	var r models.TradeRetrospective
+	db.First(&r, "sleeper_transaction_id = ?", "tx1")
+	var points map[string]float64
+	json.Unmarshal(r.RealizedPoints, &points)
+	if points["7"] != 17 {
+		t.Errorf("expected half-PPR realized points of 17, got %+v", points)
+	}
+}
@@ -0,0 +1,151 @@
+package transactioncron_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"backend/internal/activities"
+	"backend/internal/models"
+	"backend/internal/sleeper"
+	"backend/internal/transactioncron"
+)
+
+// rosterServer serves lg1's rosters, counting calls, and no transactions.
+func rosterServer(t *testing.T, rosters []sleeper.Roster, rosterCalls *atomic.Int64) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/league/lg1/rosters":
+			rosterCalls.Add(1)
+			json.NewEncoder(w).Encode(rosters)
+		case strings.Contains(r.URL.Path, "/transactions/"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestFetchLeagueTransactions_FetchesRostersOnlyWhenDue(t *testing.T) {
+	db := newTestDB(t)
+	var rosterCalls atomic.Int64
+	srv := rosterServer(t, []sleeper.Roster{{RosterID: 1, OwnerID: "u1", Players: []string{"9509", "4046"}}}, &rosterCalls)
+	defer srv.Close()
+	dfa := &activities.DataFetchActivities{DB: db, Sleeper: sleeper.NewWithBaseURL(srv.URL)}
+
+	res, err := transactioncron.FetchLeagueTransactions(context.Background(), dfa,
+		transactioncron.LeagueTransactionState{LeagueID: "lg1", Season: "2026", RostersDue: true}, week3())
+	if err != nil {
+		t.Fatalf("FetchLeagueTransactions error: %v", err)
+	}
+	if !res.RostersFetched || len(res.Rosters) != 1 || string(res.Rosters[0].Players) != `["4046","9509"]` {
+		t.Errorf("expected one roster with sorted players, got fetched=%v rosters=%+v", res.RostersFetched, res.Rosters)
+	}
+
+	res, err = transactioncron.FetchLeagueTransactions(context.Background(), dfa,
+		transactioncron.LeagueTransactionState{LeagueID: "lg1", Season: "2026"}, week3())
+	if err != nil {
+		t.Fatalf("FetchLeagueTransactions error: %v", err)
+	}
+	if res.RostersFetched || rosterCalls.Load() != 1 {
+		t.Errorf("expected no rosters fetch when not due, got calls=%d", rosterCalls.Load())
+	}
+}
+
+func TestFlushLeagueTransactions_AppendsChangedRosterSnapshots(t *testing.T) {
+	db := newTestDB(t)
+	claimedLeague(t, db, "lg1")
+	dfa := &activities.DataFetchActivities{DB: db}
+
+	flush := func(takenAt time.Time, rosters []sleeper.Roster) {
+		t.Helper()
+		batch := []transactioncron.LeagueTransactionFetchResult{{
+			LeagueID:       "lg1",
+			Rosters:        snapshotsOf(t, takenAt, rosters),
+			RostersFetched: true,
+		}}
+		if err := transactioncron.FlushLeagueTransactions(context.Background(), dfa, db, batch); err != nil {
+			t.Fatalf("FlushLeagueTransactions error: %v", err)
+		}
+	}
+	day1 := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	flush(day1, []sleeper.Roster{
+		{RosterID: 1, OwnerID: "u1", Players: []string{"4046", "9509"}},
+		{RosterID: 2, OwnerID: "u2", Players: []string{"6794"}},
+	})
+	// A day later roster 1 is unchanged (in another order) and roster 2 added
+	// a player.
+	flush(day1.AddDate(0, 0, 1), []sleeper.Roster{
+		{RosterID: 1, OwnerID: "u1", Players: []string{"9509", "4046"}},
+		{RosterID: 2, OwnerID: "u2", Players: []string{"6794", "8146"}},
+	})
+
+	var snaps []models.SleeperRosterSnapshot
+	db.Order("roster_id, taken_at").Find(&snaps)
+	if len(snaps) != 3 {
+		t.Fatalf("expected roster 1 once and roster 2 twice, got %+v", snaps)
+	}
+	if snaps[0].RosterID != 1 || snaps[2].RosterID != 2 || string(snaps[2].Players) != `["6794","8146"]` {
+		t.Errorf("unexpected snapshots %+v", snaps)
+	}
+	var league models.SleeperLeague
+	db.First(&league, "sleeper_league_id = ?", "lg1")
+	if league.LastRostersFetchedAt == nil {
+		t.Error("expected last_rosters_fetched_at set")
+	}
+}
+
+// TestFlushLeagueTransactions_ComparesSnapshotsAsStoredByPostgres stores a
+// snapshot the way Postgres reads JSONB back, with a space after each
+// comma: an unchanged roster must still match it.
+func TestFlushLeagueTransactions_ComparesSnapshotsAsStoredByPostgres(t *testing.T) {
+	db := newTestDB(t)
+	claimedLeague(t, db, "lg1")
+	day1 := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	if err := db.Create(&models.SleeperRosterSnapshot{
+		SleeperLeagueID: "lg1", RosterID: 1, TakenAt: day1, OwnerID: "u1",
+		Players: json.RawMessage(`["4046", "9509"]`),
+	}).Error; err != nil {
+		t.Fatalf("seed snapshot: %v", err)
+	}
+
+	batch := []transactioncron.LeagueTransactionFetchResult{{
+		LeagueID:       "lg1",
+		Rosters:        snapshotsOf(t, day1.AddDate(0, 0, 1), []sleeper.Roster{{RosterID: 1, OwnerID: "u1", Players: []string{"9509", "4046"}}}),
+		RostersFetched: true,
+	}}
+	if err := transactioncron.FlushLeagueTransactions(context.Background(), &activities.DataFetchActivities{DB: db}, db, batch); err != nil {
+		t.Fatalf("FlushLeagueTransactions error: %v", err)
+	}
+	var n int64
+	db.Model(&models.SleeperRosterSnapshot{}).Count(&n)
+	if n != 1 {
+		t.Errorf("expected the unchanged roster to keep its one snapshot, got %d", n)
+	}
+}
+
+// snapshotsOf round-trips rosters through a fetch against a stub server, so
+// the flush sees exactly what FetchLeagueTransactions produces.
+func snapshotsOf(t *testing.T, takenAt time.Time, rosters []sleeper.Roster) []models.SleeperRosterSnapshot {
+	t.Helper()
+	var calls atomic.Int64
+	srv := rosterServer(t, rosters, &calls)
+	defer srv.Close()
+	dfa := &activities.DataFetchActivities{Sleeper: sleeper.NewWithBaseURL(srv.URL)}
+	res, err := transactioncron.FetchLeagueTransactions(context.Background(), dfa,
+		transactioncron.LeagueTransactionState{LeagueID: "lg1", Season: "2026", RostersDue: true}, week3())
+	if err != nil {
+		t.Fatalf("fetch rosters: %v", err)
+	}
+	for i := range res.Rosters {
+		res.Rosters[i].TakenAt = takenAt
+	}
+	return res.Rosters
+}
@@ -44,6 +44,15 @@ type Config struct {
 	// ReconcileTimeout is the sweep's own deadline, independent of the run's
 	// overall -max-duration — it must never be the reason a tick runs long.
 	ReconcileTimeout time.Duration `env:"CRON_TXN_RECONCILE_TIMEOUT_DURATION,default=5s,min=1s"`
+	// RetrospectiveLimit caps how many trades RunTradeRetrospectives seeds,
+	// and separately re-values, per tick.
+	RetrospectiveLimit int `env:"CRON_TXN_RETROSPECTIVE_LIMIT,default=200,min=1"`
+	// RetrospectiveLaterWeeks is how long after a trade its values_later is
+	// taken.
+	RetrospectiveLaterWeeks int `env:"CRON_TXN_RETROSPECTIVE_LATER_WEEKS,default=4,min=1"`
+	// RetrospectiveTimeout is RunTradeRetrospectives' own deadline, like
+	// ReconcileTimeout.
+	RetrospectiveTimeout time.Duration `env:"CRON_TXN_RETROSPECTIVE_TIMEOUT_DURATION,default=5s,min=1s"`
 }
 
 // LoadConfig reads Config from env.
@@ -98,6 +107,16 @@ func RunTransactionSync(ctx context.Context, dfa *activities.DataFetchActivities
 		}()
 	}
 
+	var retrospectiveErrCh chan error
+	if cfg.RetrospectiveLimit > 0 && cfg.RetrospectiveTimeout > 0 {
+		retrospectiveCtx, retrospectiveCancel := context.WithTimeout(ctx, cfg.RetrospectiveTimeout)
+		defer retrospectiveCancel()
+		retrospectiveErrCh = make(chan error, 1)
+		go func() {
+			retrospectiveErrCh <- RunTradeRetrospectives(retrospectiveCtx, dfa.DB, cfg.RetrospectiveLimit, cfg.RetrospectiveLaterWeeks)
+		}()
+	}
+
 	state, err := dfa.Sleeper.GetNFLState(ctx)
 	if err != nil {
 		logger.Warn("GetNFLState failed; falling back to full 18-leg sweep", "error", err)
@@ -150,6 +169,11 @@ func RunTransactionSync(ctx context.Context, dfa *activities.DataFetchActivities
 			logger.Warn("trade value reconciliation failed", "error", err)
 		}
 	}
+	if retrospectiveErrCh != nil {
+		if err := <-retrospectiveErrCh; err != nil {
+			logger.Warn("trade retrospective refresh failed", "error", err)
+		}
+	}
 
 	report := Report{
 		LeaguesProcessed: result.Processed,
@@ -3,6 +3,7 @@ package transactioncron
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	"time"
 
 	"gorm.io/gorm"
@@ -16,11 +17,13 @@ import (
 // resolved by the caller (it needs the trade's league settings, which this
 // package's two call sites fetch differently) and is "" for trades outside
 // the model's covered segments — computeTradeValuesForRows skips those
-// without touching the database.
+// without touching the database. Picks is the trade's draft_picks grouped by
+// receiving roster (valuation.GroupPicksByRoster).
 type tradeValuationInput struct {
 	ID        string
 	TradeTime time.Time
 	Adds      map[string]int
+	Picks     map[int][]valuation.PickKey
 	Segment   string
 }
 
@@ -30,14 +33,16 @@ type tradeValuationInput struct {
 // have Values non-nil (one side resolved) while Complete is still false
 // (another side is still pending), which is what lets ReconcileTradeValues
 // keep retrying it instead of treating it as settled just because
-// trade_values is non-null.
+// trade_values is non-null. The one exception is a trade whose picks predate
+// pick_valuations: see onlyPickSidesPending.
 type tradeValuationResult struct {
 	Values   json.RawMessage
 	Complete bool
 }
 
-// computeTradeValuesForRows batch-loads player_valuations once per distinct
-// segment present in inputs (not once per trade), then computes each
+// computeTradeValuesForRows batch-loads player_valuations and
+// pick_valuations once per distinct segment present in inputs (not once per
+// trade), then computes each
 // trade's per-side totals. Mirrors the batching handlers.GetSleeperTrades
 // used to do inline before this package took over — see
 // docs/superpowers/specs/2026-08-10-trade-valuation-totals-design.md.
@@ -49,6 +54,7 @@ func computeTradeValuesForRows(ctx context.Context, db *gorm.DB, inputs []tradeV
 	result := map[string]tradeValuationResult{}
 
 	playersBySegment := map[string]map[string]struct{}{}
+	picksBySegment := map[string]map[valuation.PickKey]struct{}{}
 	var minTime, maxTime time.Time
 	for _, in := range inputs {
 		if in.Segment == "" {
@@ -66,6 +72,14 @@ func computeTradeValuesForRows(ctx context.Context, db *gorm.DB, inputs []tradeV
 		for pid := range in.Adds {
 			playersBySegment[in.Segment][pid] = struct{}{}
 		}
+		for _, picks := range in.Picks {
+			for _, p := range picks {
+				if picksBySegment[in.Segment] == nil {
+					picksBySegment[in.Segment] = map[valuation.PickKey]struct{}{}
+				}
+				picksBySegment[in.Segment][p] = struct{}{}
+			}
+		}
 	}
 	if len(playersBySegment) == 0 {
 		return result
@@ -79,14 +93,50 @@ func computeTradeValuesForRows(ctx context.Context, db *gorm.DB, inputs []tradeV
 		}
 		historyBySegment[seg] = valuation.LoadSnapshotHistory(db.WithContext(ctx), seg, ids, minTime.Add(-valuation.FreshnessWindow), maxTime)
 	}
+	pickHistoryBySegment := map[string]map[valuation.PickKey][]valuation.Snapshot{}
+	firstPickValuation := map[string]time.Time{}
+	for seg, keySet := range picksBySegment {
+		keys := make([]valuation.PickKey, 0, len(keySet))
+		for k := range keySet {
+			keys = append(keys, k)
+		}
+		pickHistoryBySegment[seg] = valuation.LoadPickHistory(db.WithContext(ctx), seg, keys, minTime.Add(-valuation.FreshnessWindow), maxTime)
+		if first, ok := valuation.EarliestPickValuation(db.WithContext(ctx), seg); ok {
+			firstPickValuation[seg] = first
+		}
+	}
 
 	for _, in := range inputs {
 		if in.Segment == "" {
 			continue
 		}
 		rosterPlayers := valuation.GroupPlayersByRoster(in.Adds)
-		values, complete := valuation.ComputeTradeValues(rosterPlayers, in.TradeTime, historyBySegment[in.Segment])
+		values, complete := valuation.ComputeTradeValues(rosterPlayers, in.Picks, in.TradeTime, historyBySegment[in.Segment], pickHistoryBySegment[in.Segment])
+		if first, ok := firstPickValuation[in.Segment]; ok && !complete && in.TradeTime.Before(first) {
+			complete = onlyPickSidesPending(values, rosterPlayers, in.Picks)
+		}
 		result[in.ID] = tradeValuationResult{Values: values, Complete: complete}
 	}
 	return result
 }
+
+// onlyPickSidesPending reports whether every side missing from values
+// received a pick. It's asked of trades made before their segment's first
+// pick valuation: pick_valuations has no history to price those picks
+// from, so such a side will never resolve and the trade is settled with the
+// sides it has, rather than retried by ReconcileTradeValues forever. A side
+// of players alone may still resolve and keeps the trade pending.
+func onlyPickSidesPending(values json.RawMessage, rosterPlayers map[int][]string, rosterPicks map[int][]valuation.PickKey) bool {
+	totals := map[string]float64{}
+	if len(values) > 0 {
+		if err := json.Unmarshal(values, &totals); err != nil {
+			return false
+		}
+	}
+	for rosterID, playerIDs := range rosterPlayers {
+		if _, ok := totals[strconv.Itoa(rosterID)]; !ok && len(playerIDs) > 0 && len(rosterPicks[rosterID]) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -19,7 +19,7 @@ func newValuationTestDB(t *testing.T) *gorm.DB {
 	if err != nil {
 		t.Fatalf("open sqlite: %v", err)
 	}
-	if err := db.AutoMigrate(&valuation.Snapshot{}); err != nil {
+	if err := db.AutoMigrate(&valuation.Snapshot{}, &valuation.PickSnapshot{}); err != nil {
 		t.Fatalf("automigrate: %v", err)
 	}
 	return db
@@ -86,3 +86,28 @@ func TestComputeTradeValuesForRows_EmptyInputsReturnsEmptyMap(t *testing.T) {
 		t.Errorf("expected empty result, got %+v", got)
 	}
 }
+
+func TestComputeTradeValuesForRows_ValuesPicks(t *testing.T) {
+	db := newValuationTestDB(t)
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	db.Create(&valuation.Snapshot{Segment: "ppr-sf-10", SleeperPlayerID: "p1", ValuationDate: now.Add(-6 * time.Hour), Value: 5000})
+	db.Create(&valuation.PickSnapshot{Segment: "ppr-sf-10", Season: "2026", Round: 1, Slot: 0, ValuationDate: now.Add(-6 * time.Hour), Value: 3000})
+	db.Create(&valuation.PickSnapshot{Segment: "ppr-sf-10", Season: "2026", Round: 2, Slot: 0, ValuationDate: now.Add(-6 * time.Hour), Value: 1200})
+
+	picks := valuation.GroupPicksByRoster(json.RawMessage(`[
+		{"season": "2026", "round": 1, "roster_id": 7, "previous_owner_id": 7, "owner_id": 8},
+		{"season": "2026", "round": 2, "roster_id": 7, "previous_owner_id": 7, "owner_id": 8}]`))
+	got := computeTradeValuesForRows(context.Background(), db, []tradeValuationInput{
+		{ID: "tx-picks", TradeTime: now, Adds: map[string]int{"p1": 7}, Picks: picks, Segment: "ppr-sf-10"},
+	})
+
+	res := got["tx-picks"]
+	if !res.Complete {
+		t.Fatal("expected tx-picks Complete=true — both sides resolved")
+	}
+	var totals map[string]float64
+	json.Unmarshal(res.Values, &totals)
+	if totals["7"] != 5000 || totals["8"] != 4200 {
+		t.Errorf("expected {7:5000, 8:4200}, got %+v", totals)
+	}
+}
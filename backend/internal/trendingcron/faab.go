@@ -0,0 +1,179 @@
+package trendingcron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+)
+
+// faabRecentWindow is how far back RunFAABRollup looks for waiver claims to
+// decide which weeks to recompute. Any week with a claim inside it is
+// recomputed from all of that week's claims, not just the recent ones.
+const faabRecentWindow = 7 * 24 * time.Hour
+
+// FAABReport summarizes one RunFAABRollup call. Skipped counts bids left
+// out because their claim's adds didn't decode.
+type FAABReport struct {
+	Weeks   int
+	Bids    int
+	Skipped int
+	Rows    int
+}
+
+// faabWeek is one (season, week) with recent waiver activity.
+type faabWeek struct {
+	Season string `gorm:"column:season"`
+	Leg    int    `gorm:"column:leg"`
+}
+
+// bidRow is one winning bid and the league settings that segment it.
+type bidRow struct {
+	Adds         json.RawMessage `gorm:"column:adds"`
+	WaiverBid    int             `gorm:"column:waiver_bid"`
+	WaiverBidPct *float64        `gorm:"column:waiver_bid_pct"`
+	TotalRosters int             `gorm:"column:total_rosters"`
+	PPR          *float64        `gorm:"column:ppr"`
+	IsSuperflex  *bool           `gorm:"column:is_superflex"`
+}
+
+type faabKey struct {
+	segment  string
+	playerID string
+}
+
+type faabBids struct {
+	bids []float64
+	pcts []float64
+}
+
+// RunFAABRollup recomputes faab_bid_stats for every week with a winning
+// FAAB bid in the last faabRecentWindow, replacing each week's rows in one
+// transaction. Bids come from complete waiver claims with a captured
+// waiver_bid in leagues with fetched settings (the season is the league's).
+// Like RunMarketPulse it reads only cloud, so a week whose earliest claims
+// are older than the scavenger's retention — in practice only an
+// offseason-long leg 1 — is summarized from the claims still there.
+func RunFAABRollup(ctx context.Context, db *gorm.DB) (FAABReport, error) {
+	return runFAABRollup(ctx, db, time.Now().UTC())
+}
+
+func runFAABRollup(ctx context.Context, db *gorm.DB, now time.Time) (FAABReport, error) {
+	var weeks []faabWeek
+	if err := db.WithContext(ctx).Table("sleeper_transactions t").
+		Select("DISTINCT l.season, t.leg").
+		Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = t.sleeper_league_id").
+		Where("t.type = ? AND t.status = ? AND t.waiver_bid IS NOT NULL AND t.created_at_sleeper >= ?",
+			"waiver", "complete", now.Add(-faabRecentWindow).UnixMilli()).
+		Order("l.season, t.leg").
+		Scan(&weeks).Error; err != nil {
+		return FAABReport{}, fmt.Errorf("select recent FAAB weeks: %w", err)
+	}
+
+	var report FAABReport
+	for _, w := range weeks {
+		bids, skipped, rows, err := rollupFAABWeek(ctx, db, w, now)
+		if err != nil {
+			return report, fmt.Errorf("season %s week %d: %w", w.Season, w.Leg, err)
+		}
+		report.Weeks++
+		report.Bids += bids
+		report.Skipped += skipped
+		report.Rows += rows
+	}
+	return report, nil
+}
+
+// rollupFAABWeek replaces one week's faab_bid_stats rows, returning how many
+// bids it read, how many of those it skipped for undecodable adds, and how
+// many rows it wrote.
+func rollupFAABWeek(ctx context.Context, db *gorm.DB, w faabWeek, now time.Time) (int, int, int, error) {
+	var bids []bidRow
+	if err := db.WithContext(ctx).Table("sleeper_transactions t").
+		Select("t.adds, t.waiver_bid, t.waiver_bid_pct, l.total_rosters, l.ppr, l.is_superflex").
+		Joins("JOIN sleeper_leagues l ON l.sleeper_league_id = t.sleeper_league_id").
+		Where("t.type = ? AND t.status = ? AND t.waiver_bid IS NOT NULL AND l.season = ? AND t.leg = ?",
+			"waiver", "complete", w.Season, w.Leg).
+		Scan(&bids).Error; err != nil {
+		return 0, 0, 0, fmt.Errorf("select bids: %w", err)
+	}
+
+	skipped := 0
+	byPlayer := map[faabKey]*faabBids{}
+	for _, b := range bids {
+		var adds map[string]int
+		if len(b.Adds) > 0 {
+			if err := json.Unmarshal(b.Adds, &adds); err != nil {
+				skipped++
+				continue
+			}
+		}
+		segments := []string{models.TrendSegmentAll}
+		if seg, ok := models.ADPSegmentForLeague(b.TotalRosters, b.PPR, b.IsSuperflex); ok {
+			segments = append(segments, seg.Key())
+		}
+		for playerID := range adds {
+			for _, seg := range segments {
+				k := faabKey{seg, playerID}
+				fb, ok := byPlayer[k]
+				if !ok {
+					fb = &faabBids{}
+					byPlayer[k] = fb
+				}
+				fb.bids = append(fb.bids, float64(b.WaiverBid))
+				if b.WaiverBidPct != nil {
+					fb.pcts = append(fb.pcts, *b.WaiverBidPct)
+				}
+			}
+		}
+	}
+
+	records := make([]models.FAABBidStat, 0, len(byPlayer))
+	for k, fb := range byPlayer {
+		slices.Sort(fb.bids)
+		stat := models.FAABBidStat{
+			Segment: k.segment, Season: w.Season, Week: w.Leg, SleeperPlayerID: k.playerID,
+			Bids:       len(fb.bids),
+			MedianBid:  quantile(fb.bids, 0.5),
+			P75Bid:     quantile(fb.bids, 0.75),
+			P90Bid:     quantile(fb.bids, 0.9),
+			ComputedAt: now,
+		}
+		if len(fb.pcts) > 0 {
+			slices.Sort(fb.pcts)
+			median, p75, p90 := quantile(fb.pcts, 0.5), quantile(fb.pcts, 0.75), quantile(fb.pcts, 0.9)
+			stat.MedianBidPct, stat.P75BidPct, stat.P90BidPct = &median, &p75, &p90
+		}
+		records = append(records, stat)
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("season = ? AND week = ?", w.Season, w.Leg).Delete(&models.FAABBidStat{}).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&records, insertBatchSize).Error
+	})
+	if err != nil {
+		return len(bids), skipped, 0, fmt.Errorf("replace faab_bid_stats: %w", err)
+	}
+	return len(bids), skipped, len(records), nil
+}
+
+// quantile returns the q-th quantile of sorted (ascending, non-empty),
+// interpolating linearly between the closest ranks like percentile_cont.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lo := int(pos)
+	if lo+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lo] + (pos-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
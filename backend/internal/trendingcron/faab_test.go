@@ -0,0 +1,96 @@
+package trendingcron
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+)
+
+func seedBid(t *testing.T, db *gorm.DB, id, leagueID, status string, leg int, created time.Time, playerID string, bid int, pct *float64) {
+	t.Helper()
+	adds, _ := json.Marshal(map[string]int{playerID: 1})
+	if err := db.Create(&models.SleeperTransaction{
+		SleeperTransactionID: id, SleeperLeagueID: leagueID, Type: "waiver", Status: status,
+		CreatedAtSleeper: created.UnixMilli(), Leg: leg, Adds: adds, WaiverBid: &bid, WaiverBidPct: pct,
+	}).Error; err != nil {
+		t.Fatalf("seed bid %s: %v", id, err)
+	}
+}
+
+func TestQuantile_InterpolatesLikePercentileCont(t *testing.T) {
+	bids := []float64{1, 2, 3, 4, 10}
+	for _, tc := range []struct {
+		q    float64
+		want float64
+	}{{0.5, 3}, {0.75, 4}, {0.9, 7.6}, {1, 10}} {
+		if got := quantile(bids, tc.q); got < tc.want-1e-9 || got > tc.want+1e-9 {
+			t.Errorf("quantile(%v) = %v, want %v", tc.q, got, tc.want)
+		}
+	}
+	if got := quantile([]float64{7}, 0.9); got != 7 {
+		t.Errorf("expected a single bid to be every quantile, got %v", got)
+	}
+}
+
+func TestRunFAABRollup_RecomputesRecentWeeksBySegment(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.AutoMigrate(&models.FAABBidStat{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	now := time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC)
+	ppr, sf := 1.0, true
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "lg-ppr", Season: "2025", TotalRosters: 12, PPR: &ppr, IsSuperflex: &sf})
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "lg-odd", Season: "2025", TotalRosters: 11, PPR: &ppr, IsSuperflex: &sf})
+
+	pct := func(v float64) *float64 { return &v }
+	// Week 6: one claim is recent, so the whole week is recomputed,
+	// including the claim from 9 days ago.
+	seedBid(t, db, "a", "lg-ppr", "complete", 6, now.Add(-9*24*time.Hour), "p1", 10, pct(10))
+	seedBid(t, db, "b", "lg-ppr", "complete", 6, now.Add(-time.Hour), "p1", 30, pct(30))
+	seedBid(t, db, "c", "lg-odd", "complete", 6, now.Add(-time.Hour), "p1", 50, nil)
+	seedBid(t, db, "lost", "lg-ppr", "failed", 6, now.Add(-time.Hour), "p1", 99, pct(99))
+	// Week 3 has nothing recent: its stale row stays as it was.
+	seedBid(t, db, "old", "lg-ppr", "complete", 3, now.Add(-30*24*time.Hour), "p2", 5, pct(5))
+	db.Create(&models.FAABBidStat{Segment: "all", Season: "2025", Week: 3, SleeperPlayerID: "p2", Bids: 1, MedianBid: 5})
+	// A row that no longer has bids behind it is dropped with its week.
+	db.Create(&models.FAABBidStat{Segment: "all", Season: "2025", Week: 6, SleeperPlayerID: "gone", Bids: 1})
+
+	report, err := runFAABRollup(context.Background(), db, now)
+	if err != nil {
+		t.Fatalf("runFAABRollup: %v", err)
+	}
+	if report.Weeks != 1 || report.Bids != 3 || report.Rows != 2 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	var all models.FAABBidStat
+	db.Where("segment = ? AND season = ? AND week = ? AND sleeper_player_id = ?", "all", "2025", 6, "p1").First(&all)
+	if all.Bids != 3 || all.MedianBid != 30 || all.P75Bid != 40 || all.P90Bid != 46 || !all.ComputedAt.Equal(now) {
+		t.Errorf("expected p1's 3 winning bids 10/30/50 across leagues, got %+v", all)
+	}
+	// The 11-team league's bid has no budget, so only two percentages.
+	if all.MedianBidPct == nil || *all.MedianBidPct != 20 {
+		t.Errorf("expected a 20%% median over the bids with a budget, got %+v", all.MedianBidPct)
+	}
+	var seg models.FAABBidStat
+	db.Where("segment = ? AND week = ? AND sleeper_player_id = ?", "12-ppr-sf", 6, "p1").First(&seg)
+	if seg.Bids != 2 || seg.MedianBid != 20 {
+		t.Errorf("expected the 12-ppr-sf segment to see only its 2 bids, got %+v", seg)
+	}
+
+	var count int64
+	db.Model(&models.FAABBidStat{}).Where("sleeper_player_id = ?", "gone").Count(&count)
+	if count != 0 {
+		t.Errorf("expected the recomputed week's stale row removed, got %d", count)
+	}
+	var untouched models.FAABBidStat
+	db.Where("week = ? AND sleeper_player_id = ?", 3, "p2").First(&untouched)
+	if untouched.Bids != 1 || !untouched.ComputedAt.IsZero() {
+		t.Errorf("expected week 3 left alone, got %+v", untouched)
+	}
+}
@@ -0,0 +1,174 @@
+// Package trendingcron implements cmd/cron's "market-pulse" job: an hourly
+// rollup of the scraped Sleeper population's transactions into per-player
+// add, drop, waiver-claim and trade counts over rolling windows
+// (models.TrendWindows), per ADP segment and population-wide, served by GET
+// /api/v1/sleeper/trending — and of the winning FAAB bids on waiver claims
+// into per-player, per-week bid distributions (RunFAABRollup), served by GET
+// /api/v1/sleeper/faab. It reads only cloud: every window is far inside the
+// scavenger's retention, so no transaction it needs has moved to the archive
+// yet.
+package trendingcron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+)
+
+// insertBatchSize bounds each player_trends or faab_bid_stats insert
+// statement.
+const insertBatchSize = 1000
+
+// Report summarizes one RunMarketPulse call. Skipped counts transactions
+// left out of the rollup because their adds or drops didn't decode.
+type Report struct {
+	Transactions int
+	Skipped      int
+	Rows         int
+}
+
+// txnRow is the subset of a transaction and its league the rollup reads.
+// The league columns are nil when the league's settings haven't been
+// fetched, which leaves the transaction counted only under
+// models.TrendSegmentAll.
+type txnRow struct {
+	Type             string          `gorm:"column:type"`
+	CreatedAtSleeper int64           `gorm:"column:created_at_sleeper"`
+	Adds             json.RawMessage `gorm:"column:adds"`
+	Drops            json.RawMessage `gorm:"column:drops"`
+	TotalRosters     *int            `gorm:"column:total_rosters"`
+	PPR              *float64        `gorm:"column:ppr"`
+	IsSuperflex      *bool           `gorm:"column:is_superflex"`
+}
+
+type trendKey struct {
+	segment  string
+	window   string
+	playerID string
+}
+
+// RunMarketPulse recomputes player_trends from every complete transaction
+// inside the widest window and replaces the table's contents in one
+// transaction, so readers never see a half-written rollup.
+func RunMarketPulse(ctx context.Context, db *gorm.DB) (Report, error) {
+	return runMarketPulse(ctx, db, time.Now().UTC())
+}
+
+func runMarketPulse(ctx context.Context, db *gorm.DB, now time.Time) (Report, error) {
+	var widest time.Duration
+	for _, d := range models.TrendWindows {
+		widest = max(widest, d)
+	}
+
+	rows, err := db.WithContext(ctx).Table("sleeper_transactions t").
+		Select("t.type, t.created_at_sleeper, t.adds, t.drops, l.total_rosters, l.ppr, l.is_superflex").
+		Joins("LEFT JOIN sleeper_leagues l ON l.sleeper_league_id = t.sleeper_league_id").
+		Where("t.status = ? AND t.type IN ? AND t.created_at_sleeper >= ?",
+			"complete", []string{"free_agent", "waiver", "trade"}, now.Add(-widest).UnixMilli()).
+		Rows()
+	if err != nil {
+		return Report{}, fmt.Errorf("select transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var report Report
+	counts := map[trendKey]*models.PlayerTrend{}
+	for rows.Next() {
+		var r txnRow
+		if err := db.ScanRows(rows, &r); err != nil {
+			return report, fmt.Errorf("scan transaction: %w", err)
+		}
+		report.Transactions++
+		if err := tally(counts, r, now); err != nil {
+			report.Skipped++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return report, fmt.Errorf("read transactions: %w", err)
+	}
+
+	records := make([]models.PlayerTrend, 0, len(counts))
+	for _, t := range counts {
+		t.ComputedAt = now
+		records = append(records, *t)
+	}
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&models.PlayerTrend{}).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&records, insertBatchSize).Error
+	})
+	if err != nil {
+		return report, fmt.Errorf("replace player_trends: %w", err)
+	}
+	report.Rows = len(records)
+	return report, nil
+}
+
+// tally adds one transaction's moves to counts under every window it falls
+// inside, for both its league's segment (when it has one) and
+// models.TrendSegmentAll. A transaction whose adds or drops don't decode
+// adds nothing and returns the error.
+func tally(counts map[trendKey]*models.PlayerTrend, r txnRow, now time.Time) error {
+	var adds, drops map[string]int
+	if len(r.Adds) > 0 {
+		if err := json.Unmarshal(r.Adds, &adds); err != nil {
+			return fmt.Errorf("adds: %w", err)
+		}
+	}
+	if len(r.Drops) > 0 {
+		if err := json.Unmarshal(r.Drops, &drops); err != nil {
+			return fmt.Errorf("drops: %w", err)
+		}
+	}
+
+	segments := []string{models.TrendSegmentAll}
+	if r.TotalRosters != nil {
+		if seg, ok := models.ADPSegmentForLeague(*r.TotalRosters, r.PPR, r.IsSuperflex); ok {
+			segments = append(segments, seg.Key())
+		}
+	}
+	age := now.Sub(time.UnixMilli(r.CreatedAtSleeper))
+
+	for window, d := range models.TrendWindows {
+		if age > d {
+			continue
+		}
+		for _, seg := range segments {
+			get := func(playerID string) *models.PlayerTrend {
+				k := trendKey{seg, window, playerID}
+				t, ok := counts[k]
+				if !ok {
+					t = &models.PlayerTrend{Segment: seg, TimeWindow: window, SleeperPlayerID: playerID}
+					counts[k] = t
+				}
+				return t
+			}
+			if r.Type == "trade" {
+				for playerID := range adds {
+					get(playerID).Trades++
+				}
+				continue
+			}
+			for playerID := range adds {
+				t := get(playerID)
+				t.Adds++
+				if r.Type == "waiver" {
+					t.WaiverClaims++
+				}
+			}
+			for playerID := range drops {
+				get(playerID).Drops++
+			}
+		}
+	}
+	return nil
+}
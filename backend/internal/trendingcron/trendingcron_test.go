@@ -0,0 +1,128 @@
+package trendingcron
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"backend/internal/models"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.SleeperLeague{}, &models.SleeperTransaction{}, &models.PlayerTrend{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return db
+}
+
+func seedTxn(t *testing.T, db *gorm.DB, id, leagueID, txnType, status string, created time.Time, adds, drops map[string]int) {
+	t.Helper()
+	addsJSON, _ := json.Marshal(adds)
+	dropsJSON, _ := json.Marshal(drops)
+	if err := db.Create(&models.SleeperTransaction{
+		SleeperTransactionID: id, SleeperLeagueID: leagueID, Type: txnType, Status: status,
+		CreatedAtSleeper: created.UnixMilli(), Adds: addsJSON, Drops: dropsJSON,
+	}).Error; err != nil {
+		t.Fatalf("seed txn %s: %v", id, err)
+	}
+}
+
+func trendFor(t *testing.T, db *gorm.DB, segment, window, playerID string) models.PlayerTrend {
+	t.Helper()
+	var tr models.PlayerTrend
+	db.Where("segment = ? AND time_window = ? AND sleeper_player_id = ?", segment, window, playerID).Find(&tr)
+	return tr
+}
+
+func TestRunMarketPulse_CountsByWindowAndSegment(t *testing.T) {
+	db := newTestDB(t)
+	now := time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC)
+	ppr, sf := 1.0, true
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "lg-ppr", TotalRosters: 12, PPR: &ppr, IsSuperflex: &sf})
+	db.Create(&models.SleeperLeague{SleeperLeagueID: "lg-odd", TotalRosters: 11, PPR: &ppr, IsSuperflex: &sf})
+
+	seedTxn(t, db, "w1", "lg-ppr", "waiver", "complete", now.Add(-2*time.Hour), map[string]int{"p1": 1}, map[string]int{"p2": 1})
+	seedTxn(t, db, "f1", "lg-odd", "free_agent", "complete", now.Add(-3*24*time.Hour), map[string]int{"p1": 4}, nil)
+	seedTxn(t, db, "t1", "lg-ppr", "trade", "complete", now.Add(-time.Hour), map[string]int{"p3": 1, "p1": 2}, map[string]int{"p3": 2, "p1": 1})
+	// Not counted: a failed claim, and a move outside the widest window.
+	seedTxn(t, db, "w2", "lg-ppr", "waiver", "failed", now.Add(-time.Hour), map[string]int{"p1": 1}, nil)
+	seedTxn(t, db, "f2", "lg-ppr", "free_agent", "complete", now.Add(-8*24*time.Hour), map[string]int{"p1": 1}, nil)
+
+	report, err := runMarketPulse(context.Background(), db, now)
+	if err != nil {
+		t.Fatalf("runMarketPulse: %v", err)
+	}
+	if report.Transactions != 3 {
+		t.Errorf("expected 3 transactions in the 7d window, got %+v", report)
+	}
+
+	if got := trendFor(t, db, "all", "24h", "p1"); got.Adds != 1 || got.WaiverClaims != 1 || got.Trades != 1 {
+		t.Errorf("expected p1 24h: 1 add (a waiver claim) and 1 trade, got %+v", got)
+	}
+	if got := trendFor(t, db, "all", "7d", "p1"); got.Adds != 2 || got.WaiverClaims != 1 {
+		t.Errorf("expected p1 7d: 2 adds, got %+v", got)
+	}
+	// The 11-team league has no ADP segment, so its add is only in "all".
+	if got := trendFor(t, db, "12-ppr-sf", "7d", "p1"); got.Adds != 1 {
+		t.Errorf("expected p1 12-ppr-sf 7d: 1 add, got %+v", got)
+	}
+	if got := trendFor(t, db, "12-ppr-sf", "24h", "p2"); got.Drops != 1 {
+		t.Errorf("expected p2 dropped once, got %+v", got)
+	}
+	// A trade's drops side is the same players moving, not drops.
+	if got := trendFor(t, db, "all", "24h", "p3"); got.Trades != 1 || got.Drops != 0 {
+		t.Errorf("expected p3 traded once and not dropped, got %+v", got)
+	}
+	if got := trendFor(t, db, "all", "24h", "p1"); !got.ComputedAt.Equal(now) {
+		t.Errorf("expected computed_at %s, got %s", now, got.ComputedAt)
+	}
+}
+
+func TestRunMarketPulse_ReplacesPreviousRollup(t *testing.T) {
+	db := newTestDB(t)
+	now := time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC)
+	db.Create(&models.PlayerTrend{Segment: "all", TimeWindow: "24h", SleeperPlayerID: "stale", Adds: 9, ComputedAt: now.Add(-time.Hour)})
+	seedTxn(t, db, "w1", "lg", "waiver", "complete", now.Add(-time.Hour), map[string]int{"p1": 1}, nil)
+
+	report, err := runMarketPulse(context.Background(), db, now)
+	if err != nil {
+		t.Fatalf("runMarketPulse: %v", err)
+	}
+	var count int64
+	db.Model(&models.PlayerTrend{}).Count(&count)
+	// p1 in "all" for both windows; its league isn't known, so no segment.
+	if report.Rows != 2 || count != 2 {
+		t.Errorf("expected the stale row replaced by p1's 2 rows, got report %+v and %d rows", report, count)
+	}
+}
+
+func TestRunMarketPulse_SkipsUndecodableMoves(t *testing.T) {
+	db := newTestDB(t)
+	now := time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC)
+	seedTxn(t, db, "w1", "lg", "waiver", "complete", now.Add(-time.Hour), map[string]int{"p1": 1}, nil)
+	db.Create(&models.SleeperTransaction{
+		SleeperTransactionID: "bad", SleeperLeagueID: "lg", Type: "free_agent", Status: "complete",
+		CreatedAtSleeper: now.Add(-time.Hour).UnixMilli(), Adds: json.RawMessage(`{"p1": 1}`), Drops: json.RawMessage(`["p2"]`),
+	})
+
+	report, err := runMarketPulse(context.Background(), db, now)
+	if err != nil {
+		t.Fatalf("runMarketPulse: %v", err)
+	}
+	if report.Transactions != 2 || report.Skipped != 1 {
+		t.Errorf("expected 2 transactions with 1 skipped, got %+v", report)
+	}
+	if got := trendFor(t, db, "all", "24h", "p1"); got.Adds != 1 {
+		t.Errorf("expected only the decodable claim's add counted, got %+v", got)
+	}
+}
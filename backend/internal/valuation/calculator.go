@@ -0,0 +1,48 @@
+package valuation
+
+import "math"
+
+// FairnessTolerance is how far apart, as a fraction of the larger side's
+// total, two sides of a trade can be and still count as even. Valuations are
+// noisy enough that chasing the last few percent isn't meaningful.
+const FairnessTolerance = 0.05
+
+// SideTotal sums one side of a calculated trade. Dispersion combines its
+// assets' market dispersions as independent errors (root sum of squares), so
+// a gap smaller than the sides' dispersions is within the market's noise.
+// Assets without a dispersion or projection add nothing to those totals.
+type SideTotal struct {
+	Value        float64
+	Dispersion   float64
+	ProjectedPAR float64
+}
+
+// SumSide totals one side's valued snapshots.
+func SumSide(snaps []Snapshot) SideTotal {
+	var t SideTotal
+	var variance float64
+	for _, s := range snaps {
+		t.Value += s.Value
+		if s.MarketDispersion != nil {
+			variance += *s.MarketDispersion * *s.MarketDispersion
+		}
+		if s.ProjectedPAR != nil {
+			t.ProjectedPAR += *s.ProjectedPAR
+		}
+	}
+	t.Dispersion = math.Sqrt(variance)
+	return t
+}
+
+// IsEven reports whether two side totals are within FairnessTolerance of
+// each other.
+func IsEven(a, b float64) bool {
+	return math.Abs(a-b) <= FairnessTolerance*math.Max(a, b)
+}
+
+// BalancingThreshold is the least value that, added to the lighter side,
+// makes a trade even: the gap less the tolerance the heavier side allows.
+// 0 when the trade is already even.
+func BalancingThreshold(heavier, lighter float64) float64 {
+	return math.Max(0, heavier-lighter-FairnessTolerance*heavier)
+}
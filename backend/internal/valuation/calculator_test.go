@@ -0,0 +1,38 @@
+package valuation_test
+
+import (
+	"math"
+	"testing"
+
+	"backend/internal/valuation"
+)
+
+func TestSumSide(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	total := valuation.SumSide([]valuation.Snapshot{
+		{Value: 3000, MarketDispersion: f(300), ProjectedPAR: f(4)},
+		{Value: 1000, MarketDispersion: f(400)},
+		{Value: 500},
+	})
+	if total.Value != 4500 || total.ProjectedPAR != 4 {
+		t.Errorf("expected value 4500 and PAR 4, got %+v", total)
+	}
+	if math.Abs(total.Dispersion-500) > 1e-9 {
+		t.Errorf("expected dispersions 300 and 400 to combine to 500, got %v", total.Dispersion)
+	}
+}
+
+func TestIsEvenAndBalancingThreshold(t *testing.T) {
+	if !valuation.IsEven(1000, 960) || valuation.IsEven(1000, 900) {
+		t.Error("expected a 4% gap even and a 10% gap not")
+	}
+	if got := valuation.BalancingThreshold(1000, 900); math.Abs(got-50) > 1e-9 {
+		t.Errorf("expected 50 to balance 1000 against 900, got %v", got)
+	}
+	if !valuation.IsEven(1000, 900+valuation.BalancingThreshold(1000, 900)) {
+		t.Error("expected adding the threshold to make the trade even")
+	}
+	if got := valuation.BalancingThreshold(1000, 980); got != 0 {
+		t.Errorf("expected nothing needed for an even trade, got %v", got)
+	}
+}
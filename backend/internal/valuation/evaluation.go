@@ -0,0 +1,123 @@
+package valuation
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Season is one NFL season's calendar as the valuation model keys it: the
+// model's clock starts at DraftDate, and week W's scores reach it
+// ScoreLagDays after that week's kickoff (see WeekScored).
+type Season struct {
+	DraftDate time.Time
+	Start     time.Time // week 1 kickoff
+}
+
+// ScoreLagDays is how long after a week's kickoff its scores land.
+const ScoreLagDays = 4
+
+// Seasons mirrors analysis/src/config.py's SEASONS; a season the model
+// replays must be added to both.
+var Seasons = map[string]Season{
+	"2025": {DraftDate: time.Date(2025, 8, 25, 0, 0, 0, 0, time.UTC), Start: time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC)},
+	"2026": {DraftDate: time.Date(2026, 8, 24, 0, 0, 0, 0, time.UTC), Start: time.Date(2026, 9, 10, 0, 0, 0, 0, time.UTC)},
+}
+
+// WeekScored returns when week's scores reach the model: a snapshot dated
+// before it hasn't seen the week.
+func (s Season) WeekScored(week int) time.Time {
+	return s.Start.AddDate(0, 0, (week-1)*7+ScoreLagDays)
+}
+
+// replacementPerTeam is how many players per team at each position start
+// ahead of weekly replacement level in a 1QB league — the per-team ratios
+// behind analysis/src/config.py's repl_rank_by_pos.
+var replacementPerTeam = map[string]float64{"QB": 1, "RB": 2.5, "WR": 3, "TE": 1, "K": 1, "DEF": 1}
+
+// defaultReplacementRank is the replacement rank at a position outside
+// replacementPerTeam, as in analysis/src/performance.py.
+const defaultReplacementRank = 24
+
+// ReplacementRank returns the segment's weekly replacement rank at
+// position: the Nth-best scorer that week is replacement level. Superflex
+// leagues start two QBs a team.
+func (s Segment) ReplacementRank(position string) int {
+	per, ok := replacementPerTeam[position]
+	if !ok {
+		return defaultReplacementRank
+	}
+	if position == "QB" && s.Superflex {
+		per = 2
+	}
+	return int(math.Round(per * float64(s.Teams())))
+}
+
+// WeekScore is one player's points in one week.
+type WeekScore struct {
+	SleeperPlayerID string
+	Position        string
+	Points          float64
+}
+
+// ReplacementLevels returns each position's replacement score in one week's
+// scores: the ReplacementRank-th best, or the worst when fewer scored, the
+// way the model's performance tracker sets it.
+func (s Segment) ReplacementLevels(scores []WeekScore) map[string]float64 {
+	byPos := map[string][]float64{}
+	for _, w := range scores {
+		byPos[w.Position] = append(byPos[w.Position], w.Points)
+	}
+	levels := make(map[string]float64, len(byPos))
+	for pos, pts := range byPos {
+		sort.Sort(sort.Reverse(sort.Float64Slice(pts)))
+		n := min(s.ReplacementRank(pos), len(pts))
+		levels[pos] = pts[n-1]
+	}
+	return levels
+}
+
+// RealizedPAR is a player's mean weekly points above replacement over
+// weeks, given their points and each week's replacement levels by week.
+// Like projected_par it's a per-game rate, so weeks the player didn't score
+// are skipped; ok is false when they scored in none.
+func RealizedPAR(points map[int]float64, replacement map[int]map[string]float64, position string, weeks []int) (float64, bool) {
+	var total float64
+	var games int
+	for _, w := range weeks {
+		p, ok := points[w]
+		if !ok {
+			continue
+		}
+		total += p - replacement[w][position]
+		games++
+	}
+	if games == 0 {
+		return 0, false
+	}
+	return total / float64(games), true
+}
+
+// Evaluation accumulates how snapshots' projected_par compared with the
+// PAR their players went on to realize.
+type Evaluation struct {
+	Count       int
+	SumAbsError float64
+	SumError    float64 // projected less realized
+	Within1     int     // realized within one projection_uncertainty
+	Within2     int     // realized within two
+}
+
+// Add records one snapshot's projection against its realized PAR.
+func (e *Evaluation) Add(projected, uncertainty, realized float64) {
+	err := projected - realized
+	e.Count++
+	e.SumAbsError += math.Abs(err)
+	e.SumError += err
+	if math.Abs(err) <= uncertainty {
+		e.Within1++
+	}
+	if math.Abs(err) <= 2*uncertainty {
+		e.Within2++
+	}
+}
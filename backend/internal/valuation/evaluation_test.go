@@ -0,0 +1,65 @@
+package valuation_test
+
+import (
+	"testing"
+	"time"
+
+	"backend/internal/valuation"
+)
+
+func TestReplacementRank_MatchesModelSegments(t *testing.T) {
+	sf10, _ := valuation.ParseSegment("ppr-sf-10")
+	for pos, want := range map[string]int{"QB": 20, "RB": 25, "WR": 30, "TE": 10, "K": 10, "DEF": 10, "LB": 24} {
+		if got := sf10.ReplacementRank(pos); got != want {
+			t.Errorf("ppr-sf-10 %s: expected %d, got %d", pos, want, got)
+		}
+	}
+	oneQB, _ := valuation.ParseSegment("half_ppr-1qb-12-dynasty")
+	if got := oneQB.ReplacementRank("QB"); got != 12 {
+		t.Errorf("expected a 1QB league's QB rank to be 12, got %d", got)
+	}
+}
+
+func TestReplacementLevels(t *testing.T) {
+	seg, _ := valuation.ParseSegment("ppr-sf-8") // TE rank 8, QB rank 16
+	var scores []valuation.WeekScore
+	for i := range 10 {
+		scores = append(scores, valuation.WeekScore{Position: "TE", Points: float64(i)})
+	}
+	scores = append(scores, valuation.WeekScore{Position: "QB", Points: 20}, valuation.WeekScore{Position: "QB", Points: 12})
+	levels := seg.ReplacementLevels(scores)
+	if levels["TE"] != 2 {
+		t.Errorf("expected the 8th-best TE score, 2, got %v", levels["TE"])
+	}
+	if levels["QB"] != 12 {
+		t.Errorf("expected the worst QB score when fewer than 16 scored, got %v", levels["QB"])
+	}
+}
+
+func TestRealizedPAR_SkipsWeeksNotPlayed(t *testing.T) {
+	replacement := map[int]map[string]float64{1: {"WR": 10}, 2: {"WR": 8}, 3: {"WR": 12}}
+	got, ok := valuation.RealizedPAR(map[int]float64{1: 20, 3: 14}, replacement, "WR", []int{1, 2, 3})
+	if !ok || got != 6 {
+		t.Errorf("expected a mean PAR of 6 over the two weeks played, got %v %v", got, ok)
+	}
+	if _, ok := valuation.RealizedPAR(map[int]float64{4: 20}, replacement, "WR", []int{1, 2, 3}); ok {
+		t.Error("expected no realized PAR for a player who didn't score")
+	}
+}
+
+func TestEvaluation_Add(t *testing.T) {
+	var e valuation.Evaluation
+	e.Add(10, 2, 9)  // within one band
+	e.Add(10, 2, 13) // within two
+	e.Add(10, 2, 16) // outside both
+	if e.Count != 3 || e.SumAbsError != 10 || e.SumError != -8 || e.Within1 != 1 || e.Within2 != 2 {
+		t.Errorf("unexpected evaluation %+v", e)
+	}
+}
+
+func TestSeason_WeekScored(t *testing.T) {
+	s := valuation.Seasons["2025"]
+	if got := s.WeekScored(2); !got.Equal(time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected week 2 scored on 2025-09-15, got %v", got)
+	}
+}
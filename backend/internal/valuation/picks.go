@@ -0,0 +1,238 @@
+package valuation
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FuturePickSeasons is how many seasons past the ADP season picks are valued
+// for — the same horizon Sleeper lets owners trade picks over.
+const FuturePickSeasons = 3
+
+// FuturePickDiscount is applied once per season a pick lies beyond the ADP
+// season it's valued from: a pick further out is worth less today, both for
+// the wait and for the uncertainty in where it will land.
+const FuturePickDiscount = 0.85
+
+// pickValueNeighbors is how many players, by ADP closest to a pick's overall
+// position, are averaged into that pick's value. One player alone would make
+// each slot hostage to a single player's valuation; a handful smooths that
+// out without blurring adjacent rounds together.
+const pickValueNeighbors = 5
+
+// PickKey identifies one draft pick in a segment's pick valuations. Slot is
+// the pick's position within its round, or 0 when the draft order isn't
+// known yet — a future pick, or one traded before its draft's order was set.
+type PickKey struct {
+	Season string
+	Round  int
+	Slot   int
+}
+
+// PickSnapshot is one dated draft pick valuation, read from and written to
+// pick_valuations. Unlike Snapshot, Go is the writer: see
+// ComputePickSlotValues.
+type PickSnapshot struct {
+	Segment       string    `gorm:"primaryKey;column:segment"`
+	Season        string    `gorm:"primaryKey;column:season"`
+	Round         int       `gorm:"primaryKey;column:round"`
+	Slot          int       `gorm:"primaryKey;column:slot"`
+	ValuationDate time.Time `gorm:"primaryKey;column:valuation_date"`
+	Value         float64   `gorm:"column:value"`
+}
+
+func (PickSnapshot) TableName() string { return "pick_valuations" }
+
+// ADPSegmentFor maps a valuation segment to the draft_adp segment its pick
+// values are derived from (see Segment.ADPSegment), or "" for a key that
+// isn't a segment.
+func ADPSegmentFor(segment string) string {
+	s, ok := ParseSegment(segment)
+	if !ok {
+		return ""
+	}
+	return s.ADPSegment().Key()
+}
+
+// TeamsInSegment returns the league size a valuation segment covers, or 0
+// for a key that isn't a segment.
+func TeamsInSegment(segment string) int {
+	s, _ := ParseSegment(segment)
+	return s.Teams()
+}
+
+// ADPEntry is one player's average draft position within a season's drafts.
+type ADPEntry struct {
+	SleeperPlayerID string
+	AvgPickNo       float64
+}
+
+// PickSlotValue is the value of one (round, slot) in a draft; Slot 0 is the
+// round's average.
+type PickSlotValue struct {
+	Round int
+	Slot  int
+	Value float64
+}
+
+// ComputePickSlotValues values every pick in a teams-sized draft from the
+// players who typically go there: a pick's value is the mean current value
+// of the pickValueNeighbors valued players whose ADP is closest to its
+// overall position. ADP comes from completed drafts, so this is what a
+// drafter at that slot has historically come away with, priced at today's
+// player values. Players without a value (kickers, defenses, anyone the
+// model doesn't cover) are skipped. Only rounds the ADP pool reaches all the
+// way through are valued, and each valued round also gets a slot-0 entry
+// holding its average.
+func ComputePickSlotValues(adp []ADPEntry, playerValues map[string]float64, teams int) []PickSlotValue {
+	if teams <= 0 {
+		return nil
+	}
+	ranked := make([]ADPEntry, 0, len(adp))
+	for _, e := range adp {
+		if _, ok := playerValues[e.SleeperPlayerID]; ok {
+			ranked = append(ranked, e)
+		}
+	}
+	if len(ranked) < pickValueNeighbors {
+		return nil
+	}
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].AvgPickNo < ranked[j].AvgPickNo })
+	depth := ranked[len(ranked)-1].AvgPickNo
+
+	var values []PickSlotValue
+	for round := 1; float64(round*teams) <= depth; round++ {
+		var roundTotal float64
+		for slot := 1; slot <= teams; slot++ {
+			overall := float64((round-1)*teams + slot)
+			v := meanNearest(ranked, overall, playerValues)
+			values = append(values, PickSlotValue{Round: round, Slot: slot, Value: v})
+			roundTotal += v
+		}
+		values = append(values, PickSlotValue{Round: round, Slot: 0, Value: roundTotal / float64(teams)})
+	}
+	return values
+}
+
+// meanNearest averages the values of the pickValueNeighbors entries in
+// ranked (sorted by AvgPickNo) closest to overall.
+func meanNearest(ranked []ADPEntry, overall float64, playerValues map[string]float64) float64 {
+	hi := sort.Search(len(ranked), func(i int) bool { return ranked[i].AvgPickNo >= overall })
+	lo := hi - 1
+	var total float64
+	for n := 0; n < pickValueNeighbors; n++ {
+		if lo < 0 || (hi < len(ranked) && ranked[hi].AvgPickNo-overall <= overall-ranked[lo].AvgPickNo) {
+			total += playerValues[ranked[hi].SleeperPlayerID]
+			hi++
+		} else {
+			total += playerValues[ranked[lo].SleeperPlayerID]
+			lo--
+		}
+	}
+	return total / pickValueNeighbors
+}
+
+// PickSeasonValues expands one ADP season's slot values into pick valuations
+// for that season and the FuturePickSeasons after it, discounted by
+// FuturePickDiscount per season out.
+func PickSeasonValues(segment, adpSeason string, valuationDate time.Time, slots []PickSlotValue) []PickSnapshot {
+	year, err := strconv.Atoi(adpSeason)
+	if err != nil {
+		return nil
+	}
+	snaps := make([]PickSnapshot, 0, len(slots)*(FuturePickSeasons+1))
+	for ahead := 0; ahead <= FuturePickSeasons; ahead++ {
+		discount := math.Pow(FuturePickDiscount, float64(ahead))
+		for _, s := range slots {
+			snaps = append(snaps, PickSnapshot{
+				Segment:       segment,
+				Season:        strconv.Itoa(year + ahead),
+				Round:         s.Round,
+				Slot:          s.Slot,
+				ValuationDate: valuationDate,
+				Value:         s.Value * discount,
+			})
+		}
+	}
+	return snaps
+}
+
+// LoadPickHistory is LoadSnapshotHistory for draft picks: one segment's pick
+// valuations dated within [from, upTo] for the given picks, grouped per pick
+// and sorted by date ascending so ValueAsOf can resolve them.
+func LoadPickHistory(db *gorm.DB, segment string, picks []PickKey, from, upTo time.Time) map[PickKey][]Snapshot {
+	history := map[PickKey][]Snapshot{}
+	if segment == "" || len(picks) == 0 {
+		return history
+	}
+	seasonSet := map[string]struct{}{}
+	for _, p := range picks {
+		seasonSet[p.Season] = struct{}{}
+	}
+	seasons := make([]string, 0, len(seasonSet))
+	for s := range seasonSet {
+		seasons = append(seasons, s)
+	}
+	var snaps []PickSnapshot
+	db.Table("pick_valuations").
+		Where("segment = ? AND season IN ? AND valuation_date >= ? AND valuation_date <= ?", segment, seasons, from, upTo).
+		Order("season, round, slot, valuation_date ASC").
+		Scan(&snaps)
+	wanted := make(map[PickKey]bool, len(picks))
+	for _, p := range picks {
+		wanted[p] = true
+	}
+	for _, s := range snaps {
+		k := PickKey{Season: s.Season, Round: s.Round, Slot: s.Slot}
+		if wanted[k] {
+			history[k] = append(history[k], Snapshot{Segment: s.Segment, ValuationDate: s.ValuationDate, Value: s.Value})
+		}
+	}
+	return history
+}
+
+// GroupPicksByRoster decodes a trade's draft_picks (the shape of
+// sleeper_transactions.draft_picks) into receiving roster_id -> picks. A pick
+// carries its slot only when the draft order was already set when the trade
+// was ingested (transactioncron stamps it in); any other is keyed at slot 0.
+func GroupPicksByRoster(draftPicks json.RawMessage) map[int][]PickKey {
+	rosters := map[int][]PickKey{}
+	if len(draftPicks) == 0 {
+		return rosters
+	}
+	var picks []struct {
+		Season  string `json:"season"`
+		Round   int    `json:"round"`
+		Slot    int    `json:"slot"`
+		OwnerID int    `json:"owner_id"`
+	}
+	if err := json.Unmarshal(draftPicks, &picks); err != nil {
+		return rosters
+	}
+	for _, p := range picks {
+		rosters[p.OwnerID] = append(rosters[p.OwnerID], PickKey{Season: p.Season, Round: p.Round, Slot: p.Slot})
+	}
+	return rosters
+}
+
+// EarliestPickValuation returns the oldest valuation_date in segment's pick
+// valuations, false when it has none. pick_valuations only runs forward from
+// the first rollup, so a pick traded before this date can never be valued.
+func EarliestPickValuation(db *gorm.DB, segment string) (time.Time, bool) {
+	var first []PickSnapshot
+	db.Table("pick_valuations").
+		Select("valuation_date").
+		Where("segment = ?", segment).
+		Order("valuation_date ASC").
+		Limit(1).
+		Scan(&first)
+	if len(first) == 0 {
+		return time.Time{}, false
+	}
+	return first[0].ValuationDate, true
+}
@@ -0,0 +1,111 @@
+package valuation_test
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"backend/internal/valuation"
+)
+
+func TestADPSegmentFor(t *testing.T) {
+	if got := valuation.ADPSegmentFor("ppr-sf-10"); got != "10-ppr-sf" {
+		t.Errorf("expected 10-ppr-sf, got %q", got)
+	}
+	if got := valuation.ADPSegmentFor("half_ppr-1qb-14+-keeper"); got != "14+-half_ppr-1qb-keeper" {
+		t.Errorf("expected 14+-half_ppr-1qb-keeper, got %q", got)
+	}
+	if got := valuation.ADPSegmentFor("ppr-sf-12-dynasty"); got != "12-ppr-sf-rookie" {
+		t.Errorf("expected a dynasty segment's picks priced off rookie drafts, got %q", got)
+	}
+	if got := valuation.ADPSegmentFor("ppr-sf-14"); got != "" {
+		t.Errorf("expected no ADP segment for an unknown segment, got %q", got)
+	}
+}
+
+// TestComputePickSlotValues uses a 2-team draft over ten players whose ADP
+// is exactly their pick number and whose value falls by 100 per pick, so
+// each slot's value is the mean of the five values nearest it.
+func TestComputePickSlotValues(t *testing.T) {
+	var adp []valuation.ADPEntry
+	values := map[string]float64{}
+	for i := 1; i <= 10; i++ {
+		id := "p" + strconv.Itoa(i)
+		adp = append(adp, valuation.ADPEntry{SleeperPlayerID: id, AvgPickNo: float64(i)})
+		values[id] = float64(1100 - 100*i)
+	}
+	// An unvalued kicker at pick 3 takes no part.
+	adp = append(adp, valuation.ADPEntry{SleeperPlayerID: "k1", AvgPickNo: 3})
+
+	got := valuation.ComputePickSlotValues(adp, values, 2)
+	byKey := map[[2]int]float64{}
+	for _, v := range got {
+		byKey[[2]int{v.Round, v.Slot}] = v.Value
+	}
+	if len(got) != 15 {
+		t.Fatalf("expected 5 rounds of 2 slots plus a round average each, got %d: %+v", len(got), got)
+	}
+	// Pick 1: players 1-5 -> (1000+900+800+700+600)/5.
+	if byKey[[2]int{1, 1}] != 800 {
+		t.Errorf("expected 1.01 = 800, got %v", byKey[[2]int{1, 1}])
+	}
+	// Pick 5: players 3-7.
+	if byKey[[2]int{3, 1}] != 600 {
+		t.Errorf("expected 3.01 = 600, got %v", byKey[[2]int{3, 1}])
+	}
+	// Round 3's average is the mean of picks 5 and 6 (600 and 500).
+	if byKey[[2]int{3, 0}] != 550 {
+		t.Errorf("expected round 3 average 550, got %v", byKey[[2]int{3, 0}])
+	}
+	if byKey[[2]int{1, 1}] < byKey[[2]int{1, 2}] || byKey[[2]int{1, 2}] < byKey[[2]int{2, 1}] {
+		t.Errorf("expected pick values to fall with draft position, got %+v", got)
+	}
+}
+
+func TestComputePickSlotValues_TooFewPlayers(t *testing.T) {
+	adp := []valuation.ADPEntry{{SleeperPlayerID: "p1", AvgPickNo: 1}}
+	if got := valuation.ComputePickSlotValues(adp, map[string]float64{"p1": 1000}, 10); got != nil {
+		t.Errorf("expected no values from a single valued player, got %+v", got)
+	}
+}
+
+func TestPickSeasonValues_DiscountsFutureSeasons(t *testing.T) {
+	date := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	slots := []valuation.PickSlotValue{{Round: 1, Slot: 0, Value: 1000}}
+
+	got := valuation.PickSeasonValues("ppr-sf-10", "2025", date, slots)
+	if len(got) != valuation.FuturePickSeasons+1 {
+		t.Fatalf("expected one row per season through %d ahead, got %+v", valuation.FuturePickSeasons, got)
+	}
+	if got[0].Season != "2025" || got[0].Value != 1000 {
+		t.Errorf("expected the ADP season undiscounted, got %+v", got[0])
+	}
+	if got[2].Season != "2027" || math.Abs(got[2].Value-1000*valuation.FuturePickDiscount*valuation.FuturePickDiscount) > 1e-9 {
+		t.Errorf("expected 2027 discounted twice, got %+v", got[2])
+	}
+	if !got[3].ValuationDate.Equal(date) || got[3].Segment != "ppr-sf-10" {
+		t.Errorf("expected segment and valuation date carried through, got %+v", got[3])
+	}
+}
+
+func TestGroupPicksByRoster(t *testing.T) {
+	raw := json.RawMessage(`[
+		{"season": "2026", "round": 1, "roster_id": 3, "previous_owner_id": 3, "owner_id": 5},
+		{"season": "2027", "round": 2, "roster_id": 5, "previous_owner_id": 5, "owner_id": 3},
+		{"season": "2026", "round": 3, "roster_id": 3, "previous_owner_id": 3, "owner_id": 5, "slot": 8}]`)
+	got := valuation.GroupPicksByRoster(raw)
+	if len(got[5]) != 2 || len(got[3]) != 1 {
+		t.Fatalf("expected roster 5 with 2 picks and roster 3 with 1, got %+v", got)
+	}
+	if got[3][0] != (valuation.PickKey{Season: "2027", Round: 2}) {
+		t.Errorf("expected roster 3 to receive the 2027 2nd at slot 0, got %+v", got[3][0])
+	}
+	if got[5][1] != (valuation.PickKey{Season: "2026", Round: 3, Slot: 8}) {
+		t.Errorf("expected the stamped slot carried through, got %+v", got[5][1])
+	}
+	if len(valuation.GroupPicksByRoster(nil)) != 0 {
+		t.Error("expected no picks from an empty draft_picks")
+	}
+}
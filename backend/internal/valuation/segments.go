@@ -0,0 +1,176 @@
+package valuation
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+)
+
+// LeagueTypes are the sleeper_leagues.league_type values a segment covers.
+var LeagueTypes = []string{"redraft", "keeper", "dynasty"}
+
+// Segment is a league format the valuation model values players in: the
+// same league size, scoring and superflex dimensions as models.ADPSegment's
+// base, plus the league type, since redraft, keeper and dynasty leagues
+// price players over different horizons.
+type Segment struct {
+	LeagueSize    string // one of models.ADPLeagueSizes
+	ScoringFormat string // one of models.ADPScoringFormats
+	Superflex     bool
+	LeagueType    string // one of LeagueTypes
+}
+
+// Key returns the segment's player_valuations.segment key: scoring,
+// superflex and size, e.g. "ppr-sf-10" or "half_ppr-1qb-14+", with the league
+// type appended when it isn't redraft ("ppr-sf-12-dynasty"), so the original
+// redraft keys are unchanged.
+func (s Segment) Key() string {
+	sf := "1qb"
+	if s.Superflex {
+		sf = "sf"
+	}
+	key := s.ScoringFormat + "-" + sf + "-" + s.LeagueSize
+	if s.LeagueType != "redraft" {
+		key += "-" + s.LeagueType
+	}
+	return key
+}
+
+// ParseSegment is the inverse of Segment.Key; ok is false for a key outside
+// the segment dimensions.
+func ParseSegment(key string) (Segment, bool) {
+	parts := strings.Split(key, "-")
+	if len(parts) != 3 && len(parts) != 4 {
+		return Segment{}, false
+	}
+	s := Segment{ScoringFormat: parts[0], LeagueSize: parts[2], LeagueType: "redraft"}
+	switch parts[1] {
+	case "sf":
+		s.Superflex = true
+	case "1qb":
+	default:
+		return Segment{}, false
+	}
+	if len(parts) == 4 {
+		if parts[3] == "redraft" {
+			return Segment{}, false
+		}
+		s.LeagueType = parts[3]
+	}
+	if !slices.Contains(models.ADPScoringFormats, s.ScoringFormat) ||
+		!slices.Contains(models.ADPLeagueSizes, s.LeagueSize) ||
+		!slices.Contains(LeagueTypes, s.LeagueType) {
+		return Segment{}, false
+	}
+	return s, true
+}
+
+// SegmentForLeague buckets a league's settings into its valuation segment,
+// the way models.ADPSegmentForLeague does for ADP. ok is false for a league
+// no segment covers: an unbucketed size or ppr value, an unknown league
+// type, or settings not yet fetched.
+func SegmentForLeague(ppr *float64, isSuperflex *bool, totalRosters int, leagueType string) (Segment, bool) {
+	adp, ok := models.ADPSegmentForLeague(totalRosters, ppr, isSuperflex)
+	if !ok || !slices.Contains(LeagueTypes, leagueType) {
+		return Segment{}, false
+	}
+	return Segment{LeagueSize: adp.LeagueSize, ScoringFormat: adp.ScoringFormat, Superflex: adp.Superflex, LeagueType: leagueType}, true
+}
+
+// Teams returns the number of teams in the segment's leagues — the fewest,
+// for "14+".
+func (s Segment) Teams() int {
+	n, _ := strconv.Atoi(strings.TrimSuffix(s.LeagueSize, "+"))
+	return n
+}
+
+// ADPSegment returns the draft_adp segment the segment's pick values are
+// derived from. A dynasty league's traded picks are rookie-draft picks, so
+// its ADP comes from rookie drafts.
+func (s Segment) ADPSegment() models.ADPSegment {
+	adp := models.ADPSegment{LeagueSize: s.LeagueSize, ScoringFormat: s.ScoringFormat, Superflex: s.Superflex}
+	switch s.LeagueType {
+	case "keeper":
+		adp.Format = models.ADPFormatKeeper
+	case "dynasty":
+		adp.Format = models.ADPFormatRookie
+	}
+	return adp
+}
+
+// ValuedSegments returns, sorted, the key of every segment with
+// player_valuations rows — the segments the model actually runs on, which
+// is decided by analysis/src/config.py's SEGMENTS, not here.
+func ValuedSegments(db *gorm.DB) ([]string, error) {
+	var keys []string
+	if err := db.Table("player_valuations").
+		Distinct("segment").
+		Order("segment").
+		Pluck("segment", &keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// HasValuations reports whether the model has produced any player_valuations
+// for segment — the one-segment form of ValuedSegments.
+func HasValuations(db *gorm.DB, segment string) (bool, error) {
+	var found []int
+	if err := db.Table("player_valuations").Select("1").Where("segment = ?", segment).Limit(1).Scan(&found).Error; err != nil {
+		return false, err
+	}
+	return len(found) > 0, nil
+}
+
+// LeagueCondition returns a WHERE condition, over sleeper_leagues aliased
+// as "l", matching the leagues in any of the segments keys — the SQL twin of
+// SegmentForLeague, so a query can keep leagues outside them from crowding
+// its LIMIT. Keys that don't parse are skipped; with none left the
+// condition matches nothing.
+func LeagueCondition(keys []string) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+	for _, key := range keys {
+		s, ok := ParseSegment(key)
+		if !ok {
+			continue
+		}
+		size := "l.total_rosters = ?"
+		if strings.HasSuffix(s.LeagueSize, "+") {
+			size = "l.total_rosters >= ?"
+		}
+		clauses = append(clauses, "(l.ppr = ? AND l.is_superflex = ? AND "+size+" AND l.league_type = ?)")
+		args = append(args, s.ppr(), s.Superflex, s.Teams(), s.LeagueType)
+	}
+	if len(clauses) == 0 {
+		return "1 = 0", nil
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// PointsColumn is the sleeper_player_week_stats column scored the way the
+// segment's leagues score.
+func (s Segment) PointsColumn() string {
+	switch s.ScoringFormat {
+	case "half_ppr":
+		return "pts_half_ppr"
+	case "standard":
+		return "pts_std"
+	}
+	return "pts_ppr"
+}
+
+// ppr is the sleeper_leagues.ppr value of the segment's scoring format.
+func (s Segment) ppr() float64 {
+	switch s.ScoringFormat {
+	case "half_ppr":
+		return 0.5
+	case "ppr":
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,52 @@
+package valuation_test
+
+import (
+	"testing"
+
+	"backend/internal/valuation"
+)
+
+func TestParseSegment(t *testing.T) {
+	for _, key := range []string{"ppr-sf-10", "half_ppr-1qb-14+", "standard-sf-8-keeper", "ppr-1qb-12-dynasty"} {
+		s, ok := valuation.ParseSegment(key)
+		if !ok {
+			t.Errorf("%s: expected a segment", key)
+			continue
+		}
+		if got := s.Key(); got != key {
+			t.Errorf("%s: expected the key to round-trip, got %q", key, got)
+		}
+	}
+	for _, key := range []string{"", "ppr-sf-14", "half-1qb-10", "ppr-2qb-10", "ppr-sf-10-redraft", "ppr-sf-10-bestball", "ppr-sf-10-dynasty-x"} {
+		if _, ok := valuation.ParseSegment(key); ok {
+			t.Errorf("%q: expected no segment", key)
+		}
+	}
+}
+
+func TestSegmentTeams(t *testing.T) {
+	if got := valuation.TeamsInSegment("ppr-sf-10-dynasty"); got != 10 {
+		t.Errorf("expected 10 teams, got %d", got)
+	}
+	if got := valuation.TeamsInSegment("ppr-1qb-14+"); got != 14 {
+		t.Errorf("expected 14 teams for 14+, got %d", got)
+	}
+	if got := valuation.TeamsInSegment("nope"); got != 0 {
+		t.Errorf("expected 0 teams for an unknown segment, got %d", got)
+	}
+}
+
+func TestLeagueCondition(t *testing.T) {
+	cond, args := valuation.LeagueCondition([]string{"ppr-sf-10", "bogus", "half_ppr-1qb-14+-dynasty"})
+	want := "((l.ppr = ? AND l.is_superflex = ? AND l.total_rosters = ? AND l.league_type = ?) OR " +
+		"(l.ppr = ? AND l.is_superflex = ? AND l.total_rosters >= ? AND l.league_type = ?))"
+	if cond != want {
+		t.Errorf("expected %s, got %s", want, cond)
+	}
+	if len(args) != 8 || args[0] != 1.0 || args[3] != "redraft" || args[4] != 0.5 || args[5] != false || args[6] != 14 || args[7] != "dynasty" {
+		t.Errorf("unexpected args %v", args)
+	}
+	if cond, args := valuation.LeagueCondition(nil); cond != "1 = 0" || len(args) != 0 {
+		t.Errorf("expected a condition matching nothing, got %s %v", cond, args)
+	}
+}
@@ -1,7 +1,10 @@
 // Package valuation resolves a league's model-valuation segment and computes
-// per-side trade totals from player_valuations snapshots. It is used by
-// transactioncron to persist sleeper_transactions.trade_values at sync time
-// — see docs/superpowers/specs/2026-08-10-trade-valuation-totals-design.md.
+// per-side trade totals from player_valuations and pick_valuations
+// snapshots. It is used by transactioncron to persist
+// sleeper_transactions.trade_values at sync time — see
+// docs/superpowers/specs/2026-08-10-trade-valuation-totals-design.md — and
+// by the on-demand trade calculator (calculator.go). evaluation.go scores
+// the model's projections against the points players went on to score.
 package valuation
 
 import (
@@ -16,39 +19,35 @@ import (
 // it's being valued as-of, before it's treated as absent rather than used.
 const FreshnessWindow = 24 * time.Hour
 
-// KnownSegments mirrors SEGMENTS in analysis/src/config.py — the league
-// formats the valuation model runs on. Only ppr-sf-10 has a systemd job
-// actually producing snapshots today; the other two are supported here so
-// adding their replay job later doesn't require a code change.
-var KnownSegments = map[string]struct{}{
-	"ppr-sf-12": {},
-	"ppr-sf-10": {},
-	"ppr-sf-8":  {},
-}
-
 // Snapshot is one dated model valuation for a player, read from
 // player_valuations (written by analysis/main.py, never by Go in
 // production — this model exists for reads and test fixtures only).
+// MarketScore, MarketDispersion, ProjectedPAR and ProjectionUncertainty are
+// nil where the model had no estimate, and always for pick snapshots.
 type Snapshot struct {
-	Segment         string    `gorm:"column:segment"`
-	SleeperPlayerID string    `gorm:"column:sleeper_player_id"`
-	ValuationDate   time.Time `gorm:"column:valuation_date"`
-	Value           float64   `gorm:"column:value"`
+	Segment               string    `gorm:"column:segment"`
+	SleeperPlayerID       string    `gorm:"column:sleeper_player_id"`
+	ValuationDate         time.Time `gorm:"column:valuation_date"`
+	Position              string    `gorm:"column:position"`
+	Value                 float64   `gorm:"column:value"`
+	MarketScore           *float64  `gorm:"column:market_score"`
+	MarketDispersion      *float64  `gorm:"column:market_dispersion"`
+	ProjectedPAR          *float64  `gorm:"column:projected_par"`
+	ProjectionUncertainty *float64  `gorm:"column:projection_uncertainty"`
 }
 
 func (Snapshot) TableName() string { return "player_valuations" }
 
-// SegmentKeyForLeague maps a league's settings to its valuation segment key,
-// or "" when no segment covers that format.
+// SegmentKeyForLeague maps a league's settings to its valuation segment key
+// (see Segment.Key), or "" when its format falls in no segment. A key is
+// returned whether or not the model has produced snapshots for it yet — see
+// ValuedSegments.
 func SegmentKeyForLeague(ppr *float64, isSuperflex *bool, totalRosters int, leagueType string) string {
-	if ppr == nil || *ppr != 1.0 || isSuperflex == nil || !*isSuperflex || leagueType != "redraft" {
+	seg, ok := SegmentForLeague(ppr, isSuperflex, totalRosters, leagueType)
+	if !ok {
 		return ""
 	}
-	key := "ppr-sf-" + strconv.Itoa(totalRosters)
-	if _, ok := KnownSegments[key]; !ok {
-		return ""
-	}
-	return key
+	return seg.Key()
 }
 
 // LoadSnapshotHistory fetches one segment's valuation snapshots dated within
@@ -64,7 +63,7 @@ func LoadSnapshotHistory(db *gorm.DB, segment string, playerIDs []string, from,
 	}
 	var snaps []Snapshot
 	db.Table("player_valuations").
-		Select("sleeper_player_id, valuation_date, value").
+		Select("sleeper_player_id, valuation_date, value, market_dispersion, projected_par").
 		Where("segment = ? AND sleeper_player_id IN ? AND valuation_date >= ? AND valuation_date <= ?", segment, playerIDs, from, upTo).
 		Order("sleeper_player_id, valuation_date ASC").
 		Scan(&snaps)
@@ -78,21 +77,28 @@ func LoadSnapshotHistory(db *gorm.DB, segment string, playerIDs []string, from,
 // within maxAge of ts — a snapshot older than that is treated the same as no
 // snapshot at all. snaps must be sorted by date ascending.
 func ValueAsOf(snaps []Snapshot, ts time.Time, maxAge time.Duration) (float64, bool) {
+	s, ok := SnapshotAsOf(snaps, ts, maxAge)
+	return s.Value, ok
+}
+
+// SnapshotAsOf is ValueAsOf returning the whole snapshot, for callers that
+// want its dispersion and projection too.
+func SnapshotAsOf(snaps []Snapshot, ts time.Time, maxAge time.Duration) (Snapshot, bool) {
 	for i := len(snaps) - 1; i >= 0; i-- {
 		if !snaps[i].ValuationDate.After(ts) {
 			if ts.Sub(snaps[i].ValuationDate) > maxAge {
-				return 0, false
+				return Snapshot{}, false
 			}
-			return snaps[i].Value, true
+			return snaps[i], true
 		}
 	}
-	return 0, false
+	return Snapshot{}, false
 }
 
 // GroupPlayersByRoster inverts a trade's `adds` map (player_id -> roster_id,
 // the shape of sleeper_transactions.adds) into roster_id -> player IDs.
-// Draft picks are never included — there is no pick-valuation model, and
-// picks live in a separate column (draft_picks) this function never sees.
+// Draft picks live in a separate column (draft_picks) — see
+// GroupPicksByRoster.
 func GroupPlayersByRoster(adds map[string]int) map[int][]string {
 	rosters := map[int][]string{}
 	for playerID, rosterID := range adds {
@@ -101,29 +107,37 @@ func GroupPlayersByRoster(adds map[string]int) map[int][]string {
 	return rosters
 }
 
-// ComputeTradeValues sums each roster's players into a total, requiring
-// every player on a roster to resolve via ValueAsOf before that roster's
-// total is included in values — a roster with any unvalued player, or with
-// no players at all (a picks-only side), is simply omitted from values.
+// ComputeTradeValues sums each roster's players and picks into a total,
+// requiring every player and pick a roster received to resolve via
+// ValueAsOf before that roster's total is included in values — a roster
+// with anything unvalued is simply omitted from values, and one that
+// received nothing at all isn't a side of the trade.
 //
 // complete reports whether every non-empty roster resolved (vacuously true
-// when there are none, e.g. a picks-only trade) — independent of whether
-// values itself is nil. This distinction is what lets a caller keep retrying
-// a trade with some resolved sides and some still-pending ones: values can
-// be non-nil (one side already has a total worth showing) while complete is
-// still false (another side's player hasn't been valued yet), so the caller
-// knows to try again later rather than treating the trade as settled.
-func ComputeTradeValues(rosterPlayers map[int][]string, tradeTime time.Time, history map[string][]Snapshot) (values json.RawMessage, complete bool) {
-	totals := map[string]float64{}
-	nonEmptyRosters := 0
+// when there are none) — independent of whether values itself is nil. This
+// distinction is what lets a caller keep retrying a trade with some resolved
+// sides and some still-pending ones: values can be non-nil (one side already
+// has a total worth showing) while complete is still false (another side's
+// player hasn't been valued yet), so the caller knows to try again later
+// rather than treating the trade as settled.
+func ComputeTradeValues(rosterPlayers map[int][]string, rosterPicks map[int][]PickKey, tradeTime time.Time, history map[string][]Snapshot, pickHistory map[PickKey][]Snapshot) (values json.RawMessage, complete bool) {
+	rosters := map[int]struct{}{}
 	for rosterID, playerIDs := range rosterPlayers {
-		if len(playerIDs) == 0 {
-			continue
+		if len(playerIDs) > 0 {
+			rosters[rosterID] = struct{}{}
 		}
-		nonEmptyRosters++
+	}
+	for rosterID, picks := range rosterPicks {
+		if len(picks) > 0 {
+			rosters[rosterID] = struct{}{}
+		}
+	}
+
+	totals := map[string]float64{}
+	for rosterID := range rosters {
 		var total float64
 		resolved := true
-		for _, pid := range playerIDs {
+		for _, pid := range rosterPlayers[rosterID] {
 			v, ok := ValueAsOf(history[pid], tradeTime, FreshnessWindow)
 			if !ok {
 				resolved = false
@@ -131,11 +145,22 @@ func ComputeTradeValues(rosterPlayers map[int][]string, tradeTime time.Time, his
 			}
 			total += v
 		}
+		for _, pick := range rosterPicks[rosterID] {
+			if !resolved {
+				break
+			}
+			v, ok := ValueAsOf(pickHistory[pick], tradeTime, FreshnessWindow)
+			if !ok {
+				resolved = false
+				break
+			}
+			total += v
+		}
 		if resolved {
 			totals[strconv.Itoa(rosterID)] = total
 		}
 	}
-	complete = len(totals) == nonEmptyRosters
+	complete = len(totals) == len(rosters)
 	if len(totals) == 0 {
 		return nil, complete
 	}
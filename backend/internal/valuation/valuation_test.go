@@ -9,7 +9,7 @@ import (
 )
 
 func TestSegmentKeyForLeague(t *testing.T) {
-	ppr, half := 1.0, 0.5
+	ppr, half, std, tenth := 1.0, 0.5, 0.0, 0.1
 	sf, oneQB := true, false
 
 	cases := []struct {
@@ -23,10 +23,14 @@ func TestSegmentKeyForLeague(t *testing.T) {
 		{"ppr superflex 12 redraft", &ppr, &sf, 12, "redraft", "ppr-sf-12"},
 		{"ppr superflex 10 redraft", &ppr, &sf, 10, "redraft", "ppr-sf-10"},
 		{"ppr superflex 8 redraft", &ppr, &sf, 8, "redraft", "ppr-sf-8"},
-		{"unsupported size", &ppr, &sf, 14, "redraft", ""},
-		{"half ppr", &half, &sf, 12, "redraft", ""},
-		{"one qb", &ppr, &oneQB, 12, "redraft", ""},
-		{"dynasty", &ppr, &sf, 12, "dynasty", ""},
+		{"large league", &ppr, &sf, 16, "redraft", "ppr-sf-14+"},
+		{"half ppr", &half, &sf, 12, "redraft", "half_ppr-sf-12"},
+		{"one qb", &ppr, &oneQB, 12, "redraft", "ppr-1qb-12"},
+		{"dynasty", &ppr, &sf, 12, "dynasty", "ppr-sf-12-dynasty"},
+		{"keeper standard", &std, &oneQB, 10, "keeper", "standard-1qb-10-keeper"},
+		{"unbucketed size", &ppr, &sf, 9, "redraft", ""},
+		{"unbucketed ppr", &tenth, &sf, 12, "redraft", ""},
+		{"unknown league type", &ppr, &sf, 12, "", ""},
 		{"nil ppr", nil, &sf, 12, "redraft", ""},
 		{"nil superflex", &ppr, nil, 12, "redraft", ""},
 	}
@@ -93,7 +97,7 @@ func TestComputeTradeValues_FullCoverage(t *testing.T) {
 		"p3": {{ValuationDate: now.Add(-6 * time.Hour), Value: 7000}},
 	}
 
-	raw, complete := valuation.ComputeTradeValues(rosterPlayers, now, history)
+	raw, complete := valuation.ComputeTradeValues(rosterPlayers, nil, now, history, nil)
 	if !complete {
 		t.Fatal("expected complete=true — every roster resolved")
 	}
@@ -127,7 +131,7 @@ func TestComputeTradeValues_PartialSideStaysAbsent(t *testing.T) {
 		"p3": {{ValuationDate: now.Add(-6 * time.Hour), Value: 7000}},
 	}
 
-	raw, complete := valuation.ComputeTradeValues(rosterPlayers, now, history)
+	raw, complete := valuation.ComputeTradeValues(rosterPlayers, nil, now, history, nil)
 	if complete {
 		t.Fatal("expected complete=false — roster 7 still has an unvalued player")
 	}
@@ -153,7 +157,7 @@ func TestComputeTradeValues_StaleSnapshotTreatedAsAbsent(t *testing.T) {
 		"p1": {{ValuationDate: now.Add(-30 * time.Hour), Value: 5000}},
 	}
 
-	raw, complete := valuation.ComputeTradeValues(rosterPlayers, now, history)
+	raw, complete := valuation.ComputeTradeValues(rosterPlayers, nil, now, history, nil)
 	if raw != nil {
 		t.Errorf("expected nil result — the only snapshot is 30h stale, beyond FreshnessWindow, got %s", raw)
 	}
@@ -162,31 +166,49 @@ func TestComputeTradeValues_StaleSnapshotTreatedAsAbsent(t *testing.T) {
 	}
 }
 
-func TestComputeTradeValues_PicksOnlySideStaysAbsent(t *testing.T) {
+func TestComputeTradeValues_PicksOnlySideIsValued(t *testing.T) {
 	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
-	// Roster 9 traded only a pick (not represented in rosterPlayers at all,
-	// since GroupPlayersByRoster only sees `adds`), roster 7 traded a valued
-	// player.
+	// Roster 9 received only a pick (absent from rosterPlayers, since
+	// GroupPlayersByRoster only sees `adds`), roster 7 a valued player.
 	rosterPlayers := map[int][]string{7: {"p1"}}
+	first := valuation.PickKey{Season: "2026", Round: 1}
+	rosterPicks := map[int][]valuation.PickKey{9: {first}}
 	history := map[string][]valuation.Snapshot{
 		"p1": {{ValuationDate: now.Add(-6 * time.Hour), Value: 5000}},
 	}
+	pickHistory := map[valuation.PickKey][]valuation.Snapshot{
+		first: {{ValuationDate: now.Add(-6 * time.Hour), Value: 4200}},
+	}
 
-	raw, complete := valuation.ComputeTradeValues(rosterPlayers, now, history)
+	raw, complete := valuation.ComputeTradeValues(rosterPlayers, rosterPicks, now, history, pickHistory)
 	if !complete {
-		t.Fatal("expected complete=true — roster 7 is the only non-empty roster and it resolved")
+		t.Fatal("expected complete=true — both sides resolved")
 	}
 	var totals map[string]float64
 	json.Unmarshal(raw, &totals)
-	if len(totals) != 1 || totals["7"] != 5000 {
-		t.Errorf("expected only roster 7 valued at 5000, got %+v", totals)
+	if len(totals) != 2 || totals["7"] != 5000 || totals["9"] != 4200 {
+		t.Errorf("expected roster 7 at 5000 and roster 9 at 4200, got %+v", totals)
+	}
+}
+
+func TestComputeTradeValues_UnvaluedPickLeavesSidePending(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	rosterPlayers := map[int][]string{7: {"p1"}}
+	rosterPicks := map[int][]valuation.PickKey{7: {{Season: "2031", Round: 1}}}
+	history := map[string][]valuation.Snapshot{
+		"p1": {{ValuationDate: now.Add(-6 * time.Hour), Value: 5000}},
+	}
+
+	raw, complete := valuation.ComputeTradeValues(rosterPlayers, rosterPicks, now, history, nil)
+	if raw != nil || complete {
+		t.Errorf("expected roster 7 pending on its unvalued pick, got %s complete=%v", raw, complete)
 	}
 }
 
 func TestComputeTradeValues_NoneValuedReturnsFalse(t *testing.T) {
 	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
 	rosterPlayers := map[int][]string{7: {"unvalued"}}
-	raw, complete := valuation.ComputeTradeValues(rosterPlayers, now, map[string][]valuation.Snapshot{})
+	raw, complete := valuation.ComputeTradeValues(rosterPlayers, nil, now, map[string][]valuation.Snapshot{}, nil)
 	if raw != nil {
 		t.Errorf("expected nil result when nothing is valued, got %s", raw)
 	}
@@ -196,13 +218,12 @@ func TestComputeTradeValues_NoneValuedReturnsFalse(t *testing.T) {
 }
 
 // TestComputeTradeValues_EmptyRosterPlayersIsVacuouslyComplete covers a
-// trade whose adds map is empty (e.g. a picks-only trade — GroupPlayersByRoster
-// never produces a roster entry for a side that only received picks). There
+// trade with nothing in adds or draft_picks (e.g. a FAAB-only swap). There
 // is nothing to resolve, so it must be reported complete immediately rather
 // than being retried by ReconcileTradeValues forever.
 func TestComputeTradeValues_EmptyRosterPlayersIsVacuouslyComplete(t *testing.T) {
 	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
-	raw, complete := valuation.ComputeTradeValues(map[int][]string{}, now, map[string][]valuation.Snapshot{})
+	raw, complete := valuation.ComputeTradeValues(map[int][]string{}, nil, now, map[string][]valuation.Snapshot{}, nil)
 	if raw != nil {
 		t.Errorf("expected nil result for an empty trade, got %s", raw)
 	}
@@ -0,0 +1,229 @@
+// Package valuationcron implements cmd/cron's "valuation-movers" job: a daily
+// pass over player_valuations that ranks each segment's biggest market_score
+// risers and fallers per position over rolling windows (models.MoverWindows),
+// served by GET /api/v1/players/movers. It reads only player_valuations, which the valuation replay writes
+// (analysis/main.py), so it's scheduled a few hours after that.
+package valuationcron
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+// MoverLimit is how many risers and how many fallers are kept per segment,
+// window and position.
+const MoverLimit = 25
+
+// MoverMinZ is the smallest move, in units of the player's market_dispersion,
+// that counts as a mover: the model's scores wander within the spread of the
+// trades behind them, so a smaller move is noise, not news. Players without a
+// dispersion aren't gated.
+const MoverMinZ = 1.0
+
+// insertBatchSize bounds each valuation_movers insert statement.
+const insertBatchSize = 1000
+
+// Report summarizes one RunMovers call.
+type Report struct {
+	Segments int
+	Movers   int
+}
+
+// moverSnapshot is the subset of a player_valuations row the job reads.
+type moverSnapshot struct {
+	SleeperPlayerID  string   `gorm:"column:sleeper_player_id"`
+	Position         *string  `gorm:"column:position"`
+	Value            *float64 `gorm:"column:value"`
+	MarketScore      float64  `gorm:"column:market_score"`
+	MarketDispersion *float64 `gorm:"column:market_dispersion"`
+}
+
+// RunMovers recomputes valuation_movers for every segment with valuations,
+// replacing the table's contents in one transaction so readers never see a
+// half-written ranking.
+func RunMovers(ctx context.Context, db *gorm.DB) (Report, error) {
+	return runMovers(ctx, db, time.Now().UTC())
+}
+
+func runMovers(ctx context.Context, db *gorm.DB, now time.Time) (Report, error) {
+	var report Report
+	segments, err := valuation.ValuedSegments(db.WithContext(ctx))
+	if err != nil {
+		return report, fmt.Errorf("valued segments: %w", err)
+	}
+
+	var records []models.ValuationMover
+	for _, seg := range segments {
+		latest, ok, err := latestValuationDate(ctx, db, seg)
+		if err != nil {
+			return report, err
+		}
+		if !ok {
+			continue
+		}
+		movers, err := segmentMovers(ctx, db, seg, latest)
+		if err != nil {
+			return report, err
+		}
+		records = append(records, movers...)
+		report.Segments++
+	}
+	for i := range records {
+		records[i].ComputedAt = now
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&models.ValuationMover{}).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&records, insertBatchSize).Error
+	})
+	if err != nil {
+		return report, fmt.Errorf("replace valuation_movers: %w", err)
+	}
+	report.Movers = len(records)
+	return report, nil
+}
+
+// latestValuationDate returns segment's newest valuation_date.
+func latestValuationDate(ctx context.Context, db *gorm.DB, segment string) (time.Time, bool, error) {
+	var latest []valuation.Snapshot
+	if err := db.WithContext(ctx).Table("player_valuations").
+		Select("valuation_date").
+		Where("segment = ?", segment).
+		Order("valuation_date DESC").
+		Limit(1).
+		Scan(&latest).Error; err != nil {
+		return time.Time{}, false, fmt.Errorf("latest valuation date for %s: %w", segment, err)
+	}
+	if len(latest) == 0 {
+		return time.Time{}, false, nil
+	}
+	return latest[0].ValuationDate, true, nil
+}
+
+// loadMoverSnapshots reads segment's scored snapshots dated date, by player.
+func loadMoverSnapshots(ctx context.Context, db *gorm.DB, segment string, date time.Time) (map[string]moverSnapshot, error) {
+	var rows []moverSnapshot
+	if err := db.WithContext(ctx).Table("player_valuations").
+		Select("sleeper_player_id, position, value, market_score, market_dispersion").
+		Where("segment = ? AND valuation_date = ? AND market_score IS NOT NULL", segment, date).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("valuations for %s on %s: %w", segment, date.Format("2006-01-02"), err)
+	}
+	out := make(map[string]moverSnapshot, len(rows))
+	for _, r := range rows {
+		out[r.SleeperPlayerID] = r
+	}
+	return out, nil
+}
+
+// segmentMovers ranks segment's movers into latest over every window. A
+// window reaching back before the segment's first valuation is skipped.
+func segmentMovers(ctx context.Context, db *gorm.DB, segment string, latest time.Time) ([]models.ValuationMover, error) {
+	current, err := loadMoverSnapshots(ctx, db, segment, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	windows := make([]string, 0, len(models.MoverWindows))
+	for w := range models.MoverWindows {
+		windows = append(windows, w)
+	}
+	sort.Strings(windows)
+
+	var out []models.ValuationMover
+	for _, window := range windows {
+		var from []valuation.Snapshot
+		if err := db.WithContext(ctx).Table("player_valuations").
+			Select("valuation_date").
+			Where("segment = ? AND valuation_date <= ?", segment, latest.AddDate(0, 0, -models.MoverWindows[window])).
+			Order("valuation_date DESC").
+			Limit(1).
+			Scan(&from).Error; err != nil {
+			return nil, fmt.Errorf("%s start date for %s: %w", window, segment, err)
+		}
+		if len(from) == 0 {
+			continue
+		}
+		fromDate := from[0].ValuationDate
+		previous, err := loadMoverSnapshots(ctx, db, segment, fromDate)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rankMovers(segment, window, fromDate, latest, previous, current)...)
+	}
+	return out, nil
+}
+
+// rankMovers compares each player's market_score in current against
+// previous and keeps the MoverLimit biggest risers and fallers per
+// position, past MoverMinZ.
+func rankMovers(segment, window string, fromDate, toDate time.Time, previous, current map[string]moverSnapshot) []models.ValuationMover {
+	type group struct{ position, direction string }
+	groups := map[group][]models.ValuationMover{}
+	for id, to := range current {
+		prev, ok := previous[id]
+		if !ok {
+			continue
+		}
+		change := to.MarketScore - prev.MarketScore
+		if change == 0 {
+			continue
+		}
+		var z *float64
+		if d := to.MarketDispersion; d != nil && *d > 0 {
+			v := change / *d
+			if math.Abs(v) < MoverMinZ {
+				continue
+			}
+			z = &v
+		}
+		position := ""
+		if to.Position != nil {
+			position = *to.Position
+		}
+		direction := models.MoverRiser
+		if change < 0 {
+			direction = models.MoverFaller
+		}
+		g := group{position, direction}
+		groups[g] = append(groups[g], models.ValuationMover{
+			Segment: segment, TimeWindow: window, SleeperPlayerID: id,
+			Position: position, Direction: direction,
+			FromDate: fromDate, ValuationDate: toDate,
+			MarketScoreFrom: prev.MarketScore, MarketScoreTo: to.MarketScore, ScoreChange: change,
+			MarketDispersion: to.MarketDispersion, ZScore: z,
+			ValueFrom: prev.Value, ValueTo: to.Value,
+		})
+	}
+
+	var out []models.ValuationMover
+	for _, movers := range groups {
+		sort.Slice(movers, func(i, j int) bool {
+			if a, b := math.Abs(movers[i].ScoreChange), math.Abs(movers[j].ScoreChange); a != b {
+				return a > b
+			}
+			return movers[i].SleeperPlayerID < movers[j].SleeperPlayerID
+		})
+		if len(movers) > MoverLimit {
+			movers = movers[:MoverLimit]
+		}
+		for i := range movers {
+			movers[i].Rank = i + 1
+		}
+		out = append(out, movers...)
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package valuationcron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"backend/internal/models"
+	"backend/internal/valuation"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&valuation.Snapshot{}, &models.ValuationMover{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return db
+}
+
+var (
+	day30   = time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	day7    = time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC)
+	day1    = time.Date(2025, 10, 30, 0, 0, 0, 0, time.UTC)
+	latestD = time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// seedSnapshot writes one ppr-sf-10 valuation; dispersion 0 leaves it null.
+func seedSnapshot(t *testing.T, db *gorm.DB, id, position string, date time.Time, value, score, dispersion float64) {
+	t.Helper()
+	s := valuation.Snapshot{Segment: "ppr-sf-10", SleeperPlayerID: id, Position: position, ValuationDate: date, Value: value, MarketScore: &score}
+	if dispersion > 0 {
+		s.MarketDispersion = &dispersion
+	}
+	if err := db.Create(&s).Error; err != nil {
+		t.Fatalf("seed snapshot %s: %v", id, err)
+	}
+}
+
+// seedMoverHistory seeds four WRs and an RB with a score on every window's
+// start date and on latestD:
+//
+//	w1 100 -> 160, dispersion 20: riser, z = 3
+//	w2 200 -> 190, dispersion 20: within the noise, z = -0.5
+//	w3 150 -> 100, dispersion 10: faller, z = -5
+//	r1  50 ->  80, no dispersion: riser, ungated
+//	w4 new on latestD: nothing to compare against
+func seedMoverHistory(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	for _, d := range []time.Time{day30, day7, day1} {
+		seedSnapshot(t, db, "w1", "WR", d, 1000, 100, 20)
+		seedSnapshot(t, db, "w2", "WR", d, 1000, 200, 20)
+		seedSnapshot(t, db, "w3", "WR", d, 3000, 150, 10)
+		seedSnapshot(t, db, "r1", "RB", d, 500, 50, 0)
+	}
+	seedSnapshot(t, db, "w1", "WR", latestD, 1200, 160, 20)
+	seedSnapshot(t, db, "w2", "WR", latestD, 1050, 190, 20)
+	seedSnapshot(t, db, "w3", "WR", latestD, 2000, 100, 10)
+	seedSnapshot(t, db, "r1", "RB", latestD, 700, 80, 0)
+	seedSnapshot(t, db, "w4", "WR", latestD, 900, 120, 20)
+}
+
+func TestRunMovers_RanksRisersAndFallersPerPositionAndWindow(t *testing.T) {
+	db := newTestDB(t)
+	seedMoverHistory(t, db)
+	now := time.Date(2025, 10, 31, 9, 0, 0, 0, time.UTC)
+
+	report, err := runMovers(context.Background(), db, now)
+	if err != nil {
+		t.Fatalf("runMovers: %v", err)
+	}
+	if report.Segments != 1 || report.Movers != 9 {
+		t.Errorf("expected 3 movers in each of 3 windows, got %+v", report)
+	}
+
+	var movers []models.ValuationMover
+	db.Where("time_window = ?", "7d").Order("sleeper_player_id").Find(&movers)
+	if len(movers) != 3 {
+		t.Fatalf("expected r1, w1 and w3 in the 7d window, got %+v", movers)
+	}
+	r1, w1, w3 := movers[0], movers[1], movers[2]
+	if r1.Position != "RB" || r1.Direction != models.MoverRiser || r1.Rank != 1 || r1.ZScore != nil {
+		t.Errorf("expected r1 the top RB riser without a z-score, got %+v", r1)
+	}
+	if w1.Direction != models.MoverRiser || w1.Rank != 1 || w1.ScoreChange != 60 || w1.ZScore == nil || *w1.ZScore != 3 {
+		t.Errorf("expected w1 the top WR riser, +60 at z 3, got %+v", w1)
+	}
+	if !w1.FromDate.Equal(day7) || !w1.ValuationDate.Equal(latestD) || *w1.ValueFrom != 1000 || *w1.ValueTo != 1200 || !w1.ComputedAt.Equal(now) {
+		t.Errorf("unexpected w1 dates or values: %+v", w1)
+	}
+	if w3.Direction != models.MoverFaller || w3.Rank != 1 || w3.ScoreChange != -50 {
+		t.Errorf("expected w3 the top WR faller, -50, got %+v", w3)
+	}
+
+	// A rerun replaces the rankings rather than adding to them.
+	if _, err := runMovers(context.Background(), db, now); err != nil {
+		t.Fatalf("runMovers: %v", err)
+	}
+	var n int64
+	db.Model(&models.ValuationMover{}).Count(&n)
+	if n != 9 {
+		t.Errorf("expected 9 rows after a rerun, got %d", n)
+	}
+}
+
+func TestRunMovers_SkipsWindowsBeforeFirstValuation(t *testing.T) {
+	db := newTestDB(t)
+	seedSnapshot(t, db, "w1", "WR", day1, 1000, 100, 20)
+	seedSnapshot(t, db, "w1", "WR", latestD, 1200, 160, 20)
+
+	report, err := runMovers(context.Background(), db, latestD)
+	if err != nil {
+		t.Fatalf("runMovers: %v", err)
+	}
+	var windows []string
+	db.Model(&models.ValuationMover{}).Pluck("time_window", &windows)
+	if report.Movers != 1 || len(windows) != 1 || windows[0] != "1d" {
+		t.Errorf("expected only the 1d window, got %v", windows)
+	}
+}
@@ -40,21 +40,72 @@ func ADPRollupDispatcher(ctx workflow.Context) (ADPRollupDispatchReport, error)
 	return report, nil
 }
 
-// SegmentSeasonADPRollupWorkflow computes and upserts ADP for one
-// (segment, season) pair. A compute failure is logged rather than returned,
-// so one bad segment/season doesn't surface as a workflow failure.
+// SegmentSeasonADPRollupWorkflow computes and upserts ADP, the refined
+// segments under it with enough drafts (one activity each), its weekly
+// trend, then auction values, for one (segment, season) pair. A compute failure is
+// logged rather than returned, so one bad segment/season doesn't surface as
+// a workflow failure — and one failed rollup doesn't hold back the others.
 func SegmentSeasonADPRollupWorkflow(ctx workflow.Context, params SegmentSeasonADPParams) (SegmentADPReport, error) {
 	ara := &activities.ADPRollupActivities{}
 	actCtx := workflow.WithActivityOptions(ctx, defaultActivityOptions)
-
-	var res activities.ADPRollupResult
-	if err := workflow.ExecuteActivity(actCtx, ara.ComputeSegmentSeasonADP, activities.ComputeSegmentSeasonADPParams{
+	actParams := activities.ComputeSegmentSeasonADPParams{
 		Segment: params.Segment,
 		Season:  params.Season,
-	}).Get(ctx, &res); err != nil {
+	}
+
+	var report SegmentADPReport
+	var res activities.ADPRollupResult
+	if err := workflow.ExecuteActivity(actCtx, ara.ComputeSegmentSeasonADP, actParams).Get(ctx, &res); err != nil {
 		workflow.GetLogger(ctx).Warn("ComputeSegmentSeasonADP failed",
 			"segment", params.Segment.Key(), "season", params.Season, "error", err)
-		return SegmentADPReport{}, nil
+	} else {
+		report.PlayersUpserted = res.PlayersUpserted
+	}
+
+	var refinements []models.ADPSegment
+	if err := workflow.ExecuteActivity(actCtx, ara.ListSegmentSeasonADPRefinements, actParams).Get(ctx, &refinements); err != nil {
+		workflow.GetLogger(ctx).Warn("ListSegmentSeasonADPRefinements failed",
+			"segment", params.Segment.Key(), "season", params.Season, "error", err)
+	}
+	for _, seg := range refinements {
+		var refined activities.ADPRollupResult
+		refinedParams := activities.ComputeSegmentSeasonADPParams{Segment: seg, Season: params.Season}
+		if err := workflow.ExecuteActivity(actCtx, ara.ComputeSegmentSeasonADP, refinedParams).Get(ctx, &refined); err != nil {
+			workflow.GetLogger(ctx).Warn("ComputeSegmentSeasonADP failed for refinement",
+				"segment", seg.Key(), "season", params.Season, "error", err)
+			continue
+		}
+		report.RefinedSegments++
+	}
+
+	var trend activities.ADPRollupResult
+	if err := workflow.ExecuteActivity(actCtx, ara.ComputeSegmentSeasonADPTrend, actParams).Get(ctx, &trend); err != nil {
+		workflow.GetLogger(ctx).Warn("ComputeSegmentSeasonADPTrend failed",
+			"segment", params.Segment.Key(), "season", params.Season, "error", err)
+	} else {
+		report.TrendRowsUpserted = trend.PlayersUpserted
+	}
+
+	var auction activities.ADPRollupResult
+	if err := workflow.ExecuteActivity(actCtx, ara.ComputeSegmentSeasonAuctionValues, actParams).Get(ctx, &auction); err != nil {
+		workflow.GetLogger(ctx).Warn("ComputeSegmentSeasonAuctionValues failed",
+			"segment", params.Segment.Key(), "season", params.Season, "error", err)
+	} else {
+		report.AuctionPlayersUpserted = auction.PlayersUpserted
+	}
+	return report, nil
+}
+
+// PickValuationWorkflow recomputes pick_valuations from the latest draft_adp
+// and player_valuations. Scheduled separately from ADPRollupDispatcher, whose
+// per-season children are fire-and-forget and so can't be waited on here.
+func PickValuationWorkflow(ctx workflow.Context) (PickValuationReport, error) {
+	ara := &activities.ADPRollupActivities{}
+	actCtx := workflow.WithActivityOptions(ctx, defaultActivityOptions)
+
+	var res activities.PickValuationResult
+	if err := workflow.ExecuteActivity(actCtx, ara.ComputePickValuations).Get(ctx, &res); err != nil {
+		return PickValuationReport{}, err
 	}
-	return SegmentADPReport{PlayersUpserted: res.PlayersUpserted}, nil
+	return PickValuationReport{SegmentsValued: res.SegmentsValued, PicksUpserted: res.PicksUpserted}, nil
 }
@@ -13,6 +13,7 @@ const (
 	TaskQueueWeekStats  = "sleeper-week-stats"
 	TaskQueueADP        = "sleeper-adp"
 	TaskQueueArchive    = "archive-maintenance"
+	TaskQueueLeagues    = "sleeper-leagues"
 
 	// MaxDispatchIterations bounds a sync dispatcher's claim loop so one run's
 	// event history stays small; the schedule picks up any remainder.
@@ -6,6 +6,10 @@ type SyncWeekStatsParams struct {
 	Season string
 }
 
+type RegisterSleeperLeagueParams struct {
+	SleeperLeagueID string
+}
+
 type SegmentSeasonADPParams struct {
 	Segment models.ADPSegment
 	Season  string
@@ -39,6 +43,20 @@ type WeekStatsReport struct {
 	PlayersUpserted int
 }
 
+// WeekProjectionsReport summarizes a SyncWeekProjections (or
+// WeekProjectionsSyncDispatcher) run.
+type WeekProjectionsReport struct {
+	WeeksFetched    int
+	PlayersUpserted int
+}
+
+// SleeperLeagueSyncReport summarizes one SleeperLeagueSyncDispatcher run.
+type SleeperLeagueSyncReport struct {
+	LeaguesSynced    int
+	LeaguesFailed    int
+	MatchupsUpserted int
+}
+
 // ADPRollupDispatchReport summarizes one ADPRollupDispatcher run. Child
 // workflows are fire-and-forget (ParentClosePolicy: ABANDON), so this counts
 // segments scheduled, not completed.
@@ -48,7 +66,16 @@ type ADPRollupDispatchReport struct {
 
 // SegmentADPReport summarizes one SegmentSeasonADPRollupWorkflow run.
 type SegmentADPReport struct {
-	PlayersUpserted int
+	PlayersUpserted        int
+	RefinedSegments        int
+	TrendRowsUpserted      int
+	AuctionPlayersUpserted int
+}
+
+// PickValuationReport summarizes one PickValuationWorkflow run.
+type PickValuationReport struct {
+	SegmentsValued int
+	PicksUpserted  int
 }
 
 // BackfillReport summarizes one ArchiveBackfillWorkflow execution (not the
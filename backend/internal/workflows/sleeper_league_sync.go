@@ -0,0 +1,48 @@
+package workflows
+
+import (
+	"go.temporal.io/sdk/workflow"
+
+	"backend/internal/activities"
+)
+
+// RegisterSleeperLeagueWorkflow imports a Sleeper league's full history (every
+// season back along previous_league_id) into League/Team/Matchup/BoxScore.
+// Re-registering a renewed league's new ID links it to the existing League.
+func RegisterSleeperLeagueWorkflow(ctx workflow.Context, params RegisterSleeperLeagueParams) (activities.SleeperLeagueImportResult, error) {
+	sla := &activities.SleeperLeagueActivities{}
+	actCtx := workflow.WithActivityOptions(ctx, defaultActivityOptions)
+
+	var res activities.SleeperLeagueImportResult
+	err := workflow.ExecuteActivity(actCtx, sla.RegisterSleeperLeague,
+		activities.RegisterSleeperLeagueParams{SleeperLeagueID: params.SleeperLeagueID}).Get(ctx, &res)
+	return res, err
+}
+
+// SleeperLeagueSyncDispatcher is the scheduled entry point: it re-imports the
+// current season of every registered Sleeper league, one at a time. A
+// league's failure is counted rather than failing the run, so one broken
+// league doesn't hold back the rest.
+func SleeperLeagueSyncDispatcher(ctx workflow.Context) (SleeperLeagueSyncReport, error) {
+	sla := &activities.SleeperLeagueActivities{}
+	actCtx := workflow.WithActivityOptions(ctx, defaultActivityOptions)
+
+	var leagueIDs []uint
+	if err := workflow.ExecuteActivity(actCtx, sla.ListSleeperLeagues).Get(ctx, &leagueIDs); err != nil {
+		return SleeperLeagueSyncReport{}, err
+	}
+
+	var report SleeperLeagueSyncReport
+	for _, id := range leagueIDs {
+		var res activities.SleeperLeagueImportResult
+		if err := workflow.ExecuteActivity(actCtx, sla.SyncSleeperLeague,
+			activities.SyncSleeperLeagueParams{LeagueID: id}).Get(ctx, &res); err != nil {
+			workflow.GetLogger(ctx).Warn("sleeper league sync failed", "league_id", id, "error", err)
+			report.LeaguesFailed++
+			continue
+		}
+		report.LeaguesSynced++
+		report.MatchupsUpserted += res.Matchups
+	}
+	return report, nil
+}
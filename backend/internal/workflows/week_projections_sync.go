@@ -0,0 +1,52 @@
+package workflows
+
+import (
+	"go.temporal.io/sdk/workflow"
+
+	"backend/internal/activities"
+)
+
+// SyncWeekProjections fetches weekly Sleeper projections for every week 1-18
+// of params.Season that isn't already finalized — SyncWeekStats' counterpart
+// for sleeper_player_week_projections, and likewise directly invocable for
+// backfills.
+func SyncWeekProjections(ctx workflow.Context, params SyncWeekStatsParams) (WeekProjectionsReport, error) {
+	wsa := &activities.WeekStatsActivities{}
+	actCtx := workflow.WithActivityOptions(ctx, defaultActivityOptions)
+
+	var finalizedWeeks []int
+	if err := workflow.ExecuteActivity(actCtx, wsa.GetFinalizedProjectionWeeks, activities.GetFinalizedWeeksParams{Season: params.Season}).Get(ctx, &finalizedWeeks); err != nil {
+		return WeekProjectionsReport{}, err
+	}
+	finalized := make(map[int]bool, len(finalizedWeeks))
+	for _, w := range finalizedWeeks {
+		finalized[w] = true
+	}
+
+	var report WeekProjectionsReport
+	for week := 1; week <= lastFantasyWeek; week++ {
+		if finalized[week] {
+			continue
+		}
+		var res activities.WeekProjectionsResult
+		if err := workflow.ExecuteActivity(actCtx, wsa.FetchWeekProjections, activities.FetchWeekProjectionsParams{Season: params.Season, Week: week}).Get(ctx, &res); err != nil {
+			return report, err
+		}
+		report.WeeksFetched++
+		report.PlayersUpserted += res.PlayersUpserted
+	}
+	return report, nil
+}
+
+// WeekProjectionsSyncDispatcher is the scheduled entry point: it resolves the
+// current NFL season, then runs SyncWeekProjections for it.
+func WeekProjectionsSyncDispatcher(ctx workflow.Context) (WeekProjectionsReport, error) {
+	wsa := &activities.WeekStatsActivities{}
+	actCtx := workflow.WithActivityOptions(ctx, defaultActivityOptions)
+
+	var season string
+	if err := workflow.ExecuteActivity(actCtx, wsa.GetCurrentSeason).Get(ctx, &season); err != nil {
+		return WeekProjectionsReport{}, err
+	}
+	return SyncWeekProjections(ctx, SyncWeekStatsParams{Season: season})
+}
@@ -227,6 +227,32 @@ func TestSyncWeekStats_AllWeeksFinalized_NoFetchCalls(t *testing.T) {
 	env.AssertExpectations(t)
 }
 
+// ---- SyncWeekProjections ----
+
+func TestSyncWeekProjections_SkipsFinalizedWeeks(t *testing.T) {
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+
+	wsa := &activities.WeekStatsActivities{}
+	finalized := make([]int, 0, 17)
+	for w := 1; w <= 17; w++ {
+		finalized = append(finalized, w)
+	}
+	env.OnActivity(wsa.GetFinalizedProjectionWeeks, mock.Anything, activities.GetFinalizedWeeksParams{Season: "2025"}).
+		Return(finalized, nil)
+	env.OnActivity(wsa.FetchWeekProjections, mock.Anything, activities.FetchWeekProjectionsParams{Season: "2025", Week: 18}).
+		Return(activities.WeekProjectionsResult{PlayersUpserted: 300}, nil).Once()
+
+	env.ExecuteWorkflow(workflows.SyncWeekProjections, workflows.SyncWeekStatsParams{Season: "2025"})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var report workflows.WeekProjectionsReport
+	require.NoError(t, env.GetWorkflowResult(&report))
+	require.Equal(t, workflows.WeekProjectionsReport{WeeksFetched: 1, PlayersUpserted: 300}, report)
+	env.AssertExpectations(t)
+}
+
 // ---- WeekStatsSyncDispatcher ----
 
 func TestWeekStatsSyncDispatcher_ResolvesSeasonAndSyncs(t *testing.T) {
@@ -263,8 +289,8 @@ func TestADPRollupDispatcher_SpawnsChildPerSeasonSegment(t *testing.T) {
 
 	env.RegisterWorkflow(workflows.SegmentSeasonADPRollupWorkflow)
 	segments := models.AllADPSegments()
-	if len(segments) != 24 {
-		t.Fatalf("expected 24 segments, got %d", len(segments))
+	if len(segments) != 96 {
+		t.Fatalf("expected 96 segments, got %d", len(segments))
 	}
 	for _, seg := range segments {
 		env.OnWorkflow(workflows.SegmentSeasonADPRollupWorkflow, mock.Anything, workflows.SegmentSeasonADPParams{
@@ -279,7 +305,7 @@ func TestADPRollupDispatcher_SpawnsChildPerSeasonSegment(t *testing.T) {
 	require.NoError(t, env.GetWorkflowError())
 	var report workflows.ADPRollupDispatchReport
 	require.NoError(t, env.GetWorkflowResult(&report))
-	require.Equal(t, workflows.ADPRollupDispatchReport{SegmentsScheduled: 24}, report)
+	require.Equal(t, workflows.ADPRollupDispatchReport{SegmentsScheduled: 96}, report)
 	env.AssertExpectations(t)
 }
 
@@ -298,6 +324,12 @@ func TestADPRollupDispatcher_ChildWorkflowIDIsDeterministic(t *testing.T) {
 			seenIDs[activity.GetInfo(ctx).WorkflowExecution.ID] = true
 			return true
 		}), activities.ComputeSegmentSeasonADPParams{Segment: seg, Season: "2024"}).Return(activities.ADPRollupResult{}, nil)
+		env.OnActivity(ara.ComputeSegmentSeasonAuctionValues, mock.Anything,
+			activities.ComputeSegmentSeasonADPParams{Segment: seg, Season: "2024"}).Return(activities.ADPRollupResult{}, nil)
+		env.OnActivity(ara.ComputeSegmentSeasonADPTrend, mock.Anything,
+			activities.ComputeSegmentSeasonADPParams{Segment: seg, Season: "2024"}).Return(activities.ADPRollupResult{}, nil)
+		env.OnActivity(ara.ListSegmentSeasonADPRefinements, mock.Anything,
+			activities.ComputeSegmentSeasonADPParams{Segment: seg, Season: "2024"}).Return([]models.ADPSegment{}, nil)
 	}
 
 	env.ExecuteWorkflow(workflows.ADPRollupDispatcher)
@@ -327,9 +359,28 @@ func TestADPRollupDispatcher_NoSeasons_NoChildren(t *testing.T) {
 	require.Equal(t, workflows.ADPRollupDispatchReport{}, report)
 }
 
+// ---- PickValuationWorkflow ----
+
+func TestPickValuationWorkflow_ReportsActivityResult(t *testing.T) {
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+
+	ara := &activities.ADPRollupActivities{}
+	env.OnActivity(ara.ComputePickValuations, mock.Anything).
+		Return(activities.PickValuationResult{SegmentsValued: 2, PicksUpserted: 360}, nil)
+
+	env.ExecuteWorkflow(workflows.PickValuationWorkflow)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var report workflows.PickValuationReport
+	require.NoError(t, env.GetWorkflowResult(&report))
+	require.Equal(t, workflows.PickValuationReport{SegmentsValued: 2, PicksUpserted: 360}, report)
+}
+
 // ---- SegmentSeasonADPRollupWorkflow ----
 
-func TestSegmentSeasonADPRollupWorkflow_CallsComputeActivity(t *testing.T) {
+func TestSegmentSeasonADPRollupWorkflow_CallsComputeActivities(t *testing.T) {
 	ts := testsuite.WorkflowTestSuite{}
 	env := ts.NewTestWorkflowEnvironment()
 
@@ -339,6 +390,28 @@ func TestSegmentSeasonADPRollupWorkflow_CallsComputeActivity(t *testing.T) {
 		Segment: seg,
 		Season:  "2024",
 	}).Return(activities.ADPRollupResult{PlayersUpserted: 5}, nil)
+	env.OnActivity(ara.ComputeSegmentSeasonAuctionValues, mock.Anything, activities.ComputeSegmentSeasonADPParams{
+		Segment: seg,
+		Season:  "2024",
+	}).Return(activities.ADPRollupResult{PlayersUpserted: 3}, nil)
+	env.OnActivity(ara.ComputeSegmentSeasonADPTrend, mock.Anything, activities.ComputeSegmentSeasonADPParams{
+		Segment: seg,
+		Season:  "2024",
+	}).Return(activities.ADPRollupResult{PlayersUpserted: 40}, nil)
+	tep, deep := seg, seg
+	tep.TEPremium, deep.Lineup = "tep", "deep"
+	env.OnActivity(ara.ListSegmentSeasonADPRefinements, mock.Anything, activities.ComputeSegmentSeasonADPParams{
+		Segment: seg,
+		Season:  "2024",
+	}).Return([]models.ADPSegment{tep, deep}, nil)
+	env.OnActivity(ara.ComputeSegmentSeasonADP, mock.Anything, activities.ComputeSegmentSeasonADPParams{
+		Segment: tep,
+		Season:  "2024",
+	}).Return(activities.ADPRollupResult{PlayersUpserted: 150}, nil)
+	env.OnActivity(ara.ComputeSegmentSeasonADP, mock.Anything, activities.ComputeSegmentSeasonADPParams{
+		Segment: deep,
+		Season:  "2024",
+	}).Return(activities.ADPRollupResult{PlayersUpserted: 150}, nil)
 
 	env.ExecuteWorkflow(workflows.SegmentSeasonADPRollupWorkflow, workflows.SegmentSeasonADPParams{
 		Segment: seg,
@@ -349,7 +422,7 @@ func TestSegmentSeasonADPRollupWorkflow_CallsComputeActivity(t *testing.T) {
 	require.NoError(t, env.GetWorkflowError())
 	var report workflows.SegmentADPReport
 	require.NoError(t, env.GetWorkflowResult(&report))
-	require.Equal(t, workflows.SegmentADPReport{PlayersUpserted: 5}, report)
+	require.Equal(t, workflows.SegmentADPReport{PlayersUpserted: 5, RefinedSegments: 2, TrendRowsUpserted: 40, AuctionPlayersUpserted: 3}, report)
 	env.AssertExpectations(t)
 }
 
@@ -363,6 +436,28 @@ func TestSegmentSeasonADPRollupWorkflow_ActivityFailure_WorkflowStillSucceeds(t
 		Segment: seg,
 		Season:  "2024",
 	}).Return(activities.ADPRollupResult{}, temporal.NewApplicationError("db error", "DB_ERROR", nil))
+	env.OnActivity(ara.ComputeSegmentSeasonAuctionValues, mock.Anything, activities.ComputeSegmentSeasonADPParams{
+		Segment: seg,
+		Season:  "2024",
+	}).Return(activities.ADPRollupResult{PlayersUpserted: 3}, nil)
+	env.OnActivity(ara.ComputeSegmentSeasonADPTrend, mock.Anything, activities.ComputeSegmentSeasonADPParams{
+		Segment: seg,
+		Season:  "2024",
+	}).Return(activities.ADPRollupResult{PlayersUpserted: 40}, nil)
+	tep, deep := seg, seg
+	tep.TEPremium, deep.Lineup = "tep", "deep"
+	env.OnActivity(ara.ListSegmentSeasonADPRefinements, mock.Anything, activities.ComputeSegmentSeasonADPParams{
+		Segment: seg,
+		Season:  "2024",
+	}).Return([]models.ADPSegment{tep, deep}, nil)
+	env.OnActivity(ara.ComputeSegmentSeasonADP, mock.Anything, activities.ComputeSegmentSeasonADPParams{
+		Segment: tep,
+		Season:  "2024",
+	}).Return(activities.ADPRollupResult{PlayersUpserted: 150}, nil)
+	env.OnActivity(ara.ComputeSegmentSeasonADP, mock.Anything, activities.ComputeSegmentSeasonADPParams{
+		Segment: deep,
+		Season:  "2024",
+	}).Return(activities.ADPRollupResult{}, temporal.NewApplicationError("timeout", "TIMEOUT", nil))
 
 	env.ExecuteWorkflow(workflows.SegmentSeasonADPRollupWorkflow, workflows.SegmentSeasonADPParams{
 		Segment: seg,
@@ -373,7 +468,8 @@ func TestSegmentSeasonADPRollupWorkflow_ActivityFailure_WorkflowStillSucceeds(t
 	require.NoError(t, env.GetWorkflowError()) // logged and swallowed, not propagated
 	var report workflows.SegmentADPReport
 	require.NoError(t, env.GetWorkflowResult(&report))
-	require.Equal(t, workflows.SegmentADPReport{}, report)
+	// The failed ADP rollup and refinement don't stop the others.
+	require.Equal(t, workflows.SegmentADPReport{RefinedSegments: 1, TrendRowsUpserted: 40, AuctionPlayersUpserted: 3}, report)
 	env.AssertExpectations(t)
 }
 
@@ -450,3 +546,26 @@ func TestArchiveBackfillWorkflow_StreamFailureFailsTheExecution(t *testing.T) {
 	require.Error(t, env.GetWorkflowError())
 	require.False(t, workflow.IsContinueAsNewError(env.GetWorkflowError()))
 }
+
+// ---- SleeperLeagueSyncDispatcher ----
+
+func TestSleeperLeagueSyncDispatcher_LeagueFailureDoesNotFailRun(t *testing.T) {
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+
+	sla := &activities.SleeperLeagueActivities{}
+	env.OnActivity(sla.ListSleeperLeagues, mock.Anything).Return([]uint{1, 2}, nil)
+	env.OnActivity(sla.SyncSleeperLeague, mock.Anything, activities.SyncSleeperLeagueParams{LeagueID: 1}).
+		Return(activities.SleeperLeagueImportResult{}, temporal.NewNonRetryableApplicationError("boom", "test", nil))
+	env.OnActivity(sla.SyncSleeperLeague, mock.Anything, activities.SyncSleeperLeagueParams{LeagueID: 2}).
+		Return(activities.SleeperLeagueImportResult{LeagueID: 2, Matchups: 6}, nil)
+
+	env.ExecuteWorkflow(workflows.SleeperLeagueSyncDispatcher)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var report workflows.SleeperLeagueSyncReport
+	require.NoError(t, env.GetWorkflowResult(&report))
+	require.Equal(t, workflows.SleeperLeagueSyncReport{LeaguesSynced: 1, LeaguesFailed: 1, MatchupsUpserted: 6}, report)
+	env.AssertExpectations(t)
+}
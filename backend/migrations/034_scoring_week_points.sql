@@ -0,0 +1,30 @@
+-- +goose Up
+
+-- Cached per-week fantasy points under an arbitrary Sleeper scoring map,
+-- computed from sleeper_player_week_stats.stats by scoring.WeekPoints. Keyed
+-- by a hash of the scoring map rather than by league: most leagues share one
+-- of a handful of rulesets, so a per-league table would store the same
+-- numbers thousands of times over. See models.ScoringWeekPoints.
+CREATE TABLE scoring_week_points (
+    scoring_hash       TEXT  NOT NULL,
+    season             TEXT  NOT NULL,
+    week               INT   NOT NULL,
+    sleeper_player_id  TEXT  NOT NULL,
+    points             FLOAT NOT NULL,
+    PRIMARY KEY (scoring_hash, season, week, sleeper_player_id)
+);
+
+-- One row per built (scoring_hash, season, week); a build older than the
+-- week's sleeper_week_stat_fetches.last_fetched_at is rebuilt on next read.
+CREATE TABLE scoring_week_points_builds (
+    scoring_hash  TEXT        NOT NULL,
+    season        TEXT        NOT NULL,
+    week          INT         NOT NULL,
+    built_at      TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (scoring_hash, season, week)
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS scoring_week_points_builds;
+DROP TABLE IF EXISTS scoring_week_points;
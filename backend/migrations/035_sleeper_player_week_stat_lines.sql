@@ -0,0 +1,94 @@
+-- +goose Up
+
+-- Typed columns parsed out of sleeper_player_week_stats.stats, one row per
+-- raw stats row, so usage (targets, snap share, attempts) is queryable in SQL
+-- rather than by unmarshalling jsonb per row. Sleeper omits zero-valued stats,
+-- so every column is NOT NULL DEFAULT 0. See models.SleeperPlayerWeekStatLine;
+-- FetchWeekStats keeps this in step with the raw row on every upsert.
+CREATE TABLE sleeper_player_week_stat_lines (
+    season             TEXT             NOT NULL,
+    week               INT              NOT NULL,
+    sleeper_player_id  TEXT             NOT NULL,
+    gp                 DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pass_att           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pass_cmp           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pass_yd            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pass_td            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pass_int           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rush_att           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rush_yd            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rush_td            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rec_tgt            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rec                DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rec_yd             DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rec_td             DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fum_lost           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    off_snp            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    tm_off_snp         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    def_snp            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    tm_def_snp         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    st_snp             DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fga                DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fgm                DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fgm_0_19           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fgm_20_29          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fgm_30_39          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fgm_40_49          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fgm_50p            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    xpa                DOUBLE PRECISION NOT NULL DEFAULT 0,
+    xpm                DOUBLE PRECISION NOT NULL DEFAULT 0,
+    sack               DOUBLE PRECISION NOT NULL DEFAULT 0,
+    "int"              DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fum_rec            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    def_td             DOUBLE PRECISION NOT NULL DEFAULT 0,
+    safe               DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pts_allow          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    yds_allow          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at         TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    PRIMARY KEY (season, week, sleeper_player_id)
+);
+
+-- Player-first lookups for the usage endpoint (GET /players/:id/usage).
+CREATE INDEX idx_sleeper_player_week_stat_lines_player
+    ON sleeper_player_week_stat_lines (sleeper_player_id, season, week);
+
+-- Backfill from the raw rows already on disk. Non-numeric values (Sleeper
+-- never sends them for these keys, but the column is untyped jsonb) would
+-- abort the cast, so each key is read only when its jsonb type is a number.
+INSERT INTO sleeper_player_week_stat_lines (
+    season, week, sleeper_player_id,
+    gp, pass_att, pass_cmp, pass_yd, pass_td, pass_int, rush_att,
+    rush_yd, rush_td, rec_tgt, rec, rec_yd, rec_td, fum_lost,
+    off_snp, tm_off_snp, def_snp, tm_def_snp, st_snp, fga, fgm,
+    fgm_0_19, fgm_20_29, fgm_30_39, fgm_40_49, fgm_50p, xpa, xpm,
+    sack, "int", fum_rec, def_td, safe, pts_allow, yds_allow
+)
+SELECT
+    s.season, s.week, s.sleeper_player_id,
+    COALESCE(r.gp, 0), COALESCE(r.pass_att, 0), COALESCE(r.pass_cmp, 0), COALESCE(r.pass_yd, 0),
+    COALESCE(r.pass_td, 0), COALESCE(r.pass_int, 0), COALESCE(r.rush_att, 0), COALESCE(r.rush_yd, 0),
+    COALESCE(r.rush_td, 0), COALESCE(r.rec_tgt, 0), COALESCE(r.rec, 0), COALESCE(r.rec_yd, 0),
+    COALESCE(r.rec_td, 0), COALESCE(r.fum_lost, 0), COALESCE(r.off_snp, 0), COALESCE(r.tm_off_snp, 0),
+    COALESCE(r.def_snp, 0), COALESCE(r.tm_def_snp, 0), COALESCE(r.st_snp, 0), COALESCE(r.fga, 0),
+    COALESCE(r.fgm, 0), COALESCE(r.fgm_0_19, 0), COALESCE(r.fgm_20_29, 0), COALESCE(r.fgm_30_39, 0),
+    COALESCE(r.fgm_40_49, 0), COALESCE(r.fgm_50p, 0), COALESCE(r.xpa, 0), COALESCE(r.xpm, 0),
+    COALESCE(r.sack, 0), COALESCE(r."int", 0), COALESCE(r.fum_rec, 0), COALESCE(r.def_td, 0),
+    COALESCE(r.safe, 0), COALESCE(r.pts_allow, 0), COALESCE(r.yds_allow, 0)
+FROM sleeper_player_week_stats s
+CROSS JOIN LATERAL jsonb_to_record((
+    SELECT COALESCE(jsonb_object_agg(e.key, e.value), '{}'::jsonb)
+    FROM jsonb_each(s.stats) e
+    WHERE jsonb_typeof(e.value) = 'number'
+)) AS r(
+    gp FLOAT, pass_att FLOAT, pass_cmp FLOAT, pass_yd FLOAT, pass_td FLOAT, pass_int FLOAT, rush_att FLOAT,
+    rush_yd FLOAT, rush_td FLOAT, rec_tgt FLOAT, rec FLOAT, rec_yd FLOAT, rec_td FLOAT, fum_lost FLOAT,
+    off_snp FLOAT, tm_off_snp FLOAT, def_snp FLOAT, tm_def_snp FLOAT, st_snp FLOAT, fga FLOAT, fgm FLOAT,
+    fgm_0_19 FLOAT, fgm_20_29 FLOAT, fgm_30_39 FLOAT, fgm_40_49 FLOAT, fgm_50p FLOAT, xpa FLOAT, xpm FLOAT,
+    sack FLOAT, "int" FLOAT, fum_rec FLOAT, def_td FLOAT, safe FLOAT, pts_allow FLOAT, yds_allow FLOAT
+)
+WHERE s.stats IS NOT NULL AND jsonb_typeof(s.stats) = 'object'
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+
+DROP TABLE IF EXISTS sleeper_player_week_stat_lines;
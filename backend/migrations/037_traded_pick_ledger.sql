@@ -0,0 +1,45 @@
+-- +goose Up
+
+-- Draft pick ownership ledger for dynasty/keeper leagues. Sleeper's
+-- traded_picks endpoint is a snapshot of every pick not owned by its original
+-- roster; sleeper_traded_picks mirrors it per league (replaced wholesale on
+-- each fetch, since a pick traded back home simply drops out).
+-- sleeper_pick_trades is the history: one row per pick moved by a completed
+-- trade, taken from the trade's draft_picks before the transaction sync
+-- drops pick-bearing trades from sleeper_transactions.
+CREATE TABLE sleeper_traded_picks (
+    sleeper_league_id  TEXT NOT NULL,
+    season             TEXT NOT NULL,
+    round              INT  NOT NULL,
+    roster_id          INT  NOT NULL,
+    owner_id           INT  NOT NULL,
+    previous_owner_id  INT  NOT NULL DEFAULT 0,
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (sleeper_league_id, season, round, roster_id)
+);
+
+CREATE TABLE sleeper_pick_trades (
+    sleeper_transaction_id  TEXT   NOT NULL,
+    season                  TEXT   NOT NULL,
+    round                   INT    NOT NULL,
+    roster_id               INT    NOT NULL,
+    sleeper_league_id       TEXT   NOT NULL,
+    created_at_sleeper      BIGINT NOT NULL,
+    previous_owner_id       INT    NOT NULL DEFAULT 0,
+    owner_id                INT    NOT NULL,
+    PRIMARY KEY (sleeper_transaction_id, season, round, roster_id)
+);
+
+CREATE INDEX idx_sleeper_pick_trades_league_created
+    ON sleeper_pick_trades (sleeper_league_id, created_at_sleeper DESC);
+
+-- How many picks per season each roster starts with, so the ledger can list
+-- untraded picks too. Populated by league discovery; NULL until a league's
+-- details are next fetched.
+ALTER TABLE sleeper_leagues ADD COLUMN draft_rounds INT;
+
+-- +goose Down
+
+ALTER TABLE sleeper_leagues DROP COLUMN IF EXISTS draft_rounds;
+DROP TABLE IF EXISTS sleeper_pick_trades;
+DROP TABLE IF EXISTS sleeper_traded_picks;
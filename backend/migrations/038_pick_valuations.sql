@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- Dated draft pick values per valuation segment, so trades that include
+-- picks can be totalled like player-only ones. Written daily by the pick
+-- valuation workflow (see internal/valuation/picks.go) from draft_adp and the
+-- segment's latest player_valuations; valuation_date matches the
+-- player_valuations date the values were derived from. slot 0 is a pick whose
+-- draft slot isn't known yet, valued at its round's average.
+CREATE TABLE pick_valuations (
+    segment         TEXT  NOT NULL,
+    season          TEXT  NOT NULL,
+    round           INT   NOT NULL,
+    slot            INT   NOT NULL,
+    valuation_date  DATE  NOT NULL,
+    value           FLOAT NOT NULL,
+    PRIMARY KEY (segment, season, round, slot, valuation_date)
+);
+
+CREATE INDEX idx_pick_valuations_segment_date
+    ON pick_valuations (segment, valuation_date);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS pick_valuations;
@@ -0,0 +1,21 @@
+-- +goose Up
+
+-- Population "market pulse": per-player add/drop/waiver-claim/trade counts
+-- over rolling 24h and 7d windows, per ADP segment plus an 'all' segment.
+-- Replaced wholesale each hour by the market-pulse cron job from
+-- sleeper_transactions, so every row in a run shares one computed_at.
+CREATE TABLE player_trends (
+    segment            TEXT NOT NULL,
+    time_window        TEXT NOT NULL,
+    sleeper_player_id  TEXT NOT NULL,
+    adds               INT  NOT NULL DEFAULT 0,
+    drops              INT  NOT NULL DEFAULT 0,
+    waiver_claims      INT  NOT NULL DEFAULT 0,
+    trades             INT  NOT NULL DEFAULT 0,
+    computed_at        TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (segment, time_window, sleeper_player_id)
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS player_trends;
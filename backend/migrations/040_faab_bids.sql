@@ -0,0 +1,37 @@
+-- +goose Up
+
+-- FAAB budget of a league whose waivers are FAAB (settings.waiver_type 2),
+-- NULL otherwise or until league discovery next fetches its details.
+ALTER TABLE sleeper_leagues ADD COLUMN waiver_budget INT;
+
+-- The winning bid on a complete waiver claim in a FAAB league, and that bid
+-- as a percentage (0-100) of the league's budget, captured at ingest.
+ALTER TABLE sleeper_transactions ADD COLUMN waiver_bid INT;
+ALTER TABLE sleeper_transactions ADD COLUMN waiver_bid_pct DOUBLE PRECISION;
+
+-- Distribution of winning FAAB bids per player per week, per ADP segment
+-- plus an 'all' segment. Recomputed by the market-pulse cron job for every
+-- week with a claim in the last 7 days; the *_pct columns are NULL when none
+-- of the week's bids came from a league with a known budget.
+CREATE TABLE faab_bid_stats (
+    segment            TEXT NOT NULL,
+    season             TEXT NOT NULL,
+    week               INT  NOT NULL,
+    sleeper_player_id  TEXT NOT NULL,
+    bids               INT  NOT NULL,
+    median_bid         DOUBLE PRECISION NOT NULL,
+    p75_bid            DOUBLE PRECISION NOT NULL,
+    p90_bid            DOUBLE PRECISION NOT NULL,
+    median_bid_pct     DOUBLE PRECISION,
+    p75_bid_pct        DOUBLE PRECISION,
+    p90_bid_pct        DOUBLE PRECISION,
+    computed_at        TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (segment, season, week, sleeper_player_id)
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS faab_bid_stats;
+ALTER TABLE sleeper_transactions DROP COLUMN IF EXISTS waiver_bid_pct;
+ALTER TABLE sleeper_transactions DROP COLUMN IF EXISTS waiver_bid;
+ALTER TABLE sleeper_leagues DROP COLUMN IF EXISTS waiver_budget;
@@ -0,0 +1,32 @@
+-- +goose Up
+
+-- Each team's budget in an auction draft, so auction prices can be compared
+-- across leagues with different budgets. NULL for snake/linear drafts, and
+-- for auction drafts synced before this column existed.
+ALTER TABLE sleeper_drafts ADD COLUMN budget INTEGER;
+
+-- Auction counterpart to draft_adp: what each player sold for in complete
+-- redraft auction drafts, per (segment, season). Prices are normalized to a
+-- $200 budget (price * 200 / draft budget) so leagues are comparable.
+CREATE TABLE auction_values (
+    segment           TEXT NOT NULL,
+    season            TEXT NOT NULL,
+    sleeper_player_id TEXT NOT NULL REFERENCES sleeper_players(sleeper_player_id),
+    avg_value         NUMERIC NOT NULL,
+    pick_count        INTEGER NOT NULL,
+    min_value         NUMERIC NOT NULL,
+    max_value         NUMERIC NOT NULL,
+    p10_value         NUMERIC NOT NULL DEFAULT 0,
+    p50_value         NUMERIC NOT NULL DEFAULT 0,
+    p90_value         NUMERIC NOT NULL DEFAULT 0,
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (segment, season, sleeper_player_id)
+);
+
+CREATE INDEX idx_auction_values_segment_season_avg_value
+    ON auction_values (segment, season, avg_value DESC);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS auction_values;
+ALTER TABLE sleeper_drafts DROP COLUMN IF EXISTS budget;
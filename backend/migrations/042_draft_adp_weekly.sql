@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- When a draft started, in Unix milliseconds, so ADP can be bucketed by
+-- draft date. NULL for drafts synced before this column existed.
+ALTER TABLE sleeper_drafts ADD COLUMN start_time BIGINT;
+
+-- ADP within a season, per week of draft start (week_start is the Monday,
+-- UTC): the series behind /sleeper/adp's risers/fallers and per-player ADP
+-- charts. Upserted alongside draft_adp by the ADP rollup.
+CREATE TABLE draft_adp_weekly (
+    segment           TEXT NOT NULL,
+    season            TEXT NOT NULL,
+    week_start        DATE NOT NULL,
+    sleeper_player_id TEXT NOT NULL REFERENCES sleeper_players(sleeper_player_id),
+    avg_pick_no       NUMERIC NOT NULL,
+    pick_count        INTEGER NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (segment, season, week_start, sleeper_player_id)
+);
+
+CREATE INDEX idx_draft_adp_weekly_player
+    ON draft_adp_weekly (segment, season, sleeper_player_id, week_start);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS draft_adp_weekly;
+ALTER TABLE sleeper_drafts DROP COLUMN IF EXISTS start_time;
@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- How many rounds a draft has, which tells a dynasty league's startup draft
+-- from its rookie drafts for the startup/rookie ADP segments. NULL for
+-- drafts synced before this column existed.
+ALTER TABLE sleeper_drafts ADD COLUMN rounds INT;
+
+-- +goose Down
+
+ALTER TABLE sleeper_drafts DROP COLUMN IF EXISTS rounds;
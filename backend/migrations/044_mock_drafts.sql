@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- Mock drafts run through /mock-drafts: one seat driven by API calls, every
+-- other seat simulated from draft_adp. picks is the drafted Sleeper player
+-- IDs in pick order; seed makes the simulated picks reproducible.
+CREATE TABLE mock_drafts (
+    id               BIGSERIAL PRIMARY KEY,
+    segment          TEXT NOT NULL,
+    season           TEXT NOT NULL,
+    teams            INTEGER NOT NULL,
+    rounds           INTEGER NOT NULL,
+    user_slot        INTEGER NOT NULL,
+    roster_positions JSONB NOT NULL,
+    picks            JSONB NOT NULL DEFAULT '[]',
+    seed             BIGINT NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS mock_drafts;
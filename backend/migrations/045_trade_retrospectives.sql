@@ -0,0 +1,43 @@
+-- +goose Up
+
+-- One row per fully-valued trade in a covered valuation format, re-valued
+-- after the fact by transactioncron (RunTradeRetrospectives): each side's
+-- total as of the segment's latest valuations (values_now) and later_weeks
+-- after the trade (values_later), and the PPR points each side's players
+-- scored from the trade's week on (realized_points). All three share
+-- trade_values' shape, {"<roster_id>": <float>, ...}. The trade's adds,
+-- draft_picks and trade-time values are copied in so a retrospective keeps
+-- refreshing after the scavenger purges its sleeper_transactions row.
+-- hindsight_gap is the spread between the highest and lowest values_now
+-- side, set once every side resolves; GET /sleeper/trades?sort=hindsight
+-- orders by it.
+CREATE TABLE trade_retrospectives (
+    sleeper_transaction_id TEXT PRIMARY KEY,
+    segment                TEXT NOT NULL,
+    season                 TEXT NOT NULL,
+    leg                    INTEGER NOT NULL,
+    traded_at              TIMESTAMPTZ NOT NULL,
+    adds                   JSONB,
+    draft_picks            JSONB,
+    values_at_trade        JSONB NOT NULL,
+    later_weeks            INTEGER NOT NULL,
+    valuation_date         DATE,
+    values_now             JSONB,
+    values_later           JSONB,
+    realized_points        JSONB,
+    hindsight_gap          DOUBLE PRECISION,
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- RunTradeRetrospectives refreshes rows whose valuation_date trails the
+-- segment's latest.
+CREATE INDEX idx_trade_retrospectives_segment_valuation_date
+    ON trade_retrospectives (segment, valuation_date);
+
+CREATE INDEX idx_trade_retrospectives_hindsight_gap
+    ON trade_retrospectives (hindsight_gap DESC)
+    WHERE hindsight_gap IS NOT NULL;
+
+-- +goose Down
+
+DROP TABLE IF EXISTS trade_retrospectives;
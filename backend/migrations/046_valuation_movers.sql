@@ -0,0 +1,34 @@
+-- +goose Up
+
+-- The biggest market_score risers and fallers per valuation segment, window
+-- and position, as of each segment's latest player_valuations date.
+-- Replaced wholesale by the daily valuation-movers cron job, so every row
+-- in a run shares one computed_at. z_score is score_change in units of the
+-- player's latest market_dispersion; rank is 1-based within (segment,
+-- time_window, position, direction).
+CREATE TABLE valuation_movers (
+    segment            TEXT NOT NULL,
+    time_window        TEXT NOT NULL,
+    sleeper_player_id  TEXT NOT NULL,
+    position           TEXT NOT NULL,
+    direction          TEXT NOT NULL,
+    rank               INT  NOT NULL,
+    from_date          DATE NOT NULL,
+    valuation_date     DATE NOT NULL,
+    market_score_from  DOUBLE PRECISION NOT NULL,
+    market_score_to    DOUBLE PRECISION NOT NULL,
+    score_change       DOUBLE PRECISION NOT NULL,
+    market_dispersion  DOUBLE PRECISION,
+    z_score            DOUBLE PRECISION,
+    value_from         DOUBLE PRECISION,
+    value_to           DOUBLE PRECISION,
+    computed_at        TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (segment, time_window, sleeper_player_id)
+);
+
+CREATE INDEX idx_valuation_movers_position
+    ON valuation_movers (segment, time_window, position, direction, rank);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS valuation_movers;
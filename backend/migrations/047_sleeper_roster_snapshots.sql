@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- Sleeper roster history for team valuations. The transaction sync fetches a
+-- league's rosters at most once per day (tracked by last_rosters_fetched_at)
+-- and appends a row for each roster whose owner or players changed since its
+-- latest one, so a roster's players at any moment are those of its latest
+-- row taken before it. players is a sorted JSON array of sleeper player IDs.
+CREATE TABLE sleeper_roster_snapshots (
+    sleeper_league_id  TEXT NOT NULL,
+    roster_id          INT  NOT NULL,
+    taken_at           TIMESTAMPTZ NOT NULL,
+    owner_id           TEXT NOT NULL DEFAULT '',
+    players            JSONB NOT NULL,
+    PRIMARY KEY (sleeper_league_id, roster_id, taken_at)
+);
+
+ALTER TABLE sleeper_leagues ADD COLUMN last_rosters_fetched_at TIMESTAMPTZ;
+
+-- +goose Down
+
+ALTER TABLE sleeper_leagues DROP COLUMN IF EXISTS last_rosters_fetched_at;
+DROP TABLE IF EXISTS sleeper_roster_snapshots;
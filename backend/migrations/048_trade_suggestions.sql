@@ -0,0 +1,70 @@
+-- +goose Up
+
+-- Roster-aware trade suggestions for one team in a Sleeper league, computed
+-- against every other roster in the league the way
+-- analysis/src/suggestions.py does, and written here by the
+-- trade-suggestions cron job (internal/suggestioncron). A suggestion is
+-- always from the requesting team's side (roster_id): it gives `give` to
+-- counterparty_roster_id for `receive`, each team cutting its drops to fit
+-- the roster cap. fairness_delta is the requesting team's outlay (give plus
+-- drops, market value) less the counterparty's; utility_delta and
+-- counterparty_utility_delta are each team's change in optimized
+-- starting-lineup projected points. label is 'pareto' (both teams improve)
+-- or 'one-sided'; rank is 1-based within one generated_at run. Player lists
+-- are JSON arrays of Sleeper player IDs.
+--
+-- status is 'open' for every suggestion a run writes. 'dismissed' and
+-- 'proposed' are for managers to triage suggestions with, which waits on the
+-- API being able to verify who manages a roster. A run replaces the team's
+-- open suggestions and keeps the dismissed and proposed ones, skipping any
+-- package that matches one.
+CREATE TABLE trade_suggestions (
+    id                          BIGSERIAL PRIMARY KEY,
+    sleeper_league_id           TEXT NOT NULL,
+    roster_id                   INT  NOT NULL,
+    counterparty_roster_id      INT  NOT NULL,
+    segment                     TEXT NOT NULL,
+    valuation_date              DATE NOT NULL,
+    generated_at                TIMESTAMPTZ NOT NULL,
+    rank                        INT  NOT NULL,
+    label                       TEXT NOT NULL,
+    give                        JSONB NOT NULL,
+    receive                     JSONB NOT NULL,
+    drops                       JSONB NOT NULL DEFAULT '[]',
+    counterparty_drops          JSONB NOT NULL DEFAULT '[]',
+    fairness_delta              DOUBLE PRECISION NOT NULL,
+    utility_delta               DOUBLE PRECISION NOT NULL,
+    counterparty_utility_delta  DOUBLE PRECISION NOT NULL,
+    lineup_entered              JSONB NOT NULL DEFAULT '[]',
+    lineup_exited               JSONB NOT NULL DEFAULT '[]',
+    status                      TEXT NOT NULL DEFAULT 'open',
+    status_updated_at           TIMESTAMPTZ
+);
+
+CREATE INDEX idx_trade_suggestions_team
+    ON trade_suggestions (sleeper_league_id, roster_id, status, rank);
+
+-- The suggestion job queue: one row per team, re-queued by the API when
+-- the team's suggestions are stale. A request is pending while completed_at
+-- is NULL or before requested_at; the job claims one by setting
+-- claimed_at, and finishes it by setting completed_at (and error, when it
+-- failed) in the same transaction as its trade_suggestions writes. A claim
+-- older than an hour is abandoned and may be taken again.
+CREATE TABLE trade_suggestion_requests (
+    sleeper_league_id  TEXT NOT NULL,
+    roster_id          INT  NOT NULL,
+    requested_at       TIMESTAMPTZ NOT NULL,
+    claimed_at         TIMESTAMPTZ,
+    completed_at       TIMESTAMPTZ,
+    error              TEXT,
+    PRIMARY KEY (sleeper_league_id, roster_id)
+);
+
+CREATE INDEX idx_trade_suggestion_requests_pending
+    ON trade_suggestion_requests (requested_at)
+    WHERE completed_at IS NULL OR completed_at < requested_at;
+
+-- +goose Down
+
+DROP TABLE IF EXISTS trade_suggestion_requests;
+DROP TABLE IF EXISTS trade_suggestions;
@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- Mock draft IDs become random hex strings: the ID is the only handle on a
+-- draft, so a sequence would let anyone read or pick in anyone's draft.
+-- Mock drafts are short-lived scratch state, so the existing ones are
+-- dropped rather than re-keyed. updated_at is indexed for the TTL sweep.
+DELETE FROM mock_drafts;
+ALTER TABLE mock_drafts ALTER COLUMN id DROP DEFAULT;
+ALTER TABLE mock_drafts ALTER COLUMN id TYPE TEXT;
+DROP SEQUENCE IF EXISTS mock_drafts_id_seq;
+
+CREATE INDEX idx_mock_drafts_updated_at ON mock_drafts (updated_at);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_mock_drafts_updated_at;
+DELETE FROM mock_drafts;
+CREATE SEQUENCE mock_drafts_id_seq OWNED BY mock_drafts.id;
+ALTER TABLE mock_drafts ALTER COLUMN id TYPE BIGINT USING id::BIGINT;
+ALTER TABLE mock_drafts ALTER COLUMN id SET DEFAULT nextval('mock_drafts_id_seq');
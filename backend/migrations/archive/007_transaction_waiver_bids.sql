@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- Mirrors cloud's migrations/040_faab_bids.sql: the winning FAAB bid on a
+-- complete waiver claim, carried through replication and age-based routing.
+ALTER TABLE sleeper_transactions ADD COLUMN IF NOT EXISTS waiver_bid integer;
+ALTER TABLE sleeper_transactions ADD COLUMN IF NOT EXISTS waiver_bid_pct double precision;
+
+-- +goose Down
+
+ALTER TABLE sleeper_transactions DROP COLUMN IF EXISTS waiver_bid_pct;
+ALTER TABLE sleeper_transactions DROP COLUMN IF EXISTS waiver_bid;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Mirrors cloud's migrations/041_auction_values.sql: an auction draft's
+-- per-team budget, which the auction value rollup normalizes prices by.
+ALTER TABLE sleeper_drafts ADD COLUMN IF NOT EXISTS budget integer;
+
+-- +goose Down
+
+ALTER TABLE sleeper_drafts DROP COLUMN IF EXISTS budget;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Mirrors cloud's migrations/042_draft_adp_weekly.sql: when a draft started,
+-- which the weekly ADP rollup buckets drafts by.
+ALTER TABLE sleeper_drafts ADD COLUMN IF NOT EXISTS start_time bigint;
+
+-- +goose Down
+
+ALTER TABLE sleeper_drafts DROP COLUMN IF EXISTS start_time;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Mirrors cloud's migrations/043_draft_rounds.sql: a draft's round count,
+-- which splits dynasty startup from rookie ADP.
+ALTER TABLE sleeper_drafts ADD COLUMN IF NOT EXISTS rounds int;
+
+-- +goose Down
+
+ALTER TABLE sleeper_drafts DROP COLUMN IF EXISTS rounds;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+
+-- Supports the API's reads of history older than cloud's hot window
+-- (GetSleeperTrades/GetSleeperTransactions with days, from or to), ordered
+-- by created_at_sleeper DESC, mirroring cloud's
+-- idx_sleeper_transactions_trade_complete,
+-- idx_sleeper_transactions_created_at_sleeper and
+-- idx_sleeper_transactions_league_id (migrations/012_sleeper_indexes.sql,
+-- migrations/023_purge_event_time_indexes.sql).
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_archive_sleeper_transactions_trade_complete_time
+    ON sleeper_transactions (created_at_sleeper DESC)
+    WHERE type = 'trade' AND status = 'complete';
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_archive_sleeper_transactions_created_at_sleeper
+    ON sleeper_transactions (created_at_sleeper);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_archive_sleeper_transactions_league_id
+    ON sleeper_transactions (sleeper_league_id);
+
+-- +goose Down
+-- +goose NO TRANSACTION
+
+DROP INDEX CONCURRENTLY IF EXISTS idx_archive_sleeper_transactions_league_id;
+DROP INDEX CONCURRENTLY IF EXISTS idx_archive_sleeper_transactions_created_at_sleeper;
+DROP INDEX CONCURRENTLY IF EXISTS idx_archive_sleeper_transactions_trade_complete_time;
@@ -75,11 +75,56 @@ func Register(ctx context.Context, c client.Client, archiveEnabled bool) error {
 		return err
 	}
 
+	// Daily like week stats, so each week's projection row keeps tracking
+	// Sleeper's latest until the week is finalized.
+	if err := upsert(ctx, c, client.ScheduleOptions{
+		ID: "sleeper-week-projections-schedule",
+		Spec: client.ScheduleSpec{
+			Calendars: []client.ScheduleCalendarSpec{
+				{
+					Hour:   []client.ScheduleRange{{Start: 9}}, // 04:30 EST (UTC-5)
+					Minute: []client.ScheduleRange{{Start: 30}},
+				},
+			},
+		},
+		Action: &client.ScheduleWorkflowAction{
+			Workflow:                 workflows.WeekProjectionsSyncDispatcher,
+			TaskQueue:                workflows.TaskQueueWeekStats,
+			WorkflowExecutionTimeout: 60 * time.Minute,
+		},
+		Overlap: enums.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE,
+	}); err != nil {
+		return err
+	}
+
+	// After the week-stats run so a league's box scores land against the same
+	// week's stats; Tuesday catches Monday night's games as final.
+	if err := upsert(ctx, c, client.ScheduleOptions{
+		ID: "sleeper-league-sync-schedule",
+		Spec: client.ScheduleSpec{
+			Calendars: []client.ScheduleCalendarSpec{
+				{
+					DayOfWeek: []client.ScheduleRange{{Start: 2}},  // Tuesday
+					Hour:      []client.ScheduleRange{{Start: 10}}, // 05:00 EST (UTC-5)
+					Minute:    []client.ScheduleRange{{Start: 0}},
+				},
+			},
+		},
+		Action: &client.ScheduleWorkflowAction{
+			Workflow:                 workflows.SleeperLeagueSyncDispatcher,
+			TaskQueue:                workflows.TaskQueueLeagues,
+			WorkflowExecutionTimeout: 60 * time.Minute,
+		},
+		Overlap: enums.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE,
+	}); err != nil {
+		return err
+	}
+
 	if !archiveEnabled {
 		return nil
 	}
 
-	return upsert(ctx, c, client.ScheduleOptions{
+	if err := upsert(ctx, c, client.ScheduleOptions{
 		ID: "sleeper-adp-rollup-schedule",
 		Spec: client.ScheduleSpec{
 			Calendars: []client.ScheduleCalendarSpec{
@@ -95,6 +140,28 @@ func Register(ctx context.Context, c client.Client, archiveEnabled bool) error {
 			WorkflowExecutionTimeout: 30 * time.Minute,
 		},
 		Overlap: enums.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE,
+	}); err != nil {
+		return err
+	}
+
+	// An hour after the ADP rollup, so pick values use the refreshed ADP and
+	// the morning player valuations run.
+	return upsert(ctx, c, client.ScheduleOptions{
+		ID: "sleeper-pick-valuations-schedule",
+		Spec: client.ScheduleSpec{
+			Calendars: []client.ScheduleCalendarSpec{
+				{
+					Hour:   []client.ScheduleRange{{Start: 12}}, // 07:00 EST (UTC-5)
+					Minute: []client.ScheduleRange{{Start: 0}},
+				},
+			},
+		},
+		Action: &client.ScheduleWorkflowAction{
+			Workflow:                 workflows.PickValuationWorkflow,
+			TaskQueue:                workflows.TaskQueueADP,
+			WorkflowExecutionTimeout: 10 * time.Minute,
+		},
+		Overlap: enums.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE,
 	})
 }
 
@@ -152,7 +152,7 @@ first_sync_analysis() {
 
 install_units() {
   echo "Installing systemd units"
-  for unit in ff-sims-worker.service ff-sims-espn-worker.service ff-sims-deploy.service ff-sims-deploy.timer ff-sims-discovery.service ff-sims-discovery.timer ff-sims-lifetime-counts.service ff-sims-lifetime-counts.timer ff-sims-transactions.service ff-sims-transactions.timer ff-sims-player-valuations.service ff-sims-player-valuations.timer; do
+  for unit in ff-sims-worker.service ff-sims-espn-worker.service ff-sims-deploy.service ff-sims-deploy.timer ff-sims-discovery.service ff-sims-discovery.timer ff-sims-lifetime-counts.service ff-sims-lifetime-counts.timer ff-sims-transactions.service ff-sims-transactions.timer ff-sims-market-pulse.service ff-sims-market-pulse.timer ff-sims-valuation-movers.service ff-sims-valuation-movers.timer ff-sims-trade-suggestions.service ff-sims-trade-suggestions.timer ff-sims-player-valuations.service ff-sims-player-valuations.timer; do
     sed "s#{{REPO_DIR}}#${REPO_DIR}#g; s#{{SERVICE_USER}}#${SERVICE_USER}#g" \
       "$SCRIPT_DIR/$unit" > "$SYSTEMD_DIR/$unit"
   done
@@ -177,6 +177,9 @@ Logs:
   journalctl -u ff-sims-discovery -f   # discovery cron job logs (runs hourly)
   journalctl -u ff-sims-lifetime-counts -f   # lifetime-counts snapshot job logs (runs hourly)
   journalctl -u ff-sims-transactions -f      # transaction-sync cron job logs (runs every ~10min)
+  journalctl -u ff-sims-market-pulse -f      # trending-players rollup logs (runs hourly at :30)
+  journalctl -u ff-sims-valuation-movers -f  # valuation movers rankings (runs daily at 09:00 UTC)
+  journalctl -u ff-sims-trade-suggestions -f # queued trade suggestion runs (every 5 minutes)
   journalctl -u ff-sims-player-valuations -f # player-valuation replay logs (runs daily at 00:00 UTC)
 
 $(if archive_url_configured; then cat <<'ARMED'
@@ -204,8 +207,8 @@ main() {
   install_units
 
   if ensure_env_file; then
-    systemctl enable ff-sims-worker.service ff-sims-espn-worker.service ff-sims-deploy.timer ff-sims-discovery.timer ff-sims-lifetime-counts.timer ff-sims-transactions.timer
-    systemctl start ff-sims-worker.service ff-sims-espn-worker.service ff-sims-deploy.timer ff-sims-discovery.timer ff-sims-lifetime-counts.timer ff-sims-transactions.timer
+    systemctl enable ff-sims-worker.service ff-sims-espn-worker.service ff-sims-deploy.timer ff-sims-discovery.timer ff-sims-lifetime-counts.timer ff-sims-transactions.timer ff-sims-market-pulse.timer ff-sims-valuation-movers.timer ff-sims-trade-suggestions.timer
+    systemctl start ff-sims-worker.service ff-sims-espn-worker.service ff-sims-deploy.timer ff-sims-discovery.timer ff-sims-lifetime-counts.timer ff-sims-transactions.timer ff-sims-market-pulse.timer ff-sims-valuation-movers.timer ff-sims-trade-suggestions.timer
 
     # Gated separately: everything above runs fine without the archive DB.
     # Converges either way, since this script is meant to be re-run — filling